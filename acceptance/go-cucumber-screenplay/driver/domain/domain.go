@@ -0,0 +1,24 @@
+// Domain package adapts the back end's domain test driver, which cannot import
+// the driver package, to the TestDriver interface
+package domain
+
+import (
+	"github.com/sirockin/cucumber-screenplay-go/acceptance/driver"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/testhelpers"
+)
+
+// AcceptanceTestDriver drives the domain directly, without any protocol in between
+type AcceptanceTestDriver struct {
+	*testhelpers.DomainTestDriver
+}
+
+func New() *AcceptanceTestDriver {
+	return &AcceptanceTestDriver{testhelpers.NewDomainTestDriver()}
+}
+
+// verify that AcceptanceTestDriver implements AcceptanceTestDriver
+var _ driver.TestDriver = (*AcceptanceTestDriver)(nil)
+
+func (d *AcceptanceTestDriver) OnDevice(device string) driver.TestDriver {
+	return &AcceptanceTestDriver{d.DomainTestDriver.OnDevice(device)}
+}
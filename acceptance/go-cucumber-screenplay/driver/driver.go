@@ -1,17 +1,127 @@
 package driver
 
 import (
+	"crypto/rand"
+	"strings"
+	"time"
+
 	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/export"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/notifier"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/testhelpers"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/webhooks"
+)
+
+const (
+	// NamespaceHeader names the namespace on the server that a request's data is kept in
+	NamespaceHeader = "X-Test-Namespace"
+	// NamespaceCookie names the namespace for requests from a browser, which cannot add
+	// a header to all of them
+	NamespaceCookie = "test-namespace"
 )
 
-// TestDriver is our interface to the system under test
+// NewNamespace returns a namespace that no other scenario, in this run of the tests or
+// any other sharing the server, is using
+func NewNamespace() string {
+	return "scenario-" + strings.ToLower(rand.Text())
+}
+
+// TestDriver is our interface to the system under test.
+// Each driver acts as a single client, such as a browser, that keeps its own
+// session for each account it signs in to.
 type TestDriver interface {
-	CreateAccount(name string) error
+	CreateAccount(name string, password string) error
+	// CreateAccountWithWebhook creates an account along with a webhook, which is then
+	// told when the account is activated
+	CreateAccountWithWebhook(name string, password string, url string, secret string) error
+	// ClearAll starts afresh with no data. Drivers for a server clear their namespace on
+	// it and move to a new one, so that scenarios sharing the server keep apart.
 	ClearAll()
+	// Snapshot captures the state of the system under test along with the client's
+	// sessions, so that a baseline built once can be restored before each scenario
+	Snapshot() (testhelpers.Snapshot, error)
+	// Restore puts the system and the client's sessions back as they were when the
+	// snapshot was taken. Other devices start afresh, as they do after ClearAll.
+	Restore(snapshot testhelpers.Snapshot) error
+	// AdvanceClock makes time pass for the system under test, without waiting
+	AdvanceClock(d time.Duration) error
 	GetAccount(name string) (entities.Account, error)
-	Authenticate(name string) error
+	Authenticate(name string, password string) error
 	IsAuthenticated(name string) bool
-	Activate(name string) error
-	CreateProject(name string) error
+	SignOut(name string) error
+	// ChangePlan moves the named account to another plan, acting as its holder
+	ChangePlan(name string, plan entities.Plan) error
+	// CloseAccount closes the named account for good, acting as its holder
+	CloseAccount(name string) error
+	// ExportAccount downloads everything kept about the named account, acting as its holder
+	ExportAccount(name string) (export.Archive, error)
+	// OnDevice returns a driver for another client of the same system, such as a
+	// second device someone signs in on. The same driver is returned for the same device.
+	OnDevice(device string) TestDriver
+	GetMessages(name string) ([]notifier.Message, error)
+	// GetEvents lists the events the system has published about the named account
+	GetEvents(name string) ([]events.Event, error)
+	FollowActivationLink(link string) error
+	// WatchAccount starts notifying the client of the named account's events as they
+	// happen, acting as its holder. Watching again catches up on what was missed.
+	WatchAccount(name string) error
+	StopWatchingAccount(name string) error
+	// AwaitNotification waits for the client to be notified of an event of the given
+	// kind about an account it watches
+	AwaitNotification(name string, kind events.Kind) error
+	// GetNotifications lists the events the client has been notified of about the named account
+	GetNotifications(name string) ([]events.Event, error)
+	// RegisterWebhook registers a webhook for the named account, acting as its holder
+	RegisterWebhook(name string, url string, secret string) (entities.Webhook, error)
+	GetWebhooks(name string) ([]entities.Webhook, error)
+	// AwaitWebhookDelivery waits for the latest delivery to one of the named account's
+	// webhooks to have been attempted the given number of times, and returns it
+	AwaitWebhookDelivery(name string, webhookID string, attempts int) (webhooks.Delivery, error)
+	CreateProject(name string, projectName string) (entities.Project, error)
+	// GetProjects lists all of the projects the named account owns or shares that are not
+	// archived, acting as its holder
 	GetProjects(name string) ([]entities.Project, error)
+	// ListProjects lists a page of the projects the named account owns or shares, chosen
+	// and ordered by query, acting as its holder. Pass the page's NextCursor in the query
+	// to get the next.
+	ListProjects(name string, query entities.ProjectQuery) (entities.ProjectPage, error)
+	// GetProjectsOf lists the projects of the owner's account, acting as name
+	GetProjectsOf(name string, owner string) ([]entities.Project, error)
+	GetProject(name string, projectID string) (entities.Project, error)
+	RenameProject(name string, projectID string, projectName string) (entities.Project, error)
+	// DeleteProject deletes one of the named account's projects for good, whether it is
+	// active or archived
+	DeleteProject(name string, projectID string) error
+	// ArchiveProject archives one of the named account's projects, which is refused with
+	// entities.ErrProjectArchived if it already is
+	ArchiveProject(name string, projectID string) (entities.Project, error)
+	// RestoreProject makes one of the named account's archived projects active again,
+	// which is refused with entities.ErrProjectNotArchived if it is not archived
+	RestoreProject(name string, projectID string) (entities.Project, error)
+	// InviteToProject invites the invitee to share one of the named account's projects
+	// with the given role, acting as its holder
+	InviteToProject(name string, projectID string, invitee string, role entities.Role) error
+	// GetInvitations lists the invitations to share projects that the named account has
+	// not yet answered, acting as its holder
+	GetInvitations(name string) ([]entities.Invitation, error)
+	AcceptInvitation(name string, projectID string) error
+	DeclineInvitation(name string, projectID string) error
+	// CreateOrganisation creates an organisation with the named account as its owner,
+	// acting as its holder
+	CreateOrganisation(name string, orgName string) error
+	// GetOrganisations lists the organisations the named account belongs to, and its
+	// role in each, acting as its holder
+	GetOrganisations(name string) ([]entities.Membership, error)
+	// GetOrganisationMembers lists the members of an organisation, acting as name
+	GetOrganisationMembers(name string, orgName string) ([]entities.Membership, error)
+	// SetOrganisationMember adds the member to an organisation with the given role, or
+	// changes the role they have, acting as name
+	SetOrganisationMember(name string, orgName string, member string, role entities.OrgRole) error
+	RemoveOrganisationMember(name string, orgName string, member string) error
+	// DeleteOrganisation deletes an organisation along with its projects, acting as name
+	DeleteOrganisation(name string, orgName string) error
+	CreateOrganisationProject(name string, orgName string, projectName string) (entities.Project, error)
+	GetOrganisationProjects(name string, orgName string) ([]entities.Project, error)
+	DeleteOrganisationProject(name string, orgName string, projectID string) error
 }
@@ -0,0 +1,29 @@
+package driver
+
+import "github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+
+// DomainError is a domain error reported across a protocol boundary. It keeps the
+// message the system under test gave, but unwraps to the domain error so that
+// steps can test for it with errors.Is whichever driver they use.
+type DomainError struct {
+	Err     error
+	Message string
+}
+
+func (e *DomainError) Error() string {
+	return e.Message
+}
+
+func (e *DomainError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorFromCode returns the domain error identified by code, carrying message, or
+// nil if code does not identify a domain error
+func ErrorFromCode(code, message string) error {
+	domainErr := entities.ErrorFromCode(code)
+	if domainErr == nil {
+		return nil
+	}
+	return &DomainError{Err: domainErr, Message: message}
+}
@@ -1,21 +1,42 @@
 package ui
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/playwright-community/playwright-go"
 	"github.com/sirockin/cucumber-screenplay-go/acceptance/driver"
 	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/export"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/notifier"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/testhelpers"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/webhooks"
 )
 
+// AcceptanceTestDriver drives the front end through a single browser page.
+// The page can only do one thing at a time, so calls are serialized.
 type AcceptanceTestDriver struct {
+	mu          sync.Mutex
+	t           *testing.T
 	browser     playwright.Browser
 	context     playwright.BrowserContext
 	page        playwright.Page
 	frontendURL string
+	// namespace is the namespace on the server that the browser's requests are in
+	namespace string
+	devices   map[string]*AcceptanceTestDriver
+	// watching holds a page showing notifications for each account being watched, next
+	// to the main page, and notified what was shown on such pages since closed
+	watching map[string]playwright.Page
+	notified map[string][]events.Event
 }
 
 func New(t *testing.T, frontendURL string) *AcceptanceTestDriver {
@@ -36,40 +57,82 @@ func New(t *testing.T, frontendURL string) *AcceptanceTestDriver {
 		t.Fatalf("failed to launch browser: %v", err)
 	}
 
+	driver := newInBrowser(t, browser, frontendURL, driver.NewNamespace())
+
+	t.Cleanup(func() {
+		if driver.browser != nil {
+			if err := driver.browser.Close(); err != nil {
+				t.Logf("Warning: Failed to close browser: %v", err)
+			}
+		}
+	})
+
+	return driver
+}
+
+// newInBrowser creates a driver with its own context in the browser, whose requests
+// are in the namespace on the server. Contexts do not share storage, so each one is a
+// separate client with its own sessions.
+func newInBrowser(t *testing.T, browser playwright.Browser, frontendURL string, namespace string) *AcceptanceTestDriver {
 	context, err := browser.NewContext()
 	if err != nil {
 		t.Fatalf("failed to create browser context: %v", err)
 	}
+	if err := useNamespace(context, frontendURL, namespace); err != nil {
+		t.Fatalf("failed to set namespace: %v", err)
+	}
 
 	page, err := context.NewPage()
 	if err != nil {
 		t.Fatalf("failed to create page: %v", err)
 	}
 
-	driver := &AcceptanceTestDriver{
+	return &AcceptanceTestDriver{
+		t:           t,
 		browser:     browser,
 		context:     context,
 		page:        page,
 		frontendURL: frontendURL,
+		namespace:   namespace,
+		devices:     make(map[string]*AcceptanceTestDriver),
+		watching:    make(map[string]playwright.Page),
+		notified:    make(map[string][]events.Event),
 	}
-
-	t.Cleanup(func() {
-		if driver.browser != nil {
-			if err := driver.browser.Close(); err != nil {
-				t.Logf("Warning: Failed to close browser: %v", err)
-			}
-		}
-	})
-
-	return driver
 }
 
 // verify that AcceptanceTestDriver implements AcceptanceTestDriver
 var _ driver.TestDriver = (*AcceptanceTestDriver)(nil)
 
-func (u *AcceptanceTestDriver) CreateAccount(name string) error {
+func (u *AcceptanceTestDriver) OnDevice(device string) driver.TestDriver {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if _, exists := u.devices[device]; !exists {
+		// Other devices are clients of the same data, so share the namespace
+		u.devices[device] = newInBrowser(u.t, u.browser, u.frontendURL, u.namespace)
+	}
+	return u.devices[device]
+}
+
+func (u *AcceptanceTestDriver) CreateAccount(name string, password string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
 	log.Printf("UI: Creating account for %s", name)
 
+	return u.signUp(name, password, "", "")
+}
+
+func (u *AcceptanceTestDriver) CreateAccountWithWebhook(name string, password string, webhookURL string, secret string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Creating account for %s with webhook %s", name, webhookURL)
+
+	return u.signUp(name, password, webhookURL, secret)
+}
+
+// signUp fills in the signup form, along with the webhook to register if webhookURL is set
+func (u *AcceptanceTestDriver) signUp(name string, password string, webhookURL string, secret string) error {
 	// Navigate to the account creation page
 	_, err := u.page.Goto(u.frontendURL + "/signup")
 	if err != nil {
@@ -90,24 +153,43 @@ func (u *AcceptanceTestDriver) CreateAccount(name string) error {
 		return fmt.Errorf("failed to fill name field: %w", err)
 	}
 
+	// Fill in the password field
+	err = u.page.Fill("input[name='password']", password)
+	if err != nil {
+		return fmt.Errorf("failed to fill password field: %w", err)
+	}
+
+	// Fill in the optional webhook fields
+	if webhookURL != "" {
+		if err := u.page.Fill("input[name='webhook-url']", webhookURL); err != nil {
+			return fmt.Errorf("failed to fill webhook URL field: %w", err)
+		}
+		if err := u.page.Fill("input[name='webhook-secret']", secret); err != nil {
+			return fmt.Errorf("failed to fill webhook secret field: %w", err)
+		}
+	}
+
 	// Click create account button
 	err = u.page.Click("button[type='submit']")
 	if err != nil {
 		return fmt.Errorf("failed to click create account button: %w", err)
 	}
 
-	// Wait for success message or redirect
-	_, err = u.page.WaitForSelector(".success, .message", playwright.PageWaitForSelectorOptions{
+	// Wait for success or error message
+	_, err = u.page.WaitForSelector(".success, .message, .error", playwright.PageWaitForSelectorOptions{
 		Timeout: playwright.Float(5000),
 	})
 	if err != nil {
 		return fmt.Errorf("account creation failed or timed out: %w", err)
 	}
 
-	return nil
+	return u.errorOnPage()
 }
 
 func (u *AcceptanceTestDriver) ClearAll() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
 	log.Println("UI: Clearing all data")
 	// Navigate to the clear admin page
 	_, err := u.page.Goto(u.frontendURL + "/admin/clear")
@@ -139,9 +221,171 @@ func (u *AcceptanceTestDriver) ClearAll() {
 	if err != nil {
 		log.Printf("Warning: Clear operation may not have completed: %v", err)
 	}
+
+	// Start the next scenario in a namespace of its own, which starts out empty
+	u.namespace = driver.NewNamespace()
+	if err := useNamespace(u.context, u.frontendURL, u.namespace); err != nil {
+		log.Printf("Warning: Failed to move to a new namespace: %v", err)
+	}
+
+	// Sessions on other devices were ended by clearing, so start afresh with new ones
+	u.startAfresh()
+}
+
+// useNamespace puts the browser context's requests to the server in the namespace. The
+// front end passes cookies on to the server, including with the requests for event
+// streams, which cannot carry a header of their own.
+func useNamespace(context playwright.BrowserContext, frontendURL string, namespace string) error {
+	return context.AddCookies([]playwright.OptionalCookie{{
+		Name:  driver.NamespaceCookie,
+		Value: namespace,
+		URL:   playwright.String(frontendURL),
+	}})
+}
+
+// startAfresh closes the other devices and the notifications pages, once the data they
+// were showing has gone. The caller must hold the lock.
+func (u *AcceptanceTestDriver) startAfresh() {
+	for _, device := range u.devices {
+		if err := device.context.Close(); err != nil {
+			log.Printf("Warning: Failed to close browser context: %v", err)
+		}
+	}
+	u.devices = make(map[string]*AcceptanceTestDriver)
+	for _, page := range u.watching {
+		if err := page.Close(); err != nil {
+			log.Printf("Warning: Failed to close notifications page: %v", err)
+		}
+	}
+	u.watching = make(map[string]playwright.Page)
+	u.notified = make(map[string][]events.Event)
+}
+
+func (u *AcceptanceTestDriver) Snapshot() (testhelpers.Snapshot, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Println("UI: Taking a snapshot")
+	if err := u.openSnapshot(); err != nil {
+		return testhelpers.Snapshot{}, err
+	}
+
+	// The snapshot page adds the browser's sessions to the server's snapshot
+	if err := u.page.Click("button.take-snapshot"); err != nil {
+		return testhelpers.Snapshot{}, fmt.Errorf("failed to click take snapshot button: %w", err)
+	}
+	if err := u.awaitSnapshotResult(); err != nil {
+		return testhelpers.Snapshot{}, err
+	}
+
+	text, err := u.page.InputValue("textarea[name='snapshot']")
+	if err != nil {
+		return testhelpers.Snapshot{}, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	var snapshot testhelpers.Snapshot
+	if err := json.Unmarshal([]byte(text), &snapshot); err != nil {
+		return testhelpers.Snapshot{}, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+func (u *AcceptanceTestDriver) Restore(snapshot testhelpers.Snapshot) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Println("UI: Restoring a snapshot")
+	text, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	if err := u.openSnapshot(); err != nil {
+		return err
+	}
+
+	if err := u.page.Fill("textarea[name='snapshot']", string(text)); err != nil {
+		return fmt.Errorf("failed to fill snapshot field: %w", err)
+	}
+	if err := u.page.Click("button.restore-snapshot"); err != nil {
+		return fmt.Errorf("failed to click restore snapshot button: %w", err)
+	}
+	if err := u.awaitSnapshotResult(); err != nil {
+		return err
+	}
+
+	// Restoring ended the sessions on other devices and cleared the events, as clearing does
+	u.startAfresh()
+	return nil
+}
+
+func (u *AcceptanceTestDriver) openSnapshot() error {
+	if _, err := u.page.Goto(u.frontendURL + "/admin/snapshot"); err != nil {
+		return fmt.Errorf("failed to navigate to snapshot page: %w", err)
+	}
+	_, err := u.page.WaitForSelector("textarea[name='snapshot']", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return fmt.Errorf("snapshot page not found: %w", err)
+	}
+	return nil
+}
+
+// awaitSnapshotResult waits for taking or restoring a snapshot to succeed or fail
+func (u *AcceptanceTestDriver) awaitSnapshotResult() error {
+	_, err := u.page.WaitForSelector(".success, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return fmt.Errorf("snapshot operation failed or timed out: %w", err)
+	}
+	return u.errorOnPage()
+}
+
+func (u *AcceptanceTestDriver) AdvanceClock(d time.Duration) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Advancing clock by %v", d)
+
+	// Navigate to the clock admin page
+	_, err := u.page.Goto(u.frontendURL + "/admin/clock")
+	if err != nil {
+		return fmt.Errorf("failed to navigate to clock page: %w", err)
+	}
+
+	// Wait for the form
+	_, err = u.page.WaitForSelector("input[name='duration']", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return fmt.Errorf("clock form not found: %w", err)
+	}
+
+	err = u.page.Fill("input[name='duration']", d.String())
+	if err != nil {
+		return fmt.Errorf("failed to fill duration field: %w", err)
+	}
+
+	err = u.page.Click("button.advance-clock")
+	if err != nil {
+		return fmt.Errorf("failed to click advance clock button: %w", err)
+	}
+
+	// Wait for the new time or an error
+	_, err = u.page.WaitForSelector(".success, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return fmt.Errorf("advancing clock failed or timed out: %w", err)
+	}
+
+	return u.errorOnPage()
 }
 
 func (u *AcceptanceTestDriver) GetAccount(name string) (entities.Account, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
 	log.Printf("UI: Getting account for %s", name)
 
 	// Navigate to account details page
@@ -155,29 +399,50 @@ func (u *AcceptanceTestDriver) GetAccount(name string) (entities.Account, error)
 		Timeout: playwright.Float(5000),
 	})
 	if err != nil {
-		return entities.Account{}, fmt.Errorf("account not found: %s", name)
+		return entities.Account{}, fmt.Errorf("%w: %s", entities.ErrAccountNotFound, name)
 	}
 
 	// Extract account information from the page
+	id, err := u.page.TextContent(".account-id")
+	if err != nil {
+		return entities.Account{}, fmt.Errorf("account id not found: %w", err)
+	}
+
 	activated, err := u.page.IsVisible(".status-activated")
 	if err != nil {
 		activated = false
 	}
 
-	authenticated, err := u.page.IsVisible(".status-authenticated")
+	plan, err := u.page.GetAttribute(".account-plan", "data-plan")
 	if err != nil {
-		authenticated = false
+		return entities.Account{}, fmt.Errorf("account plan not found: %w", err)
+	}
+
+	// Closed accounts show when they were closed
+	var closedAt time.Time
+	if closed, _ := u.page.IsVisible(".status-closed"); closed {
+		attr, err := u.page.GetAttribute(".status-closed", "data-closed-at")
+		if err != nil {
+			return entities.Account{}, fmt.Errorf("account closure time not found: %w", err)
+		}
+		if closedAt, err = time.Parse(time.RFC3339, attr); err != nil {
+			return entities.Account{}, fmt.Errorf("account closure time not understood: %w", err)
+		}
 	}
 
 	// Create domain account
-	domainAccount := entities.NewAccount(name)
+	domainAccount := entities.NewAccount(id, name)
 	domainAccount.SetActivated(activated)
-	domainAccount.SetAuthenticated(authenticated)
+	domainAccount.SetPlan(entities.Plan(plan))
+	domainAccount.SetClosedAt(closedAt)
 
 	return *domainAccount, nil
 }
 
-func (u *AcceptanceTestDriver) Authenticate(name string) error {
+func (u *AcceptanceTestDriver) Authenticate(name string, password string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
 	log.Printf("UI: Authenticating %s", name)
 
 	// Navigate to login page
@@ -200,6 +465,12 @@ func (u *AcceptanceTestDriver) Authenticate(name string) error {
 		return fmt.Errorf("failed to fill name field: %w", err)
 	}
 
+	// Fill in the password field
+	err = u.page.Fill("input[name='password']", password)
+	if err != nil {
+		return fmt.Errorf("failed to fill password field: %w", err)
+	}
+
 	// Click login button
 	err = u.page.Click("button[type='submit']")
 	if err != nil {
@@ -208,16 +479,13 @@ func (u *AcceptanceTestDriver) Authenticate(name string) error {
 
 	// Check for error message
 	time.Sleep(1 * time.Second) // Give time for response
-	errorVisible, _ := u.page.IsVisible(".error")
-	if errorVisible {
-		errorText, _ := u.page.TextContent(".error")
-		return fmt.Errorf("%s", errorText)
-	}
-
-	return nil
+	return u.errorOnPage()
 }
 
 func (u *AcceptanceTestDriver) IsAuthenticated(name string) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
 	log.Printf("UI: Checking authentication status for %s", name)
 
 	// Navigate to account page and check authentication status
@@ -226,6 +494,14 @@ func (u *AcceptanceTestDriver) IsAuthenticated(name string) bool {
 		return false
 	}
 
+	// The status is shown along with the rest of the account, once both have loaded
+	_, err = u.page.WaitForSelector(".account-info", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return false
+	}
+
 	// Check if authenticated indicator is visible
 	authenticated, err := u.page.IsVisible(".status-authenticated")
 	if err != nil {
@@ -235,102 +511,1462 @@ func (u *AcceptanceTestDriver) IsAuthenticated(name string) bool {
 	return authenticated
 }
 
-func (u *AcceptanceTestDriver) Activate(name string) error {
-	log.Printf("UI: Activating account for %s", name)
+func (u *AcceptanceTestDriver) SignOut(name string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Signing out %s", name)
 
-	// Navigate to activation page
-	_, err := u.page.Goto(u.frontendURL + "/activate/" + name)
+	// Navigate to account page, which offers to sign out of the account
+	_, err := u.page.Goto(u.frontendURL + "/account/" + url.PathEscape(name))
 	if err != nil {
-		return fmt.Errorf("failed to navigate to activation page: %w", err)
+		return fmt.Errorf("failed to navigate to account page: %w", err)
 	}
 
-	// Wait for activation button
-	_, err = u.page.WaitForSelector("button.activate", playwright.PageWaitForSelectorOptions{
+	_, err = u.page.WaitForSelector(".account-info", playwright.PageWaitForSelectorOptions{
 		Timeout: playwright.Float(5000),
 	})
 	if err != nil {
-		return fmt.Errorf("activation button not found: %w", err)
+		if pageErr := u.errorOnPage(); pageErr != nil {
+			return pageErr
+		}
+		return fmt.Errorf("account page not found: %w", err)
 	}
 
-	// Click activate button
-	err = u.page.Click("button.activate")
+	// The button is only offered to a browser that is signed in
+	signOut, err := u.page.QuerySelector("button.sign-out")
+	if err != nil || signOut == nil {
+		return fmt.Errorf("%w: %s", entities.ErrNotSignedIn, name)
+	}
+	if err := signOut.Click(); err != nil {
+		return fmt.Errorf("failed to click sign out button: %w", err)
+	}
+
+	// Wait for the outcome
+	_, err = u.page.WaitForSelector(".signed-out, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
 	if err != nil {
-		return fmt.Errorf("failed to click activate button: %w", err)
+		return fmt.Errorf("sign out failed or timed out: %w", err)
 	}
 
-	// Wait for success message
-	_, err = u.page.WaitForSelector(".success", playwright.PageWaitForSelectorOptions{
+	return u.errorOnPage()
+}
+
+func (u *AcceptanceTestDriver) ChangePlan(name string, plan entities.Plan) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Moving %s to the %s plan", name, plan)
+
+	if err := u.openSignedInAccountPage(name); err != nil {
+		return err
+	}
+
+	// The button is only offered for the plan the account is not already on
+	button, err := u.page.QuerySelector(fmt.Sprintf("button.change-plan[data-plan=%q]", plan))
+	if err != nil {
+		return fmt.Errorf("failed to find change plan button: %w", err)
+	}
+	if button == nil {
+		return nil
+	}
+	if err := button.Click(); err != nil {
+		return fmt.Errorf("failed to click change plan button: %w", err)
+	}
+
+	// Wait for the outcome
+	_, err = u.page.WaitForSelector(".plan-changed, .error", playwright.PageWaitForSelectorOptions{
 		Timeout: playwright.Float(5000),
 	})
 	if err != nil {
-		return fmt.Errorf("activation failed or timed out: %w", err)
+		return fmt.Errorf("changing plan failed or timed out: %w", err)
+	}
+
+	return u.errorOnPage()
+}
+
+func (u *AcceptanceTestDriver) CloseAccount(name string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Closing %s", name)
+
+	if err := u.openSignedInAccountPage(name); err != nil {
+		return err
+	}
+
+	// Closing is confirmed before it is done
+	if err := u.page.Click("button.close-account"); err != nil {
+		return fmt.Errorf("failed to click close account button: %w", err)
+	}
+	if err := u.page.Click("button.confirm-close-account"); err != nil {
+		return fmt.Errorf("failed to confirm closing the account: %w", err)
+	}
+
+	// Wait for the outcome
+	_, err := u.page.WaitForSelector(".account-closed, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return fmt.Errorf("closing account failed or timed out: %w", err)
+	}
+
+	return u.errorOnPage()
+}
+
+func (u *AcceptanceTestDriver) ExportAccount(name string) (export.Archive, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Downloading data for %s", name)
+
+	if err := u.openSignedInAccountPage(name); err != nil {
+		return export.Archive{}, err
+	}
+
+	download, err := u.page.ExpectDownload(func() error {
+		return u.page.Click("button.export-account")
+	}, playwright.PageExpectDownloadOptions{Timeout: playwright.Float(5000)})
+	if err != nil {
+		if pageErr := u.errorOnPage(); pageErr != nil {
+			return export.Archive{}, pageErr
+		}
+		return export.Archive{}, fmt.Errorf("downloading data failed or timed out: %w", err)
+	}
+	if download.SuggestedFilename() != export.Filename(name) {
+		return export.Archive{}, fmt.Errorf("data downloaded as %q, not %q", download.SuggestedFilename(), export.Filename(name))
+	}
+
+	path, err := download.Path()
+	if err != nil {
+		return export.Archive{}, fmt.Errorf("failed to save downloaded data: %w", err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return export.Archive{}, err
+	}
+	defer file.Close()
+	return export.Read(file)
+}
+
+// openSignedInAccountPage opens the account's page, refusing if this browser is not
+// signed in to it, as only then does the page offer to act on the account
+func (u *AcceptanceTestDriver) openSignedInAccountPage(name string) error {
+	_, err := u.page.Goto(u.frontendURL + "/account/" + url.PathEscape(name))
+	if err != nil {
+		return fmt.Errorf("failed to navigate to account page: %w", err)
+	}
+
+	_, err = u.page.WaitForSelector(".account-info", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		if pageErr := u.errorOnPage(); pageErr != nil {
+			return pageErr
+		}
+		return fmt.Errorf("account page not found: %w", err)
 	}
 
+	if authenticated, _ := u.page.IsVisible(".status-authenticated"); !authenticated {
+		return fmt.Errorf("%w: %s", entities.ErrNotSignedIn, name)
+	}
 	return nil
 }
 
-func (u *AcceptanceTestDriver) CreateProject(name string) error {
-	log.Printf("UI: Creating project for %s", name)
+func (u *AcceptanceTestDriver) GetMessages(name string) ([]notifier.Message, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
 
-	// Navigate to projects page
-	_, err := u.page.Goto(u.frontendURL + "/account/" + name + "/projects")
+	log.Printf("UI: Getting messages for %s", name)
+
+	// Navigate to outbox page
+	_, err := u.page.Goto(u.frontendURL + "/admin/outbox/" + url.PathEscape(name))
 	if err != nil {
-		return fmt.Errorf("failed to navigate to projects page: %w", err)
+		return nil, fmt.Errorf("failed to navigate to outbox page: %w", err)
 	}
 
-	// Wait for create project button
-	_, err = u.page.WaitForSelector("button.create-project", playwright.PageWaitForSelectorOptions{
+	// Wait for messages list
+	_, err = u.page.WaitForSelector(".messages-list", playwright.PageWaitForSelectorOptions{
 		Timeout: playwright.Float(5000),
 	})
 	if err != nil {
-		return fmt.Errorf("create project button not found: %w", err)
+		return nil, fmt.Errorf("messages list not found: %w", err)
 	}
 
-	// Click create project button
-	err = u.page.Click("button.create-project")
+	messageElements, err := u.page.QuerySelectorAll(".message")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query messages: %w", err)
+	}
+
+	messages := make([]notifier.Message, 0, len(messageElements))
+	for _, element := range messageElements {
+		message := notifier.Message{To: name}
+		if message.Subject, err = textOf(element, ".message-subject"); err != nil {
+			return nil, err
+		}
+		if message.Body, err = textOf(element, ".message-body"); err != nil {
+			return nil, err
+		}
+		linkElement, err := element.QuerySelector("a.message-link")
+		if err != nil || linkElement == nil {
+			return nil, fmt.Errorf("message link not found: %w", err)
+		}
+		if message.Link, err = linkElement.GetAttribute("href"); err != nil {
+			return nil, fmt.Errorf("failed to read message link: %w", err)
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+func (u *AcceptanceTestDriver) GetEvents(name string) ([]events.Event, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Getting events for %s", name)
+
+	// Navigate to events page
+	_, err := u.page.Goto(u.frontendURL + "/admin/events/" + url.PathEscape(name))
 	if err != nil {
-		return fmt.Errorf("failed to click create project button: %w", err)
+		return nil, fmt.Errorf("failed to navigate to events page: %w", err)
 	}
 
-	// Wait for success message
-	_, err = u.page.WaitForSelector(".project-created", playwright.PageWaitForSelectorOptions{
+	// Wait for events list
+	_, err = u.page.WaitForSelector(".events-list", playwright.PageWaitForSelectorOptions{
 		Timeout: playwright.Float(5000),
 	})
 	if err != nil {
-		return fmt.Errorf("project creation failed or timed out: %w", err)
+		return nil, fmt.Errorf("events list not found: %w", err)
 	}
 
-	return nil
+	return readEvents(u.page, name)
 }
 
-func (u *AcceptanceTestDriver) GetProjects(name string) ([]entities.Project, error) {
-	log.Printf("UI: Getting projects for %s", name)
+// readEvents reads the events listed on a page about the named account
+func readEvents(page playwright.Page, name string) ([]events.Event, error) {
+	eventElements, err := page.QuerySelectorAll(".event")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
 
-	// Navigate to projects page
-	_, err := u.page.Goto(u.frontendURL + "/account/" + name + "/projects")
+	listed := make([]events.Event, 0, len(eventElements))
+	for _, element := range eventElements {
+		event := events.Event{Account: name}
+		kind, err := textOf(element, ".event-kind")
+		if err != nil {
+			return nil, err
+		}
+		event.Kind = events.Kind(kind)
+		if event.ProjectID, err = element.GetAttribute("data-project-id"); err != nil {
+			return nil, fmt.Errorf("failed to read event project: %w", err)
+		}
+		occurredAtElement, err := element.QuerySelector("time.event-occurred-at")
+		if err != nil || occurredAtElement == nil {
+			return nil, fmt.Errorf("event time not found: %w", err)
+		}
+		occurredAt, err := occurredAtElement.GetAttribute("datetime")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read event time: %w", err)
+		}
+		if event.OccurredAt, err = time.Parse(time.RFC3339Nano, occurredAt); err != nil {
+			return nil, fmt.Errorf("failed to parse event time %q: %w", occurredAt, err)
+		}
+		listed = append(listed, event)
+	}
+
+	return listed, nil
+}
+
+// WatchAccount opens the account's notifications page alongside the main page, so
+// that it can keep following the account while the main page is used for other things
+func (u *AcceptanceTestDriver) WatchAccount(name string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Watching account %s", name)
+	if _, ok := u.watching[name]; ok {
+		return nil
+	}
+
+	page, err := u.context.NewPage()
+	if err != nil {
+		return fmt.Errorf("failed to open notifications page: %w", err)
+	}
+	_, err = page.Goto(u.frontendURL + "/account/" + url.PathEscape(name) + "/notifications")
 	if err != nil {
-		return nil, fmt.Errorf("failed to navigate to projects page: %w", err)
+		page.Close()
+		return fmt.Errorf("failed to navigate to notifications page: %w", err)
 	}
 
-	// Wait for projects list
-	_, err = u.page.WaitForSelector(".projects-list", playwright.PageWaitForSelectorOptions{
+	// Wait until the page has caught up and is watching, or failed to
+	_, err = page.WaitForSelector(".watching, .error", playwright.PageWaitForSelectorOptions{
 		Timeout: playwright.Float(5000),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("projects list not found: %w", err)
+		page.Close()
+		return fmt.Errorf("watching account failed or timed out: %w", err)
+	}
+	if err := errorOn(page); err != nil {
+		page.Close()
+		return err
 	}
 
-	// Count project items
-	projectElements, err := u.page.QuerySelectorAll(".project-item")
+	u.watching[name] = page
+	return nil
+}
+
+// StopWatchingAccount closes the account's notifications page, remembering what it showed
+func (u *AcceptanceTestDriver) StopWatchingAccount(name string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Stopping watching account %s", name)
+	page, ok := u.watching[name]
+	if !ok {
+		return nil
+	}
+
+	shown, err := readEvents(page, name)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find project items: %w", err)
+		return err
 	}
+	u.notified[name] = append(u.notified[name], shown...)
+	delete(u.watching, name)
+	return page.Close()
+}
+
+func (u *AcceptanceTestDriver) AwaitNotification(name string, kind events.Kind) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
 
-	// Create project entities (simplified - just return count)
-	projects := make([]entities.Project, len(projectElements))
-	for i := range projectElements {
-		projects[i] = entities.Project{} // Minimal implementation
+	log.Printf("UI: Waiting for %s to be notified of %s", name, kind)
+	for _, event := range u.notified[name] {
+		if event.Kind == kind {
+			return nil
+		}
+	}
+	page, ok := u.watching[name]
+	if !ok {
+		return fmt.Errorf("no %s notification: %s is not being watched", kind, name)
+	}
+
+	_, err := page.WaitForSelector(fmt.Sprintf(".notifications-list .event[data-kind='%s']", kind), playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(float64(testhelpers.NotificationTimeout.Milliseconds())),
+	})
+	if err != nil {
+		return fmt.Errorf("no %s notification within %v: %w", kind, testhelpers.NotificationTimeout, err)
+	}
+	return nil
+}
+
+func (u *AcceptanceTestDriver) GetNotifications(name string) ([]events.Event, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Getting notifications for %s", name)
+	notified := append([]events.Event(nil), u.notified[name]...)
+	if page, ok := u.watching[name]; ok {
+		shown, err := readEvents(page, name)
+		if err != nil {
+			return nil, err
+		}
+		notified = append(notified, shown...)
+	}
+	return notified, nil
+}
+
+func (u *AcceptanceTestDriver) FollowActivationLink(link string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Following activation link %s", link)
+
+	// Navigate to the page the link points to
+	_, err := u.page.Goto(u.frontendURL + link)
+	if err != nil {
+		return fmt.Errorf("failed to navigate to activation page: %w", err)
+	}
+
+	// Wait for activation button
+	_, err = u.page.WaitForSelector("button.activate", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return fmt.Errorf("activation button not found: %w", err)
+	}
+
+	// Click activate button
+	err = u.page.Click("button.activate")
+	if err != nil {
+		return fmt.Errorf("failed to click activate button: %w", err)
+	}
+
+	// Wait for the outcome
+	_, err = u.page.WaitForSelector(".success, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return fmt.Errorf("activation failed or timed out: %w", err)
+	}
+
+	return u.errorOnPage()
+}
+
+func (u *AcceptanceTestDriver) CreateProject(name string, projectName string) (entities.Project, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Creating project %s for %s", projectName, name)
+
+	// Navigate to projects page
+	_, err := u.page.Goto(u.projectsURL(name))
+	if err != nil {
+		return entities.Project{}, fmt.Errorf("failed to navigate to projects page: %w", err)
+	}
+
+	// Wait for create project form
+	_, err = u.page.WaitForSelector("input[name='project-name']", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return entities.Project{}, fmt.Errorf("create project form not found: %w", err)
+	}
+
+	// Fill in the project name
+	err = u.page.Fill("input[name='project-name']", projectName)
+	if err != nil {
+		return entities.Project{}, fmt.Errorf("failed to fill project name field: %w", err)
+	}
+
+	// Click create project button
+	err = u.page.Click("button.create-project")
+	if err != nil {
+		return entities.Project{}, fmt.Errorf("failed to click create project button: %w", err)
+	}
+
+	// Wait for success or error message
+	_, err = u.page.WaitForSelector(".project-created, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return entities.Project{}, fmt.Errorf("project creation failed or timed out: %w", err)
+	}
+	if err := u.errorOnPage(); err != nil {
+		return entities.Project{}, err
+	}
+
+	// The success message links to the new project, so read it from its own page
+	projectID, err := u.page.GetAttribute(".project-created", "data-project-id")
+	if err != nil {
+		return entities.Project{}, fmt.Errorf("created project id not found: %w", err)
+	}
+	return u.projectFromDetailsPage(name, projectID)
+}
+
+func (u *AcceptanceTestDriver) GetProjects(name string) ([]entities.Project, error) {
+	return u.GetProjectsOf(name, name)
+}
+
+// GetProjectsOf lists all of the owner's projects, acting as name, by following the
+// link from each page of them to the next
+func (u *AcceptanceTestDriver) GetProjectsOf(name string, owner string) ([]entities.Project, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Getting projects for %s as %s", owner, name)
+
+	values := url.Values{"limit": {strconv.Itoa(entities.MaxProjectLimit)}}
+	// The browser may be signed in to several accounts, so say which one to act as
+	if name != owner {
+		values.Set("as", name)
+	}
+	if err := u.openProjects(owner, values); err != nil {
+		return nil, err
+	}
+
+	var projects []entities.Project
+	cursor := ""
+	for {
+		page, err := u.projectPage(cursor)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, page.Projects...)
+		if page.NextCursor == "" {
+			return projects, nil
+		}
+		if err := u.page.Click(".next-page"); err != nil {
+			return nil, fmt.Errorf("failed to go to next page of projects: %w", err)
+		}
+		cursor = page.NextCursor
+	}
+}
+
+func (u *AcceptanceTestDriver) ListProjects(name string, query entities.ProjectQuery) (entities.ProjectPage, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Listing projects for %s", name)
+
+	// Carry on from the page on show by following its link to the next, as someone
+	// paging through their projects would
+	nextPage := fmt.Sprintf(".next-page[data-cursor=%q]", query.Cursor)
+	if visible, _ := u.page.IsVisible(nextPage); query.Cursor != "" && visible {
+		if err := u.page.Click(nextPage); err != nil {
+			return entities.ProjectPage{}, fmt.Errorf("failed to go to next page of projects: %w", err)
+		}
+		return u.projectPage(query.Cursor)
+	}
+
+	values := url.Values{}
+	if query.Limit != 0 {
+		values.Set("limit", strconv.Itoa(query.Limit))
+	}
+	for param, value := range map[string]string{
+		"cursor":     query.Cursor,
+		"sort":       string(query.Sort),
+		"namePrefix": query.NamePrefix,
+	} {
+		if value != "" {
+			values.Set(param, value)
+		}
+	}
+	if !query.CreatedFrom.IsZero() {
+		values.Set("createdFrom", query.CreatedFrom.Format(time.RFC3339))
+	}
+	if !query.CreatedBefore.IsZero() {
+		values.Set("createdBefore", query.CreatedBefore.Format(time.RFC3339))
+	}
+	if query.IncludeArchived {
+		values.Set("includeArchived", "true")
+	}
+	if err := u.openProjects(name, values); err != nil {
+		return entities.ProjectPage{}, err
+	}
+	return u.projectPage(query.Cursor)
+}
+
+// openProjects navigates to the page listing the owner's projects that values choose
+func (u *AcceptanceTestDriver) openProjects(owner string, values url.Values) error {
+	pageURL := u.projectsURL(owner)
+	if len(values) > 0 {
+		pageURL += "?" + values.Encode()
+	}
+	if _, err := u.page.Goto(pageURL); err != nil {
+		return fmt.Errorf("failed to navigate to projects page: %w", err)
+	}
+	return nil
+}
+
+// projectPage reads the page of projects listed from cursor once it has loaded, along
+// with the cursor that its link to the next page carries
+func (u *AcceptanceTestDriver) projectPage(cursor string) (entities.ProjectPage, error) {
+	// The list is tagged with the cursor it was listed from, so that one page is not
+	// mistaken for the next while it loads
+	_, err := u.page.WaitForSelector(fmt.Sprintf(".projects-list[data-cursor=%q], .error", cursor), playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return entities.ProjectPage{}, fmt.Errorf("projects list not found: %w", err)
+	}
+	if err := u.errorOnPage(); err != nil {
+		return entities.ProjectPage{}, err
+	}
+
+	projectElements, err := u.page.QuerySelectorAll(".project-item")
+	if err != nil {
+		return entities.ProjectPage{}, fmt.Errorf("failed to find project items: %w", err)
+	}
+
+	page := entities.ProjectPage{Projects: make([]entities.Project, 0, len(projectElements))}
+	for _, element := range projectElements {
+		project, err := readProject(element)
+		if err != nil {
+			return entities.ProjectPage{}, err
+		}
+		page.Projects = append(page.Projects, project)
+	}
+
+	next, err := u.page.QuerySelector(".next-page")
+	if err != nil {
+		return entities.ProjectPage{}, fmt.Errorf("failed to find link to next page: %w", err)
+	}
+	if next != nil {
+		if page.NextCursor, err = next.GetAttribute("data-cursor"); err != nil {
+			return entities.ProjectPage{}, fmt.Errorf("next page cursor not found: %w", err)
+		}
+	}
+	return page, nil
+}
+
+func (u *AcceptanceTestDriver) GetProject(name string, projectID string) (entities.Project, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Getting project %s for %s", projectID, name)
+
+	return u.projectFromDetailsPage(name, projectID)
+}
+
+func (u *AcceptanceTestDriver) RenameProject(name string, projectID string, projectName string) (entities.Project, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Renaming project %s for %s to %s", projectID, name, projectName)
+
+	if err := u.openProjectDetails(name, projectID); err != nil {
+		return entities.Project{}, err
+	}
+
+	// Fill in the new name
+	err := u.page.Fill(".project-details input[name='project-name']", projectName)
+	if err != nil {
+		return entities.Project{}, fmt.Errorf("failed to fill project name field: %w", err)
+	}
+
+	// Click rename button
+	err = u.page.Click("button.rename-project")
+	if err != nil {
+		return entities.Project{}, fmt.Errorf("failed to click rename project button: %w", err)
+	}
+
+	// Wait for success or error message
+	_, err = u.page.WaitForSelector(".project-updated, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return entities.Project{}, fmt.Errorf("project rename failed or timed out: %w", err)
+	}
+	if err := u.errorOnPage(); err != nil {
+		return entities.Project{}, err
+	}
+
+	return u.projectOnDetailsPage()
+}
+
+func (u *AcceptanceTestDriver) DeleteProject(name string, projectID string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Deleting project %s for %s", projectID, name)
+
+	if err := u.openProjectDetails(name, projectID); err != nil {
+		return err
+	}
+
+	// Click delete button
+	err := u.page.Click("button.delete-project")
+	if err != nil {
+		return fmt.Errorf("failed to click delete project button: %w", err)
+	}
+
+	// Wait for success or error message
+	_, err = u.page.WaitForSelector(".project-deleted, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return fmt.Errorf("project deletion failed or timed out: %w", err)
+	}
+
+	return u.errorOnPage()
+}
+
+func (u *AcceptanceTestDriver) ArchiveProject(name string, projectID string) (entities.Project, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Archiving project %s for %s", projectID, name)
+
+	return u.moveProject(name, projectID, "button.archive-project")
+}
+
+func (u *AcceptanceTestDriver) RestoreProject(name string, projectID string) (entities.Project, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Restoring project %s for %s", projectID, name)
+
+	return u.moveProject(name, projectID, "button.restore-project")
+}
+
+// moveProject archives or restores a project by clicking the button that does so on
+// its details page
+func (u *AcceptanceTestDriver) moveProject(name string, projectID string, button string) (entities.Project, error) {
+	if err := u.openProjectDetails(name, projectID); err != nil {
+		return entities.Project{}, err
+	}
+
+	if err := u.page.Click(button); err != nil {
+		return entities.Project{}, fmt.Errorf("failed to click %s: %w", button, err)
+	}
+
+	// Wait for success or error message
+	_, err := u.page.WaitForSelector(".project-updated, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return entities.Project{}, fmt.Errorf("project change failed or timed out: %w", err)
+	}
+	if err := u.errorOnPage(); err != nil {
+		return entities.Project{}, err
+	}
+
+	return u.projectOnDetailsPage()
+}
+
+func (u *AcceptanceTestDriver) InviteToProject(name string, projectID string, invitee string, role entities.Role) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Inviting %s to project %s of %s as %s", invitee, projectID, name, role)
+
+	if err := u.openProjectDetails(name, projectID); err != nil {
+		return err
+	}
+
+	// Fill in who to invite, and as what
+	err := u.page.Fill("input[name='invitee']", invitee)
+	if err != nil {
+		return fmt.Errorf("failed to fill invitee field: %w", err)
+	}
+	_, err = u.page.SelectOption("select[name='invite-role']", playwright.SelectOptionValues{Values: playwright.StringSlice(string(role))})
+	if err != nil {
+		return fmt.Errorf("failed to choose role: %w", err)
+	}
+
+	// Click invite button
+	err = u.page.Click("button.invite-to-project")
+	if err != nil {
+		return fmt.Errorf("failed to click invite button: %w", err)
+	}
+
+	// Wait for success or error message
+	_, err = u.page.WaitForSelector(".project-updated, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return fmt.Errorf("invitation failed or timed out: %w", err)
+	}
+
+	return u.errorOnPage()
+}
+
+func (u *AcceptanceTestDriver) GetInvitations(name string) ([]entities.Invitation, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Getting invitations for %s", name)
+
+	if err := u.openInvitations(name); err != nil {
+		return nil, err
+	}
+
+	invitationElements, err := u.page.QuerySelectorAll(".invitation")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find invitations: %w", err)
+	}
+
+	invitations := make([]entities.Invitation, 0, len(invitationElements))
+	for _, element := range invitationElements {
+		invitation, err := readInvitation(element)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	return invitations, nil
+}
+
+func (u *AcceptanceTestDriver) AcceptInvitation(name string, projectID string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Accepting invitation of %s to project %s", name, projectID)
+
+	return u.answerInvitation(name, projectID, "button.accept-invitation")
+}
+
+func (u *AcceptanceTestDriver) DeclineInvitation(name string, projectID string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Declining invitation of %s to project %s", name, projectID)
+
+	return u.answerInvitation(name, projectID, "button.decline-invitation")
+}
+
+// answerInvitation accepts or declines an invitation by clicking the button that does
+// so beside it on the invitations page
+func (u *AcceptanceTestDriver) answerInvitation(name string, projectID string, button string) error {
+	if err := u.openInvitations(name); err != nil {
+		return err
+	}
+
+	selector := fmt.Sprintf(".invitation[data-project-id=%q] %s", projectID, button)
+	if visible, _ := u.page.IsVisible(selector); !visible {
+		return fmt.Errorf("no invitation to project %s shown", projectID)
+	}
+	if err := u.page.Click(selector); err != nil {
+		return fmt.Errorf("failed to click %s: %w", button, err)
+	}
+
+	// Wait for success or error message
+	_, err := u.page.WaitForSelector(".invitation-answered, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return fmt.Errorf("answering invitation failed or timed out: %w", err)
+	}
+
+	return u.errorOnPage()
+}
+
+// openInvitations navigates to the account's invitations page, returning the error shown if it cannot be loaded
+func (u *AcceptanceTestDriver) openInvitations(name string) error {
+	_, err := u.page.Goto(u.frontendURL + "/account/" + url.PathEscape(name) + "/invitations")
+	if err != nil {
+		return fmt.Errorf("failed to navigate to invitations page: %w", err)
+	}
+
+	_, err = u.page.WaitForSelector(".invitations-list, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return fmt.Errorf("invitations list not found: %w", err)
+	}
+
+	return u.errorOnPage()
+}
+
+func (u *AcceptanceTestDriver) CreateOrganisation(name string, orgName string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Creating organisation %s for %s", orgName, name)
+
+	if err := u.openOrganisations(name); err != nil {
+		return err
+	}
+
+	// Fill in the organisation name
+	err := u.page.Fill("input[name='org-name']", orgName)
+	if err != nil {
+		return fmt.Errorf("failed to fill organisation name field: %w", err)
+	}
+
+	// Click create organisation button
+	err = u.page.Click("button.create-organisation")
+	if err != nil {
+		return fmt.Errorf("failed to click create organisation button: %w", err)
+	}
+
+	// Wait for success or error message
+	_, err = u.page.WaitForSelector(".organisation-created, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return fmt.Errorf("organisation creation failed or timed out: %w", err)
+	}
+
+	return u.errorOnPage()
+}
+
+func (u *AcceptanceTestDriver) GetOrganisations(name string) ([]entities.Membership, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Getting organisations of %s", name)
+
+	if err := u.openOrganisations(name); err != nil {
+		return nil, err
+	}
+
+	membershipElements, err := u.page.QuerySelectorAll(".membership")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find organisations: %w", err)
+	}
+
+	memberships := make([]entities.Membership, 0, len(membershipElements))
+	for _, element := range membershipElements {
+		orgName, err := element.GetAttribute("data-organisation")
+		if err != nil {
+			return nil, fmt.Errorf("organisation name not found: %w", err)
+		}
+		role, err := element.GetAttribute("data-role")
+		if err != nil {
+			return nil, fmt.Errorf("organisation role not found: %w", err)
+		}
+		memberships = append(memberships, entities.Membership{Organisation: orgName, Account: name, Role: entities.OrgRole(role)})
+	}
+
+	return memberships, nil
+}
+
+func (u *AcceptanceTestDriver) GetOrganisationMembers(name string, orgName string) ([]entities.Membership, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Getting members of organisation %s as %s", orgName, name)
+
+	if err := u.openOrganisation(name, orgName); err != nil {
+		return nil, err
+	}
+
+	memberElements, err := u.page.QuerySelectorAll(".member")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find members: %w", err)
+	}
+
+	members := make([]entities.Membership, 0, len(memberElements))
+	for _, element := range memberElements {
+		account, err := element.GetAttribute("data-account")
+		if err != nil {
+			return nil, fmt.Errorf("member account not found: %w", err)
+		}
+		role, err := element.GetAttribute("data-role")
+		if err != nil {
+			return nil, fmt.Errorf("member role not found: %w", err)
+		}
+		members = append(members, entities.Membership{Organisation: orgName, Account: account, Role: entities.OrgRole(role)})
+	}
+
+	return members, nil
+}
+
+func (u *AcceptanceTestDriver) SetOrganisationMember(name string, orgName string, member string, role entities.OrgRole) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Making %s %s of organisation %s as %s", member, role, orgName, name)
+
+	if err := u.openOrganisation(name, orgName); err != nil {
+		return err
+	}
+
+	// Fill in who to add, and as what
+	err := u.page.Fill("input[name='member']", member)
+	if err != nil {
+		return fmt.Errorf("failed to fill member field: %w", err)
+	}
+	_, err = u.page.SelectOption("select[name='member-role']", playwright.SelectOptionValues{Values: playwright.StringSlice(string(role))})
+	if err != nil {
+		return fmt.Errorf("failed to choose role: %w", err)
+	}
+
+	return u.changeOrganisation("button.set-member")
+}
+
+func (u *AcceptanceTestDriver) RemoveOrganisationMember(name string, orgName string, member string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Removing %s from organisation %s as %s", member, orgName, name)
+
+	if err := u.openOrganisation(name, orgName); err != nil {
+		return err
+	}
+
+	selector := fmt.Sprintf(".member[data-account=%q] button.remove-member", member)
+	if visible, _ := u.page.IsVisible(selector); !visible {
+		return fmt.Errorf("%w: %s is not shown as a member of %s", entities.ErrAccountNotFound, member, orgName)
+	}
+	return u.changeOrganisation(selector)
+}
+
+func (u *AcceptanceTestDriver) DeleteOrganisation(name string, orgName string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Deleting organisation %s as %s", orgName, name)
+
+	if err := u.openOrganisation(name, orgName); err != nil {
+		return err
+	}
+
+	return u.changeOrganisation("button.delete-organisation")
+}
+
+func (u *AcceptanceTestDriver) CreateOrganisationProject(name string, orgName string, projectName string) (entities.Project, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Creating project %s in organisation %s as %s", projectName, orgName, name)
+
+	if err := u.openOrganisation(name, orgName); err != nil {
+		return entities.Project{}, err
+	}
+
+	// Fill in the project name
+	err := u.page.Fill("input[name='org-project-name']", projectName)
+	if err != nil {
+		return entities.Project{}, fmt.Errorf("failed to fill project name field: %w", err)
+	}
+	if err := u.changeOrganisation("button.create-org-project"); err != nil {
+		return entities.Project{}, err
+	}
+
+	// The page lists the new project once it has loaded the organisation again
+	selector := fmt.Sprintf(".org-projects-list .project-item:has(.project-name:text-is(%q))", projectName)
+	element, err := u.page.WaitForSelector(selector, playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return entities.Project{}, fmt.Errorf("created project not listed: %w", err)
+	}
+	return readProject(element)
+}
+
+func (u *AcceptanceTestDriver) GetOrganisationProjects(name string, orgName string) ([]entities.Project, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Getting projects of organisation %s as %s", orgName, name)
+
+	if err := u.openOrganisation(name, orgName); err != nil {
+		return nil, err
+	}
+
+	projectElements, err := u.page.QuerySelectorAll(".org-projects-list .project-item")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find projects: %w", err)
+	}
+
+	projects := make([]entities.Project, 0, len(projectElements))
+	for _, element := range projectElements {
+		project, err := readProject(element)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
 	}
 
 	return projects, nil
 }
+
+func (u *AcceptanceTestDriver) DeleteOrganisationProject(name string, orgName string, projectID string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Deleting project %s of organisation %s as %s", projectID, orgName, name)
+
+	if err := u.openOrganisation(name, orgName); err != nil {
+		return err
+	}
+
+	selector := fmt.Sprintf(".project-item[data-project-id=%q] button.delete-org-project", projectID)
+	if visible, _ := u.page.IsVisible(selector); !visible {
+		return fmt.Errorf("%w: %s", entities.ErrProjectNotFound, projectID)
+	}
+	return u.changeOrganisation(selector)
+}
+
+// changeOrganisation clicks a button on the organisation's page that changes it, and
+// returns the error shown if the change is refused
+func (u *AcceptanceTestDriver) changeOrganisation(button string) error {
+	if err := u.page.Click(button); err != nil {
+		return fmt.Errorf("failed to click %s: %w", button, err)
+	}
+
+	// Wait for success or error message
+	_, err := u.page.WaitForSelector(".organisation-updated, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return fmt.Errorf("organisation change failed or timed out: %w", err)
+	}
+
+	return u.errorOnPage()
+}
+
+// openOrganisations navigates to the page listing the organisations an account belongs
+// to, returning the error shown if it cannot be loaded
+func (u *AcceptanceTestDriver) openOrganisations(name string) error {
+	_, err := u.page.Goto(u.frontendURL + "/account/" + url.PathEscape(name) + "/orgs")
+	if err != nil {
+		return fmt.Errorf("failed to navigate to organisations page: %w", err)
+	}
+
+	_, err = u.page.WaitForSelector(".organisations-list, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return fmt.Errorf("organisations list not found: %w", err)
+	}
+
+	return u.errorOnPage()
+}
+
+// openOrganisation navigates to an organisation's page as name, returning the error
+// shown if it cannot be loaded
+func (u *AcceptanceTestDriver) openOrganisation(name string, orgName string) error {
+	_, err := u.page.Goto(u.frontendURL + "/account/" + url.PathEscape(name) + "/orgs/" + url.PathEscape(orgName))
+	if err != nil {
+		return fmt.Errorf("failed to navigate to organisation page: %w", err)
+	}
+
+	_, err = u.page.WaitForSelector(".organisation-details, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return fmt.Errorf("organisation page not loaded: %w", err)
+	}
+
+	return u.errorOnPage()
+}
+
+func (u *AcceptanceTestDriver) RegisterWebhook(name string, webhookURL string, secret string) (entities.Webhook, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Registering webhook %s for %s", webhookURL, name)
+
+	if err := u.openWebhooks(name); err != nil {
+		return entities.Webhook{}, err
+	}
+
+	// Fill in the webhook to register
+	err := u.page.Fill("input[name='webhook-url']", webhookURL)
+	if err != nil {
+		return entities.Webhook{}, fmt.Errorf("failed to fill webhook URL field: %w", err)
+	}
+	err = u.page.Fill("input[name='webhook-secret']", secret)
+	if err != nil {
+		return entities.Webhook{}, fmt.Errorf("failed to fill webhook secret field: %w", err)
+	}
+
+	// Click register button
+	err = u.page.Click("button.register-webhook")
+	if err != nil {
+		return entities.Webhook{}, fmt.Errorf("failed to click register webhook button: %w", err)
+	}
+
+	// Wait for success or error message
+	_, err = u.page.WaitForSelector(".webhook-registered, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return entities.Webhook{}, fmt.Errorf("webhook registration failed or timed out: %w", err)
+	}
+	if err := u.errorOnPage(); err != nil {
+		return entities.Webhook{}, err
+	}
+
+	// The success message is tagged with the new webhook, which is then listed with the others
+	webhookID, err := u.page.GetAttribute(".webhook-registered", "data-webhook-id")
+	if err != nil {
+		return entities.Webhook{}, fmt.Errorf("registered webhook id not found: %w", err)
+	}
+	element, err := u.page.WaitForSelector(fmt.Sprintf(".webhook[data-webhook-id='%s']", webhookID), playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return entities.Webhook{}, fmt.Errorf("registered webhook not listed: %w", err)
+	}
+	return readWebhook(element)
+}
+
+func (u *AcceptanceTestDriver) GetWebhooks(name string) ([]entities.Webhook, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Getting webhooks for %s", name)
+
+	if err := u.openWebhooks(name); err != nil {
+		return nil, err
+	}
+
+	webhookElements, err := u.page.QuerySelectorAll(".webhook")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhooks: %w", err)
+	}
+
+	registered := make([]entities.Webhook, 0, len(webhookElements))
+	for _, element := range webhookElements {
+		webhook, err := readWebhook(element)
+		if err != nil {
+			return nil, err
+		}
+		registered = append(registered, webhook)
+	}
+
+	return registered, nil
+}
+
+func (u *AcceptanceTestDriver) AwaitWebhookDelivery(name string, webhookID string, attempts int) (webhooks.Delivery, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	log.Printf("UI: Waiting for delivery to webhook %s of %s to be attempted %d times", webhookID, name, attempts)
+
+	// The page shows the deliveries as they were when it loaded, so load it afresh each time
+	return testhelpers.AwaitDeliveryAttempts(func() ([]webhooks.Delivery, error) {
+		return u.deliveriesOnPage(name, webhookID)
+	}, attempts)
+}
+
+func (u *AcceptanceTestDriver) webhooksURL(name string) string {
+	return u.frontendURL + "/account/" + url.PathEscape(name) + "/webhooks"
+}
+
+// openWebhooks navigates to the account's webhooks page, returning the error shown if it cannot be loaded
+func (u *AcceptanceTestDriver) openWebhooks(name string) error {
+	_, err := u.page.Goto(u.webhooksURL(name))
+	if err != nil {
+		return fmt.Errorf("failed to navigate to webhooks page: %w", err)
+	}
+
+	_, err = u.page.WaitForSelector(".webhooks-list, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return fmt.Errorf("webhooks list not found: %w", err)
+	}
+
+	return u.errorOnPage()
+}
+
+// deliveriesOnPage loads the page logging the deliveries to a webhook and reads them from it
+func (u *AcceptanceTestDriver) deliveriesOnPage(name string, webhookID string) ([]webhooks.Delivery, error) {
+	_, err := u.page.Goto(u.webhooksURL(name) + "/" + url.PathEscape(webhookID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to navigate to webhook page: %w", err)
+	}
+
+	_, err = u.page.WaitForSelector(".deliveries-list, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("deliveries list not found: %w", err)
+	}
+	if err := u.errorOnPage(); err != nil {
+		return nil, err
+	}
+
+	deliveryElements, err := u.page.QuerySelectorAll(".delivery")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find deliveries: %w", err)
+	}
+
+	deliveries := make([]webhooks.Delivery, 0, len(deliveryElements))
+	for _, element := range deliveryElements {
+		delivery, err := readDelivery(element, name, webhookID)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+// readWebhook reads a webhook from an element that the front end has tagged with its fields
+func readWebhook(element playwright.ElementHandle) (entities.Webhook, error) {
+	id, err := element.GetAttribute("data-webhook-id")
+	if err != nil {
+		return entities.Webhook{}, fmt.Errorf("webhook id not found: %w", err)
+	}
+	createdAtText, err := element.GetAttribute("data-created-at")
+	if err != nil {
+		return entities.Webhook{}, fmt.Errorf("webhook creation time not found: %w", err)
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtText)
+	if err != nil {
+		return entities.Webhook{}, fmt.Errorf("invalid webhook creation time: %w", err)
+	}
+	webhookURL, err := textOf(element, ".webhook-url")
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+	// The front end is never shown the secret, nor the owner, which is the account itself
+	return *entities.NewWebhook(id, "", webhookURL, "", createdAt), nil
+}
+
+// readInvitation reads an invitation from an element that the front end has tagged with its fields
+func readInvitation(element playwright.ElementHandle) (entities.Invitation, error) {
+	projectID, err := element.GetAttribute("data-project-id")
+	if err != nil {
+		return entities.Invitation{}, fmt.Errorf("invitation project id not found: %w", err)
+	}
+	role, err := element.GetAttribute("data-role")
+	if err != nil {
+		return entities.Invitation{}, fmt.Errorf("invitation role not found: %w", err)
+	}
+	invitedAtText, err := element.GetAttribute("data-invited-at")
+	if err != nil {
+		return entities.Invitation{}, fmt.Errorf("invitation time not found: %w", err)
+	}
+	invitedAt, err := time.Parse(time.RFC3339Nano, invitedAtText)
+	if err != nil {
+		return entities.Invitation{}, fmt.Errorf("invalid invitation time: %w", err)
+	}
+	projectName, err := textOf(element, ".invitation-project")
+	if err != nil {
+		return entities.Invitation{}, err
+	}
+	owner, err := textOf(element, ".invitation-owner")
+	if err != nil {
+		return entities.Invitation{}, err
+	}
+	return entities.Invitation{
+		ProjectID:   projectID,
+		ProjectName: projectName,
+		Owner:       owner,
+		Role:        entities.Role(role),
+		InvitedAt:   invitedAt,
+	}, nil
+}
+
+// readDelivery reads a delivery to a webhook from an element that the front end has tagged with its fields
+func readDelivery(element playwright.ElementHandle, name string, webhookID string) (webhooks.Delivery, error) {
+	delivery := webhooks.Delivery{WebhookID: webhookID, Event: events.Event{Account: name}}
+	var err error
+	if delivery.ID, err = element.GetAttribute("data-delivery-id"); err != nil {
+		return webhooks.Delivery{}, fmt.Errorf("delivery id not found: %w", err)
+	}
+	kind, err := element.GetAttribute("data-kind")
+	if err != nil {
+		return webhooks.Delivery{}, fmt.Errorf("delivery event kind not found: %w", err)
+	}
+	delivery.Event.Kind = events.Kind(kind)
+	status, err := element.GetAttribute("data-status")
+	if err != nil {
+		return webhooks.Delivery{}, fmt.Errorf("delivery status not found: %w", err)
+	}
+	delivery.Status = webhooks.Status(status)
+	if delivery.Attempts, err = intAttribute(element, "data-attempts"); err != nil {
+		return webhooks.Delivery{}, err
+	}
+	if delivery.ResponseStatus, err = intAttribute(element, "data-response-status"); err != nil {
+		return webhooks.Delivery{}, err
+	}
+	if errorElement, _ := element.QuerySelector(".delivery-error"); errorElement != nil {
+		if delivery.Error, err = errorElement.TextContent(); err != nil {
+			return webhooks.Delivery{}, fmt.Errorf("failed to read delivery error: %w", err)
+		}
+	}
+	return delivery, nil
+}
+
+// intAttribute reads a number from an attribute of element, taking a missing or empty one as 0
+func intAttribute(element playwright.ElementHandle, attribute string) (int, error) {
+	text, err := element.GetAttribute(attribute)
+	if err != nil {
+		return 0, fmt.Errorf("%s not found: %w", attribute, err)
+	}
+	if text == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", attribute, text, err)
+	}
+	return n, nil
+}
+
+func (u *AcceptanceTestDriver) projectsURL(name string) string {
+	return u.frontendURL + "/account/" + url.PathEscape(name) + "/projects"
+}
+
+// openProjectDetails navigates to a project's own page, returning the error shown if it cannot be loaded
+func (u *AcceptanceTestDriver) openProjectDetails(name string, projectID string) error {
+	_, err := u.page.Goto(u.projectsURL(name) + "/" + url.PathEscape(projectID))
+	if err != nil {
+		return fmt.Errorf("failed to navigate to project page: %w", err)
+	}
+
+	_, err = u.page.WaitForSelector(".project-details, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return fmt.Errorf("project page not loaded: %w", err)
+	}
+
+	return u.errorOnPage()
+}
+
+func (u *AcceptanceTestDriver) projectFromDetailsPage(name string, projectID string) (entities.Project, error) {
+	if err := u.openProjectDetails(name, projectID); err != nil {
+		return entities.Project{}, err
+	}
+	return u.projectOnDetailsPage()
+}
+
+func (u *AcceptanceTestDriver) projectOnDetailsPage() (entities.Project, error) {
+	element, err := u.page.QuerySelector(".project-details")
+	if err != nil || element == nil {
+		return entities.Project{}, fmt.Errorf("project details not found: %w", err)
+	}
+	return readProject(element)
+}
+
+// readProject reads a project from an element that the front end has tagged with its fields
+func readProject(element playwright.ElementHandle) (entities.Project, error) {
+	id, err := element.GetAttribute("data-project-id")
+	if err != nil {
+		return entities.Project{}, fmt.Errorf("project id not found: %w", err)
+	}
+	ownerID, err := element.GetAttribute("data-owner-id")
+	if err != nil {
+		return entities.Project{}, fmt.Errorf("project owner not found: %w", err)
+	}
+	createdAtText, err := element.GetAttribute("data-created-at")
+	if err != nil {
+		return entities.Project{}, fmt.Errorf("project creation time not found: %w", err)
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtText)
+	if err != nil {
+		return entities.Project{}, fmt.Errorf("invalid project creation time: %w", err)
+	}
+	nameElement, err := element.QuerySelector(".project-name")
+	if err != nil || nameElement == nil {
+		return entities.Project{}, fmt.Errorf("project name not found: %w", err)
+	}
+	name, err := nameElement.TextContent()
+	if err != nil {
+		return entities.Project{}, fmt.Errorf("project name not found: %w", err)
+	}
+	state, err := element.GetAttribute("data-state")
+	if err != nil {
+		return entities.Project{}, fmt.Errorf("project state not found: %w", err)
+	}
+	project := entities.NewProject(id, name, ownerID, createdAt)
+	project.SetState(entities.ProjectState(state))
+	return *project, nil
+}
+
+// textOf returns the text of the element matching selector within element
+func textOf(element playwright.ElementHandle, selector string) (string, error) {
+	child, err := element.QuerySelector(selector)
+	if err != nil || child == nil {
+		return "", fmt.Errorf("%s not found: %w", selector, err)
+	}
+	text, err := child.TextContent()
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", selector, err)
+	}
+	return text, nil
+}
+
+// errorOnPage returns the error message shown on the current page, if any. The front
+// end tags the message with the API's error code, so domain errors keep their kind.
+func (u *AcceptanceTestDriver) errorOnPage() error {
+	return errorOn(u.page)
+}
+
+// errorOn returns the error message shown on page, if any
+func errorOn(page playwright.Page) error {
+	errorVisible, _ := page.IsVisible(".error")
+	if !errorVisible {
+		return nil
+	}
+
+	errorText, _ := page.TextContent(".error")
+	code, _ := page.GetAttribute(".error", "data-error-code")
+	if domainErr := driver.ErrorFromCode(code, errorText); domainErr != nil {
+		return domainErr
+	}
+	return fmt.Errorf("%s", errorText)
+}
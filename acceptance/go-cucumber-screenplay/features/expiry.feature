@@ -0,0 +1,28 @@
+Feature: Expiry
+
+  Activation links and sessions only last for a while, so that
+  forgotten sign-ups and devices do not stay usable for ever.
+
+  Scenario: Activate an account just before the link expires
+    Given Tanya has created an account
+    And 6 days have passed
+    When Tanya activates her account
+    Then Tanya should be authenticated
+
+  Scenario: Try to activate an account after the link has expired
+    Given Tanya has created an account
+    And 8 days have passed
+    When Tanya tries to activate her account
+    Then Tanya should see an error telling her the activation link has expired
+    And no AccountActivated event should have been published for Tanya
+
+  Scenario: Sign up again with the name of an account that expired
+    Given Tanya has created an account
+    And 8 days have passed
+    When Tanya signs up
+    Then Tanya should be authenticated
+
+  Scenario: Session times out
+    Given Sue has signed up
+    When 2 days have passed
+    Then Sue should not be authenticated
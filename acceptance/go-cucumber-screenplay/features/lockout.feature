@@ -0,0 +1,32 @@
+Feature: Lockout
+
+  Accounts are locked for a while after too many failed
+  sign-ins, so that passwords cannot be guessed by trying
+  them one after another.
+
+  Scenario: Account is locked after repeated failed sign-ins
+    Given Bob has signed up
+    When Bob tries to sign in with the password "wrong-password-1" 5 times
+    Then Bob should see an error telling him the account is locked
+
+  Scenario: Try to sign in with the right password while locked
+    Given Bob has signed up
+    And Bob has tried to sign in with the password "wrong-password-1" 5 times
+    When Bob tries to sign in
+    Then Bob should see an error telling him the account is locked
+    And no Authenticated event should have been published for Bob
+
+  Scenario: Sign in again once the lockout is over
+    Given Bob has signed up
+    And Bob has tried to sign in with the password "wrong-password-1" 5 times
+    And 20 minutes have passed
+    When Bob signs in again
+    Then Bob should be authenticated
+    And an Authenticated event should have been published for Bob
+
+  Scenario: Failed sign-ins are forgotten after a while
+    Given Bob has signed up
+    And Bob has tried to sign in with the password "wrong-password-1" 4 times
+    And 20 minutes have passed
+    When Bob tries to sign in with the password "wrong-password-1"
+    Then Bob should see an error telling him the name or password is wrong
@@ -0,0 +1,27 @@
+Feature: Notifications
+
+  Account holders can watch their account to be notified of
+  activity on it as it happens, and catch up on what they
+  missed when they watch it again.
+
+  Scenario: Be notified when a project is created
+    Given Sue has signed up
+    And Sue is watching her account
+    When Sue creates a project
+    Then Sue should be notified that her project was created
+
+  Scenario: Be notified of signing in on another device
+    Given Sue has signed up
+    And Sue is watching her account
+    When Sue signs in on her phone
+    Then Sue should be notified that she signed in
+
+  Scenario: Catch up on notifications missed while not watching
+    Given Sue has signed up
+    And Sue is watching her account
+    And Sue has created a project
+    And Sue has been notified that her project was created
+    And Sue has stopped watching her account
+    When Sue creates another project
+    And Sue watches her account again
+    Then Sue should have been notified that 2 projects were created
@@ -0,0 +1,59 @@
+Feature: Organisation permissions
+
+  What members of an organisation may do depends on their role. Members can create
+  projects, admins can also delete projects and add or remove members, and only owners
+  can make admins or delete the organisation. Others cannot see the organisation at all.
+
+  Scenario: Members can create projects
+    Given Sue has signed up
+    And Bob has signed up
+    And Sue has created the organisation "Acme"
+    And Sue has added Bob to the organisation "Acme" as a member
+    When Bob creates the project "Roadmap" in the organisation "Acme"
+    Then Sue should see the project "Roadmap" in the organisation "Acme"
+
+  Scenario: Members cannot delete projects
+    Given Sue has signed up
+    And Bob has signed up
+    And Sue has created the organisation "Acme"
+    And Sue has added Bob to the organisation "Acme" as a member
+    And Sue has created the project "Roadmap" in the organisation "Acme"
+    When Bob tries to delete the project "Roadmap" in the organisation "Acme"
+    Then Bob should be refused access
+    And Sue should see the project "Roadmap" in the organisation "Acme"
+
+  Scenario: Admins can delete projects
+    Given Sue has signed up
+    And Bob has signed up
+    And Sue has created the organisation "Acme"
+    And Sue has added Bob to the organisation "Acme" as an admin
+    And Sue has created the project "Roadmap" in the organisation "Acme"
+    When Bob deletes the project "Roadmap" in the organisation "Acme"
+    Then Sue should not see the project "Roadmap" in the organisation "Acme"
+
+  Scenario: Admins cannot make other admins
+    Given Sue has signed up
+    And Bob has signed up
+    And Tanya has signed up
+    And Sue has created the organisation "Acme"
+    And Sue has added Bob to the organisation "Acme" as an admin
+    When Bob tries to add Tanya to the organisation "Acme" as an admin
+    Then Bob should be refused access
+    And Tanya should not belong to the organisation "Acme"
+
+  Scenario: Only owners can delete the organisation
+    Given Sue has signed up
+    And Bob has signed up
+    And Sue has created the organisation "Acme"
+    And Sue has added Bob to the organisation "Acme" as an admin
+    When Bob tries to delete the organisation "Acme"
+    Then Bob should be refused access
+    And Bob should be an admin of the organisation "Acme"
+
+  Scenario: Others cannot see an organisation's projects
+    Given Sue has signed up
+    And Tanya has signed up
+    And Sue has created the organisation "Acme"
+    And Sue has created the project "Roadmap" in the organisation "Acme"
+    When Tanya tries to look at the projects in the organisation "Acme"
+    Then Tanya should see an error telling them there is no such organisation
@@ -0,0 +1,50 @@
+Feature: Project lifecycle
+
+  Users can archive finished projects to keep them out of the way, restore them if work
+  starts again, and delete them for good
+
+  Scenario: Archive a finished project
+    Given Sue has signed up
+    And Sue has created a project called "Roadmap"
+    And Sue has created a project called "Budget"
+    When Sue archives the project "Roadmap"
+    Then Sue should not see the project called "Roadmap"
+    But Sue should see the archived project called "Roadmap"
+    And Sue should see the project called "Budget"
+
+  Scenario: Restore an archived project
+    Given Sue has signed up
+    And Sue has created a project called "Roadmap"
+    And Sue has archived the project "Roadmap"
+    When Sue restores the project "Roadmap"
+    Then Sue should see the project called "Roadmap"
+    And Sue should not see the archived project called "Roadmap"
+
+  Scenario: Delete an archived project for good
+    Given Sue has signed up
+    And Sue has created a project called "Roadmap"
+    And Sue has archived the project "Roadmap"
+    When Sue deletes the project "Roadmap"
+    Then Sue should not see the project called "Roadmap"
+    And Sue should not see the archived project called "Roadmap"
+
+  Scenario: Archive a project that is already archived
+    Given Sue has signed up
+    And Sue has created a project called "Roadmap"
+    And Sue has archived the project "Roadmap"
+    When Sue tries to archive the project "Roadmap"
+    Then Sue should see an error telling them the project is archived
+
+  Scenario: Rename an archived project
+    Given Sue has signed up
+    And Sue has created a project called "Roadmap"
+    And Sue has archived the project "Roadmap"
+    When Sue tries to rename the project "Roadmap" to "Plan"
+    Then Sue should see an error telling them the project is archived
+    And Sue should see the archived project called "Roadmap"
+
+  Scenario: Restore a project that is not archived
+    Given Sue has signed up
+    And Sue has created a project called "Roadmap"
+    When Sue tries to restore the project "Roadmap"
+    Then Sue should see an error telling them the project is not archived
@@ -0,0 +1,68 @@
+Feature: Share projects
+
+  Users can invite others to share their projects, as viewers who can see them or as
+  editors who can also rename them. Only the owner can share, archive or delete a project.
+
+  Scenario: Share a project
+    Given Sue has signed up
+    And Bob has signed up
+    And Sue has created a project called "Roadmap"
+    When Sue invites Bob to the project "Roadmap" as a viewer
+    And Bob accepts the invitation to the project "Roadmap"
+    Then Bob should see the project called "Roadmap"
+
+  Scenario: See a shared project only once the invitation is accepted
+    Given Sue has signed up
+    And Bob has signed up
+    And Sue has created a project called "Roadmap"
+    When Sue invites Bob to the project "Roadmap" as an editor
+    Then Bob should see an invitation to the project "Roadmap"
+    But Bob should not see the project called "Roadmap"
+
+  Scenario: Decline an invitation
+    Given Sue has signed up
+    And Bob has signed up
+    And Sue has created a project called "Roadmap"
+    And Sue has invited Bob to the project "Roadmap" as an editor
+    When Bob declines the invitation to the project "Roadmap"
+    Then Bob should not see an invitation to the project "Roadmap"
+    And Bob should not see the project called "Roadmap"
+
+  Scenario: Editors can rename a shared project
+    Given Sue has signed up
+    And Bob has signed up
+    And Sue has created a project called "Roadmap"
+    And Sue has invited Bob to the project "Roadmap" as an editor
+    And Bob has accepted the invitation to the project "Roadmap"
+    When Bob renames the project "Roadmap" to "Plan"
+    Then Sue should see the project called "Plan"
+
+  Scenario: Viewers cannot rename a shared project
+    Given Sue has signed up
+    And Bob has signed up
+    And Sue has created a project called "Roadmap"
+    And Sue has invited Bob to the project "Roadmap" as a viewer
+    And Bob has accepted the invitation to the project "Roadmap"
+    When Bob tries to rename the project "Roadmap" to "Plan"
+    Then Bob should be refused access
+    And Sue should see the project called "Roadmap"
+
+  Scenario: Only the owner can share a project
+    Given Sue has signed up
+    And Bob has signed up
+    And Tanya has signed up
+    And Sue has created a project called "Roadmap"
+    And Sue has invited Bob to the project "Roadmap" as an editor
+    And Bob has accepted the invitation to the project "Roadmap"
+    When Bob tries to invite Tanya to the project "Roadmap" as a viewer
+    Then Bob should be refused access
+    And Tanya should not see an invitation to the project "Roadmap"
+
+  Scenario: Invite someone who already shares the project
+    Given Sue has signed up
+    And Bob has signed up
+    And Sue has created a project called "Roadmap"
+    And Sue has invited Bob to the project "Roadmap" as a viewer
+    And Bob has accepted the invitation to the project "Roadmap"
+    When Sue tries to invite Bob to the project "Roadmap" as an editor
+    Then Sue should see an error telling her Bob already shares the project
@@ -1,15 +1,38 @@
 Feature: Sign up
 
   New accounts need to confirm their email to activate
-  their account.
+  their account, and are protected by a password that
+  is hard to guess.
 
   Scenario: Successful sign-up
     Given Tanya has created an account
     When Tanya activates her account
     Then Tanya should be authenticated
+    And an AccountActivated event should have been published for Tanya
+
+  Scenario: Try to activate an account with a link that has already been used
+    Given Tanya has signed up
+    When Tanya follows her activation link again
+    Then Tanya should see an error telling her the activation link is not valid
 
   Scenario: Try to sign in without activating account
     Given Bob has created an account
     When Bob tries to sign in
     Then Bob should not be authenticated
     And Bob should see an error telling him to activate the account
+
+  Scenario: Try to sign up with a name that is already taken
+    Given Sue has signed up
+    When Bob tries to sign up as Sue
+    Then Bob should see an error telling him the name is already taken
+    And Sue should be authenticated
+
+  Scenario: Try to sign up with a weak password
+    When Bob tries to sign up with the password "secret"
+    Then Bob should see an error telling him the password is too weak
+    And no AccountCreated event should have been published for Bob
+
+  Scenario: Try to sign in with the wrong password
+    Given Sue has signed up
+    When Sue tries to sign in with the password "wrong-password-1"
+    Then Sue should see an error telling her the name or password is wrong
@@ -1,12 +1,39 @@
 package features_test
 
-import "github.com/sirockin/cucumber-screenplay-go/acceptance/screenplay"
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/acceptance/screenplay"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+// defaultPassword is used when the scenario does not care what a password is
+const defaultPassword = "correct-horse-1"
 
 var CreateAccount = struct {
 	forThemselves screenplay.Action
+	called        func(accountName string) screenplay.Action
+	withPassword  func(password string) screenplay.Action
+	withMyWebhook screenplay.Action
 }{
 	forThemselves: func(abilities screenplay.Abilities) error {
-		return abilities.App.CreateAccount(abilities.Name)
+		return abilities.App.CreateAccount(abilities.Name, defaultPassword)
+	},
+	called: func(accountName string) screenplay.Action {
+		return func(abilities screenplay.Abilities) error {
+			return abilities.App.CreateAccount(accountName, defaultPassword)
+		}
+	},
+	withPassword: func(password string) screenplay.Action {
+		return func(abilities screenplay.Abilities) error {
+			return abilities.App.CreateAccount(abilities.Name, password)
+		}
+	},
+	withMyWebhook: func(abilities screenplay.Abilities) error {
+		return abilities.App.CreateAccountWithWebhook(abilities.Name, defaultPassword, abilities.Webhook.URL(), abilities.Webhook.Secret())
 	},
 }
 
@@ -17,7 +44,11 @@ var Activate = struct {
 		if _, err := abilities.App.GetAccount(abilities.Name); err != nil {
 			return nil
 		}
-		return abilities.App.Activate(abilities.Name)
+		message, err := latestMessage(abilities)
+		if err != nil {
+			return err
+		}
+		return abilities.App.FollowActivationLink(message.Link)
 	},
 }
 
@@ -29,9 +60,300 @@ func signUp(abilities screenplay.Abilities) error {
 }
 
 func signIn(abilities screenplay.Abilities) error {
-	return abilities.App.Authenticate(abilities.Name)
+	return signInWithPassword(defaultPassword)(abilities)
+}
+
+func signInWithPassword(password string) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		return abilities.App.Authenticate(abilities.Name, password)
+	}
+}
+
+func signOut(abilities screenplay.Abilities) error {
+	return abilities.App.SignOut(abilities.Name)
+}
+
+// onTheir performs an action on another of the actor's devices, which has sessions of its own
+func onTheir(device string, action screenplay.Action) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		abilities.App = abilities.App.OnDevice(device)
+		return action(abilities)
+	}
+}
+
+// watchMyAccount keeps the actor notified of activity on their account, catching up on
+// anything they missed since they last watched it
+func watchMyAccount(abilities screenplay.Abilities) error {
+	return abilities.App.WatchAccount(abilities.Name)
+}
+
+func stopWatchingMyAccount(abilities screenplay.Abilities) error {
+	return abilities.App.StopWatchingAccount(abilities.Name)
+}
+
+// registerMyWebhook has the actor's events posted to their own service, signed with its secret
+func registerMyWebhook(abilities screenplay.Abilities) error {
+	_, err := abilities.App.RegisterWebhook(abilities.Name, abilities.Webhook.URL(), abilities.Webhook.Secret())
+	return err
+}
+
+// takeMyWebhookDown makes the actor's service refuse whatever is posted to it until it is brought back up
+func takeMyWebhookDown(abilities screenplay.Abilities) error {
+	abilities.Webhook.SetDown(true)
+	return nil
 }
 
+func bringMyWebhookBackUp(abilities screenplay.Abilities) error {
+	abilities.Webhook.SetDown(false)
+	return nil
+}
+
+// defaultProjectName is used when the scenario does not care what a project is called
+const defaultProjectName = "My project"
+
 func createProject(abilities screenplay.Abilities) error {
-	return abilities.App.CreateProject(abilities.Name)
+	return createProjectCalled(defaultProjectName)(abilities)
+}
+
+func createProjectCalled(projectName string) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		_, err := abilities.App.CreateProject(abilities.Name, projectName)
+		return err
+	}
+}
+
+func renameProject(projectName string, newName string) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		project, err := findProjectCalled(abilities, projectName)
+		if err != nil {
+			return err
+		}
+		_, err = abilities.App.RenameProject(abilities.Name, project.ID(), newName)
+		return err
+	}
+}
+
+func deleteProject(projectName string) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		project, err := findProjectCalled(abilities, projectName)
+		if err != nil {
+			return err
+		}
+		return abilities.App.DeleteProject(abilities.Name, project.ID())
+	}
+}
+
+func archiveProject(projectName string) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		project, err := findProjectCalled(abilities, projectName)
+		if err != nil {
+			return err
+		}
+		_, err = abilities.App.ArchiveProject(abilities.Name, project.ID())
+		return err
+	}
+}
+
+func restoreProject(projectName string) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		project, err := findProjectCalled(abilities, projectName)
+		if err != nil {
+			return err
+		}
+		_, err = abilities.App.RestoreProject(abilities.Name, project.ID())
+		return err
+	}
+}
+
+// inviteToProject invites another account to share one of the actor's projects
+func inviteToProject(projectName string, invitee string, role entities.Role) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		project, err := findProjectCalled(abilities, projectName)
+		if err != nil {
+			return err
+		}
+		return abilities.App.InviteToProject(abilities.Name, project.ID(), invitee, role)
+	}
+}
+
+func acceptInvitation(projectName string) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		invitation, err := findInvitationTo(abilities, projectName)
+		if err != nil {
+			return err
+		}
+		return abilities.App.AcceptInvitation(abilities.Name, invitation.ProjectID)
+	}
+}
+
+func declineInvitation(projectName string) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		invitation, err := findInvitationTo(abilities, projectName)
+		if err != nil {
+			return err
+		}
+		return abilities.App.DeclineInvitation(abilities.Name, invitation.ProjectID)
+	}
+}
+
+func createOrganisation(orgName string) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		return abilities.App.CreateOrganisation(abilities.Name, orgName)
+	}
+}
+
+// setOrganisationMember adds another account to an organisation with the given role, or
+// changes the role they have
+func setOrganisationMember(orgName string, member string, role entities.OrgRole) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		return abilities.App.SetOrganisationMember(abilities.Name, orgName, member, role)
+	}
+}
+
+func removeOrganisationMember(orgName string, member string) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		return abilities.App.RemoveOrganisationMember(abilities.Name, orgName, member)
+	}
+}
+
+func deleteOrganisation(orgName string) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		return abilities.App.DeleteOrganisation(abilities.Name, orgName)
+	}
+}
+
+func createOrganisationProject(orgName string, projectName string) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		_, err := abilities.App.CreateOrganisationProject(abilities.Name, orgName, projectName)
+		return err
+	}
+}
+
+func deleteOrganisationProject(orgName string, projectName string) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		project, err := findOrganisationProjectCalled(abilities, orgName, projectName)
+		if err != nil {
+			return err
+		}
+		return abilities.App.DeleteOrganisationProject(abilities.Name, orgName, project.ID())
+	}
+}
+
+// openOrganisationProjects opens the list of an organisation's projects
+func openOrganisationProjects(orgName string) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		_, err := abilities.App.GetOrganisationProjects(abilities.Name, orgName)
+		return err
+	}
+}
+
+// openProjectsOf opens the list of another account's projects
+func openProjectsOf(owner string) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		_, err := abilities.App.GetProjectsOf(abilities.Name, owner)
+		return err
+	}
+}
+
+// changePlan moves the actor's account to another plan
+func changePlan(plan entities.Plan) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		return abilities.App.ChangePlan(abilities.Name, plan)
+	}
+}
+
+// createProjects creates count projects one after another, called "Project 1" and so on
+func createProjects(count int) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		for i := 0; i < count; i++ {
+			if err := createProjectCalled(fmt.Sprintf("Project %d", i+1))(abilities); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// downloadNote is the note an actor keeps of the archive of their data they last downloaded
+const downloadNote = "download"
+
+// downloadMyData downloads everything kept about the actor's account, and notes the archive
+func downloadMyData(abilities screenplay.Abilities) error {
+	archive, err := abilities.App.ExportAccount(abilities.Name)
+	if err != nil {
+		return err
+	}
+	abilities.Notes[downloadNote] = archive
+	return nil
+}
+
+// closeMyAccount closes the actor's account for good
+func closeMyAccount(abilities screenplay.Abilities) error {
+	return abilities.App.CloseAccount(abilities.Name)
+}
+
+// projectPagesNote is the note an actor keeps of the pages of projects they were last shown
+const projectPagesNote = "project pages"
+
+// pageThroughMyProjects lists all of the actor's projects, limit at a time, following
+// each page on to the next, and notes the pages shown
+func pageThroughMyProjects(limit int) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		var pages []entities.ProjectPage
+		query := entities.ProjectQuery{Limit: limit}
+		for {
+			page, err := abilities.App.ListProjects(abilities.Name, query)
+			if err != nil {
+				return err
+			}
+			pages = append(pages, page)
+			if page.NextCursor == "" {
+				break
+			}
+			query.Cursor = page.NextCursor
+		}
+		abilities.Notes[projectPagesNote] = pages
+		return nil
+	}
+}
+
+// listMyProjects lists the first page of the actor's projects that query chooses, and
+// notes the page shown
+func listMyProjects(query entities.ProjectQuery) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		page, err := abilities.App.ListProjects(abilities.Name, query)
+		if err != nil {
+			return err
+		}
+		abilities.Notes[projectPagesNote] = []entities.ProjectPage{page}
+		return nil
+	}
+}
+
+// listMyProjectsCreatedAfter lists the actor's projects created more than d after the
+// named one, which they find to see when it was created
+func listMyProjectsCreatedAfter(d time.Duration, projectName string) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		project, err := findProjectCalled(abilities, projectName)
+		if err != nil {
+			return err
+		}
+		return listMyProjects(entities.ProjectQuery{CreatedFrom: project.CreatedAt().Add(d)})(abilities)
+	}
+}
+
+func createProjectsAtTheSameTime(count int) screenplay.Action {
+	return func(abilities screenplay.Abilities) error {
+		var wg sync.WaitGroup
+		errs := make([]error, count)
+		for i := 0; i < count; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = createProjectCalled(fmt.Sprintf("Project %d", i+1))(abilities)
+			}(i)
+		}
+		wg.Wait()
+		return errors.Join(errs...)
+	}
 }
@@ -3,22 +3,33 @@ package features_test
 import (
 	"testing"
 
+	domaindriver "github.com/sirockin/cucumber-screenplay-go/acceptance/driver/domain"
 	httpdriver "github.com/sirockin/cucumber-screenplay-go/acceptance/driver/http"
 	uidriver "github.com/sirockin/cucumber-screenplay-go/acceptance/driver/ui"
 	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/testhelpers"
 )
 
 func TestDomain(t *testing.T) {
-	RunSuite(t, testhelpers.NewDomainTestDriver())
+	RunSuite(t, domaindriver.New(), nil)
+}
+
+// TestInProcess tests against an HTTP server running in the test process, so that
+// the race detector also covers the server and domain code
+func TestInProcess(t *testing.T) {
+	serverURL := testhelpers.NewInProcessServer(t)
+
+	httpDriver := httpdriver.New(serverURL)
+
+	RunSuite(t, httpDriver, nil)
 }
 
 // TestBackEnd tests against the actual running server executable
 func TestBackEnd(t *testing.T) {
-	serverURL := startServerExecutable(t)
+	server := startServerExecutable(t)
 
-	httpDriver := httpdriver.New(serverURL)
+	httpDriver := httpdriver.New(server.url)
 
-	RunSuite(t, httpDriver)
+	RunSuite(t, httpDriver, server)
 }
 
 // TestFrontEnd tests against both frontend and API running in containers using UI automation
@@ -27,5 +38,5 @@ func TestFrontEnd(t *testing.T) {
 
 	uiDriver := uidriver.New(t, frontendURL)
 
-	RunSuite(t, uiDriver)
+	RunSuite(t, uiDriver, nil)
 }
@@ -1,11 +1,30 @@
 package features_test
 
-import "github.com/sirockin/cucumber-screenplay-go/acceptance/screenplay"
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirockin/cucumber-screenplay-go/acceptance/screenplay"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/export"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/notifier"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/webhooks"
+)
 
 func amIAuthenticated(abilities screenplay.Abilities) (interface{}, error) {
 	return abilities.App.IsAuthenticated(abilities.Name), nil
 }
 
+// askedOnTheir answers a question on another of the actor's devices, which has sessions of its own
+func askedOnTheir(device string, question screenplay.Question) screenplay.Question {
+	return func(abilities screenplay.Abilities) (interface{}, error) {
+		abilities.App = abilities.App.OnDevice(device)
+		return question(abilities)
+	}
+}
+
 func howManyProjectsDoIHave(abilities screenplay.Abilities) (interface{}, error) {
 	projects, err := abilities.App.GetProjects(abilities.Name)
 	if err != nil {
@@ -13,3 +32,273 @@ func howManyProjectsDoIHave(abilities screenplay.Abilities) (interface{}, error)
 	}
 	return len(projects), nil
 }
+
+func whatPlanAmIOn(abilities screenplay.Abilities) (interface{}, error) {
+	account, err := abilities.App.GetAccount(abilities.Name)
+	if err != nil {
+		return entities.Plan(""), err
+	}
+	return account.Plan(), nil
+}
+
+func isMyAccountClosed(abilities screenplay.Abilities) (interface{}, error) {
+	account, err := abilities.App.GetAccount(abilities.Name)
+	if err != nil {
+		return false, err
+	}
+	return account.IsClosed(), nil
+}
+
+// whichProjectsDidIDownload names the actor's own projects in the archive they last noted
+func whichProjectsDidIDownload(abilities screenplay.Abilities) (interface{}, error) {
+	archive, _ := abilities.Notes[downloadNote].(export.Archive)
+	var names []string
+	for _, project := range archive.Projects {
+		names = append(names, project.Name)
+	}
+	return strings.Join(names, ", "), nil
+}
+
+// doIHaveAProjectCalled asks whether the actor's list of projects, which leaves out
+// archived ones, has one with the given name
+func doIHaveAProjectCalled(projectName string) screenplay.Question {
+	return func(abilities screenplay.Abilities) (interface{}, error) {
+		projects, err := abilities.App.GetProjects(abilities.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range projects {
+			if project.Name() == projectName {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+func doIHaveAnArchivedProjectCalled(projectName string) screenplay.Question {
+	return func(abilities screenplay.Abilities) (interface{}, error) {
+		project, err := findProjectCalled(abilities, projectName)
+		if errors.Is(err, entities.ErrProjectNotFound) {
+			return false, nil
+		}
+		return err == nil && project.IsArchived(), err
+	}
+}
+
+// findProjectCalled finds one of the actor's projects by name, archived or not, as a
+// user would in a list of projects that includes archived ones
+func findProjectCalled(abilities screenplay.Abilities, projectName string) (entities.Project, error) {
+	query := entities.ProjectQuery{Limit: entities.MaxProjectLimit, IncludeArchived: true}
+	for {
+		page, err := abilities.App.ListProjects(abilities.Name, query)
+		if err != nil {
+			return entities.Project{}, err
+		}
+		for _, project := range page.Projects {
+			if project.Name() == projectName {
+				return project, nil
+			}
+		}
+		if page.NextCursor == "" {
+			return entities.Project{}, fmt.Errorf("%w: no project called %s", entities.ErrProjectNotFound, projectName)
+		}
+		query.Cursor = page.NextCursor
+	}
+}
+
+func doIHaveAnInvitationTo(projectName string) screenplay.Question {
+	return func(abilities screenplay.Abilities) (interface{}, error) {
+		_, err := findInvitationTo(abilities, projectName)
+		if errors.Is(err, entities.ErrInvitationNotFound) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+}
+
+// findInvitationTo finds the actor's unanswered invitation to share the project with
+// the given name
+func findInvitationTo(abilities screenplay.Abilities, projectName string) (entities.Invitation, error) {
+	invitations, err := abilities.App.GetInvitations(abilities.Name)
+	if err != nil {
+		return entities.Invitation{}, err
+	}
+	for _, invitation := range invitations {
+		if invitation.ProjectName == projectName {
+			return invitation, nil
+		}
+	}
+	return entities.Invitation{}, fmt.Errorf("%w: no invitation to %s", entities.ErrInvitationNotFound, projectName)
+}
+
+// whatIsMyRoleIn asks what role the actor has in the organisation, which is "" if they
+// do not belong to it
+func whatIsMyRoleIn(orgName string) screenplay.Question {
+	return func(abilities screenplay.Abilities) (interface{}, error) {
+		memberships, err := abilities.App.GetOrganisations(abilities.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, membership := range memberships {
+			if membership.Organisation == orgName {
+				return membership.Role, nil
+			}
+		}
+		return entities.OrgRole(""), nil
+	}
+}
+
+func doesTheOrganisationHaveAProjectCalled(orgName string, projectName string) screenplay.Question {
+	return func(abilities screenplay.Abilities) (interface{}, error) {
+		_, err := findOrganisationProjectCalled(abilities, orgName, projectName)
+		if errors.Is(err, entities.ErrProjectNotFound) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+}
+
+// findOrganisationProjectCalled finds one of an organisation's projects by name
+func findOrganisationProjectCalled(abilities screenplay.Abilities, orgName string, projectName string) (entities.Project, error) {
+	projects, err := abilities.App.GetOrganisationProjects(abilities.Name, orgName)
+	if err != nil {
+		return entities.Project{}, err
+	}
+	for _, project := range projects {
+		if project.Name() == projectName {
+			return project, nil
+		}
+	}
+	return entities.Project{}, fmt.Errorf("%w: no project called %s in %s", entities.ErrProjectNotFound, projectName, orgName)
+}
+
+// howManyPagesOfProjectsWasIShown counts the pages of projects the actor last noted
+func howManyPagesOfProjectsWasIShown(abilities screenplay.Abilities) (interface{}, error) {
+	pages, _ := abilities.Notes[projectPagesNote].([]entities.ProjectPage)
+	return len(pages), nil
+}
+
+// whichProjectsWasIShown names the projects on the pages the actor last noted, in the
+// order they were shown
+func whichProjectsWasIShown(abilities screenplay.Abilities) (interface{}, error) {
+	pages, _ := abilities.Notes[projectPagesNote].([]entities.ProjectPage)
+	var names []string
+	for _, page := range pages {
+		for _, project := range page.Projects {
+			names = append(names, project.Name())
+		}
+	}
+	return strings.Join(names, ", "), nil
+}
+
+// wasAnEventPublishedAboutMe asks the system whether it has published an event of the
+// given kind about the actor
+func wasAnEventPublishedAboutMe(kind events.Kind) screenplay.Question {
+	return func(abilities screenplay.Abilities) (interface{}, error) {
+		published, err := abilities.App.GetEvents(abilities.Name)
+		if err != nil {
+			return false, err
+		}
+		for _, event := range published {
+			if event.Kind == kind {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+// amINotifiedOf asks whether the actor has been notified of an event of the given kind
+// about their account, waiting a while for the notification to arrive
+func amINotifiedOf(kind events.Kind) screenplay.Question {
+	return func(abilities screenplay.Abilities) (interface{}, error) {
+		return abilities.App.AwaitNotification(abilities.Name, kind) == nil, nil
+	}
+}
+
+// howManyNotificationsOf counts the notifications of the given kind the actor has had
+// about their account
+func howManyNotificationsOf(kind events.Kind) screenplay.Question {
+	return func(abilities screenplay.Abilities) (interface{}, error) {
+		notified, err := abilities.App.GetNotifications(abilities.Name)
+		if err != nil {
+			return 0, err
+		}
+		count := 0
+		for _, event := range notified {
+			if event.Kind == kind {
+				count++
+			}
+		}
+		return count, nil
+	}
+}
+
+// wasMyWebhookSentASigned asks whether the actor's own service has accepted a payload about
+// an event of the given kind on their account, signed with its secret, waiting a while for it
+func wasMyWebhookSentASigned(kind events.Kind) screenplay.Question {
+	return func(abilities screenplay.Abilities) (interface{}, error) {
+		received, err := abilities.Webhook.Await(kind)
+		if err != nil {
+			return false, err
+		}
+		return received.Signed && received.Payload.Account == abilities.Name, nil
+	}
+}
+
+// deliveryOutcome is how far the delivery of an event to a webhook has got
+type deliveryOutcome struct {
+	status   webhooks.Status
+	attempts int
+}
+
+// whatBecameOfTheDeliveryToMyWebhook asks how far the latest delivery to the actor's webhook
+// has got, once it has been attempted the given number of times
+func whatBecameOfTheDeliveryToMyWebhook(attempts int) screenplay.Question {
+	return func(abilities screenplay.Abilities) (interface{}, error) {
+		delivery, err := latestDeliveryToMyWebhook(abilities, attempts)
+		if err != nil {
+			return deliveryOutcome{}, err
+		}
+		return deliveryOutcome{status: delivery.Status, attempts: delivery.Attempts}, nil
+	}
+}
+
+// howDidMyWebhookRespondToTheDelivery asks which HTTP status the actor's webhook responded
+// to the latest delivery with
+func howDidMyWebhookRespondToTheDelivery(abilities screenplay.Abilities) (interface{}, error) {
+	delivery, err := latestDeliveryToMyWebhook(abilities, 1)
+	if err != nil {
+		return 0, err
+	}
+	return delivery.ResponseStatus, nil
+}
+
+// latestDeliveryToMyWebhook waits for the latest delivery to the actor's webhook to have been
+// attempted the given number of times, finding the webhook by its URL as they would in their
+// list of webhooks
+func latestDeliveryToMyWebhook(abilities screenplay.Abilities, attempts int) (webhooks.Delivery, error) {
+	registered, err := abilities.App.GetWebhooks(abilities.Name)
+	if err != nil {
+		return webhooks.Delivery{}, err
+	}
+	for _, webhook := range registered {
+		if webhook.URL() == abilities.Webhook.URL() {
+			return abilities.App.AwaitWebhookDelivery(abilities.Name, webhook.ID(), attempts)
+		}
+	}
+	return webhooks.Delivery{}, fmt.Errorf("%w: %s has not registered a webhook", entities.ErrWebhookNotFound, abilities.Name)
+}
+
+// latestMessage is the most recent message sent to the actor, as they would find at the top of their inbox
+func latestMessage(abilities screenplay.Abilities) (notifier.Message, error) {
+	messages, err := abilities.App.GetMessages(abilities.Name)
+	if err != nil {
+		return notifier.Message{}, err
+	}
+	if len(messages) == 0 {
+		return notifier.Message{}, fmt.Errorf("no messages have been sent to %s", abilities.Name)
+	}
+	return messages[len(messages)-1], nil
+}
@@ -2,10 +2,11 @@
 package screenplay
 
 import (
+	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/sirockin/cucumber-screenplay-go/acceptance/driver"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/testhelpers"
 )
 
 type (
@@ -17,6 +18,11 @@ type Abilities struct {
 	Name      string
 	App       driver.TestDriver
 	LastError error
+	// Webhook is a service of the actor's own, which they can register as a webhook
+	Webhook *testhelpers.WebhookReceiver
+	// Notes holds what the actor has taken note of, such as what they were shown, so that
+	// later questions can be asked about it
+	Notes map[string]any
 }
 
 func (a *Abilities) AttemptsTo(actions ...Action) error {
@@ -37,13 +43,20 @@ type Actor struct {
 func NewActor(name string, app driver.TestDriver) *Actor {
 	ret := &Actor{
 		abilities: Abilities{
-			Name: name,
-			App:  app,
+			Name:    name,
+			App:     app,
+			Webhook: testhelpers.NewWebhookReceiver(),
+			Notes:   make(map[string]any),
 		},
 	}
 	return ret
 }
 
+// Exit releases what the actor was given to play their part, once the scene is over
+func (a *Actor) Exit() {
+	a.abilities.Webhook.Close()
+}
+
 func (a *Actor) AttemptsTo(actions ...Action) error {
 	return a.abilities.AttemptsTo(actions...)
 }
@@ -59,12 +72,12 @@ func (a *Actor) ExpectsAnswer(question Question, expected interface{}) error {
 	return nil
 }
 
-func (a *Actor) ExpectsLastErrorToContain(expectedText string) error {
+func (a *Actor) ExpectsLastErrorToBe(expected error) error {
 	if a.abilities.LastError == nil {
-		return fmt.Errorf("expected error containing text '%s' but there is no error", expectedText)
+		return fmt.Errorf("expected error '%v' but there is no error", expected)
 	}
-	if !strings.Contains(a.abilities.LastError.Error(), expectedText) {
-		return fmt.Errorf("expected error text containing '%s' but got %s", expectedText, a.abilities.LastError.Error())
+	if !errors.Is(a.abilities.LastError, expected) {
+		return fmt.Errorf("expected error '%v' but got %v", expected, a.abilities.LastError)
 	}
 	return nil
 }
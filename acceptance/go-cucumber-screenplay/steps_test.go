@@ -1,5 +1,16 @@
 package features_test
 
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/webhooks"
+)
+
 func (s *suite) personHasCreatedAnAccount(name string) error {
 	return s.Actor(name).AttemptsTo(CreateAccount.forThemselves)
 }
@@ -8,6 +19,33 @@ func (s *suite) personHasSignedUp(name string) error {
 	return s.Actor(name).AttemptsTo(signUp)
 }
 
+// personHasSignedUpWithProjects starts the scenario from a baseline in which the person
+// has signed up and created count projects, which must fit in the free plan. The baseline
+// is built the first time it is needed and restored after that, replacing everything, so
+// it must be the first step.
+func (s *suite) personHasSignedUpWithProjects(name string, count int) error {
+	return s.personIsOnThePlanWithProjects(name, string(entities.PlanFree), count)
+}
+
+// personIsOnThePlanWithProjects starts the scenario from a baseline in which the person
+// has signed up, moved to the plan and created count projects, as
+// personHasSignedUpWithProjects does
+func (s *suite) personIsOnThePlanWithProjects(name string, plan string, count int) error {
+	key := fmt.Sprintf("%s on the %s plan with %d projects", name, plan, count)
+	if snapshot, ok := s.baselines[key]; ok {
+		return s.driver.Restore(snapshot)
+	}
+	if err := s.Actor(name).AttemptsTo(signUp, changePlan(entities.Plan(plan)), createProjects(count)); err != nil {
+		return err
+	}
+	snapshot, err := s.driver.Snapshot()
+	if err != nil {
+		return err
+	}
+	s.baselines[key] = snapshot
+	return nil
+}
+
 func (s *suite) personShouldBeAuthenticated(name string) error {
 	return s.Actor(name).ExpectsAnswer(amIAuthenticated, true)
 }
@@ -16,6 +54,14 @@ func (s *suite) personShouldNotBeAuthenticated(name string) error {
 	return s.Actor(name).ExpectsAnswer(amIAuthenticated, false)
 }
 
+func (s *suite) personShouldBeAuthenticatedOnTheirDevice(name string, device string) error {
+	return s.Actor(name).ExpectsAnswer(askedOnTheir(device, amIAuthenticated), true)
+}
+
+func (s *suite) personShouldNotBeAuthenticatedOnTheirDevice(name string, device string) error {
+	return s.Actor(name).ExpectsAnswer(askedOnTheir(device, amIAuthenticated), false)
+}
+
 func (s *suite) personShouldNotSeeAnyProjects(name string) error {
 	return s.Actor(name).ExpectsAnswer(howManyProjectsDoIHave, 0)
 }
@@ -24,8 +70,66 @@ func (s *suite) personShouldSeeTheirProject(name string) error {
 	return s.Actor(name).ExpectsAnswer(howManyProjectsDoIHave, 1)
 }
 
+func (s *suite) personShouldSeeProjects(name string, count int) error {
+	return s.Actor(name).ExpectsAnswer(howManyProjectsDoIHave, count)
+}
+
 func (s *suite) personShouldSeeAnErrorTellingThemToActivateTheAccount(name string) error {
-	return s.Actor(name).ExpectsLastErrorToContain("you need to activate your account")
+	return s.Actor(name).ExpectsLastErrorToBe(entities.ErrAccountNotActivated)
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemTheActivationLinkIsNotValid(name string) error {
+	return s.Actor(name).ExpectsLastErrorToBe(entities.ErrInvalidActivation)
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemTheActivationLinkHasExpired(name string) error {
+	return s.Actor(name).ExpectsLastErrorToBe(entities.ErrActivationExpired)
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemThePasswordIsTooWeak(name string) error {
+	return s.Actor(name).ExpectsLastErrorToBe(entities.ErrWeakPassword)
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemTheNameOrPasswordIsWrong(name string) error {
+	return s.Actor(name).ExpectsLastErrorToBe(entities.ErrWrongCredentials)
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemTheAccountIsLocked(name string) error {
+	return s.Actor(name).ExpectsLastErrorToBe(entities.ErrAccountLocked)
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemToSignIn(name string) error {
+	return s.Actor(name).ExpectsLastErrorToBe(entities.ErrNotSignedIn)
+}
+
+func (s *suite) personShouldBeRefusedAccess(name string) error {
+	return s.Actor(name).ExpectsLastErrorToBe(entities.ErrAccessDenied)
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemTheNameIsAlreadyTaken(name string) error {
+	return s.Actor(name).ExpectsLastErrorToBe(entities.ErrAccountExists)
+}
+
+func (s *suite) personTriesToSignUpAs(name string, accountName string) error {
+	_ = s.Actor(name).AttemptsTo(CreateAccount.called(accountName))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personTriesToSignUpWithThePassword(name string, password string) error {
+	_ = s.Actor(name).AttemptsTo(CreateAccount.withPassword(password))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personTriesToSignInWithThePassword(name string, password string) error {
+	_ = s.Actor(name).AttemptsTo(signInWithPassword(password))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personTriesToSignInWithThePasswordTimes(name string, password string, times int) error {
+	for i := 0; i < times; i++ {
+		_ = s.Actor(name).AttemptsTo(signInWithPassword(password))
+	}
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
 }
 
 func (s *suite) personTriesToSignIn(name string) error {
@@ -33,10 +137,361 @@ func (s *suite) personTriesToSignIn(name string) error {
 	return nil // The step succeeds even if the result is bad to allow the next step to check the error
 }
 
+func (s *suite) personSignsIn(name string) error {
+	return s.Actor(name).AttemptsTo(signIn)
+}
+
+func (s *suite) personSignsInOnTheirDevice(name string, device string) error {
+	return s.Actor(name).AttemptsTo(onTheir(device, signIn))
+}
+
+func (s *suite) personSignsOut(name string) error {
+	return s.Actor(name).AttemptsTo(signOut)
+}
+
+func (s *suite) personSignsOutOnTheirDevice(name string, device string) error {
+	return s.Actor(name).AttemptsTo(onTheir(device, signOut))
+}
+
+func (s *suite) personTriesToSignOutOnTheirDevice(name string, device string) error {
+	_ = s.Actor(name).AttemptsTo(onTheir(device, signOut))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personTriesToOpenTheProjectsOf(name string, owner string) error {
+	_ = s.Actor(name).AttemptsTo(openProjectsOf(owner))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
 func (s *suite) personCreatesAProject(name string) error {
 	return s.Actor(name).AttemptsTo(createProject)
 }
 
+func (s *suite) personTriesToCreateAProject(name string) error {
+	_ = s.Actor(name).AttemptsTo(createProject)
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personMovesToThePlan(name string, plan string) error {
+	return s.Actor(name).AttemptsTo(changePlan(entities.Plan(plan)))
+}
+
+func (s *suite) personShouldBeOnThePlan(name string, plan string) error {
+	return s.Actor(name).ExpectsAnswer(whatPlanAmIOn, entities.Plan(plan))
+}
+
+func (s *suite) personShouldBeToldToUpgrade(name string) error {
+	return s.Actor(name).ExpectsLastErrorToBe(entities.ErrQuotaExceeded)
+}
+
+func (s *suite) personDownloadsTheirData(name string) error {
+	return s.Actor(name).AttemptsTo(downloadMyData)
+}
+
+func (s *suite) personsDownloadShouldHoldTheProjects(name string, projectNames string) error {
+	return s.Actor(name).ExpectsAnswer(whichProjectsDidIDownload, projectNames)
+}
+
+func (s *suite) personClosesTheirAccount(name string) error {
+	return s.Actor(name).AttemptsTo(closeMyAccount)
+}
+
+func (s *suite) personTriesToCloseTheirAccount(name string) error {
+	_ = s.Actor(name).AttemptsTo(closeMyAccount)
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personsAccountShouldBeClosed(name string) error {
+	return s.Actor(name).ExpectsAnswer(isMyAccountClosed, true)
+}
+
+func (s *suite) personCreatesAProjectCalled(name string, projectName string) error {
+	return s.Actor(name).AttemptsTo(createProjectCalled(projectName))
+}
+
+func (s *suite) personRenamesTheProject(name string, projectName string, newName string) error {
+	return s.Actor(name).AttemptsTo(renameProject(projectName, newName))
+}
+
+func (s *suite) personDeletesTheProject(name string, projectName string) error {
+	return s.Actor(name).AttemptsTo(deleteProject(projectName))
+}
+
+func (s *suite) personArchivesTheProject(name string, projectName string) error {
+	return s.Actor(name).AttemptsTo(archiveProject(projectName))
+}
+
+func (s *suite) personRestoresTheProject(name string, projectName string) error {
+	return s.Actor(name).AttemptsTo(restoreProject(projectName))
+}
+
+func (s *suite) personTriesToArchiveTheProject(name string, projectName string) error {
+	_ = s.Actor(name).AttemptsTo(archiveProject(projectName))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personTriesToRestoreTheProject(name string, projectName string) error {
+	_ = s.Actor(name).AttemptsTo(restoreProject(projectName))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personTriesToRenameTheProject(name string, projectName string, newName string) error {
+	_ = s.Actor(name).AttemptsTo(renameProject(projectName, newName))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personShouldSeeTheArchivedProjectCalled(name string, projectName string) error {
+	return s.Actor(name).ExpectsAnswer(doIHaveAnArchivedProjectCalled(projectName), true)
+}
+
+func (s *suite) personShouldNotSeeTheArchivedProjectCalled(name string, projectName string) error {
+	return s.Actor(name).ExpectsAnswer(doIHaveAnArchivedProjectCalled(projectName), false)
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemTheProjectIsArchived(name string) error {
+	return s.Actor(name).ExpectsLastErrorToBe(entities.ErrProjectArchived)
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemTheProjectIsNotArchived(name string) error {
+	return s.Actor(name).ExpectsLastErrorToBe(entities.ErrProjectNotArchived)
+}
+
+func (s *suite) personInvitesToTheProjectAs(name string, invitee string, projectName string, role string) error {
+	return s.Actor(name).AttemptsTo(inviteToProject(projectName, invitee, entities.Role(role)))
+}
+
+func (s *suite) personTriesToInviteToTheProjectAs(name string, invitee string, projectName string, role string) error {
+	_ = s.Actor(name).AttemptsTo(inviteToProject(projectName, invitee, entities.Role(role)))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personAcceptsTheInvitationToTheProject(name string, projectName string) error {
+	return s.Actor(name).AttemptsTo(acceptInvitation(projectName))
+}
+
+func (s *suite) personDeclinesTheInvitationToTheProject(name string, projectName string) error {
+	return s.Actor(name).AttemptsTo(declineInvitation(projectName))
+}
+
+func (s *suite) personShouldSeeAnInvitationToTheProject(name string, projectName string) error {
+	return s.Actor(name).ExpectsAnswer(doIHaveAnInvitationTo(projectName), true)
+}
+
+func (s *suite) personShouldNotSeeAnInvitationToTheProject(name string, projectName string) error {
+	return s.Actor(name).ExpectsAnswer(doIHaveAnInvitationTo(projectName), false)
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemTheyAlreadyShareTheProject(name string) error {
+	return s.Actor(name).ExpectsLastErrorToBe(entities.ErrAlreadyMember)
+}
+
+func (s *suite) personCreatesTheOrganisation(name string, orgName string) error {
+	return s.Actor(name).AttemptsTo(createOrganisation(orgName))
+}
+
+func (s *suite) personTriesToCreateTheOrganisation(name string, orgName string) error {
+	_ = s.Actor(name).AttemptsTo(createOrganisation(orgName))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personAddsToTheOrganisationAs(name string, member string, orgName string, role string) error {
+	return s.Actor(name).AttemptsTo(setOrganisationMember(orgName, member, entities.OrgRole(role)))
+}
+
+func (s *suite) personTriesToAddToTheOrganisationAs(name string, member string, orgName string, role string) error {
+	_ = s.Actor(name).AttemptsTo(setOrganisationMember(orgName, member, entities.OrgRole(role)))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personRemovesFromTheOrganisation(name string, member string, orgName string) error {
+	return s.Actor(name).AttemptsTo(removeOrganisationMember(orgName, member))
+}
+
+func (s *suite) personTriesToRemoveFromTheOrganisation(name string, member string, orgName string) error {
+	_ = s.Actor(name).AttemptsTo(removeOrganisationMember(orgName, member))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personTriesToDeleteTheOrganisation(name string, orgName string) error {
+	_ = s.Actor(name).AttemptsTo(deleteOrganisation(orgName))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personShouldBeOfTheOrganisation(name string, role string, orgName string) error {
+	return s.Actor(name).ExpectsAnswer(whatIsMyRoleIn(orgName), entities.OrgRole(role))
+}
+
+func (s *suite) personShouldNotBelongToTheOrganisation(name string, orgName string) error {
+	return s.Actor(name).ExpectsAnswer(whatIsMyRoleIn(orgName), entities.OrgRole(""))
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemTheOrganisationMustKeepAnOwner(name string) error {
+	return s.Actor(name).ExpectsLastErrorToBe(entities.ErrLastOwner)
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemTheOrganisationAlreadyExists(name string) error {
+	return s.Actor(name).ExpectsLastErrorToBe(entities.ErrOrganisationExists)
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemThereIsNoSuchOrganisation(name string) error {
+	return s.Actor(name).ExpectsLastErrorToBe(entities.ErrOrganisationNotFound)
+}
+
+func (s *suite) personCreatesTheProjectInTheOrganisation(name string, projectName string, orgName string) error {
+	return s.Actor(name).AttemptsTo(createOrganisationProject(orgName, projectName))
+}
+
+func (s *suite) personDeletesTheProjectInTheOrganisation(name string, projectName string, orgName string) error {
+	return s.Actor(name).AttemptsTo(deleteOrganisationProject(orgName, projectName))
+}
+
+func (s *suite) personTriesToDeleteTheProjectInTheOrganisation(name string, projectName string, orgName string) error {
+	_ = s.Actor(name).AttemptsTo(deleteOrganisationProject(orgName, projectName))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personTriesToLookAtTheProjectsInTheOrganisation(name string, orgName string) error {
+	_ = s.Actor(name).AttemptsTo(openOrganisationProjects(orgName))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personShouldSeeTheProjectInTheOrganisation(name string, projectName string, orgName string) error {
+	return s.Actor(name).ExpectsAnswer(doesTheOrganisationHaveAProjectCalled(orgName, projectName), true)
+}
+
+func (s *suite) personShouldNotSeeTheProjectInTheOrganisation(name string, projectName string, orgName string) error {
+	return s.Actor(name).ExpectsAnswer(doesTheOrganisationHaveAProjectCalled(orgName, projectName), false)
+}
+
+func (s *suite) personShouldSeeTheProjectCalled(name string, projectName string) error {
+	return s.Actor(name).ExpectsAnswer(doIHaveAProjectCalled(projectName), true)
+}
+
+func (s *suite) personShouldNotSeeTheProjectCalled(name string, projectName string) error {
+	return s.Actor(name).ExpectsAnswer(doIHaveAProjectCalled(projectName), false)
+}
+
+func (s *suite) personPagesThroughTheirProjects(name string, limit int) error {
+	return s.Actor(name).AttemptsTo(pageThroughMyProjects(limit))
+}
+
+func (s *suite) personListsTheirProjectsByName(name string) error {
+	return s.Actor(name).AttemptsTo(listMyProjects(entities.ProjectQuery{Sort: entities.SortByName}))
+}
+
+func (s *suite) personListsTheirProjectsWhoseNamesStartWith(name string, prefix string) error {
+	return s.Actor(name).AttemptsTo(listMyProjects(entities.ProjectQuery{NamePrefix: prefix}))
+}
+
+func (s *suite) personListsTheirProjectsCreatedMoreThanMinutesAfter(name string, minutes int, projectName string) error {
+	return s.Actor(name).AttemptsTo(listMyProjectsCreatedAfter(time.Duration(minutes)*time.Minute, projectName))
+}
+
+func (s *suite) personShouldHaveBeenShownPagesOfProjects(name string, count int) error {
+	return s.Actor(name).ExpectsAnswer(howManyPagesOfProjectsWasIShown, count)
+}
+
+// personShouldHaveBeenShownAllProjectsOnceEach expects the projects made by signing up
+// with count projects, in the order they were created
+func (s *suite) personShouldHaveBeenShownAllProjectsOnceEach(name string, count int) error {
+	names := make([]string, count)
+	for i := range names {
+		names[i] = fmt.Sprintf("Project %d", i+1)
+	}
+	return s.Actor(name).ExpectsAnswer(whichProjectsWasIShown, strings.Join(names, ", "))
+}
+
+func (s *suite) personShouldHaveBeenShownTheProjects(name string, projectNames string) error {
+	return s.Actor(name).ExpectsAnswer(whichProjectsWasIShown, projectNames)
+}
+
+func (s *suite) personCreatesProjectsAtTheSameTime(name string, count int) error {
+	return s.Actor(name).AttemptsTo(createProjectsAtTheSameTime(count))
+}
+
 func (s *suite) personActivatesTheirAccount(name string) error {
 	return s.Actor(name).AttemptsTo(Activate.theirAccount)
 }
+
+func (s *suite) personFollowsTheirActivationLinkAgain(name string) error {
+	_ = s.Actor(name).AttemptsTo(Activate.theirAccount)
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personTriesToActivateTheirAccount(name string) error {
+	_ = s.Actor(name).AttemptsTo(Activate.theirAccount)
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+// daysHavePassed makes time pass for the system rather than for any one actor
+func (s *suite) daysHavePassed(days int) error {
+	return s.driver.AdvanceClock(time.Duration(days) * 24 * time.Hour)
+}
+
+func (s *suite) anEventShouldHaveBeenPublishedFor(kind string, name string) error {
+	return s.Actor(name).ExpectsAnswer(wasAnEventPublishedAboutMe(events.Kind(kind)), true)
+}
+
+func (s *suite) noEventShouldHaveBeenPublishedFor(kind string, name string) error {
+	return s.Actor(name).ExpectsAnswer(wasAnEventPublishedAboutMe(events.Kind(kind)), false)
+}
+
+// minutesHavePassed makes time pass for the system rather than for any one actor
+func (s *suite) minutesHavePassed(minutes int) error {
+	return s.driver.AdvanceClock(time.Duration(minutes) * time.Minute)
+}
+
+func (s *suite) personWatchesTheirAccount(name string) error {
+	return s.Actor(name).AttemptsTo(watchMyAccount)
+}
+
+func (s *suite) personStopsWatchingTheirAccount(name string) error {
+	return s.Actor(name).AttemptsTo(stopWatchingMyAccount)
+}
+
+func (s *suite) personShouldBeNotifiedThatTheirProjectWasCreated(name string) error {
+	return s.Actor(name).ExpectsAnswer(amINotifiedOf(events.ProjectCreated), true)
+}
+
+func (s *suite) personShouldBeNotifiedThatTheySignedIn(name string) error {
+	return s.Actor(name).ExpectsAnswer(amINotifiedOf(events.Authenticated), true)
+}
+
+func (s *suite) personShouldHaveBeenNotifiedThatProjectsWereCreated(name string, count int) error {
+	return s.Actor(name).ExpectsAnswer(howManyNotificationsOf(events.ProjectCreated), count)
+}
+
+func (s *suite) personRegistersAWebhook(name string) error {
+	return s.Actor(name).AttemptsTo(registerMyWebhook)
+}
+
+func (s *suite) personHasCreatedAnAccountWithAWebhook(name string) error {
+	return s.Actor(name).AttemptsTo(CreateAccount.withMyWebhook)
+}
+
+func (s *suite) personsWebhookGoesDown(name string) error {
+	return s.Actor(name).AttemptsTo(takeMyWebhookDown)
+}
+
+func (s *suite) personsWebhookComesBackUp(name string) error {
+	return s.Actor(name).AttemptsTo(bringMyWebhookBackUp)
+}
+
+func (s *suite) personsWebhookShouldBeSentASignedPayload(name string, kind string) error {
+	return s.Actor(name).ExpectsAnswer(wasMyWebhookSentASigned(events.Kind(kind)), true)
+}
+
+func (s *suite) theDeliveryToPersonsWebhookShouldHaveSucceededAfter(name string, attempts int) error {
+	return s.Actor(name).ExpectsAnswer(whatBecameOfTheDeliveryToMyWebhook(attempts), deliveryOutcome{status: webhooks.StatusDelivered, attempts: attempts})
+}
+
+func (s *suite) theDeliveryToPersonsWebhookShouldBeWaitingToBeRetried(name string) error {
+	if err := s.Actor(name).ExpectsAnswer(whatBecameOfTheDeliveryToMyWebhook(1), deliveryOutcome{status: webhooks.StatusPending, attempts: 1}); err != nil {
+		return err
+	}
+	return s.Actor(name).ExpectsAnswer(howDidMyWebhookRespondToTheDelivery, http.StatusServiceUnavailable)
+}
+
+func (s *suite) theServerRestarts() error {
+	return s.server.Restart()
+}
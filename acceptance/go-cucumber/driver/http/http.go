@@ -1,33 +1,111 @@
 package http
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
+	"mime"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/sirockin/cucumber-screenplay-go/acceptance/driver"
 	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/export"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/notifier"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/testhelpers"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/webhooks"
 )
 
 type AcceptanceTestDriver struct {
-	baseURL string
-	client  *http.Client
+	baseURL   string
+	client    *http.Client
+	namespace *namespaceTransport
+
+	mu sync.Mutex
+	// sessions holds the client's session token for each account it is signed in to
+	sessions map[string]string
+	devices  map[string]*AcceptanceTestDriver
+	// watches holds the client's event stream for each account it watches
+	watches map[string]*watch
 }
 
 func New(baseURL string) *AcceptanceTestDriver {
+	return newInNamespace(baseURL, driver.NewNamespace())
+}
+
+// newInNamespace creates a driver whose requests are all in the namespace on the server
+func newInNamespace(baseURL string, namespace string) *AcceptanceTestDriver {
+	transport := &namespaceTransport{namespace: namespace}
 	return &AcceptanceTestDriver{
-		baseURL: baseURL,
-		client:  &http.Client{},
+		baseURL:   baseURL,
+		client:    &http.Client{Transport: transport},
+		namespace: transport,
+		sessions:  make(map[string]string),
+		devices:   make(map[string]*AcceptanceTestDriver),
+		watches:   make(map[string]*watch),
 	}
 }
 
+// namespaceTransport sends each request in the driver's namespace on the server, which
+// the driver moves to a new one each time it clears its data
+type namespaceTransport struct {
+	mu        sync.Mutex
+	namespace string
+}
+
+func (n *namespaceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(driver.NamespaceHeader, n.current())
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func (n *namespaceTransport) current() string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.namespace
+}
+
+func (n *namespaceTransport) moveTo(namespace string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.namespace = namespace
+}
+
 // verify that AcceptanceTestDriver implements AcceptanceTestDriver
 var _ driver.TestDriver = (*AcceptanceTestDriver)(nil)
 
-func (h *AcceptanceTestDriver) CreateAccount(name string) error {
-	reqBody := map[string]string{"name": name}
+func (h *AcceptanceTestDriver) OnDevice(device string) driver.TestDriver {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, exists := h.devices[device]; !exists {
+		// Other devices are clients of the same data, so share the namespace
+		h.devices[device] = newInNamespace(h.baseURL, h.namespace.current())
+	}
+	return h.devices[device]
+}
+
+func (h *AcceptanceTestDriver) CreateAccount(name string, password string) error {
+	return h.createAccount(map[string]any{"name": name, "password": password})
+}
+
+func (h *AcceptanceTestDriver) CreateAccountWithWebhook(name string, password string, webhookURL string, secret string) error {
+	return h.createAccount(map[string]any{
+		"name":     name,
+		"password": password,
+		"webhook":  map[string]string{"url": webhookURL, "secret": secret},
+	})
+}
+
+func (h *AcceptanceTestDriver) createAccount(reqBody map[string]any) error {
 	jsonBody, err := json.Marshal(reqBody)
 	if err != nil {
 		return err
@@ -40,8 +118,7 @@ func (h *AcceptanceTestDriver) CreateAccount(name string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("create account failed with status %d: %s", resp.StatusCode, string(body))
+		return errorFromResponse(resp, "create account")
 	}
 
 	return nil
@@ -58,6 +135,90 @@ func (h *AcceptanceTestDriver) ClearAll() {
 		return
 	}
 	defer resp.Body.Close()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	// Start the next scenario in a namespace of its own, which starts out empty
+	h.namespace.moveTo(driver.NewNamespace())
+	h.sessions = make(map[string]string)
+	h.devices = make(map[string]*AcceptanceTestDriver)
+	// Clearing the server ends the streams, so the old watches stop by themselves
+	h.watches = make(map[string]*watch)
+}
+
+func (h *AcceptanceTestDriver) Snapshot() (testhelpers.Snapshot, error) {
+	resp, err := h.client.Get(h.baseURL + "/admin/snapshot")
+	if err != nil {
+		return testhelpers.Snapshot{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return testhelpers.Snapshot{}, errorFromResponse(resp, "take snapshot")
+	}
+
+	var snapshot testhelpers.Snapshot
+	if err := json.NewDecoder(resp.Body).Decode(&snapshot); err != nil {
+		return testhelpers.Snapshot{}, err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	snapshot.Sessions = maps.Clone(h.sessions)
+	return snapshot, nil
+}
+
+func (h *AcceptanceTestDriver) Restore(snapshot testhelpers.Snapshot) error {
+	jsonBody, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("PUT", h.baseURL+"/admin/snapshot", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return errorFromResponse(resp, "restore snapshot")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sessions = maps.Clone(snapshot.Sessions)
+	if h.sessions == nil {
+		h.sessions = make(map[string]string)
+	}
+	h.devices = make(map[string]*AcceptanceTestDriver)
+	// Restoring clears the server's events, which ends the streams, so the old watches stop by themselves
+	h.watches = make(map[string]*watch)
+	return nil
+}
+
+func (h *AcceptanceTestDriver) AdvanceClock(d time.Duration) error {
+	jsonBody, err := json.Marshal(map[string]string{"duration": d.String()})
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.client.Post(h.baseURL+"/clock/advance", "application/json", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errorFromResponse(resp, "advance clock")
+	}
+
+	return nil
 }
 
 func (h *AcceptanceTestDriver) GetAccount(name string) (entities.Account, error) {
@@ -67,19 +228,16 @@ func (h *AcceptanceTestDriver) GetAccount(name string) (entities.Account, error)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return entities.Account{}, fmt.Errorf("account not found: %s", name)
-	}
-
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return entities.Account{}, fmt.Errorf("get account failed with status %d: %s", resp.StatusCode, string(body))
+		return entities.Account{}, errorFromResponse(resp, "get account")
 	}
 
 	var account struct {
-		Name          string `json:"name"`
-		Activated     bool   `json:"activated"`
-		Authenticated bool   `json:"authenticated"`
+		ID        string        `json:"id"`
+		Name      string        `json:"name"`
+		Activated bool          `json:"activated"`
+		Plan      entities.Plan `json:"plan"`
+		ClosedAt  time.Time     `json:"closedAt"`
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -93,48 +251,40 @@ func (h *AcceptanceTestDriver) GetAccount(name string) (entities.Account, error)
 	}
 
 	// Create a domain account and set its fields using the accessor methods
-	domainAccount := entities.NewAccount(account.Name)
+	domainAccount := entities.NewAccount(account.ID, account.Name)
 	domainAccount.SetActivated(account.Activated)
-	domainAccount.SetAuthenticated(account.Authenticated)
+	domainAccount.SetPlan(account.Plan)
+	domainAccount.SetClosedAt(account.ClosedAt)
 
 	return *domainAccount, nil
 }
 
-func (h *AcceptanceTestDriver) Authenticate(name string) error {
-	req, err := http.NewRequest("POST", h.baseURL+"/accounts/"+name+"/authenticate", nil)
+func (h *AcceptanceTestDriver) Authenticate(name string, password string) error {
+	jsonBody, err := json.Marshal(map[string]string{"password": password})
 	if err != nil {
 		return err
 	}
 
-	resp, err := h.client.Do(req)
+	resp, err := h.client.Post(h.baseURL+"/accounts/"+name+"/authenticate", "application/json", bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("account not found: %s", name)
-	}
-
-	if resp.StatusCode == http.StatusBadRequest {
-		body, _ := io.ReadAll(resp.Body)
-		var errorResp struct {
-			Error string `json:"error"`
-		}
-		_ = json.Unmarshal(body, &errorResp)
-		return fmt.Errorf("%s", errorResp.Error)
-	}
-
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("authenticate failed with status %d: %s", resp.StatusCode, string(body))
+		return errorFromResponse(resp, "authenticate")
 	}
 
-	return nil
+	return h.keepSession(name, resp.Body)
 }
 
 func (h *AcceptanceTestDriver) IsAuthenticated(name string) bool {
-	resp, err := h.client.Get(h.baseURL + "/accounts/" + name + "/authentication-status")
+	req, err := h.newRequest("GET", h.baseURL+"/accounts/"+name+"/authentication-status", name, nil)
+	if err != nil {
+		return false
+	}
+
+	resp, err := h.client.Do(req)
 	if err != nil {
 		return false
 	}
@@ -154,8 +304,8 @@ func (h *AcceptanceTestDriver) IsAuthenticated(name string) bool {
 	return authStatus.Authenticated
 }
 
-func (h *AcceptanceTestDriver) Activate(name string) error {
-	req, err := http.NewRequest("POST", h.baseURL+"/accounts/"+name+"/activate", nil)
+func (h *AcceptanceTestDriver) SignOut(name string) error {
+	req, err := h.newRequest("POST", h.baseURL+"/sessions/current/sign-out", name, nil)
 	if err != nil {
 		return err
 	}
@@ -166,68 +316,1023 @@ func (h *AcceptanceTestDriver) Activate(name string) error {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("account not found: %s", name)
+	if resp.StatusCode != http.StatusNoContent {
+		return errorFromResponse(resp, "sign out")
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("activate failed with status %d: %s", resp.StatusCode, string(body))
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.sessions, name)
+	return nil
+}
+
+func (h *AcceptanceTestDriver) ChangePlan(name string, plan entities.Plan) error {
+	jsonBody, err := json.Marshal(map[string]entities.Plan{"plan": plan})
+	if err != nil {
+		return err
 	}
 
-	return nil
+	req, err := h.newRequest("PUT", h.baseURL+"/accounts/"+url.PathEscape(name)+"/plan", name, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return h.doWithoutContent(req, "change plan")
 }
 
-func (h *AcceptanceTestDriver) CreateProject(name string) error {
-	req, err := http.NewRequest("POST", h.baseURL+"/accounts/"+name+"/projects", nil)
+// CloseAccount leaves the session it closed the account with in place, so that
+// scenarios can see that the server has ended it
+func (h *AcceptanceTestDriver) CloseAccount(name string) error {
+	req, err := h.newRequest("DELETE", h.baseURL+"/accounts/"+url.PathEscape(name), name, nil)
 	if err != nil {
 		return err
 	}
 
+	return h.doWithoutContent(req, "close account")
+}
+
+func (h *AcceptanceTestDriver) ExportAccount(name string) (export.Archive, error) {
+	req, err := h.newRequest("GET", h.baseURL+"/accounts/"+url.PathEscape(name)+"/export", name, nil)
+	if err != nil {
+		return export.Archive{}, err
+	}
+
 	resp, err := h.client.Do(req)
+	if err != nil {
+		return export.Archive{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return export.Archive{}, errorFromResponse(resp, "export account")
+	}
+
+	// The archive is meant to be downloaded as a file, not shown
+	_, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition"))
+	if err != nil {
+		return export.Archive{}, fmt.Errorf("export account: %w", err)
+	}
+	if params["filename"] != export.Filename(name) {
+		return export.Archive{}, fmt.Errorf("export account: downloaded as %q, not %q", params["filename"], export.Filename(name))
+	}
+
+	return export.Read(resp.Body)
+}
+
+func (h *AcceptanceTestDriver) GetMessages(name string) ([]notifier.Message, error) {
+	resp, err := h.client.Get(h.baseURL + "/outbox/" + url.PathEscape(name))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorFromResponse(resp, "get messages")
+	}
+
+	var messages []struct {
+		To      string `json:"to"`
+		Subject string `json:"subject"`
+		Body    string `json:"body"`
+		Link    string `json:"link"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&messages); err != nil {
+		return nil, err
+	}
+
+	result := make([]notifier.Message, 0, len(messages))
+	for _, m := range messages {
+		result = append(result, notifier.Message{To: m.To, Subject: m.Subject, Body: m.Body, Link: m.Link})
+	}
+	return result, nil
+}
+
+func (h *AcceptanceTestDriver) GetEvents(name string) ([]events.Event, error) {
+	resp, err := h.client.Get(h.baseURL + "/events/" + url.PathEscape(name))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorFromResponse(resp, "get events")
+	}
+
+	var published []eventBody
+	if err := json.NewDecoder(resp.Body).Decode(&published); err != nil {
+		return nil, err
+	}
+
+	result := make([]events.Event, 0, len(published))
+	for _, e := range published {
+		result = append(result, e.toEvent())
+	}
+	return result, nil
+}
+
+func (h *AcceptanceTestDriver) FollowActivationLink(link string) error {
+	// The link is for the front end, so pick out what the API needs from it
+	name, token, err := notifier.ParseActivationLink(link)
+	if err != nil {
+		return err
+	}
+
+	jsonBody, err := json.Marshal(map[string]string{"token": token})
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.client.Post(h.baseURL+"/accounts/"+url.PathEscape(name)+"/activate", "application/json", bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("account not found: %s", name)
+	if resp.StatusCode != http.StatusOK {
+		return errorFromResponse(resp, "activate")
 	}
 
-	if resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("create project failed with status %d: %s", resp.StatusCode, string(body))
+	return h.keepSession(name, resp.Body)
+}
+
+// keepSession keeps the session token from a response that signed the client in to an account
+func (h *AcceptanceTestDriver) keepSession(name string, r io.Reader) error {
+	var session struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(r).Decode(&session); err != nil {
+		return err
 	}
 
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sessions[name] = session.Token
 	return nil
 }
 
-func (h *AcceptanceTestDriver) GetProjects(name string) ([]entities.Project, error) {
-	resp, err := h.client.Get(h.baseURL + "/accounts/" + name + "/projects")
+// newRequest creates a request that carries the client's session token for the account, if it has one
+func (h *AcceptanceTestDriver) newRequest(method string, endpoint string, name string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, endpoint, body)
 	if err != nil {
 		return nil, err
 	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if token, ok := h.sessions[name]; ok {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req, nil
+}
+
+// watch is the client's event stream for an account, and what it has been notified
+// of through it
+type watch struct {
+	notifications testhelpers.Notifications
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func (h *AcceptanceTestDriver) WatchAccount(name string) error {
+	w := h.watch(name)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.cancel != nil {
+		return nil
+	}
+
+	req, err := h.newRequest("GET", h.baseURL+"/accounts/"+url.PathEscape(name)+"/events", name, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if lastID, ok := w.notifications.LastID(); ok {
+		req.Header.Set("Last-Event-ID", strconv.FormatUint(lastID, 10))
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	resp, err := h.client.Do(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		cancel()
+		return errorFromResponse(resp, "watch account")
+	}
+
+	// Read the stream until it ends, letting the caller go on once it has caught up
+	ready := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer resp.Body.Close()
+		_ = readEventStream(resp.Body, func(e streamEvent) {
+			if e.name == "ready" {
+				w.notifications.Reach(e.id)
+				close(ready)
+				return
+			}
+			var body eventBody
+			if err := json.Unmarshal([]byte(e.data), &body); err == nil {
+				w.notifications.Add(e.id, body.toEvent())
+			}
+		})
+	}()
+	select {
+	case <-ready:
+	case <-done:
+		cancel()
+		return fmt.Errorf("event stream for %s ended before catching up", name)
+	case <-time.After(testhelpers.NotificationTimeout):
+		cancel()
+		<-done
+		return fmt.Errorf("event stream for %s did not catch up within %v", name, testhelpers.NotificationTimeout)
+	}
+	w.cancel, w.done = cancel, done
+	return nil
+}
+
+func (h *AcceptanceTestDriver) StopWatchingAccount(name string) error {
+	w := h.watch(name)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.cancel == nil {
+		return nil
+	}
+	w.cancel()
+	<-w.done
+	w.cancel, w.done = nil, nil
+	return nil
+}
+
+func (h *AcceptanceTestDriver) AwaitNotification(name string, kind events.Kind) error {
+	return h.watch(name).notifications.Await(kind)
+}
+
+func (h *AcceptanceTestDriver) GetNotifications(name string) ([]events.Event, error) {
+	return h.watch(name).notifications.Events(), nil
+}
+
+func (h *AcceptanceTestDriver) watch(name string) *watch {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.watches[name] == nil {
+		h.watches[name] = &watch{}
+	}
+	return h.watches[name]
+}
+
+// streamEvent is an event read from a Server-Sent Events stream
+type streamEvent struct {
+	id   uint64
+	name string
+	data string
+}
+
+// readEventStream calls dispatch with each event in the stream until the stream ends.
+// Comments, such as heartbeats, are skipped.
+func readEventStream(r io.Reader, dispatch func(streamEvent)) error {
+	scanner := bufio.NewScanner(r)
+	var event streamEvent
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if event.name != "" {
+				dispatch(event)
+			}
+			event = streamEvent{}
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			id, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid event id %q", value)
+			}
+			event.id = id
+		case "event":
+			event.name = value
+		case "data":
+			event.data += value
+		}
+	}
+	return scanner.Err()
+}
+
+func (h *AcceptanceTestDriver) RegisterWebhook(name string, webhookURL string, secret string) (entities.Webhook, error) {
+	jsonBody, err := json.Marshal(map[string]string{"url": webhookURL, "secret": secret})
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+
+	req, err := h.newRequest("POST", h.webhooksURL(name), name, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return entities.Webhook{}, err
+	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("account not found: %s", name)
+	if resp.StatusCode != http.StatusCreated {
+		return entities.Webhook{}, errorFromResponse(resp, "register webhook")
+	}
+
+	var body webhookBody
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return entities.Webhook{}, err
+	}
+	return body.toWebhook(), nil
+}
+
+func (h *AcceptanceTestDriver) GetWebhooks(name string) ([]entities.Webhook, error) {
+	req, err := h.newRequest("GET", h.webhooksURL(name), name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
 	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("get projects failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, errorFromResponse(resp, "get webhooks")
 	}
 
-	var projects []entities.Project
-	body, err := io.ReadAll(resp.Body)
+	var body []webhookBody
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	result := make([]entities.Webhook, 0, len(body))
+	for _, webhook := range body {
+		result = append(result, webhook.toWebhook())
+	}
+	return result, nil
+}
+
+func (h *AcceptanceTestDriver) AwaitWebhookDelivery(name string, webhookID string, attempts int) (webhooks.Delivery, error) {
+	return testhelpers.AwaitDeliveryAttempts(func() ([]webhooks.Delivery, error) {
+		return h.getWebhookDeliveries(name, webhookID)
+	}, attempts)
+}
+
+func (h *AcceptanceTestDriver) getWebhookDeliveries(name string, webhookID string) ([]webhooks.Delivery, error) {
+	req, err := h.newRequest("GET", h.webhooksURL(name)+"/"+url.PathEscape(webhookID)+"/deliveries", name, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	err = json.Unmarshal(body, &projects)
+	resp, err := h.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorFromResponse(resp, "get webhook deliveries")
+	}
 
-	return projects, nil
+	var body []deliveryBody
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	result := make([]webhooks.Delivery, 0, len(body))
+	for _, delivery := range body {
+		result = append(result, delivery.toDelivery(webhookID))
+	}
+	return result, nil
+}
+
+func (h *AcceptanceTestDriver) webhooksURL(name string) string {
+	return h.baseURL + "/accounts/" + url.PathEscape(name) + "/webhooks"
+}
+
+func (h *AcceptanceTestDriver) CreateProject(name string, projectName string) (entities.Project, error) {
+	jsonBody, err := json.Marshal(map[string]string{"name": projectName})
+	if err != nil {
+		return entities.Project{}, err
+	}
+
+	req, err := h.newRequest("POST", h.projectsURL(name), name, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return entities.Project{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return entities.Project{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return entities.Project{}, errorFromResponse(resp, "create project")
+	}
+
+	return decodeProject(resp.Body)
+}
+
+func (h *AcceptanceTestDriver) GetProjects(name string) ([]entities.Project, error) {
+	return h.GetProjectsOf(name, name)
+}
+
+// GetProjectsOf lists all of the owner's projects, acting as name, by following the link
+// from each page to the next
+func (h *AcceptanceTestDriver) GetProjectsOf(name string, owner string) ([]entities.Project, error) {
+	var projects []entities.Project
+	pageURL := h.projectsURL(owner) + "?limit=" + strconv.Itoa(entities.MaxProjectLimit)
+	for pageURL != "" {
+		page, next, err := h.getProjectPage(name, pageURL)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, page...)
+		pageURL = next
+	}
+	return projects, nil
+}
+
+func (h *AcceptanceTestDriver) ListProjects(name string, query entities.ProjectQuery) (entities.ProjectPage, error) {
+	values := url.Values{}
+	if query.Limit != 0 {
+		values.Set("limit", strconv.Itoa(query.Limit))
+	}
+	setIfNotEmpty(values, "cursor", query.Cursor)
+	setIfNotEmpty(values, "sort", string(query.Sort))
+	setIfNotEmpty(values, "namePrefix", query.NamePrefix)
+	if !query.CreatedFrom.IsZero() {
+		values.Set("createdFrom", query.CreatedFrom.Format(time.RFC3339))
+	}
+	if !query.CreatedBefore.IsZero() {
+		values.Set("createdBefore", query.CreatedBefore.Format(time.RFC3339))
+	}
+	if query.IncludeArchived {
+		values.Set("includeArchived", "true")
+	}
+
+	projects, next, err := h.getProjectPage(name, h.projectsURL(name)+"?"+values.Encode())
+	if err != nil {
+		return entities.ProjectPage{}, err
+	}
+	page := entities.ProjectPage{Projects: projects}
+	if next != "" {
+		nextURL, err := url.Parse(next)
+		if err != nil {
+			return entities.ProjectPage{}, err
+		}
+		page.NextCursor = nextURL.Query().Get("cursor")
+	}
+	return page, nil
+}
+
+// getProjectPage gets the page of projects at pageURL, acting as name, along with the URL
+// of the next page, or "" if it is the last
+func (h *AcceptanceTestDriver) getProjectPage(name string, pageURL string) ([]entities.Project, string, error) {
+	req, err := h.newRequest("GET", pageURL, name, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", errorFromResponse(resp, "get projects")
+	}
+
+	var body []projectBody
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, "", err
+	}
+
+	projects := make([]entities.Project, 0, len(body))
+	for _, project := range body {
+		projects = append(projects, project.toProject())
+	}
+	next, err := nextLink(resp)
+	if err != nil {
+		return nil, "", err
+	}
+	return projects, next, nil
+}
+
+// nextLink returns the URL of the next page that the response's Link header points to,
+// or "" if there is none
+func nextLink(resp *http.Response) (string, error) {
+	for _, header := range resp.Header.Values("Link") {
+		for _, link := range strings.Split(header, ",") {
+			target, params, _ := strings.Cut(link, ";")
+			if !strings.Contains(params, `rel="next"`) {
+				continue
+			}
+			next, err := resp.Request.URL.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+			if err != nil {
+				return "", fmt.Errorf("failed to read link to next page: %w", err)
+			}
+			return next.String(), nil
+		}
+	}
+	return "", nil
+}
+
+func setIfNotEmpty(values url.Values, key string, value string) {
+	if value != "" {
+		values.Set(key, value)
+	}
+}
+
+func (h *AcceptanceTestDriver) GetProject(name string, projectID string) (entities.Project, error) {
+	req, err := h.newRequest("GET", h.projectURL(name, projectID), name, nil)
+	if err != nil {
+		return entities.Project{}, err
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return entities.Project{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return entities.Project{}, errorFromResponse(resp, "get project")
+	}
+
+	return decodeProject(resp.Body)
+}
+
+func (h *AcceptanceTestDriver) RenameProject(name string, projectID string, projectName string) (entities.Project, error) {
+	jsonBody, err := json.Marshal(map[string]string{"name": projectName})
+	if err != nil {
+		return entities.Project{}, err
+	}
+
+	req, err := h.newRequest("PATCH", h.projectURL(name, projectID), name, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return entities.Project{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return entities.Project{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return entities.Project{}, errorFromResponse(resp, "rename project")
+	}
+
+	return decodeProject(resp.Body)
+}
+
+func (h *AcceptanceTestDriver) DeleteProject(name string, projectID string) error {
+	req, err := h.newRequest("DELETE", h.projectURL(name, projectID), name, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return errorFromResponse(resp, "delete project")
+	}
+
+	return nil
+}
+
+func (h *AcceptanceTestDriver) ArchiveProject(name string, projectID string) (entities.Project, error) {
+	return h.moveProject(name, projectID, "archive")
+}
+
+func (h *AcceptanceTestDriver) RestoreProject(name string, projectID string) (entities.Project, error) {
+	return h.moveProject(name, projectID, "restore")
+}
+
+// moveProject archives or restores a project, as action says
+func (h *AcceptanceTestDriver) moveProject(name string, projectID string, action string) (entities.Project, error) {
+	req, err := h.newRequest("POST", h.projectURL(name, projectID)+"/"+action, name, nil)
+	if err != nil {
+		return entities.Project{}, err
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return entities.Project{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return entities.Project{}, errorFromResponse(resp, action+" project")
+	}
+
+	return decodeProject(resp.Body)
+}
+
+func (h *AcceptanceTestDriver) InviteToProject(name string, projectID string, invitee string, role entities.Role) error {
+	jsonBody, err := json.Marshal(map[string]string{"account": invitee, "role": string(role)})
+	if err != nil {
+		return err
+	}
+
+	req, err := h.newRequest("POST", h.projectURL(name, projectID)+"/invitations", name, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return errorFromResponse(resp, "invite to project")
+	}
+
+	return nil
+}
+
+func (h *AcceptanceTestDriver) GetInvitations(name string) ([]entities.Invitation, error) {
+	req, err := h.newRequest("GET", h.invitationsURL(name), name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorFromResponse(resp, "get invitations")
+	}
+
+	var body []invitationBody
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	result := make([]entities.Invitation, 0, len(body))
+	for _, invitation := range body {
+		result = append(result, entities.Invitation(invitation))
+	}
+	return result, nil
+}
+
+func (h *AcceptanceTestDriver) AcceptInvitation(name string, projectID string) error {
+	return h.answerInvitation(name, projectID, "accept", http.StatusOK)
+}
+
+func (h *AcceptanceTestDriver) DeclineInvitation(name string, projectID string) error {
+	return h.answerInvitation(name, projectID, "decline", http.StatusNoContent)
+}
+
+// answerInvitation accepts or declines an invitation, as action says, expecting the
+// given status code
+func (h *AcceptanceTestDriver) answerInvitation(name string, projectID string, action string, statusCode int) error {
+	req, err := h.newRequest("POST", h.invitationsURL(name)+"/"+url.PathEscape(projectID)+"/"+action, name, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != statusCode {
+		return errorFromResponse(resp, action+" invitation")
+	}
+
+	return nil
+}
+
+func (h *AcceptanceTestDriver) CreateOrganisation(name string, orgName string) error {
+	jsonBody, err := json.Marshal(map[string]string{"name": orgName})
+	if err != nil {
+		return err
+	}
+
+	req, err := h.newRequest("POST", h.baseURL+"/orgs", name, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return errorFromResponse(resp, "create organisation")
+	}
+
+	return nil
+}
+
+func (h *AcceptanceTestDriver) GetOrganisations(name string) ([]entities.Membership, error) {
+	memberships, err := h.getMemberships(name, h.baseURL+"/orgs", "get organisations")
+	for i := range memberships {
+		memberships[i].Account = name
+	}
+	return memberships, err
+}
+
+func (h *AcceptanceTestDriver) GetOrganisationMembers(name string, orgName string) ([]entities.Membership, error) {
+	members, err := h.getMemberships(name, h.organisationURL(orgName)+"/members", "get organisation members")
+	for i := range members {
+		members[i].Organisation = orgName
+	}
+	return members, err
+}
+
+// getMemberships gets a list of memberships, acting as name
+func (h *AcceptanceTestDriver) getMemberships(name string, endpoint string, action string) ([]entities.Membership, error) {
+	req, err := h.newRequest("GET", endpoint, name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorFromResponse(resp, action)
+	}
+
+	var body []membershipBody
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	result := make([]entities.Membership, 0, len(body))
+	for _, membership := range body {
+		result = append(result, entities.Membership(membership))
+	}
+	return result, nil
+}
+
+func (h *AcceptanceTestDriver) SetOrganisationMember(name string, orgName string, member string, role entities.OrgRole) error {
+	jsonBody, err := json.Marshal(map[string]string{"role": string(role)})
+	if err != nil {
+		return err
+	}
+
+	req, err := h.newRequest("PUT", h.memberURL(orgName, member), name, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return h.doWithoutContent(req, "set organisation member")
+}
+
+func (h *AcceptanceTestDriver) RemoveOrganisationMember(name string, orgName string, member string) error {
+	req, err := h.newRequest("DELETE", h.memberURL(orgName, member), name, nil)
+	if err != nil {
+		return err
+	}
+
+	return h.doWithoutContent(req, "remove organisation member")
+}
+
+func (h *AcceptanceTestDriver) DeleteOrganisation(name string, orgName string) error {
+	req, err := h.newRequest("DELETE", h.organisationURL(orgName), name, nil)
+	if err != nil {
+		return err
+	}
+
+	return h.doWithoutContent(req, "delete organisation")
+}
+
+func (h *AcceptanceTestDriver) CreateOrganisationProject(name string, orgName string, projectName string) (entities.Project, error) {
+	jsonBody, err := json.Marshal(map[string]string{"name": projectName})
+	if err != nil {
+		return entities.Project{}, err
+	}
+
+	req, err := h.newRequest("POST", h.organisationURL(orgName)+"/projects", name, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return entities.Project{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return entities.Project{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return entities.Project{}, errorFromResponse(resp, "create organisation project")
+	}
+
+	return decodeProject(resp.Body)
+}
+
+func (h *AcceptanceTestDriver) GetOrganisationProjects(name string, orgName string) ([]entities.Project, error) {
+	req, err := h.newRequest("GET", h.organisationURL(orgName)+"/projects", name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorFromResponse(resp, "get organisation projects")
+	}
+
+	var body []projectBody
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	result := make([]entities.Project, 0, len(body))
+	for _, project := range body {
+		result = append(result, project.toProject())
+	}
+	return result, nil
+}
+
+func (h *AcceptanceTestDriver) DeleteOrganisationProject(name string, orgName string, projectID string) error {
+	req, err := h.newRequest("DELETE", h.organisationURL(orgName)+"/projects/"+url.PathEscape(projectID), name, nil)
+	if err != nil {
+		return err
+	}
+
+	return h.doWithoutContent(req, "delete organisation project")
+}
+
+// doWithoutContent sends a request that answers with no content when it succeeds
+func (h *AcceptanceTestDriver) doWithoutContent(req *http.Request, action string) error {
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return errorFromResponse(resp, action)
+	}
+
+	return nil
+}
+
+func (h *AcceptanceTestDriver) organisationURL(orgName string) string {
+	return h.baseURL + "/orgs/" + url.PathEscape(orgName)
+}
+
+func (h *AcceptanceTestDriver) memberURL(orgName string, member string) string {
+	return h.organisationURL(orgName) + "/members/" + url.PathEscape(member)
+}
+
+func (h *AcceptanceTestDriver) invitationsURL(name string) string {
+	return h.baseURL + "/accounts/" + url.PathEscape(name) + "/invitations"
+}
+
+func (h *AcceptanceTestDriver) projectsURL(name string) string {
+	return h.baseURL + "/accounts/" + url.PathEscape(name) + "/projects"
+}
+
+func (h *AcceptanceTestDriver) projectURL(name string, projectID string) string {
+	return h.projectsURL(name) + "/" + url.PathEscape(projectID)
+}
+
+// projectBody is the JSON representation of a project in the API
+type projectBody struct {
+	ID        string                `json:"id"`
+	Name      string                `json:"name"`
+	OwnerID   string                `json:"ownerId"`
+	CreatedAt time.Time             `json:"createdAt"`
+	State     entities.ProjectState `json:"state"`
+}
+
+func (p projectBody) toProject() entities.Project {
+	project := entities.NewProject(p.ID, p.Name, p.OwnerID, p.CreatedAt)
+	project.SetState(p.State)
+	return *project
+}
+
+// invitationBody is the JSON representation of an invitation in the API
+type invitationBody struct {
+	ProjectID   string        `json:"projectId"`
+	ProjectName string        `json:"projectName"`
+	Owner       string        `json:"owner"`
+	Role        entities.Role `json:"role"`
+	InvitedAt   time.Time     `json:"invitedAt"`
+}
+
+// membershipBody is the JSON representation in the API of an organisation someone
+// belongs to, or of one of its members
+type membershipBody struct {
+	Organisation string           `json:"organisation"`
+	Account      string           `json:"account"`
+	Role         entities.OrgRole `json:"role"`
+}
+
+// eventBody is the JSON representation of an event in the API
+type eventBody struct {
+	Kind       string    `json:"kind"`
+	Account    string    `json:"account"`
+	ProjectID  string    `json:"projectId"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+func (e eventBody) toEvent() events.Event {
+	return events.Event{Kind: events.Kind(e.Kind), Account: e.Account, ProjectID: e.ProjectID, OccurredAt: e.OccurredAt}
+}
+
+// webhookBody is the JSON representation of a webhook in the API, which leaves out its secret
+type webhookBody struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (w webhookBody) toWebhook() entities.Webhook {
+	return *entities.NewWebhook(w.ID, "", w.URL, "", w.CreatedAt)
+}
+
+// deliveryBody is the JSON representation of a delivery to a webhook in the API
+type deliveryBody struct {
+	ID             string    `json:"id"`
+	Event          eventBody `json:"event"`
+	Status         string    `json:"status"`
+	Attempts       int       `json:"attempts"`
+	ResponseStatus int       `json:"responseStatus"`
+	Error          string    `json:"error"`
+	LastAttemptAt  time.Time `json:"lastAttemptAt"`
+	NextAttemptAt  time.Time `json:"nextAttemptAt"`
+}
+
+func (d deliveryBody) toDelivery(webhookID string) webhooks.Delivery {
+	return webhooks.Delivery{
+		ID:             d.ID,
+		WebhookID:      webhookID,
+		Event:          d.Event.toEvent(),
+		Status:         webhooks.Status(d.Status),
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		Error:          d.Error,
+		LastAttemptAt:  d.LastAttemptAt,
+		NextAttemptAt:  d.NextAttemptAt,
+	}
+}
+
+func decodeProject(r io.Reader) (entities.Project, error) {
+	var body projectBody
+	if err := json.NewDecoder(r).Decode(&body); err != nil {
+		return entities.Project{}, err
+	}
+	return body.toProject(), nil
+}
+
+// errorFromResponse turns an error response back into the domain error it reports,
+// falling back to a generic error if the response has no recognised error code
+func errorFromResponse(resp *http.Response, operation string) error {
+	body, _ := io.ReadAll(resp.Body)
+
+	var errorResp struct {
+		Error string `json:"error"`
+		Code  string `json:"code"`
+	}
+	if err := json.Unmarshal(body, &errorResp); err == nil {
+		if domainErr := driver.ErrorFromCode(errorResp.Code, errorResp.Error); domainErr != nil {
+			return domainErr
+		}
+	}
+
+	return fmt.Errorf("%s failed with status %d: %s", operation, resp.StatusCode, string(body))
 }
@@ -0,0 +1,25 @@
+Feature: Baseline
+
+  Scenarios can start from a baseline that is built the first time it is needed
+  and restored before each scenario after that, instead of being built every time
+
+  Scenario: Start from a baseline
+    Given Sue has signed up with 2 projects
+    Then Sue should see 2 projects
+    And Sue should see the project called "Project 2"
+
+  Scenario: Change what the baseline holds
+    Given Sue has signed up with 2 projects
+    When Sue creates a project called "Extra"
+    Then Sue should see 3 projects
+
+  Scenario: Let time pass after starting from a baseline
+    Given Sue has signed up with 2 projects
+    When 2 days have passed
+    Then Sue should not be authenticated
+
+  Scenario: Start from the baseline as it was taken
+    Given Sue has signed up with 2 projects
+    Then Sue should be authenticated
+    And Sue should see 2 projects
+    And Sue should not see the project called "Extra"
@@ -0,0 +1,34 @@
+Feature: Browse projects
+
+  Users with many projects can page through them, and find the ones they are after
+  by sorting and filtering them
+
+  Scenario: Sue has 120 projects and pages through them
+    Given Sue is on the pro plan with 120 projects
+    When Sue pages through her projects 50 at a time
+    Then Sue should have been shown 3 pages of projects
+    And Sue should have been shown all 120 projects once each, oldest first
+
+  Scenario: Sort projects by name
+    Given Sue has signed up
+    And Sue has created a project called "Roadmap"
+    And Sue has created a project called "budget"
+    And Sue has created a project called "Hiring"
+    When Sue lists her projects by name
+    Then Sue should have been shown the projects "budget, Hiring, Roadmap"
+
+  Scenario: Find projects by the start of their name
+    Given Sue has signed up
+    And Sue has created a project called "Roadmap"
+    And Sue has created a project called "Budget"
+    And Sue has created a project called "Road trip"
+    When Sue lists her projects whose names start with "road"
+    Then Sue should have been shown the projects "Roadmap, Road trip"
+
+  Scenario: Find projects by when they were created
+    Given Sue has signed up
+    And Sue has created a project called "Old"
+    And 30 minutes have passed
+    And Sue has created a project called "New"
+    When Sue lists her projects created more than 10 minutes after "Old"
+    Then Sue should have been shown the project "New"
@@ -0,0 +1,51 @@
+Feature: Close account
+
+  Account holders can download everything kept about their account, and close it for
+  good. Closing deletes the account's projects and signs it out everywhere, but keeps
+  its name taken so that no one else can sign up as the account holder.
+
+  Scenario: Download my data
+    Given Sue has signed up with 2 projects
+    When Sue downloads her data
+    Then Sue's download should hold the projects "Project 1, Project 2"
+
+  Scenario: Close an account
+    Given Sue has signed up
+    And Sue has signed in on her phone
+    When Sue closes her account
+    Then Sue's account should be closed
+    And Sue should not be authenticated
+    And Sue should not be authenticated on her phone
+    And an AccountClosed event should have been published for Sue
+
+  Scenario: Projects go when their owner closes the account
+    Given Sue has signed up
+    And Bob has signed up
+    And Sue has created a project called "Roadmap"
+    And Sue has invited Bob to the project "Roadmap" as a viewer
+    And Bob has accepted the invitation to the project "Roadmap"
+    When Sue closes her account
+    Then Bob should not see the project called "Roadmap"
+
+  Scenario: A closed account cannot be signed in to
+    Given Sue has signed up
+    And Sue has closed her account
+    When Sue tries to sign in
+    Then Sue should see an error telling her the name or password is wrong
+
+  Scenario: The name of a closed account stays taken
+    Given Sue has signed up
+    And Sue has closed her account
+    When Bob tries to sign up as Sue
+    Then Bob should see an error telling him the name is already taken
+
+  Scenario: The last owner of an organisation hands it over first
+    Given Sue has signed up
+    And Bob has signed up
+    And Sue has created the organisation "Acme"
+    When Sue tries to close her account
+    Then Sue should see an error telling her the organisation must keep an owner
+    And Sue should be authenticated
+    When Sue adds Bob to the organisation "Acme" as an owner
+    And Sue closes her account
+    Then Bob should be an owner of the organisation "Acme"
@@ -1,14 +1,46 @@
 Feature: Create project
 
-  Users can create projects, only visible to themselves
+  Users can create projects, only visible to themselves and anyone they share them with
 
   Scenario: Create one project
     Given Sue has signed up
     When Sue creates a project
     Then Sue should see the project
+    And a ProjectCreated event should have been published for Sue
+
+  Scenario: Create a named project
+    Given Sue has signed up
+    When Sue creates a project called "Roadmap"
+    Then Sue should see the project called "Roadmap"
 
   Scenario: Try to see someone else's project
     Given Sue has signed up
     And Bob has signed up
     When Sue creates a project
     Then Bob should not see any projects
+
+  Scenario: Try to open someone else's projects
+    Given Sue has signed up
+    And Bob has signed up
+    And Sue has created a project
+    When Bob tries to open Sue's projects
+    Then Bob should be refused access
+
+  Scenario: Create several projects at the same time
+    Given Sue has signed up
+    And Sue is on the pro plan
+    When Sue creates 10 projects at the same time
+    Then Sue should see 10 projects
+
+  Scenario: Still see projects after signing in again
+    Given Sue has signed up
+    And Sue has created a project
+    When Sue signs in again
+    Then Sue should see her project
+
+  @restart
+  Scenario: Still see projects after the server restarts
+    Given Sue has signed up
+    And Sue has created a project
+    When the server restarts
+    Then Sue should see her project
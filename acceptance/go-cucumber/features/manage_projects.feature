@@ -0,0 +1,18 @@
+Feature: Manage projects
+
+  Users can rename and delete their own projects
+
+  Scenario: Rename a project
+    Given Sue has signed up
+    And Sue has created a project called "Roadmap"
+    When Sue renames the project "Roadmap" to "Plan"
+    Then Sue should see the project called "Plan"
+    And Sue should not see the project called "Roadmap"
+
+  Scenario: Delete a project
+    Given Sue has signed up
+    And Sue has created a project called "Roadmap"
+    And Sue has created a project called "Budget"
+    When Sue deletes the project "Roadmap"
+    Then Sue should not see the project called "Roadmap"
+    And Sue should see the project called "Budget"
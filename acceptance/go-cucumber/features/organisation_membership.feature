@@ -0,0 +1,47 @@
+Feature: Organisation membership
+
+  Users can create organisations, which own projects that all of their members work on.
+  Each member is an owner, an admin or a member, and an organisation always keeps at
+  least one owner.
+
+  Scenario: Create an organisation
+    Given Sue has signed up
+    When Sue creates the organisation "Acme"
+    Then Sue should be an owner of the organisation "Acme"
+
+  Scenario: Add a member
+    Given Sue has signed up
+    And Bob has signed up
+    And Sue has created the organisation "Acme"
+    When Sue adds Bob to the organisation "Acme" as a member
+    Then Bob should be a member of the organisation "Acme"
+
+  Scenario: Make a member an admin
+    Given Sue has signed up
+    And Bob has signed up
+    And Sue has created the organisation "Acme"
+    And Sue has added Bob to the organisation "Acme" as a member
+    When Sue adds Bob to the organisation "Acme" as an admin
+    Then Bob should be an admin of the organisation "Acme"
+
+  Scenario: Remove a member
+    Given Sue has signed up
+    And Bob has signed up
+    And Sue has created the organisation "Acme"
+    And Sue has added Bob to the organisation "Acme" as a member
+    When Sue removes Bob from the organisation "Acme"
+    Then Bob should not belong to the organisation "Acme"
+
+  Scenario: An organisation keeps an owner
+    Given Sue has signed up
+    And Sue has created the organisation "Acme"
+    When Sue tries to remove Sue from the organisation "Acme"
+    Then Sue should see an error telling them the organisation must keep an owner
+    And Sue should be an owner of the organisation "Acme"
+
+  Scenario: Create an organisation whose name is taken
+    Given Sue has signed up
+    And Bob has signed up
+    And Sue has created the organisation "Acme"
+    When Bob tries to create the organisation "Acme"
+    Then Bob should see an error telling them the organisation already exists
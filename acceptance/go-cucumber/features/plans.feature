@@ -0,0 +1,34 @@
+Feature: Plans
+
+  Accounts are on the free plan, which allows 3 projects, or the pro plan,
+  which allows as many as are needed
+
+  Scenario: New accounts start on the free plan
+    Given Sue has signed up
+    Then Sue should be on the free plan
+
+  Scenario: Free accounts are told to upgrade when they reach their quota
+    Given Sue is on the free plan with 3 projects
+    When Sue tries to create a project
+    Then Sue should be told to upgrade
+    And Sue should see 3 projects
+
+  Scenario: Upgrading lifts the limit
+    Given Sue is on the free plan with 3 projects
+    When Sue upgrades to the pro plan
+    And Sue creates a project
+    Then Sue should be on the pro plan
+    And Sue should see 4 projects
+
+  Scenario: Deleting a project makes room for another
+    Given Sue is on the free plan with 3 projects
+    When Sue deletes the project "Project 1"
+    And Sue creates a project
+    Then Sue should see 3 projects
+
+  Scenario: Moving back to the free plan keeps the projects
+    Given Sue is on the pro plan with 5 projects
+    When Sue moves to the free plan
+    Then Sue should see 5 projects
+    When Sue tries to create a project
+    Then Sue should be told to upgrade
@@ -0,0 +1,27 @@
+Feature: Sign out
+
+  Each device someone signs in on has a session of its own,
+  so signing out on one device leaves the others signed in.
+
+  Scenario: Sign out
+    Given Sue has signed up
+    When Sue signs out
+    Then Sue should not be authenticated
+
+  Scenario: Sign in on a second device
+    Given Sue has signed up
+    When Sue signs in on her phone
+    Then Sue should be authenticated on her phone
+    And Sue should be authenticated
+
+  Scenario: Sign out on one device but stay signed in on another
+    Given Sue has signed up
+    And Sue has signed in on her phone
+    When Sue signs out on her phone
+    Then Sue should not be authenticated on her phone
+    But Sue should be authenticated
+
+  Scenario: Try to sign out on a device that is not signed in
+    Given Sue has signed up
+    When Sue tries to sign out on her phone
+    Then Sue should see an error telling her to sign in
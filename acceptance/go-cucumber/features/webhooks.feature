@@ -0,0 +1,29 @@
+Feature: Webhooks
+
+  Account holders can register a webhook to have activity on
+  their account posted to a service of their own. Each payload
+  is signed with a secret they chose, so that the service can
+  tell it came from us, and deliveries that fail are retried.
+
+  Scenario: Be told when a project is created
+    Given Sue has signed up
+    And Sue has registered a webhook
+    When Sue creates a project
+    Then Sue's webhook should be sent a signed ProjectCreated payload
+    And the delivery to Sue's webhook should have succeeded after 1 attempt
+
+  Scenario: Be told when an account is activated
+    Given Sue has created an account with a webhook
+    When Sue activates her account
+    Then Sue's webhook should be sent a signed AccountActivated payload
+
+  Scenario: Retry a delivery that failed
+    Given Sue has signed up
+    And Sue has registered a webhook
+    And Sue's webhook is down
+    When Sue creates a project
+    Then the delivery to Sue's webhook should be waiting to be retried
+    When Sue's webhook comes back up
+    And 2 minutes have passed
+    Then Sue's webhook should be sent a signed ProjectCreated payload
+    And the delivery to Sue's webhook should have succeeded after 2 attempts
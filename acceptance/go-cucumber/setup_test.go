@@ -2,6 +2,7 @@ package features_test
 
 import (
 	"bufio"
+	"fmt"
 	"io"
 	"net/http"
 	"os/exec"
@@ -12,16 +13,48 @@ import (
 	"time"
 )
 
-// startServerExecutable builds and starts the actual server executable using the root makefile
-// and returns the server URL. Cleanup is handled automatically via t.Cleanup.
-func startServerExecutable(t *testing.T) string {
+// serverExecutable is the actual server executable running as a child process.
+// It keeps its data in a directory that outlives the process, so that it can be
+// restarted mid-scenario without losing anything.
+type serverExecutable struct {
+	t       *testing.T
+	url     string
+	dataDir string
+	cmd     *exec.Cmd
+}
+
+// startServerExecutable builds and starts the actual server executable using the root makefile.
+// Cleanup is handled automatically via t.Cleanup.
+func startServerExecutable(t *testing.T) *serverExecutable {
+	server := &serverExecutable{
+		t:       t,
+		url:     "http://localhost:8080",
+		dataDir: t.TempDir(),
+	}
+	if err := server.start(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Register cleanup function
+	t.Cleanup(server.stop)
+
+	return server
+}
+
+// Restart stops the server executable and starts it again with the same data
+func (s *serverExecutable) Restart() error {
+	s.stop()
+	return s.start()
+}
+
+func (s *serverExecutable) start() error {
 	// Start the server process using root makefile target
-	cmd := exec.Command("make", "run-backend")
+	cmd := exec.Command("make", "run-backend", "SERVER_ARGS=--test-mode --data-dir="+s.dataDir)
 
 	// Set working directory to project root
 	projectRoot, err := filepath.Abs("../..")
 	if err != nil {
-		t.Fatalf("Failed to get project root path: %v", err)
+		return fmt.Errorf("failed to get project root path: %w", err)
 	}
 	cmd.Dir = projectRoot
 
@@ -31,60 +64,62 @@ func startServerExecutable(t *testing.T) string {
 	// Capture server output for debugging
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
-		t.Fatalf("Failed to create stdout pipe: %v", err)
+		return fmt.Errorf("failed to create stdout pipe: %w", err)
 	}
 
 	stderr, err := cmd.StderrPipe()
 	if err != nil {
-		t.Fatalf("Failed to create stderr pipe: %v", err)
+		return fmt.Errorf("failed to create stderr pipe: %w", err)
 	}
 
 	// Start the server
 	if err := cmd.Start(); err != nil {
-		t.Fatalf("Failed to start server: %v", err)
+		return fmt.Errorf("failed to start server: %w", err)
 	}
+	s.cmd = cmd
 
 	// Monitor server output in background
-	go logServerOutput(t, "STDOUT", stdout)
-	go logServerOutput(t, "STDERR", stderr)
+	go logServerOutput(s.t, "STDOUT", stdout)
+	go logServerOutput(s.t, "STDERR", stderr)
 
 	// Wait for server to be ready
-	serverURL := "http://localhost:8080"
-	waitForServerReady(t, serverURL)
-
-	t.Logf("Server started successfully at %s (PID: %d)", serverURL, cmd.Process.Pid)
-
-	// Register cleanup function
-	t.Cleanup(func() {
-		t.Logf("Shutting down server (PID: %d)", cmd.Process.Pid)
+	if err := pollServerReady(s.t, s.url, 30*time.Second); err != nil {
+		return err
+	}
 
-		// Kill the entire process group to ensure cleanup
-		syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	s.t.Logf("Server started successfully at %s (PID: %d)", s.url, cmd.Process.Pid)
+	return nil
+}
 
-		// Give the process time to shut down
-		done := make(chan error, 1)
-		go func() {
-			done <- cmd.Wait()
-		}()
+func (s *serverExecutable) stop() {
+	s.t.Logf("Shutting down server (PID: %d)", s.cmd.Process.Pid)
 
-		select {
-		case <-done:
-			t.Logf("Server shut down gracefully")
-		case <-time.After(5 * time.Second):
-			t.Logf("Server didn't shut down gracefully")
-		}
-	})
+	// Kill the entire process group to ensure cleanup
+	syscall.Kill(-s.cmd.Process.Pid, syscall.SIGKILL)
 
-	return serverURL
-}
+	// Give the process time to shut down
+	done := make(chan error, 1)
+	go func() {
+		done <- s.cmd.Wait()
+	}()
 
-// waitForServerReady waits for the server to be ready to accept connections
-func waitForServerReady(t *testing.T, serverURL string) {
-	waitForServerReadyWithTimeout(t, serverURL, 30*time.Second)
+	select {
+	case <-done:
+		s.t.Logf("Server shut down gracefully")
+	case <-time.After(5 * time.Second):
+		s.t.Logf("Server didn't shut down gracefully")
+	}
 }
 
 // waitForServerReadyWithTimeout waits for the server to be ready with a custom timeout
 func waitForServerReadyWithTimeout(t *testing.T, serverURL string, timeout time.Duration) {
+	if err := pollServerReady(t, serverURL, timeout); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// pollServerReady polls the server until it accepts connections, returning an error if it does not within the timeout
+func pollServerReady(t *testing.T, serverURL string, timeout time.Duration) error {
 	client := &http.Client{Timeout: 5 * time.Second}
 
 	// Determine which endpoint to check based on URL
@@ -106,14 +141,14 @@ func waitForServerReadyWithTimeout(t *testing.T, serverURL string, timeout time.
 			// Any response code < 500 means server is responding
 			if resp.StatusCode < 500 {
 				t.Logf("Server is ready at %s after %d attempts (%.1fs)", serverURL, attempt, time.Since(deadline.Add(-timeout)).Seconds())
-				return
+				return nil
 			}
 		}
 
 		time.Sleep(2 * time.Second)
 	}
 
-	t.Fatalf("Server at %s did not become ready within %v (tried %d times)", serverURL, timeout, attempt)
+	return fmt.Errorf("server at %s did not become ready within %v (tried %d times)", serverURL, timeout, attempt)
 }
 
 // logServerOutput logs server output for debugging
@@ -130,7 +165,7 @@ func logServerOutput(t *testing.T, prefix string, pipe io.ReadCloser) {
 // Start back end and front end services by calling `make run` and return the front end URL
 func startFrontAndBackend(t *testing.T) string {
 	// Start both back end and front end services
-	cmd := exec.Command("make", "run")
+	cmd := exec.Command("make", "run", "SERVER_ARGS=--test-mode")
 
 	// Set working directory to project root
 	projectRoot, err := filepath.Abs("../..")
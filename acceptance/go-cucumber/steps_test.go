@@ -1,22 +1,68 @@
 package features_test
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/webhooks"
 )
 
+// defaultPassword is used when the scenario does not care what a password is
+const defaultPassword = "correct-horse-1"
+
 func (s *suite) personHasCreatedAnAccount(name string) error {
-	return s.driver.CreateAccount(name)
+	return s.driver.CreateAccount(name, defaultPassword)
 }
 
 func (s *suite) personHasSignedUp(name string) error {
-	if err := s.driver.CreateAccount(name); err != nil {
+	if err := s.driver.CreateAccount(name, defaultPassword); err != nil {
 		return err
 	}
 	if _, err := s.driver.GetAccount(name); err != nil {
 		return nil
 	}
-	return s.driver.Activate(name)
+	return s.followLatestActivationLink(name)
+}
+
+// personHasSignedUpWithProjects starts the scenario from a baseline in which the person
+// has signed up and created count projects, which must fit in the free plan. The baseline
+// is built the first time it is needed and restored after that, replacing everything, so
+// it must be the first step.
+func (s *suite) personHasSignedUpWithProjects(name string, count int) error {
+	return s.personIsOnThePlanWithProjects(name, string(entities.PlanFree), count)
+}
+
+// personIsOnThePlanWithProjects starts the scenario from a baseline in which the person
+// has signed up, moved to the plan and created count projects, as
+// personHasSignedUpWithProjects does
+func (s *suite) personIsOnThePlanWithProjects(name string, plan string, count int) error {
+	key := fmt.Sprintf("%s on the %s plan with %d projects", name, plan, count)
+	if snapshot, ok := s.baselines[key]; ok {
+		return s.driver.Restore(snapshot)
+	}
+	if err := s.personHasSignedUp(name); err != nil {
+		return err
+	}
+	if err := s.personMovesToThePlan(name, plan); err != nil {
+		return err
+	}
+	for i := 0; i < count; i++ {
+		if _, err := s.driver.CreateProject(name, fmt.Sprintf("Project %d", i+1)); err != nil {
+			return err
+		}
+	}
+	snapshot, err := s.driver.Snapshot()
+	if err != nil {
+		return err
+	}
+	s.baselines[key] = snapshot
+	return nil
 }
 
 func (s *suite) personShouldBeAuthenticated(name string) error {
@@ -37,6 +83,24 @@ func (s *suite) personShouldNotBeAuthenticated(name string) error {
 	return nil
 }
 
+func (s *suite) personShouldBeAuthenticatedOnTheirDevice(name string, device string) error {
+	expected := true
+	actual := s.driver.OnDevice(device).IsAuthenticated(name)
+	if actual != expected {
+		return fmt.Errorf("expected %v to equal %v", actual, expected)
+	}
+	return nil
+}
+
+func (s *suite) personShouldNotBeAuthenticatedOnTheirDevice(name string, device string) error {
+	expected := false
+	actual := s.driver.OnDevice(device).IsAuthenticated(name)
+	if actual != expected {
+		return fmt.Errorf("expected %v to equal %v", actual, expected)
+	}
+	return nil
+}
+
 func (s *suite) personShouldNotSeeAnyProjects(name string) error {
 	projects, err := s.driver.GetProjects(name)
 	if err != nil {
@@ -63,31 +127,885 @@ func (s *suite) personShouldSeeTheirProject(name string) error {
 	return nil
 }
 
+func (s *suite) personShouldSeeProjects(name string, expected int) error {
+	projects, err := s.driver.GetProjects(name)
+	if err != nil {
+		return err
+	}
+	actual := len(projects)
+	if actual != expected {
+		return fmt.Errorf("expected %v to equal %v", actual, expected)
+	}
+	return nil
+}
+
 func (s *suite) personShouldSeeAnErrorTellingThemToActivateTheAccount(name string) error {
 	lastError := s.getLastError(name)
-	expectedText := "you need to activate your account"
+	expected := entities.ErrAccountNotActivated
+	if lastError == nil {
+		return fmt.Errorf("expected error '%v' but there is no error", expected)
+	}
+	if !errors.Is(lastError, expected) {
+		return fmt.Errorf("expected error '%v' but got %v", expected, lastError)
+	}
+	return nil
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemTheActivationLinkIsNotValid(name string) error {
+	lastError := s.getLastError(name)
+	expected := entities.ErrInvalidActivation
 	if lastError == nil {
-		return fmt.Errorf("expected error containing text '%s' but there is no error", expectedText)
+		return fmt.Errorf("expected error '%v' but there is no error", expected)
 	}
-	if !strings.Contains(lastError.Error(), expectedText) {
-		return fmt.Errorf("expected error text containing '%s' but got %s", expectedText, lastError.Error())
+	if !errors.Is(lastError, expected) {
+		return fmt.Errorf("expected error '%v' but got %v", expected, lastError)
 	}
 	return nil
 }
 
+func (s *suite) personShouldSeeAnErrorTellingThemThePasswordIsTooWeak(name string) error {
+	lastError := s.getLastError(name)
+	expected := entities.ErrWeakPassword
+	if lastError == nil {
+		return fmt.Errorf("expected error '%v' but there is no error", expected)
+	}
+	if !errors.Is(lastError, expected) {
+		return fmt.Errorf("expected error '%v' but got %v", expected, lastError)
+	}
+	return nil
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemTheNameOrPasswordIsWrong(name string) error {
+	lastError := s.getLastError(name)
+	expected := entities.ErrWrongCredentials
+	if lastError == nil {
+		return fmt.Errorf("expected error '%v' but there is no error", expected)
+	}
+	if !errors.Is(lastError, expected) {
+		return fmt.Errorf("expected error '%v' but got %v", expected, lastError)
+	}
+	return nil
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemToSignIn(name string) error {
+	lastError := s.getLastError(name)
+	expected := entities.ErrNotSignedIn
+	if lastError == nil {
+		return fmt.Errorf("expected error '%v' but there is no error", expected)
+	}
+	if !errors.Is(lastError, expected) {
+		return fmt.Errorf("expected error '%v' but got %v", expected, lastError)
+	}
+	return nil
+}
+
+func (s *suite) personShouldBeRefusedAccess(name string) error {
+	lastError := s.getLastError(name)
+	expected := entities.ErrAccessDenied
+	if lastError == nil {
+		return fmt.Errorf("expected error '%v' but there is no error", expected)
+	}
+	if !errors.Is(lastError, expected) {
+		return fmt.Errorf("expected error '%v' but got %v", expected, lastError)
+	}
+	return nil
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemTheActivationLinkHasExpired(name string) error {
+	lastError := s.getLastError(name)
+	expected := entities.ErrActivationExpired
+	if lastError == nil {
+		return fmt.Errorf("expected error '%v' but there is no error", expected)
+	}
+	if !errors.Is(lastError, expected) {
+		return fmt.Errorf("expected error '%v' but got %v", expected, lastError)
+	}
+	return nil
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemTheNameIsAlreadyTaken(name string) error {
+	lastError := s.getLastError(name)
+	expected := entities.ErrAccountExists
+	if lastError == nil {
+		return fmt.Errorf("expected error '%v' but there is no error", expected)
+	}
+	if !errors.Is(lastError, expected) {
+		return fmt.Errorf("expected error '%v' but got %v", expected, lastError)
+	}
+	return nil
+}
+
+func (s *suite) personTriesToSignUpAs(name string, accountName string) error {
+	err := s.driver.CreateAccount(accountName, defaultPassword)
+	s.setLastError(name, err)
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemTheAccountIsLocked(name string) error {
+	lastError := s.getLastError(name)
+	expected := entities.ErrAccountLocked
+	if lastError == nil {
+		return fmt.Errorf("expected error '%v' but there is no error", expected)
+	}
+	if !errors.Is(lastError, expected) {
+		return fmt.Errorf("expected error '%v' but got %v", expected, lastError)
+	}
+	return nil
+}
+
+func (s *suite) personTriesToSignUpWithThePassword(name string, password string) error {
+	err := s.driver.CreateAccount(name, password)
+	s.setLastError(name, err)
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personTriesToSignInWithThePassword(name string, password string) error {
+	err := s.driver.Authenticate(name, password)
+	s.setLastError(name, err)
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personTriesToSignInWithThePasswordTimes(name string, password string, times int) error {
+	for i := 0; i < times; i++ {
+		err := s.driver.Authenticate(name, password)
+		s.setLastError(name, err)
+	}
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
 func (s *suite) personTriesToSignIn(name string) error {
-	err := s.driver.Authenticate(name)
+	err := s.driver.Authenticate(name, defaultPassword)
+	s.setLastError(name, err)
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personSignsIn(name string) error {
+	return s.driver.Authenticate(name, defaultPassword)
+}
+
+func (s *suite) personSignsInOnTheirDevice(name string, device string) error {
+	return s.driver.OnDevice(device).Authenticate(name, defaultPassword)
+}
+
+func (s *suite) personSignsOut(name string) error {
+	return s.driver.SignOut(name)
+}
+
+func (s *suite) personSignsOutOnTheirDevice(name string, device string) error {
+	return s.driver.OnDevice(device).SignOut(name)
+}
+
+func (s *suite) personTriesToSignOutOnTheirDevice(name string, device string) error {
+	err := s.driver.OnDevice(device).SignOut(name)
+	s.setLastError(name, err)
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personTriesToOpenTheProjectsOf(name string, owner string) error {
+	_, err := s.driver.GetProjectsOf(name, owner)
 	s.setLastError(name, err)
 	return nil // The step succeeds even if the result is bad to allow the next step to check the error
 }
 
 func (s *suite) personCreatesAProject(name string) error {
-	return s.driver.CreateProject(name)
+	_, err := s.driver.CreateProject(name, defaultProjectName)
+	return err
+}
+
+func (s *suite) personTriesToCreateAProject(name string) error {
+	s.setLastError(name, s.personCreatesAProject(name))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personMovesToThePlan(name string, plan string) error {
+	return s.driver.ChangePlan(name, entities.Plan(plan))
+}
+
+func (s *suite) personShouldBeOnThePlan(name string, plan string) error {
+	account, err := s.driver.GetAccount(name)
+	if err != nil {
+		return err
+	}
+	if account.Plan() != entities.Plan(plan) {
+		return fmt.Errorf("expected %s to be on the %s plan but is on the %s plan", name, plan, account.Plan())
+	}
+	return nil
+}
+
+func (s *suite) personShouldBeToldToUpgrade(name string) error {
+	return s.expectLastError(name, entities.ErrQuotaExceeded)
+}
+
+func (s *suite) personDownloadsTheirData(name string) error {
+	archive, err := s.driver.ExportAccount(name)
+	if err != nil {
+		return err
+	}
+	s.downloads[name] = archive
+	return nil
+}
+
+func (s *suite) personsDownloadShouldHoldTheProjects(name string, expected string) error {
+	var names []string
+	for _, project := range s.downloads[name].Projects {
+		names = append(names, project.Name)
+	}
+	if actual := strings.Join(names, ", "); actual != expected {
+		return fmt.Errorf("expected %v to equal %v", actual, expected)
+	}
+	return nil
+}
+
+func (s *suite) personClosesTheirAccount(name string) error {
+	return s.driver.CloseAccount(name)
+}
+
+func (s *suite) personTriesToCloseTheirAccount(name string) error {
+	s.setLastError(name, s.personClosesTheirAccount(name))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personsAccountShouldBeClosed(name string) error {
+	account, err := s.driver.GetAccount(name)
+	if err != nil {
+		return err
+	}
+	if !account.IsClosed() {
+		return fmt.Errorf("expected %s's account to be closed", name)
+	}
+	return nil
+}
+
+func (s *suite) personCreatesAProjectCalled(name string, projectName string) error {
+	_, err := s.driver.CreateProject(name, projectName)
+	return err
+}
+
+func (s *suite) personRenamesTheProject(name string, projectName string, newName string) error {
+	project, err := s.findProjectCalled(name, projectName)
+	if err != nil {
+		return err
+	}
+	_, err = s.driver.RenameProject(name, project.ID(), newName)
+	return err
+}
+
+func (s *suite) personDeletesTheProject(name string, projectName string) error {
+	project, err := s.findProjectCalled(name, projectName)
+	if err != nil {
+		return err
+	}
+	return s.driver.DeleteProject(name, project.ID())
+}
+
+func (s *suite) personArchivesTheProject(name string, projectName string) error {
+	project, err := s.findProjectCalled(name, projectName)
+	if err != nil {
+		return err
+	}
+	_, err = s.driver.ArchiveProject(name, project.ID())
+	return err
+}
+
+func (s *suite) personRestoresTheProject(name string, projectName string) error {
+	project, err := s.findProjectCalled(name, projectName)
+	if err != nil {
+		return err
+	}
+	_, err = s.driver.RestoreProject(name, project.ID())
+	return err
+}
+
+func (s *suite) personTriesToArchiveTheProject(name string, projectName string) error {
+	s.setLastError(name, s.personArchivesTheProject(name, projectName))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personTriesToRestoreTheProject(name string, projectName string) error {
+	s.setLastError(name, s.personRestoresTheProject(name, projectName))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personTriesToRenameTheProject(name string, projectName string, newName string) error {
+	s.setLastError(name, s.personRenamesTheProject(name, projectName, newName))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personInvitesToTheProjectAs(name string, invitee string, projectName string, role string) error {
+	project, err := s.findProjectCalled(name, projectName)
+	if err != nil {
+		return err
+	}
+	return s.driver.InviteToProject(name, project.ID(), invitee, entities.Role(role))
+}
+
+func (s *suite) personTriesToInviteToTheProjectAs(name string, invitee string, projectName string, role string) error {
+	s.setLastError(name, s.personInvitesToTheProjectAs(name, invitee, projectName, role))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personAcceptsTheInvitationToTheProject(name string, projectName string) error {
+	invitation, err := s.findInvitationTo(name, projectName)
+	if err != nil {
+		return err
+	}
+	return s.driver.AcceptInvitation(name, invitation.ProjectID)
+}
+
+func (s *suite) personDeclinesTheInvitationToTheProject(name string, projectName string) error {
+	invitation, err := s.findInvitationTo(name, projectName)
+	if err != nil {
+		return err
+	}
+	return s.driver.DeclineInvitation(name, invitation.ProjectID)
+}
+
+func (s *suite) personShouldSeeAnInvitationToTheProject(name string, projectName string) error {
+	_, err := s.findInvitationTo(name, projectName)
+	return err
+}
+
+func (s *suite) personShouldNotSeeAnInvitationToTheProject(name string, projectName string) error {
+	_, err := s.findInvitationTo(name, projectName)
+	if errors.Is(err, entities.ErrInvitationNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("expected %s not to see an invitation to %s", name, projectName)
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemTheyAlreadyShareTheProject(name string) error {
+	return s.expectLastError(name, entities.ErrAlreadyMember)
+}
+
+func (s *suite) personCreatesTheOrganisation(name string, orgName string) error {
+	return s.driver.CreateOrganisation(name, orgName)
+}
+
+func (s *suite) personTriesToCreateTheOrganisation(name string, orgName string) error {
+	s.setLastError(name, s.personCreatesTheOrganisation(name, orgName))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personAddsToTheOrganisationAs(name string, member string, orgName string, role string) error {
+	return s.driver.SetOrganisationMember(name, orgName, member, entities.OrgRole(role))
+}
+
+func (s *suite) personTriesToAddToTheOrganisationAs(name string, member string, orgName string, role string) error {
+	s.setLastError(name, s.personAddsToTheOrganisationAs(name, member, orgName, role))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personRemovesFromTheOrganisation(name string, member string, orgName string) error {
+	return s.driver.RemoveOrganisationMember(name, orgName, member)
+}
+
+func (s *suite) personTriesToRemoveFromTheOrganisation(name string, member string, orgName string) error {
+	s.setLastError(name, s.personRemovesFromTheOrganisation(name, member, orgName))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personTriesToDeleteTheOrganisation(name string, orgName string) error {
+	s.setLastError(name, s.driver.DeleteOrganisation(name, orgName))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personShouldBeOfTheOrganisation(name string, role string, orgName string) error {
+	got, err := s.roleIn(name, orgName)
+	if err != nil {
+		return err
+	}
+	if got != entities.OrgRole(role) {
+		return fmt.Errorf("expected %s to be %s of %s but is %q", name, role, orgName, got)
+	}
+	return nil
+}
+
+func (s *suite) personShouldNotBelongToTheOrganisation(name string, orgName string) error {
+	got, err := s.roleIn(name, orgName)
+	if err != nil {
+		return err
+	}
+	if got != "" {
+		return fmt.Errorf("expected %s not to belong to %s but is %s", name, orgName, got)
+	}
+	return nil
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemTheOrganisationMustKeepAnOwner(name string) error {
+	return s.expectLastError(name, entities.ErrLastOwner)
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemTheOrganisationAlreadyExists(name string) error {
+	return s.expectLastError(name, entities.ErrOrganisationExists)
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemThereIsNoSuchOrganisation(name string) error {
+	return s.expectLastError(name, entities.ErrOrganisationNotFound)
+}
+
+func (s *suite) personCreatesTheProjectInTheOrganisation(name string, projectName string, orgName string) error {
+	_, err := s.driver.CreateOrganisationProject(name, orgName, projectName)
+	return err
+}
+
+func (s *suite) personDeletesTheProjectInTheOrganisation(name string, projectName string, orgName string) error {
+	project, err := s.findOrganisationProjectCalled(name, orgName, projectName)
+	if err != nil {
+		return err
+	}
+	return s.driver.DeleteOrganisationProject(name, orgName, project.ID())
+}
+
+func (s *suite) personTriesToDeleteTheProjectInTheOrganisation(name string, projectName string, orgName string) error {
+	s.setLastError(name, s.personDeletesTheProjectInTheOrganisation(name, projectName, orgName))
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personTriesToLookAtTheProjectsInTheOrganisation(name string, orgName string) error {
+	_, err := s.driver.GetOrganisationProjects(name, orgName)
+	s.setLastError(name, err)
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personShouldSeeTheProjectInTheOrganisation(name string, projectName string, orgName string) error {
+	_, err := s.findOrganisationProjectCalled(name, orgName, projectName)
+	return err
+}
+
+func (s *suite) personShouldNotSeeTheProjectInTheOrganisation(name string, projectName string, orgName string) error {
+	_, err := s.findOrganisationProjectCalled(name, orgName, projectName)
+	if errors.Is(err, entities.ErrProjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("expected %s not to see a project called %s in %s", name, projectName, orgName)
+}
+
+func (s *suite) personShouldSeeTheProjectCalled(name string, projectName string) error {
+	seen, err := s.seesProjectCalled(name, projectName)
+	if err != nil {
+		return err
+	}
+	if !seen {
+		return fmt.Errorf("expected %s to see a project called %s", name, projectName)
+	}
+	return nil
+}
+
+func (s *suite) personShouldNotSeeTheProjectCalled(name string, projectName string) error {
+	seen, err := s.seesProjectCalled(name, projectName)
+	if err != nil {
+		return err
+	}
+	if seen {
+		return fmt.Errorf("expected %s not to see a project called %s", name, projectName)
+	}
+	return nil
+}
+
+func (s *suite) personShouldSeeTheArchivedProjectCalled(name string, projectName string) error {
+	project, err := s.findProjectCalled(name, projectName)
+	if err != nil {
+		return err
+	}
+	if !project.IsArchived() {
+		return fmt.Errorf("expected %s's project called %s to be archived but it is %s", name, projectName, project.State())
+	}
+	return nil
+}
+
+func (s *suite) personShouldNotSeeTheArchivedProjectCalled(name string, projectName string) error {
+	project, err := s.findProjectCalled(name, projectName)
+	if errors.Is(err, entities.ErrProjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if project.IsArchived() {
+		return fmt.Errorf("expected %s not to see an archived project called %s", name, projectName)
+	}
+	return nil
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemTheProjectIsArchived(name string) error {
+	return s.expectLastError(name, entities.ErrProjectArchived)
+}
+
+func (s *suite) personShouldSeeAnErrorTellingThemTheProjectIsNotArchived(name string) error {
+	return s.expectLastError(name, entities.ErrProjectNotArchived)
+}
+
+func (s *suite) personPagesThroughTheirProjects(name string, limit int) error {
+	var pages []entities.ProjectPage
+	query := entities.ProjectQuery{Limit: limit}
+	for {
+		page, err := s.driver.ListProjects(name, query)
+		if err != nil {
+			return err
+		}
+		pages = append(pages, page)
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	s.shownPages[name] = pages
+	return nil
+}
+
+func (s *suite) personListsTheirProjectsByName(name string) error {
+	return s.personListsTheirProjects(name, entities.ProjectQuery{Sort: entities.SortByName})
+}
+
+func (s *suite) personListsTheirProjectsWhoseNamesStartWith(name string, prefix string) error {
+	return s.personListsTheirProjects(name, entities.ProjectQuery{NamePrefix: prefix})
+}
+
+func (s *suite) personListsTheirProjectsCreatedMoreThanMinutesAfter(name string, minutes int, projectName string) error {
+	project, err := s.findProjectCalled(name, projectName)
+	if err != nil {
+		return err
+	}
+	return s.personListsTheirProjects(name, entities.ProjectQuery{CreatedFrom: project.CreatedAt().Add(time.Duration(minutes) * time.Minute)})
+}
+
+// personListsTheirProjects lists the first page of a person's projects that query chooses
+func (s *suite) personListsTheirProjects(name string, query entities.ProjectQuery) error {
+	page, err := s.driver.ListProjects(name, query)
+	if err != nil {
+		return err
+	}
+	s.shownPages[name] = []entities.ProjectPage{page}
+	return nil
+}
+
+func (s *suite) personShouldHaveBeenShownPagesOfProjects(name string, expected int) error {
+	if actual := len(s.shownPages[name]); actual != expected {
+		return fmt.Errorf("expected %v to equal %v", actual, expected)
+	}
+	return nil
+}
+
+// personShouldHaveBeenShownAllProjectsOnceEach expects the projects made by signing up
+// with count projects, in the order they were created
+func (s *suite) personShouldHaveBeenShownAllProjectsOnceEach(name string, count int) error {
+	names := make([]string, count)
+	for i := range names {
+		names[i] = fmt.Sprintf("Project %d", i+1)
+	}
+	return s.personShouldHaveBeenShownTheProjects(name, strings.Join(names, ", "))
+}
+
+func (s *suite) personShouldHaveBeenShownTheProjects(name string, expected string) error {
+	var names []string
+	for _, page := range s.shownPages[name] {
+		for _, project := range page.Projects {
+			names = append(names, project.Name())
+		}
+	}
+	if actual := strings.Join(names, ", "); actual != expected {
+		return fmt.Errorf("expected %v to equal %v", actual, expected)
+	}
+	return nil
+}
+
+func (s *suite) personCreatesProjectsAtTheSameTime(name string, count int) error {
+	var wg sync.WaitGroup
+	errs := make([]error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = s.driver.CreateProject(name, fmt.Sprintf("Project %d", i+1))
+		}(i)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
 }
 
 func (s *suite) personActivatesTheirAccount(name string) error {
 	if _, err := s.driver.GetAccount(name); err != nil {
 		return nil
 	}
-	return s.driver.Activate(name)
+	return s.followLatestActivationLink(name)
+}
+
+func (s *suite) personFollowsTheirActivationLinkAgain(name string) error {
+	err := s.followLatestActivationLink(name)
+	s.setLastError(name, err)
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) personTriesToActivateTheirAccount(name string) error {
+	err := s.followLatestActivationLink(name)
+	s.setLastError(name, err)
+	return nil // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *suite) daysHavePassed(days int) error {
+	return s.driver.AdvanceClock(time.Duration(days) * 24 * time.Hour)
+}
+
+func (s *suite) minutesHavePassed(minutes int) error {
+	return s.driver.AdvanceClock(time.Duration(minutes) * time.Minute)
+}
+
+func (s *suite) anEventShouldHaveBeenPublishedFor(kind string, name string) error {
+	published, err := s.hasPublished(events.Kind(kind), name)
+	if err != nil {
+		return err
+	}
+	if !published {
+		return fmt.Errorf("expected a %s event to have been published for %s", kind, name)
+	}
+	return nil
+}
+
+func (s *suite) noEventShouldHaveBeenPublishedFor(kind string, name string) error {
+	published, err := s.hasPublished(events.Kind(kind), name)
+	if err != nil {
+		return err
+	}
+	if published {
+		return fmt.Errorf("expected no %s event to have been published for %s", kind, name)
+	}
+	return nil
+}
+
+func (s *suite) personWatchesTheirAccount(name string) error {
+	return s.driver.WatchAccount(name)
+}
+
+func (s *suite) personStopsWatchingTheirAccount(name string) error {
+	return s.driver.StopWatchingAccount(name)
+}
+
+func (s *suite) personShouldBeNotifiedThatTheirProjectWasCreated(name string) error {
+	return s.driver.AwaitNotification(name, events.ProjectCreated)
+}
+
+func (s *suite) personShouldBeNotifiedThatTheySignedIn(name string) error {
+	return s.driver.AwaitNotification(name, events.Authenticated)
+}
+
+func (s *suite) personShouldHaveBeenNotifiedThatProjectsWereCreated(name string, count int) error {
+	notified, err := s.driver.GetNotifications(name)
+	if err != nil {
+		return err
+	}
+	created := 0
+	for _, event := range notified {
+		if event.Kind == events.ProjectCreated {
+			created++
+		}
+	}
+	if created != count {
+		return fmt.Errorf("expected %s to have been notified that %d projects were created but there were %d notifications", name, count, created)
+	}
+	return nil
+}
+
+func (s *suite) personRegistersAWebhook(name string) error {
+	receiver := s.receiver(name)
+	_, err := s.driver.RegisterWebhook(name, receiver.URL(), receiver.Secret())
+	return err
+}
+
+func (s *suite) personHasCreatedAnAccountWithAWebhook(name string) error {
+	receiver := s.receiver(name)
+	return s.driver.CreateAccountWithWebhook(name, defaultPassword, receiver.URL(), receiver.Secret())
+}
+
+func (s *suite) personsWebhookGoesDown(name string) error {
+	s.receiver(name).SetDown(true)
+	return nil
+}
+
+func (s *suite) personsWebhookComesBackUp(name string) error {
+	s.receiver(name).SetDown(false)
+	return nil
+}
+
+func (s *suite) personsWebhookShouldBeSentASignedPayload(name string, kind string) error {
+	received, err := s.receiver(name).Await(events.Kind(kind))
+	if err != nil {
+		return err
+	}
+	if !received.Signed {
+		return fmt.Errorf("expected the %s payload sent to %s's webhook to be signed with its secret", kind, name)
+	}
+	if received.Payload.Account != name {
+		return fmt.Errorf("expected the %s payload to be about %s but it was about %s", kind, name, received.Payload.Account)
+	}
+	return nil
+}
+
+func (s *suite) theDeliveryToPersonsWebhookShouldHaveSucceededAfter(name string, attempts int) error {
+	delivery, err := s.awaitDeliveryToWebhook(name, attempts)
+	if err != nil {
+		return err
+	}
+	if delivery.Status != webhooks.StatusDelivered || delivery.Attempts != attempts {
+		return fmt.Errorf("expected the delivery to have succeeded after %d attempts but it is %s after %d", attempts, delivery.Status, delivery.Attempts)
+	}
+	return nil
+}
+
+func (s *suite) theDeliveryToPersonsWebhookShouldBeWaitingToBeRetried(name string) error {
+	delivery, err := s.awaitDeliveryToWebhook(name, 1)
+	if err != nil {
+		return err
+	}
+	if delivery.Status != webhooks.StatusPending || delivery.ResponseStatus != http.StatusServiceUnavailable {
+		return fmt.Errorf("expected the delivery to be waiting to be retried after the webhook was unavailable but it is %s after a %d response", delivery.Status, delivery.ResponseStatus)
+	}
+	return nil
+}
+
+func (s *suite) theServerRestarts() error {
+	return s.server.Restart()
+}
+
+// defaultProjectName is used when the scenario does not care what a project is called
+const defaultProjectName = "My project"
+
+// findProjectCalled finds one of a person's projects by name, archived or not, as they
+// would in a list of projects that includes archived ones
+func (s *suite) findProjectCalled(name string, projectName string) (entities.Project, error) {
+	query := entities.ProjectQuery{Limit: entities.MaxProjectLimit, IncludeArchived: true}
+	for {
+		page, err := s.driver.ListProjects(name, query)
+		if err != nil {
+			return entities.Project{}, err
+		}
+		for _, project := range page.Projects {
+			if project.Name() == projectName {
+				return project, nil
+			}
+		}
+		if page.NextCursor == "" {
+			return entities.Project{}, fmt.Errorf("%w: no project called %s", entities.ErrProjectNotFound, projectName)
+		}
+		query.Cursor = page.NextCursor
+	}
+}
+
+// findInvitationTo finds a person's unanswered invitation to share the project with the
+// given name
+func (s *suite) findInvitationTo(name string, projectName string) (entities.Invitation, error) {
+	invitations, err := s.driver.GetInvitations(name)
+	if err != nil {
+		return entities.Invitation{}, err
+	}
+	for _, invitation := range invitations {
+		if invitation.ProjectName == projectName {
+			return invitation, nil
+		}
+	}
+	return entities.Invitation{}, fmt.Errorf("%w: no invitation to %s", entities.ErrInvitationNotFound, projectName)
+}
+
+// findOrganisationProjectCalled finds one of an organisation's projects by name, as
+// one of its members would
+func (s *suite) findOrganisationProjectCalled(name string, orgName string, projectName string) (entities.Project, error) {
+	projects, err := s.driver.GetOrganisationProjects(name, orgName)
+	if err != nil {
+		return entities.Project{}, err
+	}
+	for _, project := range projects {
+		if project.Name() == projectName {
+			return project, nil
+		}
+	}
+	return entities.Project{}, fmt.Errorf("%w: no project called %s in %s", entities.ErrProjectNotFound, projectName, orgName)
+}
+
+// roleIn returns the role a person has in the organisation, or "" if they do not belong to it
+func (s *suite) roleIn(name string, orgName string) (entities.OrgRole, error) {
+	memberships, err := s.driver.GetOrganisations(name)
+	if err != nil {
+		return "", err
+	}
+	for _, membership := range memberships {
+		if membership.Organisation == orgName {
+			return membership.Role, nil
+		}
+	}
+	return "", nil
+}
+
+// seesProjectCalled reports whether a person's list of projects, which leaves out
+// archived ones, has one with the given name
+func (s *suite) seesProjectCalled(name string, projectName string) (bool, error) {
+	projects, err := s.driver.GetProjects(name)
+	if err != nil {
+		return false, err
+	}
+	for _, project := range projects {
+		if project.Name() == projectName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// expectLastError checks that a person's last attempt failed with the expected error
+func (s *suite) expectLastError(name string, expected error) error {
+	lastError := s.getLastError(name)
+	if lastError == nil {
+		return fmt.Errorf("expected error '%v' but there is no error", expected)
+	}
+	if !errors.Is(lastError, expected) {
+		return fmt.Errorf("expected error '%v' but got %v", expected, lastError)
+	}
+	return nil
+}
+
+// hasPublished reports whether an event of the given kind has been published about a person
+func (s *suite) hasPublished(kind events.Kind, name string) (bool, error) {
+	published, err := s.driver.GetEvents(name)
+	if err != nil {
+		return false, err
+	}
+	for _, event := range published {
+		if event.Kind == kind {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// awaitDeliveryToWebhook waits for the latest delivery to the webhook a person registered
+// to have been attempted the given number of times, finding the webhook by its URL as they
+// would in their list of webhooks
+func (s *suite) awaitDeliveryToWebhook(name string, attempts int) (webhooks.Delivery, error) {
+	registered, err := s.driver.GetWebhooks(name)
+	if err != nil {
+		return webhooks.Delivery{}, err
+	}
+	for _, webhook := range registered {
+		if webhook.URL() == s.receiver(name).URL() {
+			return s.driver.AwaitWebhookDelivery(name, webhook.ID(), attempts)
+		}
+	}
+	return webhooks.Delivery{}, fmt.Errorf("%w: %s has not registered a webhook", entities.ErrWebhookNotFound, name)
+}
+
+// followLatestActivationLink follows the link in the most recent message sent to a person,
+// as they would from the top of their inbox
+func (s *suite) followLatestActivationLink(name string) error {
+	messages, err := s.driver.GetMessages(name)
+	if err != nil {
+		return err
+	}
+	if len(messages) == 0 {
+		return fmt.Errorf("no messages have been sent to %s", name)
+	}
+	return s.driver.FollowActivationLink(messages[len(messages)-1].Link)
 }
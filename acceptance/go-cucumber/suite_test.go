@@ -6,11 +6,29 @@ import (
 
 	"github.com/cucumber/godog"
 	"github.com/sirockin/cucumber-screenplay-go/acceptance/driver"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/export"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/testhelpers"
 )
 
+// serverRestarter restarts the system under test without losing its data
+type serverRestarter interface {
+	Restart() error
+}
+
 type suite struct {
 	driver     driver.TestDriver
+	server     serverRestarter
 	lastErrors map[string]error
+	// receivers are the local endpoints that people have registered as their webhooks
+	receivers map[string]*testhelpers.WebhookReceiver
+	// shownPages holds the pages of projects each person was last shown
+	shownPages map[string][]entities.ProjectPage
+	// downloads holds the archive of their data each person last downloaded
+	downloads map[string]export.Archive
+	// baselines holds the snapshots that scenarios start from, built the first time
+	// each one is needed and kept for the whole run
+	baselines map[string]testhelpers.Snapshot
 }
 
 func (s *suite) getLastError(name string) error {
@@ -27,32 +45,180 @@ func (s *suite) setLastError(name string, err error) {
 	s.lastErrors[name] = err
 }
 
-func RunSuite(t *testing.T, driver driver.TestDriver) {
+// receiver returns the endpoint to register as a person's webhook, starting it if need be
+func (s *suite) receiver(name string) *testhelpers.WebhookReceiver {
+	if s.receivers[name] == nil {
+		s.receivers[name] = testhelpers.NewWebhookReceiver()
+	}
+	return s.receivers[name]
+}
+
+func (s *suite) closeReceivers() {
+	for _, receiver := range s.receivers {
+		receiver.Close()
+	}
+	s.receivers = make(map[string]*testhelpers.WebhookReceiver)
+}
+
+// RunSuite runs the feature files against the driver. Scenarios tagged @restart
+// are only run if server is not nil, as they need to restart the system under test.
+func RunSuite(t *testing.T, driver driver.TestDriver, server serverRestarter) {
+	tags := ""
+	if server == nil {
+		tags = "~@restart"
+	}
+
+	baselines := make(map[string]testhelpers.Snapshot)
 	suite := godog.TestSuite{
 		ScenarioInitializer: func(ctx *godog.ScenarioContext) {
 			s := &suite{
-				driver: driver,
+				driver:    driver,
+				server:    server,
+				baselines: baselines,
 			}
 
 			ctx.Before(func(ctx context.Context, sc *godog.Scenario) (context.Context, error) {
 				s.lastErrors = make(map[string]error)
+				s.receivers = make(map[string]*testhelpers.WebhookReceiver)
+				s.shownPages = make(map[string][]entities.ProjectPage)
+				s.downloads = make(map[string]export.Archive)
 				s.driver.ClearAll()
 				return ctx, nil
 			})
 
+			ctx.After(func(ctx context.Context, sc *godog.Scenario, err error) (context.Context, error) {
+				s.closeReceivers()
+				return ctx, nil
+			})
+
 			ctx.Step(`^(Bob|Tanya|Sue) has created an account$`, s.personHasCreatedAnAccount)
 			ctx.Step(`^(Bob|Tanya|Sue) has signed up$`, s.personHasSignedUp)
+			ctx.Step(`^(Bob|Tanya|Sue) has signed up with (\d+) projects$`, s.personHasSignedUpWithProjects)
+			ctx.Step(`^(Bob|Tanya|Sue) is on the (free|pro) plan with (\d+) projects$`, s.personIsOnThePlanWithProjects)
 			ctx.Step(`^(Bob|Tanya|Sue) should not be authenticated$`, s.personShouldNotBeAuthenticated)
 			ctx.Step(`^(Bob|Tanya|Sue) should not see any projects$`, s.personShouldNotSeeAnyProjects)
 			ctx.Step(`^(Bob|Tanya|Sue) should see an error telling (him|her|them) to activate the account$`, s.personShouldSeeAnErrorTellingThemToActivateTheAccount)
 			ctx.Step(`^(Bob|Tanya|Sue) tries to sign in$`, s.personTriesToSignIn)
+			ctx.Step(`^(Bob|Tanya|Sue) tries to sign up as (Bob|Tanya|Sue)$`, s.personTriesToSignUpAs)
+			ctx.Step(`^(Bob|Tanya|Sue) should see an error telling (him|her|them) the name is already taken$`, s.personShouldSeeAnErrorTellingThemTheNameIsAlreadyTaken)
+			ctx.Step(`^(Bob|Tanya|Sue) tries to sign up with the password "([^"]*)"$`, s.personTriesToSignUpWithThePassword)
+			ctx.Step(`^(Bob|Tanya|Sue) tries to sign in with the password "([^"]*)"$`, s.personTriesToSignInWithThePassword)
+			ctx.Step(`^(Bob|Tanya|Sue) should see an error telling (him|her|them) the password is too weak$`, s.personShouldSeeAnErrorTellingThemThePasswordIsTooWeak)
+			ctx.Step(`^(Bob|Tanya|Sue) should see an error telling (him|her|them) the name or password is wrong$`, s.personShouldSeeAnErrorTellingThemTheNameOrPasswordIsWrong)
 			ctx.Step(`^(Bob|Tanya|Sue) creates a project$`, s.personCreatesAProject)
+			ctx.Step(`^(Bob|Tanya|Sue) has created a project$`, s.personCreatesAProject)
+			ctx.Step(`^(Bob|Tanya|Sue) signs in again$`, s.personSignsIn)
 			ctx.Step(`^(Bob|Tanya|Sue) should see (his|her|the) project$`, s.personShouldSeeTheirProject)
+			ctx.Step(`^(Bob|Tanya|Sue) creates (\d+) projects at the same time$`, s.personCreatesProjectsAtTheSameTime)
+			ctx.Step(`^(Bob|Tanya|Sue) should see (\d+) projects$`, s.personShouldSeeProjects)
+			ctx.Step(`^(Bob|Tanya|Sue) tries to create a project$`, s.personTriesToCreateAProject)
+			ctx.Step(`^(Bob|Tanya|Sue) (?:upgrades|moves) to the (free|pro) plan$`, s.personMovesToThePlan)
+			ctx.Step(`^(Bob|Tanya|Sue) is on the (free|pro) plan$`, s.personMovesToThePlan)
+			ctx.Step(`^(Bob|Tanya|Sue) should be on the (free|pro) plan$`, s.personShouldBeOnThePlan)
+			ctx.Step(`^(Bob|Tanya|Sue) should be told to upgrade$`, s.personShouldBeToldToUpgrade)
+			ctx.Step(`^(Bob|Tanya|Sue) downloads (?:his|her) data$`, s.personDownloadsTheirData)
+			ctx.Step(`^(Bob|Tanya|Sue)'s download should hold the projects? "([^"]*)"$`, s.personsDownloadShouldHoldTheProjects)
+			ctx.Step(`^(Bob|Tanya|Sue) closes (?:his|her) account$`, s.personClosesTheirAccount)
+			ctx.Step(`^(Bob|Tanya|Sue) has closed (?:his|her) account$`, s.personClosesTheirAccount)
+			ctx.Step(`^(Bob|Tanya|Sue) tries to close (?:his|her) account$`, s.personTriesToCloseTheirAccount)
+			ctx.Step(`^(Bob|Tanya|Sue)'s account should be closed$`, s.personsAccountShouldBeClosed)
+			ctx.Step(`^(Bob|Tanya|Sue) creates a project called "([^"]*)"$`, s.personCreatesAProjectCalled)
+			ctx.Step(`^(Bob|Tanya|Sue) has created a project called "([^"]*)"$`, s.personCreatesAProjectCalled)
+			ctx.Step(`^(Bob|Tanya|Sue) renames the project "([^"]*)" to "([^"]*)"$`, s.personRenamesTheProject)
+			ctx.Step(`^(Bob|Tanya|Sue) deletes the project "([^"]*)"$`, s.personDeletesTheProject)
+			ctx.Step(`^(Bob|Tanya|Sue) should see the project called "([^"]*)"$`, s.personShouldSeeTheProjectCalled)
+			ctx.Step(`^(Bob|Tanya|Sue) should not see the project called "([^"]*)"$`, s.personShouldNotSeeTheProjectCalled)
+			ctx.Step(`^(Bob|Tanya|Sue) archives the project "([^"]*)"$`, s.personArchivesTheProject)
+			ctx.Step(`^(Bob|Tanya|Sue) has archived the project "([^"]*)"$`, s.personArchivesTheProject)
+			ctx.Step(`^(Bob|Tanya|Sue) restores the project "([^"]*)"$`, s.personRestoresTheProject)
+			ctx.Step(`^(Bob|Tanya|Sue) tries to archive the project "([^"]*)"$`, s.personTriesToArchiveTheProject)
+			ctx.Step(`^(Bob|Tanya|Sue) tries to restore the project "([^"]*)"$`, s.personTriesToRestoreTheProject)
+			ctx.Step(`^(Bob|Tanya|Sue) tries to rename the project "([^"]*)" to "([^"]*)"$`, s.personTriesToRenameTheProject)
+			ctx.Step(`^(Bob|Tanya|Sue) should see the archived project called "([^"]*)"$`, s.personShouldSeeTheArchivedProjectCalled)
+			ctx.Step(`^(Bob|Tanya|Sue) should not see the archived project called "([^"]*)"$`, s.personShouldNotSeeTheArchivedProjectCalled)
+			ctx.Step(`^(Bob|Tanya|Sue) should see an error telling (?:him|her|them) the project is archived$`, s.personShouldSeeAnErrorTellingThemTheProjectIsArchived)
+			ctx.Step(`^(Bob|Tanya|Sue) should see an error telling (?:him|her|them) the project is not archived$`, s.personShouldSeeAnErrorTellingThemTheProjectIsNotArchived)
+			ctx.Step(`^(Bob|Tanya|Sue) invites (Bob|Tanya|Sue) to the project "([^"]*)" as an? (viewer|editor)$`, s.personInvitesToTheProjectAs)
+			ctx.Step(`^(Bob|Tanya|Sue) has invited (Bob|Tanya|Sue) to the project "([^"]*)" as an? (viewer|editor)$`, s.personInvitesToTheProjectAs)
+			ctx.Step(`^(Bob|Tanya|Sue) tries to invite (Bob|Tanya|Sue) to the project "([^"]*)" as an? (viewer|editor)$`, s.personTriesToInviteToTheProjectAs)
+			ctx.Step(`^(Bob|Tanya|Sue) accepts the invitation to the project "([^"]*)"$`, s.personAcceptsTheInvitationToTheProject)
+			ctx.Step(`^(Bob|Tanya|Sue) has accepted the invitation to the project "([^"]*)"$`, s.personAcceptsTheInvitationToTheProject)
+			ctx.Step(`^(Bob|Tanya|Sue) declines the invitation to the project "([^"]*)"$`, s.personDeclinesTheInvitationToTheProject)
+			ctx.Step(`^(Bob|Tanya|Sue) should see an invitation to the project "([^"]*)"$`, s.personShouldSeeAnInvitationToTheProject)
+			ctx.Step(`^(Bob|Tanya|Sue) should not see an invitation to the project "([^"]*)"$`, s.personShouldNotSeeAnInvitationToTheProject)
+			ctx.Step(`^(Bob|Tanya|Sue) should see an error telling (?:him|her|them) (?:Bob|Tanya|Sue) already shares the project$`, s.personShouldSeeAnErrorTellingThemTheyAlreadyShareTheProject)
+			ctx.Step(`^(Bob|Tanya|Sue) creates the organisation "([^"]*)"$`, s.personCreatesTheOrganisation)
+			ctx.Step(`^(Bob|Tanya|Sue) has created the organisation "([^"]*)"$`, s.personCreatesTheOrganisation)
+			ctx.Step(`^(Bob|Tanya|Sue) tries to create the organisation "([^"]*)"$`, s.personTriesToCreateTheOrganisation)
+			ctx.Step(`^(Bob|Tanya|Sue) adds (Bob|Tanya|Sue) to the organisation "([^"]*)" as an? (owner|admin|member)$`, s.personAddsToTheOrganisationAs)
+			ctx.Step(`^(Bob|Tanya|Sue) has added (Bob|Tanya|Sue) to the organisation "([^"]*)" as an? (owner|admin|member)$`, s.personAddsToTheOrganisationAs)
+			ctx.Step(`^(Bob|Tanya|Sue) tries to add (Bob|Tanya|Sue) to the organisation "([^"]*)" as an? (owner|admin|member)$`, s.personTriesToAddToTheOrganisationAs)
+			ctx.Step(`^(Bob|Tanya|Sue) removes (Bob|Tanya|Sue) from the organisation "([^"]*)"$`, s.personRemovesFromTheOrganisation)
+			ctx.Step(`^(Bob|Tanya|Sue) tries to remove (Bob|Tanya|Sue) from the organisation "([^"]*)"$`, s.personTriesToRemoveFromTheOrganisation)
+			ctx.Step(`^(Bob|Tanya|Sue) tries to delete the organisation "([^"]*)"$`, s.personTriesToDeleteTheOrganisation)
+			ctx.Step(`^(Bob|Tanya|Sue) should be an? (owner|admin|member) of the organisation "([^"]*)"$`, s.personShouldBeOfTheOrganisation)
+			ctx.Step(`^(Bob|Tanya|Sue) should not belong to the organisation "([^"]*)"$`, s.personShouldNotBelongToTheOrganisation)
+			ctx.Step(`^(Bob|Tanya|Sue) should see an error telling (?:him|her|them) the organisation must keep an owner$`, s.personShouldSeeAnErrorTellingThemTheOrganisationMustKeepAnOwner)
+			ctx.Step(`^(Bob|Tanya|Sue) should see an error telling (?:him|her|them) the organisation already exists$`, s.personShouldSeeAnErrorTellingThemTheOrganisationAlreadyExists)
+			ctx.Step(`^(Bob|Tanya|Sue) should see an error telling (?:him|her|them) there is no such organisation$`, s.personShouldSeeAnErrorTellingThemThereIsNoSuchOrganisation)
+			ctx.Step(`^(Bob|Tanya|Sue) creates the project "([^"]*)" in the organisation "([^"]*)"$`, s.personCreatesTheProjectInTheOrganisation)
+			ctx.Step(`^(Bob|Tanya|Sue) has created the project "([^"]*)" in the organisation "([^"]*)"$`, s.personCreatesTheProjectInTheOrganisation)
+			ctx.Step(`^(Bob|Tanya|Sue) deletes the project "([^"]*)" in the organisation "([^"]*)"$`, s.personDeletesTheProjectInTheOrganisation)
+			ctx.Step(`^(Bob|Tanya|Sue) tries to delete the project "([^"]*)" in the organisation "([^"]*)"$`, s.personTriesToDeleteTheProjectInTheOrganisation)
+			ctx.Step(`^(Bob|Tanya|Sue) tries to look at the projects in the organisation "([^"]*)"$`, s.personTriesToLookAtTheProjectsInTheOrganisation)
+			ctx.Step(`^(Bob|Tanya|Sue) should see the project "([^"]*)" in the organisation "([^"]*)"$`, s.personShouldSeeTheProjectInTheOrganisation)
+			ctx.Step(`^(Bob|Tanya|Sue) should not see the project "([^"]*)" in the organisation "([^"]*)"$`, s.personShouldNotSeeTheProjectInTheOrganisation)
+			ctx.Step(`^(Bob|Tanya|Sue) pages through (?:his|her) projects (\d+) at a time$`, s.personPagesThroughTheirProjects)
+			ctx.Step(`^(Bob|Tanya|Sue) lists (?:his|her) projects by name$`, s.personListsTheirProjectsByName)
+			ctx.Step(`^(Bob|Tanya|Sue) lists (?:his|her) projects whose names start with "([^"]*)"$`, s.personListsTheirProjectsWhoseNamesStartWith)
+			ctx.Step(`^(Bob|Tanya|Sue) lists (?:his|her) projects created more than (\d+) minutes after "([^"]*)"$`, s.personListsTheirProjectsCreatedMoreThanMinutesAfter)
+			ctx.Step(`^(Bob|Tanya|Sue) should have been shown (\d+) pages of projects$`, s.personShouldHaveBeenShownPagesOfProjects)
+			ctx.Step(`^(Bob|Tanya|Sue) should have been shown all (\d+) projects once each, oldest first$`, s.personShouldHaveBeenShownAllProjectsOnceEach)
+			ctx.Step(`^(Bob|Tanya|Sue) should have been shown the projects? "([^"]*)"$`, s.personShouldHaveBeenShownTheProjects)
 			ctx.Step(`^(Bob|Tanya|Sue) activates (his|her) account$`, s.personActivatesTheirAccount)
+			ctx.Step(`^(Bob|Tanya|Sue) follows (his|her) activation link again$`, s.personFollowsTheirActivationLinkAgain)
+			ctx.Step(`^(Bob|Tanya|Sue) should see an error telling (him|her|them) the activation link is not valid$`, s.personShouldSeeAnErrorTellingThemTheActivationLinkIsNotValid)
 			ctx.Step(`^(Bob|Tanya|Sue) should be authenticated$`, s.personShouldBeAuthenticated)
+			ctx.Step(`^(Bob|Tanya|Sue) signs out$`, s.personSignsOut)
+			ctx.Step(`^(Bob|Tanya|Sue) signs in on (?:his|her) (phone|laptop)$`, s.personSignsInOnTheirDevice)
+			ctx.Step(`^(Bob|Tanya|Sue) has signed in on (?:his|her) (phone|laptop)$`, s.personSignsInOnTheirDevice)
+			ctx.Step(`^(Bob|Tanya|Sue) signs out on (?:his|her) (phone|laptop)$`, s.personSignsOutOnTheirDevice)
+			ctx.Step(`^(Bob|Tanya|Sue) tries to sign out on (?:his|her) (phone|laptop)$`, s.personTriesToSignOutOnTheirDevice)
+			ctx.Step(`^(Bob|Tanya|Sue) should be authenticated on (?:his|her) (phone|laptop)$`, s.personShouldBeAuthenticatedOnTheirDevice)
+			ctx.Step(`^(Bob|Tanya|Sue) should not be authenticated on (?:his|her) (phone|laptop)$`, s.personShouldNotBeAuthenticatedOnTheirDevice)
+			ctx.Step(`^(Bob|Tanya|Sue) should see an error telling (him|her|them) to sign in$`, s.personShouldSeeAnErrorTellingThemToSignIn)
+			ctx.Step(`^(Bob|Tanya|Sue) tries to open (Bob|Tanya|Sue)'s projects$`, s.personTriesToOpenTheProjectsOf)
+			ctx.Step(`^(Bob|Tanya|Sue) should be refused access$`, s.personShouldBeRefusedAccess)
+			ctx.Step(`^(Bob|Tanya|Sue) signs up$`, s.personHasSignedUp)
+			ctx.Step(`^(Bob|Tanya|Sue) tries to activate (his|her) account$`, s.personTriesToActivateTheirAccount)
+			ctx.Step(`^(Bob|Tanya|Sue) should see an error telling (him|her|them) the activation link has expired$`, s.personShouldSeeAnErrorTellingThemTheActivationLinkHasExpired)
+			ctx.Step(`^(\d+) days have passed$`, s.daysHavePassed)
+			ctx.Step(`^(Bob|Tanya|Sue) tries to sign in with the password "([^"]*)" (\d+) times$`, s.personTriesToSignInWithThePasswordTimes)
+			ctx.Step(`^(Bob|Tanya|Sue) has tried to sign in with the password "([^"]*)" (\d+) times$`, s.personTriesToSignInWithThePasswordTimes)
+			ctx.Step(`^(Bob|Tanya|Sue) should see an error telling (him|her|them) the account is locked$`, s.personShouldSeeAnErrorTellingThemTheAccountIsLocked)
+			ctx.Step(`^(\d+) minutes have passed$`, s.minutesHavePassed)
+			ctx.Step(`^an? (\w+) event should have been published for (Bob|Tanya|Sue)$`, s.anEventShouldHaveBeenPublishedFor)
+			ctx.Step(`^no (\w+) event should have been published for (Bob|Tanya|Sue)$`, s.noEventShouldHaveBeenPublishedFor)
+			ctx.Step(`^the server restarts$`, s.theServerRestarts)
+			ctx.Step(`^(Bob|Tanya|Sue) is watching (?:his|her) account$`, s.personWatchesTheirAccount)
+			ctx.Step(`^(Bob|Tanya|Sue) watches (?:his|her) account again$`, s.personWatchesTheirAccount)
+			ctx.Step(`^(Bob|Tanya|Sue) has stopped watching (?:his|her) account$`, s.personStopsWatchingTheirAccount)
+			ctx.Step(`^(Bob|Tanya|Sue) creates another project$`, s.personCreatesAProject)
+			ctx.Step(`^(Bob|Tanya|Sue) should be notified that (?:his|her) project was created$`, s.personShouldBeNotifiedThatTheirProjectWasCreated)
+			ctx.Step(`^(Bob|Tanya|Sue) has been notified that (?:his|her) project was created$`, s.personShouldBeNotifiedThatTheirProjectWasCreated)
+			ctx.Step(`^(Bob|Tanya|Sue) should be notified that (?:he|she) signed in$`, s.personShouldBeNotifiedThatTheySignedIn)
+			ctx.Step(`^(Bob|Tanya|Sue) should have been notified that (\d+) projects were created$`, s.personShouldHaveBeenNotifiedThatProjectsWereCreated)
+			ctx.Step(`^(Bob|Tanya|Sue) has registered a webhook$`, s.personRegistersAWebhook)
+			ctx.Step(`^(Bob|Tanya|Sue) has created an account with a webhook$`, s.personHasCreatedAnAccountWithAWebhook)
+			ctx.Step(`^(Bob|Tanya|Sue)'s webhook is down$`, s.personsWebhookGoesDown)
+			ctx.Step(`^(Bob|Tanya|Sue)'s webhook comes back up$`, s.personsWebhookComesBackUp)
+			ctx.Step(`^(Bob|Tanya|Sue)'s webhook should be sent a signed (\w+) payload$`, s.personsWebhookShouldBeSentASignedPayload)
+			ctx.Step(`^the delivery to (Bob|Tanya|Sue)'s webhook should have succeeded after (\d+) attempts?$`, s.theDeliveryToPersonsWebhookShouldHaveSucceededAfter)
+			ctx.Step(`^the delivery to (Bob|Tanya|Sue)'s webhook should be waiting to be retried$`, s.theDeliveryToPersonsWebhookShouldBeWaitingToBeRetried)
 		},
 		Options: &godog.Options{
 			Format:   "pretty",
+			Tags:     tags,
 			Paths:    []string{"."},
 			TestingT: t, // Testing instance that will run subtests.
 		},
@@ -0,0 +1,67 @@
+package features_test
+
+import (
+	"testing"
+)
+
+func TestSueHas120ProjectsAndPagesThroughThem(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personIsOnThePlanWithProjects(t, ctx, "Sue", "pro", 120)
+
+	// When
+	personPagesThroughTheirProjects(t, ctx, "Sue", 50)
+
+	// Then
+	personShouldHaveBeenShownPagesOfProjects(t, ctx, "Sue", 3)
+	personShouldHaveBeenShownAllProjectsOnceEach(t, ctx, "Sue", 120)
+}
+
+func TestSortProjectsByName(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+	personCreatesAProjectCalled(t, ctx, "Sue", "budget")
+	personCreatesAProjectCalled(t, ctx, "Sue", "Hiring")
+
+	// When
+	personListsTheirProjectsByName(t, ctx, "Sue")
+
+	// Then
+	personShouldHaveBeenShownTheProjects(t, ctx, "Sue", "budget", "Hiring", "Roadmap")
+}
+
+func TestFindProjectsByTheStartOfTheirName(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+	personCreatesAProjectCalled(t, ctx, "Sue", "Budget")
+	personCreatesAProjectCalled(t, ctx, "Sue", "Road trip")
+
+	// When
+	personListsTheirProjectsWhoseNamesStartWith(t, ctx, "Sue", "road")
+
+	// Then
+	personShouldHaveBeenShownTheProjects(t, ctx, "Sue", "Roadmap", "Road trip")
+}
+
+func TestFindProjectsByWhenTheyWereCreated(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personCreatesAProjectCalled(t, ctx, "Sue", "Old")
+	minutesHavePassed(t, ctx, 30)
+	personCreatesAProjectCalled(t, ctx, "Sue", "New")
+
+	// When
+	personListsTheirProjectsCreatedMoreThanMinutesAfter(t, ctx, "Sue", 10, "Old")
+
+	// Then
+	personShouldHaveBeenShownTheProjects(t, ctx, "Sue", "New")
+}
@@ -0,0 +1,105 @@
+package features_test
+
+import (
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+)
+
+func TestDownloadMyData(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUpWithProjects(t, ctx, "Sue", 2)
+
+	// When
+	personDownloadsTheirData(t, ctx, "Sue")
+
+	// Then
+	personsDownloadShouldHoldTheProjects(t, ctx, "Sue", "Project 1", "Project 2")
+}
+
+func TestCloseAnAccount(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personSignsInOnTheirDevice(t, ctx, "Sue", "phone")
+
+	// When
+	personClosesTheirAccount(t, ctx, "Sue")
+
+	// Then
+	personsAccountShouldBeClosed(t, ctx, "Sue")
+	personShouldNotBeAuthenticated(t, ctx, "Sue")
+	personShouldNotBeAuthenticatedOnTheirDevice(t, ctx, "Sue", "phone")
+	anEventShouldHaveBeenPublishedFor(t, ctx, events.AccountClosed, "Sue")
+}
+
+func TestProjectsGoWhenTheirOwnerClosesTheAccount(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Bob")
+	personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+	personInvitesToTheProjectAs(t, ctx, "Sue", "Bob", "Roadmap", "viewer")
+	personAcceptsTheInvitationToTheProject(t, ctx, "Bob", "Roadmap")
+
+	// When
+	personClosesTheirAccount(t, ctx, "Sue")
+
+	// Then
+	personShouldNotSeeTheProjectCalled(t, ctx, "Bob", "Roadmap")
+}
+
+func TestAClosedAccountCannotBeSignedInTo(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personClosesTheirAccount(t, ctx, "Sue")
+
+	// When
+	personTriesToSignIn(t, ctx, "Sue")
+
+	// Then
+	personShouldSeeAnErrorTellingThemTheNameOrPasswordIsWrong(t, ctx, "Sue")
+}
+
+func TestTheNameOfAClosedAccountStaysTaken(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personClosesTheirAccount(t, ctx, "Sue")
+
+	// When
+	personTriesToSignUpAs(t, ctx, "Bob", "Sue")
+
+	// Then
+	personShouldSeeAnErrorTellingThemTheNameIsAlreadyTaken(t, ctx, "Bob")
+}
+
+func TestTheLastOwnerOfAnOrganisationHandsItOverFirst(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Bob")
+	personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+
+	// When
+	personTriesToCloseTheirAccount(t, ctx, "Sue")
+
+	// Then
+	personShouldSeeAnErrorTellingThemTheOrganisationMustKeepAnOwner(t, ctx, "Sue")
+	personShouldBeAuthenticated(t, ctx, "Sue")
+
+	// When
+	personAddsToTheOrganisationAs(t, ctx, "Sue", "Bob", "Acme", "owner")
+	personClosesTheirAccount(t, ctx, "Sue")
+
+	// Then
+	personShouldBeOfTheOrganisation(t, ctx, "Bob", "owner", "Acme")
+}
@@ -2,6 +2,8 @@ package features_test
 
 import (
 	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
 )
 
 func TestCreateOneProject(t *testing.T) {
@@ -15,6 +17,20 @@ func TestCreateOneProject(t *testing.T) {
 
 	// Then
 	personShouldSeeTheirProject(t, ctx, "Sue")
+	anEventShouldHaveBeenPublishedFor(t, ctx, events.ProjectCreated, "Sue")
+}
+
+func TestCreateANamedProject(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+
+	// When
+	personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+
+	// Then
+	personShouldSeeTheProjectCalled(t, ctx, "Sue", "Roadmap")
 }
 
 func TestTryToSeeSomeoneElsesProject(t *testing.T) {
@@ -30,3 +46,60 @@ func TestTryToSeeSomeoneElsesProject(t *testing.T) {
 	// Then
 	personShouldNotSeeAnyProjects(t, ctx, "Bob")
 }
+
+func TestTryToOpenSomeoneElsesProjects(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Bob")
+	personCreatesAProject(t, ctx, "Sue")
+
+	// When
+	personTriesToOpenTheProjectsOf(t, ctx, "Bob", "Sue")
+
+	// Then
+	personShouldBeRefusedAccess(t, ctx, "Bob")
+}
+
+func TestCreateSeveralProjectsAtTheSameTime(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personMovesToThePlan(t, ctx, "Sue", "pro")
+
+	// When
+	personCreatesProjectsAtTheSameTime(t, ctx, "Sue", 10)
+
+	// Then
+	personShouldSeeProjects(t, ctx, "Sue", 10)
+}
+
+func TestStillSeeProjectsAfterSigningInAgain(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personCreatesAProject(t, ctx, "Sue")
+
+	// When
+	personSignsIn(t, ctx, "Sue")
+
+	// Then
+	personShouldSeeTheirProject(t, ctx, "Sue")
+}
+
+func TestStillSeeProjectsAfterTheServerRestarts(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personCreatesAProject(t, ctx, "Sue")
+
+	// When
+	theServerRestarts(t, ctx)
+
+	// Then
+	personShouldSeeTheirProject(t, ctx, "Sue")
+}
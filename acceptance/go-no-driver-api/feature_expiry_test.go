@@ -0,0 +1,63 @@
+package features_test
+
+import (
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+)
+
+func TestActivateAnAccountJustBeforeTheLinkExpires(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasCreatedAnAccount(t, ctx, "Tanya")
+	daysHavePassed(t, ctx, 6)
+
+	// When
+	personActivatesTheirAccount(t, ctx, "Tanya")
+
+	// Then
+	personShouldBeAuthenticated(t, ctx, "Tanya")
+}
+
+func TestTryToActivateAnAccountAfterTheLinkHasExpired(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasCreatedAnAccount(t, ctx, "Tanya")
+	daysHavePassed(t, ctx, 8)
+
+	// When
+	personTriesToActivateTheirAccount(t, ctx, "Tanya")
+
+	// Then
+	personShouldSeeAnErrorTellingThemTheActivationLinkHasExpired(t, ctx, "Tanya")
+	noEventShouldHaveBeenPublishedFor(t, ctx, events.AccountActivated, "Tanya")
+}
+
+func TestSignUpAgainWithTheNameOfAnAccountThatExpired(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasCreatedAnAccount(t, ctx, "Tanya")
+	daysHavePassed(t, ctx, 8)
+
+	// When
+	personHasSignedUp(t, ctx, "Tanya")
+
+	// Then
+	personShouldBeAuthenticated(t, ctx, "Tanya")
+}
+
+func TestSessionTimesOut(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+
+	// When
+	daysHavePassed(t, ctx, 2)
+
+	// Then
+	personShouldNotBeAuthenticated(t, ctx, "Sue")
+}
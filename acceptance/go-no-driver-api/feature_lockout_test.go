@@ -0,0 +1,66 @@
+package features_test
+
+import (
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+)
+
+func TestAccountIsLockedAfterRepeatedFailedSignIns(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Bob")
+
+	// When
+	personTriesToSignInWithThePasswordTimes(t, ctx, "Bob", "wrong-password-1", 5)
+
+	// Then
+	personShouldSeeAnErrorTellingThemTheAccountIsLocked(t, ctx, "Bob")
+}
+
+func TestTryToSignInWithTheRightPasswordWhileLocked(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Bob")
+	personTriesToSignInWithThePasswordTimes(t, ctx, "Bob", "wrong-password-1", 5)
+
+	// When
+	personTriesToSignIn(t, ctx, "Bob")
+
+	// Then
+	personShouldSeeAnErrorTellingThemTheAccountIsLocked(t, ctx, "Bob")
+	noEventShouldHaveBeenPublishedFor(t, ctx, events.Authenticated, "Bob")
+}
+
+func TestSignInAgainOnceTheLockoutIsOver(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Bob")
+	personTriesToSignInWithThePasswordTimes(t, ctx, "Bob", "wrong-password-1", 5)
+	minutesHavePassed(t, ctx, 20)
+
+	// When
+	personSignsIn(t, ctx, "Bob")
+
+	// Then
+	personShouldBeAuthenticated(t, ctx, "Bob")
+	anEventShouldHaveBeenPublishedFor(t, ctx, events.Authenticated, "Bob")
+}
+
+func TestFailedSignInsAreForgottenAfterAWhile(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Bob")
+	personTriesToSignInWithThePasswordTimes(t, ctx, "Bob", "wrong-password-1", 4)
+	minutesHavePassed(t, ctx, 20)
+
+	// When
+	personTriesToSignInWithThePassword(t, ctx, "Bob", "wrong-password-1")
+
+	// Then
+	personShouldSeeAnErrorTellingThemTheNameOrPasswordIsWrong(t, ctx, "Bob")
+}
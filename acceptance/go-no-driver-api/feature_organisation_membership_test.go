@@ -0,0 +1,95 @@
+package features_test
+
+import (
+	"testing"
+)
+
+func TestCreateAnOrganisation(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+
+	// When
+	personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+
+	// Then
+	personShouldBeOfTheOrganisation(t, ctx, "Sue", "owner", "Acme")
+}
+
+func TestAddAMember(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Bob")
+	personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+
+	// When
+	personAddsToTheOrganisationAs(t, ctx, "Sue", "Bob", "Acme", "member")
+
+	// Then
+	personShouldBeOfTheOrganisation(t, ctx, "Bob", "member", "Acme")
+}
+
+func TestMakeAMemberAnAdmin(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Bob")
+	personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+	personAddsToTheOrganisationAs(t, ctx, "Sue", "Bob", "Acme", "member")
+
+	// When
+	personAddsToTheOrganisationAs(t, ctx, "Sue", "Bob", "Acme", "admin")
+
+	// Then
+	personShouldBeOfTheOrganisation(t, ctx, "Bob", "admin", "Acme")
+}
+
+func TestRemoveAMember(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Bob")
+	personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+	personAddsToTheOrganisationAs(t, ctx, "Sue", "Bob", "Acme", "member")
+
+	// When
+	personRemovesFromTheOrganisation(t, ctx, "Sue", "Bob", "Acme")
+
+	// Then
+	personShouldNotBelongToTheOrganisation(t, ctx, "Bob", "Acme")
+}
+
+func TestAnOrganisationKeepsAnOwner(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+
+	// When
+	personTriesToRemoveFromTheOrganisation(t, ctx, "Sue", "Sue", "Acme")
+
+	// Then
+	personShouldSeeAnErrorTellingThemTheOrganisationMustKeepAnOwner(t, ctx, "Sue")
+	personShouldBeOfTheOrganisation(t, ctx, "Sue", "owner", "Acme")
+}
+
+func TestCreateAnOrganisationWhoseNameIsTaken(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Bob")
+	personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+
+	// When
+	personTriesToCreateTheOrganisation(t, ctx, "Bob", "Acme")
+
+	// Then
+	personShouldSeeAnErrorTellingThemTheOrganisationAlreadyExists(t, ctx, "Bob")
+}
@@ -0,0 +1,125 @@
+package features_test
+
+import (
+	"testing"
+)
+
+func TestShareAProject(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Bob")
+	personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+
+	// When
+	personInvitesToTheProjectAs(t, ctx, "Sue", "Bob", "Roadmap", "viewer")
+	personAcceptsTheInvitationToTheProject(t, ctx, "Bob", "Roadmap")
+
+	// Then
+	personShouldSeeTheProjectCalled(t, ctx, "Bob", "Roadmap")
+}
+
+func TestSeeASharedProjectOnlyOnceTheInvitationIsAccepted(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Bob")
+	personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+
+	// When
+	personInvitesToTheProjectAs(t, ctx, "Sue", "Bob", "Roadmap", "editor")
+
+	// Then
+	personShouldSeeAnInvitationToTheProject(t, ctx, "Bob", "Roadmap")
+	personShouldNotSeeTheProjectCalled(t, ctx, "Bob", "Roadmap")
+}
+
+func TestDeclineAnInvitation(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Bob")
+	personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+	personInvitesToTheProjectAs(t, ctx, "Sue", "Bob", "Roadmap", "editor")
+
+	// When
+	personDeclinesTheInvitationToTheProject(t, ctx, "Bob", "Roadmap")
+
+	// Then
+	personShouldNotSeeAnInvitationToTheProject(t, ctx, "Bob", "Roadmap")
+	personShouldNotSeeTheProjectCalled(t, ctx, "Bob", "Roadmap")
+}
+
+func TestEditorsCanRenameASharedProject(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Bob")
+	personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+	personInvitesToTheProjectAs(t, ctx, "Sue", "Bob", "Roadmap", "editor")
+	personAcceptsTheInvitationToTheProject(t, ctx, "Bob", "Roadmap")
+
+	// When
+	personRenamesTheProject(t, ctx, "Bob", "Roadmap", "Plan")
+
+	// Then
+	personShouldSeeTheProjectCalled(t, ctx, "Sue", "Plan")
+}
+
+func TestViewersCannotRenameASharedProject(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Bob")
+	personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+	personInvitesToTheProjectAs(t, ctx, "Sue", "Bob", "Roadmap", "viewer")
+	personAcceptsTheInvitationToTheProject(t, ctx, "Bob", "Roadmap")
+
+	// When
+	personTriesToRenameTheProject(t, ctx, "Bob", "Roadmap", "Plan")
+
+	// Then
+	personShouldBeRefusedAccess(t, ctx, "Bob")
+	personShouldSeeTheProjectCalled(t, ctx, "Sue", "Roadmap")
+}
+
+func TestOnlyTheOwnerCanShareAProject(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Bob")
+	personHasSignedUp(t, ctx, "Tanya")
+	personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+	personInvitesToTheProjectAs(t, ctx, "Sue", "Bob", "Roadmap", "editor")
+	personAcceptsTheInvitationToTheProject(t, ctx, "Bob", "Roadmap")
+
+	// When
+	personTriesToInviteToTheProjectAs(t, ctx, "Bob", "Tanya", "Roadmap", "viewer")
+
+	// Then
+	personShouldBeRefusedAccess(t, ctx, "Bob")
+	personShouldNotSeeAnInvitationToTheProject(t, ctx, "Tanya", "Roadmap")
+}
+
+func TestInviteSomeoneWhoAlreadySharesTheProject(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Bob")
+	personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+	personInvitesToTheProjectAs(t, ctx, "Sue", "Bob", "Roadmap", "viewer")
+	personAcceptsTheInvitationToTheProject(t, ctx, "Bob", "Roadmap")
+
+	// When
+	personTriesToInviteToTheProjectAs(t, ctx, "Sue", "Bob", "Roadmap", "editor")
+
+	// Then
+	personShouldSeeAnErrorTellingThemTheyAlreadyShareTheProject(t, ctx, "Sue")
+}
@@ -2,6 +2,8 @@ package features_test
 
 import (
 	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
 )
 
 func TestSignUp(t *testing.T) {
@@ -16,6 +18,20 @@ func TestSignUp(t *testing.T) {
 	// Then
 	personTriesToSignIn(t, ctx, "Sue")
 	personShouldBeAuthenticated(t, ctx, "Sue")
+	anEventShouldHaveBeenPublishedFor(t, ctx, events.AccountActivated, "Sue")
+}
+
+func TestActivationLinkOnlyWorksOnce(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+
+	// When
+	personFollowsTheirActivationLinkAgain(t, ctx, "Sue")
+
+	// Then
+	personShouldSeeAnErrorTellingThemTheActivationLinkIsNotValid(t, ctx, "Sue")
 }
 
 func TestSignInBeforeActivation(t *testing.T) {
@@ -41,3 +57,41 @@ func TestNewPersonCannotSeeProjects(t *testing.T) {
 	// Then
 	personShouldNotSeeAnyProjects(t, ctx, "Sue")
 }
+
+func TestTryToSignUpWithANameThatIsAlreadyTaken(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+
+	// When
+	personTriesToSignUpAs(t, ctx, "Bob", "Sue")
+
+	// Then
+	personShouldSeeAnErrorTellingThemTheNameIsAlreadyTaken(t, ctx, "Bob")
+	personShouldBeAuthenticated(t, ctx, "Sue")
+}
+
+func TestTryToSignUpWithAWeakPassword(t *testing.T) {
+	ctx := setupTest(t)
+
+	// When
+	personTriesToSignUpWithThePassword(t, ctx, "Bob", "secret")
+
+	// Then
+	personShouldSeeAnErrorTellingThemThePasswordIsTooWeak(t, ctx, "Bob")
+	noEventShouldHaveBeenPublishedFor(t, ctx, events.AccountCreated, "Bob")
+}
+
+func TestTryToSignInWithTheWrongPassword(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+
+	// When
+	personTriesToSignInWithThePassword(t, ctx, "Sue", "wrong-password-1")
+
+	// Then
+	personShouldSeeAnErrorTellingThemTheNameOrPasswordIsWrong(t, ctx, "Sue")
+}
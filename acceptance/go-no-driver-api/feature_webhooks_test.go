@@ -0,0 +1,58 @@
+package features_test
+
+import (
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+)
+
+func TestBeToldWhenAProjectIsCreated(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personRegistersAWebhook(t, ctx, "Sue")
+
+	// When
+	personCreatesAProject(t, ctx, "Sue")
+
+	// Then
+	personsWebhookShouldBeSentASignedPayload(t, ctx, "Sue", events.ProjectCreated)
+	theDeliveryToPersonsWebhookShouldHaveSucceededAfter(t, ctx, "Sue", 1)
+}
+
+func TestBeToldWhenAnAccountIsActivated(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasCreatedAnAccountWithAWebhook(t, ctx, "Sue")
+
+	// When
+	personActivatesTheirAccount(t, ctx, "Sue")
+
+	// Then
+	personsWebhookShouldBeSentASignedPayload(t, ctx, "Sue", events.AccountActivated)
+}
+
+func TestRetryADeliveryThatFailed(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personRegistersAWebhook(t, ctx, "Sue")
+	personsWebhookGoesDown(t, ctx, "Sue")
+
+	// When
+	personCreatesAProject(t, ctx, "Sue")
+
+	// Then
+	theDeliveryToPersonsWebhookShouldBeWaitingToBeRetried(t, ctx, "Sue")
+
+	// When
+	personsWebhookComesBackUp(t, ctx, "Sue")
+	minutesHavePassed(t, ctx, 2)
+
+	// Then
+	personsWebhookShouldBeSentASignedPayload(t, ctx, "Sue", events.ProjectCreated)
+	theDeliveryToPersonsWebhookShouldHaveSucceededAfter(t, ctx, "Sue", 2)
+}
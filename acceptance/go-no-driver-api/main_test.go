@@ -6,11 +6,11 @@ import (
 	"time"
 )
 
-var serverURL string
+var server *serverExecutable
 
 func TestMain(m *testing.M) {
 	var cleanup func()
-	serverURL, cleanup = startServerExecutable()
+	server, cleanup = startServerExecutable()
 	defer cleanup()
 
 	exitCode := m.Run()
@@ -19,7 +19,7 @@ func TestMain(m *testing.M) {
 }
 
 func setupTest(t *testing.T) *testContext {
-	ctx := newTestContext(t, serverURL)
+	ctx := newTestContext(t, server.url)
 	// Clear at start to ensure clean state
 	ctx.clearAll()
 	// Extra wait to ensure clear fully propagates before test starts
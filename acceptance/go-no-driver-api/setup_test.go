@@ -2,6 +2,7 @@ package features_test
 
 import (
 	"bufio"
+	"fmt"
 	"io"
 	"log"
 	"net/http"
@@ -18,6 +19,23 @@ type testContext struct {
 	client     *http.Client
 	baseURL    string
 	lastErrors map[string]error
+	// sessions holds the session token each person has on each of their devices
+	sessions map[sessionKey]string
+	// watches holds the event stream each person watches their account through
+	watches map[string]*watch
+	// receivers holds the service each person registers as their webhook
+	receivers map[string]*webhookReceiver
+	// shownPages holds the pages of projects each person was last shown
+	shownPages map[string][][]project
+	// downloads holds the archive of their data each person last downloaded
+	downloads map[string]archive
+}
+
+// sessionKey identifies a person's session on one of their devices. The device
+// they signed up on is their usual device, which has no name.
+type sessionKey struct {
+	name   string
+	device string
 }
 
 func newTestContext(t *testing.T, baseURL string) *testContext {
@@ -25,20 +43,64 @@ func newTestContext(t *testing.T, baseURL string) *testContext {
 		client:     &http.Client{},
 		baseURL:    baseURL,
 		lastErrors: make(map[string]error),
+		sessions:   make(map[sessionKey]string),
+		watches:    make(map[string]*watch),
+		receivers:  make(map[string]*webhookReceiver),
+		shownPages: make(map[string][][]project),
+		downloads:  make(map[string]archive),
 	}
 }
 
+// serverExecutable is the actual server executable running as a child process.
+// It keeps its data in a directory that outlives the process, so that it can be
+// restarted mid-test without losing anything.
+type serverExecutable struct {
+	url     string
+	dataDir string
+	cmd     *exec.Cmd
+}
+
 // startServerExecutable builds and starts the actual server executable using the root makefile
-// and returns the server URL and a cleanup function.
-func startServerExecutable() (string, func()) {
+// and returns the server and a cleanup function.
+func startServerExecutable() (*serverExecutable, func()) {
+	dataDir, err := os.MkdirTemp("", "server-data-")
+	if err != nil {
+		log.Printf("Failed to create data directory: %v", err)
+		os.Exit(1)
+	}
+
+	server := &serverExecutable{
+		url:     "http://localhost:8080",
+		dataDir: dataDir,
+	}
+	if err := server.start(); err != nil {
+		log.Print(err)
+		os.Exit(1)
+	}
+
+	// Create cleanup function
+	cleanup := func() {
+		server.stop()
+		os.RemoveAll(dataDir)
+	}
+
+	return server, cleanup
+}
+
+// Restart stops the server executable and starts it again with the same data
+func (s *serverExecutable) Restart() error {
+	s.stop()
+	return s.start()
+}
+
+func (s *serverExecutable) start() error {
 	// Start the server process using root makefile target
-	cmd := exec.Command("make", "run-backend")
+	cmd := exec.Command("make", "run-backend", "SERVER_ARGS=--test-mode --data-dir="+s.dataDir)
 
 	// Set working directory to project root
 	projectRoot, err := filepath.Abs("../..")
 	if err != nil {
-		log.Printf("Failed to get project root path: %v", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to get project root path: %w", err)
 	}
 	cmd.Dir = projectRoot
 
@@ -48,63 +110,55 @@ func startServerExecutable() (string, func()) {
 	// Capture server output for debugging
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
-		log.Printf("Failed to create stdout pipe: %v", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to create stdout pipe: %w", err)
 	}
 
 	stderr, err := cmd.StderrPipe()
 	if err != nil {
-		log.Printf("Failed to create stderr pipe: %v", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to create stderr pipe: %w", err)
 	}
 
 	// Start the server
 	if err := cmd.Start(); err != nil {
-		log.Printf("Failed to start server: %v", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to start server: %w", err)
 	}
+	s.cmd = cmd
 
 	// Monitor server output in background
 	go logServerOutput("STDOUT", stdout)
 	go logServerOutput("STDERR", stderr)
 
 	// Wait for server to be ready
-	serverURL := "http://localhost:8080"
-	waitForServerReady(serverURL)
-
-	log.Printf("Server started successfully at %s (PID: %d)", serverURL, cmd.Process.Pid)
-
-	// Create cleanup function
-	cleanup := func() {
-		log.Printf("Shutting down server (PID: %d)", cmd.Process.Pid)
-
-		// Kill the entire process group to ensure cleanup
-		syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
-
-		// Give the process time to shut down
-		done := make(chan error, 1)
-		go func() {
-			done <- cmd.Wait()
-		}()
-
-		select {
-		case <-done:
-			log.Printf("Server shut down gracefully")
-		case <-time.After(5 * time.Second):
-			log.Printf("Server didn't shut down gracefully")
-		}
+	if err := pollServerReady(s.url, 30*time.Second); err != nil {
+		return err
 	}
 
-	return serverURL, cleanup
+	log.Printf("Server started successfully at %s (PID: %d)", s.url, cmd.Process.Pid)
+	return nil
 }
 
-// waitForServerReady waits for the server to be ready to accept connections
-func waitForServerReady(serverURL string) {
-	waitForServerReadyWithTimeout(serverURL, 30*time.Second)
+func (s *serverExecutable) stop() {
+	log.Printf("Shutting down server (PID: %d)", s.cmd.Process.Pid)
+
+	// Kill the entire process group to ensure cleanup
+	syscall.Kill(-s.cmd.Process.Pid, syscall.SIGKILL)
+
+	// Give the process time to shut down
+	done := make(chan error, 1)
+	go func() {
+		done <- s.cmd.Wait()
+	}()
+
+	select {
+	case <-done:
+		log.Printf("Server shut down gracefully")
+	case <-time.After(5 * time.Second):
+		log.Printf("Server didn't shut down gracefully")
+	}
 }
 
-// waitForServerReadyWithTimeout waits for the server to be ready with a custom timeout
-func waitForServerReadyWithTimeout(serverURL string, timeout time.Duration) {
+// pollServerReady polls the server until it accepts connections, returning an error if it does not within the timeout
+func pollServerReady(serverURL string, timeout time.Duration) error {
 	client := &http.Client{Timeout: 5 * time.Second}
 
 	// Determine which endpoint to check based on URL
@@ -130,7 +184,7 @@ func waitForServerReadyWithTimeout(serverURL string, timeout time.Duration) {
 				consecutiveSuccesses++
 				if consecutiveSuccesses >= requiredSuccesses {
 					log.Printf("Server is ready at %s after %d attempts (%.1fs)", serverURL, attempt, time.Since(deadline.Add(-timeout)).Seconds())
-					return
+					return nil
 				}
 				// Don't sleep as long between successful checks
 				time.Sleep(500 * time.Millisecond)
@@ -142,8 +196,7 @@ func waitForServerReadyWithTimeout(serverURL string, timeout time.Duration) {
 		time.Sleep(2 * time.Second)
 	}
 
-	log.Printf("Server at %s did not become ready within %v (tried %d times)", serverURL, timeout, attempt)
-	os.Exit(1)
+	return fmt.Errorf("server at %s did not become ready within %v (tried %d times)", serverURL, timeout, attempt)
 }
 
 // logServerOutput logs server output for debugging
@@ -1,22 +1,35 @@
 package features_test
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/webhooks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// defaultPassword is used when the test does not care what a password is
+const defaultPassword = "correct-horse-1"
+
 func personHasCreatedAnAccount(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
 
-	reqBody := map[string]string{"name": name}
+	reqBody := map[string]string{"name": name, "password": defaultPassword}
 	jsonBody, err := json.Marshal(reqBody)
 	require.NoError(t, err)
 
@@ -34,6 +47,44 @@ func personHasSignedUp(t *testing.T, ctx *testContext, name string) {
 	personActivatesTheirAccount(t, ctx, name)
 }
 
+// baseline is a snapshot of the server's data that tests start from, along with the
+// session tokens people had when it was taken
+type baseline struct {
+	snapshot json.RawMessage
+	sessions map[sessionKey]string
+}
+
+// baselines holds the baselines that tests start from. Each one is built the first time
+// it is needed and kept for the whole run.
+var baselines = make(map[string]baseline)
+
+// personHasSignedUpWithProjects starts the test from a baseline in which the person has
+// signed up and created count projects, which must fit in the free plan. The baseline is
+// built the first time it is needed and restored after that, replacing everything, so it
+// must be the first step.
+func personHasSignedUpWithProjects(t *testing.T, ctx *testContext, name string, count int) {
+	t.Helper()
+	personIsOnThePlanWithProjects(t, ctx, name, "free", count)
+}
+
+// personIsOnThePlanWithProjects starts the test from a baseline in which the person has
+// signed up, moved to the plan and created count projects, as personHasSignedUpWithProjects does
+func personIsOnThePlanWithProjects(t *testing.T, ctx *testContext, name string, plan string, count int) {
+	t.Helper()
+	key := fmt.Sprintf("%s on the %s plan with %d projects", name, plan, count)
+	if b, ok := baselines[key]; ok {
+		restoreSnapshot(t, ctx, b.snapshot)
+		ctx.sessions = maps.Clone(b.sessions)
+		return
+	}
+	personHasSignedUp(t, ctx, name)
+	personMovesToThePlan(t, ctx, name, plan)
+	for i := 0; i < count; i++ {
+		personCreatesAProjectCalled(t, ctx, name, fmt.Sprintf("Project %d", i+1))
+	}
+	baselines[key] = baseline{snapshot: takeSnapshot(t, ctx), sessions: maps.Clone(ctx.sessions)}
+}
+
 func getAccount(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
 
@@ -47,8 +98,33 @@ func getAccount(t *testing.T, ctx *testContext, name string) {
 
 func personShouldBeAuthenticated(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
+	assert.True(t, isAuthenticated(t, ctx, name, usualDevice), "person %s should be authenticated", name)
+}
+
+func personShouldNotBeAuthenticated(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	assert.False(t, isAuthenticated(t, ctx, name, usualDevice), "person %s should not be authenticated", name)
+}
+
+func personShouldBeAuthenticatedOnTheirDevice(t *testing.T, ctx *testContext, name string, device string) {
+	t.Helper()
+	assert.True(t, isAuthenticated(t, ctx, name, device), "person %s should be authenticated on their %s", name, device)
+}
+
+func personShouldNotBeAuthenticatedOnTheirDevice(t *testing.T, ctx *testContext, name string, device string) {
+	t.Helper()
+	assert.False(t, isAuthenticated(t, ctx, name, device), "person %s should not be authenticated on their %s", name, device)
+}
+
+// isAuthenticated asks whether the session on one of a person's devices is signed in to their account
+func isAuthenticated(t *testing.T, ctx *testContext, name string, device string) bool {
+	t.Helper()
+
+	req, err := http.NewRequest("GET", ctx.baseURL+"/accounts/"+name+"/authentication-status", nil)
+	require.NoError(t, err)
+	ctx.authorize(req, name, device)
 
-	resp, err := ctx.client.Get(ctx.baseURL + "/accounts/" + name + "/authentication-status")
+	resp, err := ctx.client.Do(req)
 	require.NoError(t, err)
 	defer resp.Body.Close()
 
@@ -64,87 +140,215 @@ func personShouldBeAuthenticated(t *testing.T, ctx *testContext, name string) {
 	err = json.Unmarshal(body, &authStatus)
 	require.NoError(t, err)
 
-	assert.True(t, authStatus.Authenticated, "person %s should be authenticated", name)
+	return authStatus.Authenticated
 }
 
-func personShouldNotBeAuthenticated(t *testing.T, ctx *testContext, name string) {
+func personShouldNotSeeAnyProjects(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
 
-	resp, err := ctx.client.Get(ctx.baseURL + "/accounts/" + name + "/authentication-status")
-	require.NoError(t, err)
-	defer resp.Body.Close()
+	projects := getProjects(t, ctx, name)
+	assert.Empty(t, projects, "person %s should not see any projects", name)
+}
 
-	require.Equal(t, http.StatusOK, resp.StatusCode)
+func personShouldSeeTheirProject(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
 
-	var authStatus struct {
-		Authenticated bool `json:"authenticated"`
-	}
+	projects := getProjects(t, ctx, name)
+	assert.Len(t, projects, 1, "person %s should see exactly one project", name)
+}
 
-	body, err := io.ReadAll(resp.Body)
-	require.NoError(t, err)
+func personShouldSeeProjects(t *testing.T, ctx *testContext, name string, count int) {
+	t.Helper()
 
-	err = json.Unmarshal(body, &authStatus)
-	require.NoError(t, err)
+	projects := getProjects(t, ctx, name)
+	assert.Len(t, projects, count, "person %s should see %d projects", name, count)
+}
 
-	assert.False(t, authStatus.Authenticated, "person %s should not be authenticated", name)
+func personShouldSeeAnErrorTellingThemToActivateTheAccount(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrAccountNotActivated)
 }
 
-func personShouldNotSeeAnyProjects(t *testing.T, ctx *testContext, name string) {
+func personShouldSeeAnErrorTellingThemTheActivationLinkIsNotValid(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrInvalidActivation)
+}
 
-	resp, err := ctx.client.Get(ctx.baseURL + "/accounts/" + name + "/projects")
-	require.NoError(t, err)
-	defer resp.Body.Close()
+func personShouldSeeAnErrorTellingThemTheActivationLinkHasExpired(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrActivationExpired)
+}
 
-	require.NotEqual(t, http.StatusNotFound, resp.StatusCode, "account should exist")
-	require.Equal(t, http.StatusOK, resp.StatusCode)
+func personShouldSeeAnErrorTellingThemThePasswordIsTooWeak(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrWeakPassword)
+}
 
-	var projects []entities.Project
-	body, err := io.ReadAll(resp.Body)
-	require.NoError(t, err)
+func personShouldSeeAnErrorTellingThemTheNameOrPasswordIsWrong(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrWrongCredentials)
+}
 
-	err = json.Unmarshal(body, &projects)
-	require.NoError(t, err)
+func personShouldSeeAnErrorTellingThemTheAccountIsLocked(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrAccountLocked)
+}
 
-	assert.Empty(t, projects, "person %s should not see any projects", name)
+func personShouldSeeAnErrorTellingThemToSignIn(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrNotSignedIn)
 }
 
-func personShouldSeeTheirProject(t *testing.T, ctx *testContext, name string) {
+func personShouldBeRefusedAccess(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrAccessDenied)
+}
+
+func personShouldSeeAnErrorTellingThemTheNameIsAlreadyTaken(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrAccountExists)
+}
+
+func personTriesToSignUpAs(t *testing.T, ctx *testContext, name string, accountName string) {
+	t.Helper()
+	trySigningUp(t, ctx, name, accountName, defaultPassword)
+}
+
+func personTriesToSignUpWithThePassword(t *testing.T, ctx *testContext, name string, password string) {
+	t.Helper()
+	trySigningUp(t, ctx, name, name, password)
+}
+
+// trySigningUp creates an account, recording any error against the person who tried
+func trySigningUp(t *testing.T, ctx *testContext, name string, accountName string, password string) {
 	t.Helper()
 
-	resp, err := ctx.client.Get(ctx.baseURL + "/accounts/" + name + "/projects")
+	reqBody := map[string]string{"name": accountName, "password": password}
+	jsonBody, err := json.Marshal(reqBody)
 	require.NoError(t, err)
+
+	resp, err := ctx.client.Post(ctx.baseURL+"/accounts", "application/json", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		ctx.setLastError(name, err)
+		return
+	}
 	defer resp.Body.Close()
 
-	require.NotEqual(t, http.StatusNotFound, resp.StatusCode, "account should exist")
-	require.Equal(t, http.StatusOK, resp.StatusCode)
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		var errorResp struct {
+			Error string `json:"error"`
+			Code  string `json:"code"`
+		}
+		_ = json.Unmarshal(body, &errorResp)
+		if domainErr := entities.ErrorFromCode(errorResp.Code); domainErr != nil {
+			ctx.setLastError(name, fmt.Errorf("create account failed with status %d: %w", resp.StatusCode, domainErr))
+			return
+		}
+		ctx.setLastError(name, fmt.Errorf("create account failed with status %d: %s", resp.StatusCode, string(body)))
+		return
+	}
 
-	var projects []entities.Project
-	body, err := io.ReadAll(resp.Body)
-	require.NoError(t, err)
+	ctx.setLastError(name, nil)
+}
 
-	err = json.Unmarshal(body, &projects)
-	require.NoError(t, err)
+func personTriesToSignIn(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	personTriesToSignInWithThePassword(t, ctx, name, defaultPassword)
+}
 
-	assert.Len(t, projects, 1, "person %s should see exactly one project", name)
+func personTriesToSignInWithThePassword(t *testing.T, ctx *testContext, name string, password string) {
+	t.Helper()
+	trySigningIn(t, ctx, name, usualDevice, password)
 }
 
-func personShouldSeeAnErrorTellingThemToActivateTheAccount(t *testing.T, ctx *testContext, name string) {
+func personTriesToSignInWithThePasswordTimes(t *testing.T, ctx *testContext, name string, password string, times int) {
 	t.Helper()
-	lastError := ctx.getLastError(name)
-	expectedText := "you need to activate your account"
-	require.NotNil(t, lastError, "expected error containing text '%s' but there is no error", expectedText)
-	assert.Contains(t, lastError.Error(), expectedText, "expected error text containing '%s'", expectedText)
+	for i := 0; i < times; i++ {
+		trySigningIn(t, ctx, name, usualDevice, password)
+	}
 }
 
-func personTriesToSignIn(t *testing.T, ctx *testContext, name string) {
+// trySigningIn signs in on one of a person's devices, recording any error against them
+func trySigningIn(t *testing.T, ctx *testContext, name string, device string, password string) {
 	t.Helper()
 
-	req, err := http.NewRequest("POST", ctx.baseURL+"/accounts/"+name+"/authenticate", nil)
+	jsonBody, err := json.Marshal(map[string]string{"password": password})
+	require.NoError(t, err)
+
+	resp, err := ctx.client.Post(ctx.baseURL+"/accounts/"+name+"/authenticate", "application/json", bytes.NewBuffer(jsonBody))
 	if err != nil {
 		ctx.setLastError(name, err)
 		return
 	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		var errorResp struct {
+			Error string `json:"error"`
+			Code  string `json:"code"`
+		}
+		_ = json.Unmarshal(body, &errorResp)
+		if domainErr := entities.ErrorFromCode(errorResp.Code); domainErr != nil {
+			ctx.setLastError(name, fmt.Errorf("authenticate failed with status %d: %w", resp.StatusCode, domainErr))
+			return
+		}
+		ctx.setLastError(name, fmt.Errorf("authenticate failed with status %d: %s", resp.StatusCode, string(body)))
+		return
+	}
+
+	ctx.keepSession(t, resp.Body, name, device)
+	ctx.setLastError(name, nil)
+}
+
+func personSignsIn(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	personTriesToSignIn(t, ctx, name)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to sign in", name)
+}
+
+func personSignsInOnTheirDevice(t *testing.T, ctx *testContext, name string, device string) {
+	t.Helper()
+	trySigningIn(t, ctx, name, device, defaultPassword)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to sign in on their %s", name, device)
+}
+
+func personSignsOut(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	personSignsOutOnTheirDevice(t, ctx, name, usualDevice)
+}
+
+func personSignsOutOnTheirDevice(t *testing.T, ctx *testContext, name string, device string) {
+	t.Helper()
+	personTriesToSignOutOnTheirDevice(t, ctx, name, device)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to sign out", name)
+}
+
+func personTriesToSignOutOnTheirDevice(t *testing.T, ctx *testContext, name string, device string) {
+	t.Helper()
+
+	req, err := http.NewRequest("POST", ctx.baseURL+"/sessions/current/sign-out", nil)
+	require.NoError(t, err)
+	ctx.authorize(req, name, device)
 
 	resp, err := ctx.client.Do(req)
 	if err != nil {
@@ -153,24 +357,52 @@ func personTriesToSignIn(t *testing.T, ctx *testContext, name string) {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNotFound {
-		ctx.setLastError(name, fmt.Errorf("account not found: %s", name))
-		return
-	}
-
-	if resp.StatusCode == http.StatusBadRequest {
+	if resp.StatusCode != http.StatusNoContent {
 		body, _ := io.ReadAll(resp.Body)
 		var errorResp struct {
 			Error string `json:"error"`
+			Code  string `json:"code"`
 		}
 		_ = json.Unmarshal(body, &errorResp)
-		ctx.setLastError(name, fmt.Errorf("%s", errorResp.Error))
+		if domainErr := entities.ErrorFromCode(errorResp.Code); domainErr != nil {
+			ctx.setLastError(name, fmt.Errorf("sign out failed with status %d: %w", resp.StatusCode, domainErr))
+			return
+		}
+		ctx.setLastError(name, fmt.Errorf("sign out failed with status %d: %s", resp.StatusCode, string(body)))
+		return
+	}
+
+	delete(ctx.sessions, sessionKey{name: name, device: device})
+	ctx.setLastError(name, nil)
+}
+
+func personTriesToOpenTheProjectsOf(t *testing.T, ctx *testContext, name string, owner string) {
+	t.Helper()
+
+	// Ask for the owner's projects with the person's own session
+	req, err := http.NewRequest("GET", ctx.baseURL+"/accounts/"+owner+"/projects", nil)
+	require.NoError(t, err)
+	ctx.authorize(req, name, usualDevice)
+
+	resp, err := ctx.client.Do(req)
+	if err != nil {
+		ctx.setLastError(name, err)
 		return
 	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		ctx.setLastError(name, fmt.Errorf("authenticate failed with status %d: %s", resp.StatusCode, string(body)))
+		var errorResp struct {
+			Error string `json:"error"`
+			Code  string `json:"code"`
+		}
+		_ = json.Unmarshal(body, &errorResp)
+		if domainErr := entities.ErrorFromCode(errorResp.Code); domainErr != nil {
+			ctx.setLastError(name, fmt.Errorf("get projects failed with status %d: %w", resp.StatusCode, domainErr))
+			return
+		}
+		ctx.setLastError(name, fmt.Errorf("get projects failed with status %d: %s", resp.StatusCode, string(body)))
 		return
 	}
 
@@ -179,9 +411,19 @@ func personTriesToSignIn(t *testing.T, ctx *testContext, name string) {
 
 func personCreatesAProject(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
+	personCreatesAProjectCalled(t, ctx, name, "My project")
+}
+
+func personCreatesAProjectCalled(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+
+	jsonBody, err := json.Marshal(map[string]string{"name": projectName})
+	require.NoError(t, err)
 
-	req, err := http.NewRequest("POST", ctx.baseURL+"/accounts/"+name+"/projects", nil)
+	req, err := http.NewRequest("POST", ctx.baseURL+"/accounts/"+name+"/projects", bytes.NewBuffer(jsonBody))
 	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	ctx.authorize(req, name, usualDevice)
 
 	resp, err := ctx.client.Do(req)
 	require.NoError(t, err)
@@ -189,42 +431,1325 @@ func personCreatesAProject(t *testing.T, ctx *testContext, name string) {
 
 	require.NotEqual(t, http.StatusNotFound, resp.StatusCode, "account should exist")
 	require.Equal(t, http.StatusCreated, resp.StatusCode, "create project should return 201")
+	require.NotEmpty(t, resp.Header.Get("Location"), "create project should return the new project's location")
 }
 
-func personActivatesTheirAccount(t *testing.T, ctx *testContext, name string) {
+func personTriesToCreateAProject(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	jsonBody, err := json.Marshal(map[string]string{"name": "My project"})
+	require.NoError(t, err)
+	tryChanging(t, ctx, name, "POST", ctx.baseURL+"/accounts/"+name+"/projects", jsonBody, "create project")
+}
+
+func personMovesToThePlan(t *testing.T, ctx *testContext, name string, plan string) {
+	t.Helper()
+	jsonBody, err := json.Marshal(map[string]string{"plan": plan})
+	require.NoError(t, err)
+	tryChanging(t, ctx, name, "PUT", ctx.baseURL+"/accounts/"+name+"/plan", jsonBody, "change plan")
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to move to the %s plan", name, plan)
+}
+
+func personShouldBeOnThePlan(t *testing.T, ctx *testContext, name string, plan string) {
+	t.Helper()
+
+	resp, err := ctx.client.Get(ctx.baseURL + "/accounts/" + name)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode, "get account should return 200")
+
+	var account struct {
+		Plan string `json:"plan"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&account)
+	require.NoError(t, err)
+	assert.Equal(t, plan, account.Plan, "person %s should be on the %s plan", name, plan)
+}
+
+func personShouldBeToldToUpgrade(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrQuotaExceeded)
+}
+
+func personDownloadsTheirData(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
-	getAccount(t, ctx, name)
 
-	req, err := http.NewRequest("POST", ctx.baseURL+"/accounts/"+name+"/activate", nil)
+	req, err := http.NewRequest("GET", ctx.baseURL+"/accounts/"+name+"/export", nil)
 	require.NoError(t, err)
+	ctx.authorize(req, name, usualDevice)
 
 	resp, err := ctx.client.Do(req)
 	require.NoError(t, err)
 	defer resp.Body.Close()
 
-	require.NotEqual(t, http.StatusNotFound, resp.StatusCode, "account should exist")
-	require.Equal(t, http.StatusOK, resp.StatusCode, "activate should return 200")
+	require.Equal(t, http.StatusOK, resp.StatusCode, "export account should return 200")
+	assert.Equal(t, `attachment; filename=`+name+`-export.json`, resp.Header.Get("Content-Disposition"), "the archive should be downloaded as a file")
+
+	var download archive
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&download))
+	require.Equal(t, 1, download.Version, "the archive should be in the first version of the format")
+	ctx.downloads[name] = download
 }
 
-func (ctx *testContext) getLastError(name string) error {
-	return ctx.lastErrors[name]
+func personsDownloadShouldHoldTheProjects(t *testing.T, ctx *testContext, name string, projectNames ...string) {
+	t.Helper()
+	var held []string
+	for _, project := range ctx.downloads[name].Projects {
+		held = append(held, project.Name)
+	}
+	assert.Equal(t, projectNames, held, "person %s's download should hold the projects", name)
 }
 
-func (ctx *testContext) setLastError(name string, err error) {
-	ctx.lastErrors[name] = err
+func personClosesTheirAccount(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	personTriesToCloseTheirAccount(t, ctx, name)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to close their account", name)
 }
 
-func (ctx *testContext) clearAll() {
-	req, err := http.NewRequest("DELETE", ctx.baseURL+"/clear", nil)
-	if err != nil {
-		return
+func personTriesToCloseTheirAccount(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	tryChanging(t, ctx, name, "DELETE", ctx.baseURL+"/accounts/"+name, nil, "close account")
+}
+
+func personsAccountShouldBeClosed(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+
+	resp, err := ctx.client.Get(ctx.baseURL + "/accounts/" + name)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode, "get account should return 200")
+
+	var account struct {
+		ClosedAt *time.Time `json:"closedAt"`
 	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&account))
+	assert.NotNil(t, account.ClosedAt, "person %s's account should be closed", name)
+}
+
+func personRenamesTheProject(t *testing.T, ctx *testContext, name string, projectName string, newName string) {
+	t.Helper()
+	found := findProjectCalled(t, ctx, name, projectName)
+	require.NotNil(t, found, "person %s should have a project called %s", name, projectName)
+
+	jsonBody, err := json.Marshal(map[string]string{"name": newName})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("PATCH", ctx.baseURL+"/accounts/"+name+"/projects/"+found.ID, bytes.NewBuffer(jsonBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	ctx.authorize(req, name, usualDevice)
 
 	resp, err := ctx.client.Do(req)
-	if err != nil {
-		return
-	}
+	require.NoError(t, err)
 	defer resp.Body.Close()
 
-	ctx.lastErrors = make(map[string]error)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "rename project should return 200")
+}
+
+func personDeletesTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	found := findProjectCalled(t, ctx, name, projectName)
+	require.NotNil(t, found, "person %s should have a project called %s", name, projectName)
+
+	req, err := http.NewRequest("DELETE", ctx.baseURL+"/accounts/"+name+"/projects/"+found.ID, nil)
+	require.NoError(t, err)
+	ctx.authorize(req, name, usualDevice)
+
+	resp, err := ctx.client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusNoContent, resp.StatusCode, "delete project should return 204")
+}
+
+func personArchivesTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	personTriesToArchiveTheProject(t, ctx, name, projectName)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to archive the project %s", name, projectName)
+}
+
+func personRestoresTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	personTriesToRestoreTheProject(t, ctx, name, projectName)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to restore the project %s", name, projectName)
+}
+
+func personTriesToArchiveTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	found := findProjectCalled(t, ctx, name, projectName)
+	require.NotNil(t, found, "person %s should have a project called %s", name, projectName)
+	tryChangingProject(t, ctx, name, "POST", found.ID+"/archive", nil, "archive project")
+}
+
+func personTriesToRestoreTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	found := findProjectCalled(t, ctx, name, projectName)
+	require.NotNil(t, found, "person %s should have a project called %s", name, projectName)
+	tryChangingProject(t, ctx, name, "POST", found.ID+"/restore", nil, "restore project")
+}
+
+func personTriesToRenameTheProject(t *testing.T, ctx *testContext, name string, projectName string, newName string) {
+	t.Helper()
+	found := findProjectCalled(t, ctx, name, projectName)
+	require.NotNil(t, found, "person %s should have a project called %s", name, projectName)
+
+	jsonBody, err := json.Marshal(map[string]string{"name": newName})
+	require.NoError(t, err)
+	tryChangingProject(t, ctx, name, "PATCH", found.ID, jsonBody, "rename project")
+}
+
+// tryChangingProject sends a request to change one of a person's projects at path, within
+// their projects, recording any error against them. Any success status will do.
+func tryChangingProject(t *testing.T, ctx *testContext, name string, method string, path string, body []byte, action string) {
+	t.Helper()
+	tryChanging(t, ctx, name, method, ctx.baseURL+"/accounts/"+name+"/projects/"+path, body, action)
+}
+
+// tryChanging sends a request to change something at endpoint, acting as a person and
+// recording any error against them. Any success status will do.
+func tryChanging(t *testing.T, ctx *testContext, name string, method string, endpoint string, body []byte, action string) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, endpoint, bytes.NewReader(body))
+	require.NoError(t, err)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	ctx.authorize(req, name, usualDevice)
+
+	resp, err := ctx.client.Do(req)
+	if err != nil {
+		ctx.setLastError(name, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(resp.Body)
+		var errorResp struct {
+			Error string `json:"error"`
+			Code  string `json:"code"`
+		}
+		_ = json.Unmarshal(respBody, &errorResp)
+		if domainErr := entities.ErrorFromCode(errorResp.Code); domainErr != nil {
+			ctx.setLastError(name, fmt.Errorf("%s failed with status %d: %w", action, resp.StatusCode, domainErr))
+			return
+		}
+		ctx.setLastError(name, fmt.Errorf("%s failed with status %d: %s", action, resp.StatusCode, string(respBody)))
+		return
+	}
+
+	ctx.setLastError(name, nil)
+}
+
+func personInvitesToTheProjectAs(t *testing.T, ctx *testContext, name string, invitee string, projectName string, role string) {
+	t.Helper()
+	personTriesToInviteToTheProjectAs(t, ctx, name, invitee, projectName, role)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to invite %s to the project %s", name, invitee, projectName)
+}
+
+func personTriesToInviteToTheProjectAs(t *testing.T, ctx *testContext, name string, invitee string, projectName string, role string) {
+	t.Helper()
+	found := findProjectCalled(t, ctx, name, projectName)
+	require.NotNil(t, found, "person %s should have a project called %s", name, projectName)
+
+	jsonBody, err := json.Marshal(map[string]string{"account": invitee, "role": role})
+	require.NoError(t, err)
+	tryChangingProject(t, ctx, name, "POST", found.ID+"/invitations", jsonBody, "invite to project")
+}
+
+func personAcceptsTheInvitationToTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	answerInvitation(t, ctx, name, projectName, "accept", http.StatusOK)
+}
+
+func personDeclinesTheInvitationToTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	answerInvitation(t, ctx, name, projectName, "decline", http.StatusNoContent)
+}
+
+// answerInvitation accepts or declines a person's invitation to the project with the
+// given name, as action says
+func answerInvitation(t *testing.T, ctx *testContext, name string, projectName string, action string, expectedStatus int) {
+	t.Helper()
+	found := findInvitationTo(t, ctx, name, projectName)
+	require.NotNil(t, found, "person %s should have an invitation to %s", name, projectName)
+
+	req, err := http.NewRequest("POST", ctx.baseURL+"/accounts/"+name+"/invitations/"+found.ProjectID+"/"+action, nil)
+	require.NoError(t, err)
+	ctx.authorize(req, name, usualDevice)
+
+	resp, err := ctx.client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, expectedStatus, resp.StatusCode, "%s invitation should return %d", action, expectedStatus)
+}
+
+func personShouldSeeAnInvitationToTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	assert.NotNil(t, findInvitationTo(t, ctx, name, projectName), "person %s should see an invitation to %s", name, projectName)
+}
+
+func personShouldNotSeeAnInvitationToTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	assert.Nil(t, findInvitationTo(t, ctx, name, projectName), "person %s should not see an invitation to %s", name, projectName)
+}
+
+func personShouldSeeAnErrorTellingThemTheyAlreadyShareTheProject(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrAlreadyMember)
+}
+
+func personCreatesTheOrganisation(t *testing.T, ctx *testContext, name string, orgName string) {
+	t.Helper()
+	personTriesToCreateTheOrganisation(t, ctx, name, orgName)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to create the organisation %s", name, orgName)
+}
+
+func personTriesToCreateTheOrganisation(t *testing.T, ctx *testContext, name string, orgName string) {
+	t.Helper()
+	jsonBody, err := json.Marshal(map[string]string{"name": orgName})
+	require.NoError(t, err)
+	tryChanging(t, ctx, name, "POST", ctx.baseURL+"/orgs", jsonBody, "create organisation")
+}
+
+func personAddsToTheOrganisationAs(t *testing.T, ctx *testContext, name string, member string, orgName string, role string) {
+	t.Helper()
+	personTriesToAddToTheOrganisationAs(t, ctx, name, member, orgName, role)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to add %s to the organisation %s", name, member, orgName)
+}
+
+func personTriesToAddToTheOrganisationAs(t *testing.T, ctx *testContext, name string, member string, orgName string, role string) {
+	t.Helper()
+	jsonBody, err := json.Marshal(map[string]string{"role": role})
+	require.NoError(t, err)
+	tryChanging(t, ctx, name, "PUT", ctx.baseURL+"/orgs/"+orgName+"/members/"+member, jsonBody, "set organisation member")
+}
+
+func personRemovesFromTheOrganisation(t *testing.T, ctx *testContext, name string, member string, orgName string) {
+	t.Helper()
+	personTriesToRemoveFromTheOrganisation(t, ctx, name, member, orgName)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to remove %s from the organisation %s", name, member, orgName)
+}
+
+func personTriesToRemoveFromTheOrganisation(t *testing.T, ctx *testContext, name string, member string, orgName string) {
+	t.Helper()
+	tryChanging(t, ctx, name, "DELETE", ctx.baseURL+"/orgs/"+orgName+"/members/"+member, nil, "remove organisation member")
+}
+
+func personTriesToDeleteTheOrganisation(t *testing.T, ctx *testContext, name string, orgName string) {
+	t.Helper()
+	tryChanging(t, ctx, name, "DELETE", ctx.baseURL+"/orgs/"+orgName, nil, "delete organisation")
+}
+
+func personShouldBeOfTheOrganisation(t *testing.T, ctx *testContext, name string, role string, orgName string) {
+	t.Helper()
+	assert.Equal(t, role, roleIn(t, ctx, name, orgName), "person %s should be %s of %s", name, role, orgName)
+}
+
+func personShouldNotBelongToTheOrganisation(t *testing.T, ctx *testContext, name string, orgName string) {
+	t.Helper()
+	assert.Empty(t, roleIn(t, ctx, name, orgName), "person %s should not belong to %s", name, orgName)
+}
+
+func personShouldSeeAnErrorTellingThemTheOrganisationMustKeepAnOwner(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrLastOwner)
+}
+
+func personShouldSeeAnErrorTellingThemTheOrganisationAlreadyExists(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrOrganisationExists)
+}
+
+func personShouldSeeAnErrorTellingThemThereIsNoSuchOrganisation(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrOrganisationNotFound)
+}
+
+func personCreatesTheProjectInTheOrganisation(t *testing.T, ctx *testContext, name string, projectName string, orgName string) {
+	t.Helper()
+	jsonBody, err := json.Marshal(map[string]string{"name": projectName})
+	require.NoError(t, err)
+	tryChanging(t, ctx, name, "POST", ctx.baseURL+"/orgs/"+orgName+"/projects", jsonBody, "create organisation project")
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to create a project in the organisation %s", name, orgName)
+}
+
+func personDeletesTheProjectInTheOrganisation(t *testing.T, ctx *testContext, name string, projectName string, orgName string) {
+	t.Helper()
+	personTriesToDeleteTheProjectInTheOrganisation(t, ctx, name, projectName, orgName)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to delete the project %s", name, projectName)
+}
+
+func personTriesToDeleteTheProjectInTheOrganisation(t *testing.T, ctx *testContext, name string, projectName string, orgName string) {
+	t.Helper()
+	found := findOrganisationProjectCalled(t, ctx, name, orgName, projectName)
+	require.NotNil(t, found, "person %s should see a project called %s in %s", name, projectName, orgName)
+	tryChanging(t, ctx, name, "DELETE", ctx.baseURL+"/orgs/"+orgName+"/projects/"+found.ID, nil, "delete organisation project")
+}
+
+func personTriesToLookAtTheProjectsInTheOrganisation(t *testing.T, ctx *testContext, name string, orgName string) {
+	t.Helper()
+	_, err := getOrganisationProjects(t, ctx, name, orgName)
+	ctx.setLastError(name, err)
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func personShouldSeeTheProjectInTheOrganisation(t *testing.T, ctx *testContext, name string, projectName string, orgName string) {
+	t.Helper()
+	assert.NotNil(t, findOrganisationProjectCalled(t, ctx, name, orgName, projectName), "person %s should see a project called %s in %s", name, projectName, orgName)
+}
+
+func personShouldNotSeeTheProjectInTheOrganisation(t *testing.T, ctx *testContext, name string, projectName string, orgName string) {
+	t.Helper()
+	assert.Nil(t, findOrganisationProjectCalled(t, ctx, name, orgName, projectName), "person %s should not see a project called %s in %s", name, projectName, orgName)
+}
+
+func personShouldSeeTheProjectCalled(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	assert.True(t, seesProjectCalled(t, ctx, name, projectName), "person %s should see a project called %s", name, projectName)
+}
+
+func personShouldNotSeeTheProjectCalled(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	assert.False(t, seesProjectCalled(t, ctx, name, projectName), "person %s should not see a project called %s", name, projectName)
+}
+
+func personShouldSeeTheArchivedProjectCalled(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	found := findProjectCalled(t, ctx, name, projectName)
+	require.NotNil(t, found, "person %s should see an archived project called %s", name, projectName)
+	assert.Equal(t, "archived", found.State, "person %s's project called %s should be archived", name, projectName)
+}
+
+func personShouldNotSeeTheArchivedProjectCalled(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	found := findProjectCalled(t, ctx, name, projectName)
+	assert.False(t, found != nil && found.State == "archived", "person %s should not see an archived project called %s", name, projectName)
+}
+
+func personShouldSeeAnErrorTellingThemTheProjectIsArchived(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrProjectArchived)
+}
+
+func personShouldSeeAnErrorTellingThemTheProjectIsNotArchived(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrProjectNotArchived)
+}
+
+func personPagesThroughTheirProjects(t *testing.T, ctx *testContext, name string, limit int) {
+	t.Helper()
+
+	var pages [][]project
+	pageURL := fmt.Sprintf("%s/accounts/%s/projects?limit=%d", ctx.baseURL, name, limit)
+	for pageURL != "" {
+		var page []project
+		page, pageURL = getProjectPage(t, ctx, name, pageURL)
+		pages = append(pages, page)
+	}
+	ctx.shownPages[name] = pages
+}
+
+func personListsTheirProjectsByName(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	personListsTheirProjects(t, ctx, name, url.Values{"sort": {"name"}})
+}
+
+func personListsTheirProjectsWhoseNamesStartWith(t *testing.T, ctx *testContext, name string, prefix string) {
+	t.Helper()
+	personListsTheirProjects(t, ctx, name, url.Values{"namePrefix": {prefix}})
+}
+
+func personListsTheirProjectsCreatedMoreThanMinutesAfter(t *testing.T, ctx *testContext, name string, minutes int, projectName string) {
+	t.Helper()
+	found := findProjectCalled(t, ctx, name, projectName)
+	require.NotNil(t, found, "person %s should have a project called %s", name, projectName)
+
+	createdFrom := found.CreatedAt.Add(time.Duration(minutes) * time.Minute)
+	personListsTheirProjects(t, ctx, name, url.Values{"createdFrom": {createdFrom.Format(time.RFC3339)}})
+}
+
+// personListsTheirProjects lists the first page of a person's projects that query chooses
+func personListsTheirProjects(t *testing.T, ctx *testContext, name string, query url.Values) {
+	t.Helper()
+	page, _ := getProjectPage(t, ctx, name, ctx.baseURL+"/accounts/"+name+"/projects?"+query.Encode())
+	ctx.shownPages[name] = [][]project{page}
+}
+
+func personShouldHaveBeenShownPagesOfProjects(t *testing.T, ctx *testContext, name string, count int) {
+	t.Helper()
+	assert.Len(t, ctx.shownPages[name], count, "person %s should have been shown %d pages of projects", name, count)
+}
+
+// personShouldHaveBeenShownAllProjectsOnceEach expects the projects made by signing up
+// with count projects, in the order they were created
+func personShouldHaveBeenShownAllProjectsOnceEach(t *testing.T, ctx *testContext, name string, count int) {
+	t.Helper()
+	names := make([]string, count)
+	for i := range names {
+		names[i] = fmt.Sprintf("Project %d", i+1)
+	}
+	personShouldHaveBeenShownTheProjects(t, ctx, name, names...)
+}
+
+func personShouldHaveBeenShownTheProjects(t *testing.T, ctx *testContext, name string, projectNames ...string) {
+	t.Helper()
+	var shown []string
+	for _, page := range ctx.shownPages[name] {
+		for _, p := range page {
+			shown = append(shown, p.Name)
+		}
+	}
+	assert.Equal(t, projectNames, shown, "person %s should have been shown the projects in order", name)
+}
+
+func personCreatesProjectsAtTheSameTime(t *testing.T, ctx *testContext, name string, count int) {
+	t.Helper()
+
+	var wg sync.WaitGroup
+	statusCodes := make([]int, count)
+	errs := make([]error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			jsonBody, err := json.Marshal(map[string]string{"name": fmt.Sprintf("Project %d", i+1)})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			req, err := http.NewRequest("POST", ctx.baseURL+"/accounts/"+name+"/projects", bytes.NewBuffer(jsonBody))
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			req.Header.Set("Content-Type", "application/json")
+			ctx.authorize(req, name, usualDevice)
+			resp, err := ctx.client.Do(req)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer resp.Body.Close()
+			statusCodes[i] = resp.StatusCode
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < count; i++ {
+		require.NoError(t, errs[i])
+		require.Equal(t, http.StatusCreated, statusCodes[i], "create project should return 201")
+	}
+}
+
+func personActivatesTheirAccount(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	getAccount(t, ctx, name)
+	err := followLatestActivationLink(t, ctx, name)
+	require.NoError(t, err, "person %s should be able to activate their account", name)
+}
+
+func personFollowsTheirActivationLinkAgain(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	err := followLatestActivationLink(t, ctx, name)
+	ctx.setLastError(name, err)
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func personTriesToActivateTheirAccount(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	err := followLatestActivationLink(t, ctx, name)
+	ctx.setLastError(name, err)
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func daysHavePassed(t *testing.T, ctx *testContext, days int) {
+	t.Helper()
+	advanceClock(t, ctx, time.Duration(days)*24*time.Hour)
+}
+
+func minutesHavePassed(t *testing.T, ctx *testContext, minutes int) {
+	t.Helper()
+	advanceClock(t, ctx, time.Duration(minutes)*time.Minute)
+}
+
+func anEventShouldHaveBeenPublishedFor(t *testing.T, ctx *testContext, kind events.Kind, name string) {
+	t.Helper()
+	assert.True(t, hasPublished(t, ctx, kind, name), "expected a %s event to have been published for %s", kind, name)
+}
+
+func noEventShouldHaveBeenPublishedFor(t *testing.T, ctx *testContext, kind events.Kind, name string) {
+	t.Helper()
+	assert.False(t, hasPublished(t, ctx, kind, name), "expected no %s event to have been published for %s", kind, name)
+}
+
+// hasPublished reports whether an event of the given kind has been published about a
+// person, which only works while the server runs in test mode
+func hasPublished(t *testing.T, ctx *testContext, kind events.Kind, name string) bool {
+	t.Helper()
+
+	resp, err := ctx.client.Get(ctx.baseURL + "/events/" + url.PathEscape(name))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode, "get events should return 200")
+
+	var published []struct {
+		Kind string `json:"kind"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&published)
+	require.NoError(t, err)
+	for _, event := range published {
+		if events.Kind(event.Kind) == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func personWatchesTheirAccount(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+
+	w, ok := ctx.watches[name]
+	if !ok {
+		w = &watch{}
+		ctx.watches[name] = w
+	}
+	if w.cancel != nil {
+		return
+	}
+
+	req, err := http.NewRequest("GET", ctx.baseURL+"/accounts/"+url.PathEscape(name)+"/events", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "text/event-stream")
+	ctx.authorize(req, name, usualDevice)
+	// Pick up after the last event seen, if the person has watched before
+	if lastEventID := w.lastID(); lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	streamCtx, cancel := context.WithCancel(context.Background())
+	resp, err := ctx.client.Do(req.WithContext(streamCtx))
+	if err != nil {
+		cancel()
+	}
+	require.NoError(t, err)
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		cancel()
+	}
+	require.Equal(t, http.StatusOK, resp.StatusCode, "watching the account should return 200")
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	ready := make(chan struct{})
+	w.cancel, w.done = cancel, make(chan struct{})
+	go w.read(resp.Body, ready)
+	select {
+	case <-ready:
+	case <-time.After(5 * time.Second):
+		w.stop()
+		require.Fail(t, "the event stream should catch up")
+	}
+}
+
+func personStopsWatchingTheirAccount(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	w, ok := ctx.watches[name]
+	require.True(t, ok, "person %s should be watching their account", name)
+	w.stop()
+}
+
+func personShouldBeNotifiedThatTheirProjectWasCreated(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	shouldBeNotifiedOf(t, ctx, name, events.ProjectCreated)
+}
+
+func personShouldBeNotifiedThatTheySignedIn(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	shouldBeNotifiedOf(t, ctx, name, events.Authenticated)
+}
+
+func personShouldHaveBeenNotifiedThatProjectsWereCreated(t *testing.T, ctx *testContext, name string, count int) {
+	t.Helper()
+	w, ok := ctx.watches[name]
+	require.True(t, ok, "person %s should have watched their account", name)
+	assert.Equal(t, count, w.count(events.ProjectCreated), "person %s should have been notified that %d projects were created", name, count)
+}
+
+// shouldBeNotifiedOf waits for an event of the given kind to arrive on the person's stream
+func shouldBeNotifiedOf(t *testing.T, ctx *testContext, name string, kind events.Kind) {
+	t.Helper()
+	w, ok := ctx.watches[name]
+	require.True(t, ok, "person %s should be watching their account", name)
+	assert.Eventually(t, func() bool {
+		return w.count(kind) > 0
+	}, 5*time.Second, 20*time.Millisecond, "person %s should be notified of %s", name, kind)
+}
+
+// watch is a person's event stream for their account, and what it has told them
+type watch struct {
+	mu          sync.Mutex
+	kinds       []events.Kind
+	lastEventID string
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+// read reads Server-Sent Events from the stream until it ends, closing ready when the
+// stream says it has caught up
+func (w *watch) read(stream io.ReadCloser, ready chan struct{}) {
+	defer close(w.done)
+	defer stream.Close()
+
+	var id, name string
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			name = strings.TrimPrefix(line, "event: ")
+		case line == "":
+			// A blank line ends an event; heartbeats are comments, so have no name
+			w.mu.Lock()
+			if id != "" {
+				w.lastEventID = id
+			}
+			if name != "" && name != "ready" {
+				w.kinds = append(w.kinds, events.Kind(name))
+			}
+			w.mu.Unlock()
+			if name == "ready" {
+				close(ready)
+			}
+			id, name = "", ""
+		}
+	}
+}
+
+func (w *watch) lastID() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.lastEventID
+}
+
+func (w *watch) count(kind events.Kind) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	count := 0
+	for _, k := range w.kinds {
+		if k == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// stop closes the stream, if it is open, and waits for reading it to finish
+func (w *watch) stop() {
+	if w.cancel == nil {
+		return
+	}
+	w.cancel()
+	<-w.done
+	w.cancel, w.done = nil, nil
+}
+
+func personRegistersAWebhook(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	receiver := ctx.receiver(t, name)
+
+	jsonBody, err := json.Marshal(map[string]string{"url": receiver.url(), "secret": receiver.secret})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", ctx.baseURL+"/accounts/"+url.PathEscape(name)+"/webhooks", bytes.NewBuffer(jsonBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	ctx.authorize(req, name, usualDevice)
+
+	resp, err := ctx.client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "register webhook should return 201")
+	require.NotEmpty(t, resp.Header.Get("Location"), "register webhook should return the new webhook's location")
+}
+
+func personHasCreatedAnAccountWithAWebhook(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	receiver := ctx.receiver(t, name)
+
+	reqBody := map[string]any{
+		"name":     name,
+		"password": defaultPassword,
+		"webhook":  map[string]string{"url": receiver.url(), "secret": receiver.secret},
+	}
+	jsonBody, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	resp, err := ctx.client.Post(ctx.baseURL+"/accounts", "application/json", bytes.NewBuffer(jsonBody))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "create account with a webhook should return 201")
+}
+
+func personsWebhookGoesDown(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	ctx.receiver(t, name).setDown(true)
+}
+
+func personsWebhookComesBackUp(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	ctx.receiver(t, name).setDown(false)
+}
+
+func personsWebhookShouldBeSentASignedPayload(t *testing.T, ctx *testContext, name string, kind events.Kind) {
+	t.Helper()
+	receiver := ctx.receiver(t, name)
+	var accepted receivedPayload
+	require.Eventually(t, func() bool {
+		var ok bool
+		accepted, ok = receiver.accepted(kind)
+		return ok
+	}, 5*time.Second, 20*time.Millisecond, "person %s's webhook should be sent a %s payload", name, kind)
+	assert.True(t, accepted.signed, "the %s payload should be signed with the webhook's secret", kind)
+	assert.Equal(t, name, accepted.payload.Account)
+}
+
+func theDeliveryToPersonsWebhookShouldHaveSucceededAfter(t *testing.T, ctx *testContext, name string, attempts int) {
+	t.Helper()
+	latest := awaitDeliveryToWebhook(t, ctx, name, attempts)
+	assert.Equal(t, string(webhooks.StatusDelivered), latest.Status)
+	assert.Equal(t, attempts, latest.Attempts)
+}
+
+func theDeliveryToPersonsWebhookShouldBeWaitingToBeRetried(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	latest := awaitDeliveryToWebhook(t, ctx, name, 1)
+	assert.Equal(t, string(webhooks.StatusPending), latest.Status)
+	assert.Equal(t, http.StatusServiceUnavailable, latest.ResponseStatus)
+}
+
+// delivery is the part of the API's representation of a delivery to a webhook that the tests look at
+type delivery struct {
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	ResponseStatus int    `json:"responseStatus"`
+}
+
+// awaitDeliveryToWebhook waits for the latest delivery to the webhook a person registered
+// to have been attempted the given number of times. The log is updated once the webhook
+// has responded, so it can lag a moment behind what the webhook was sent.
+func awaitDeliveryToWebhook(t *testing.T, ctx *testContext, name string, attempts int) delivery {
+	t.Helper()
+	webhookID := findWebhookAt(t, ctx, name, ctx.receiver(t, name).url())
+	var latest delivery
+	require.Eventually(t, func() bool {
+		deliveries := getWebhookDeliveries(t, ctx, name, webhookID)
+		if len(deliveries) == 0 {
+			return false
+		}
+		latest = deliveries[len(deliveries)-1]
+		return latest.Attempts >= attempts
+	}, 5*time.Second, 50*time.Millisecond, "the delivery to person %s's webhook should be attempted %d times", name, attempts)
+	return latest
+}
+
+// findWebhookAt finds the ID of the person's webhook with the given URL, as they would in their list of webhooks
+func findWebhookAt(t *testing.T, ctx *testContext, name string, webhookURL string) string {
+	t.Helper()
+
+	req, err := http.NewRequest("GET", ctx.baseURL+"/accounts/"+url.PathEscape(name)+"/webhooks", nil)
+	require.NoError(t, err)
+	ctx.authorize(req, name, usualDevice)
+
+	resp, err := ctx.client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode, "get webhooks should return 200")
+
+	var registered []struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&registered)
+	require.NoError(t, err)
+	for _, webhook := range registered {
+		if webhook.URL == webhookURL {
+			return webhook.ID
+		}
+	}
+	require.Failf(t, "webhook not found", "person %s should have registered a webhook at %s", name, webhookURL)
+	return ""
+}
+
+func getWebhookDeliveries(t *testing.T, ctx *testContext, name string, webhookID string) []delivery {
+	t.Helper()
+
+	req, err := http.NewRequest("GET", ctx.baseURL+"/accounts/"+url.PathEscape(name)+"/webhooks/"+url.PathEscape(webhookID)+"/deliveries", nil)
+	require.NoError(t, err)
+	ctx.authorize(req, name, usualDevice)
+
+	resp, err := ctx.client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode, "get webhook deliveries should return 200")
+
+	var deliveries []delivery
+	err = json.NewDecoder(resp.Body).Decode(&deliveries)
+	require.NoError(t, err)
+	return deliveries
+}
+
+// receiver returns the service a person registers as their webhook, starting it if need
+// be and stopping it when the test is over
+func (ctx *testContext) receiver(t *testing.T, name string) *webhookReceiver {
+	if r, ok := ctx.receivers[name]; ok {
+		return r
+	}
+	r := &webhookReceiver{secret: "secret-of-" + strings.ToLower(name) + "-for-webhooks"}
+	r.server = httptest.NewServer(http.HandlerFunc(r.receive))
+	t.Cleanup(r.server.Close)
+	ctx.receivers[name] = r
+	return r
+}
+
+// webhookReceiver is a person's own service, which checks that what is posted to it is
+// signed with their secret and can be taken down to refuse it
+type webhookReceiver struct {
+	server *httptest.Server
+	secret string
+
+	mu       sync.Mutex
+	received []receivedPayload
+	down     bool
+}
+
+type receivedPayload struct {
+	payload  webhooks.Payload
+	signed   bool
+	accepted bool
+}
+
+func (r *webhookReceiver) url() string {
+	return r.server.URL + "/webhook"
+}
+
+func (r *webhookReceiver) setDown(down bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.down = down
+}
+
+// accepted returns the first payload about an event of the given kind that the receiver accepted
+func (r *webhookReceiver) accepted(kind events.Kind) (receivedPayload, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, received := range r.received {
+		if received.accepted && received.payload.Kind == kind {
+			return received, true
+		}
+	}
+	return receivedPayload{}, false
+}
+
+func (r *webhookReceiver) receive(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, "Failed to read payload", http.StatusBadRequest)
+		return
+	}
+	var payload webhooks.Payload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.received = append(r.received, receivedPayload{
+		payload:  payload,
+		signed:   webhooks.Verify(r.secret, body, req.Header.Get(webhooks.SignatureHeader)),
+		accepted: !r.down,
+	})
+	if r.down {
+		http.Error(w, "Down", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// advanceClock moves the server's clock on, which only works while the server runs
+// in test mode
+func advanceClock(t *testing.T, ctx *testContext, duration time.Duration) {
+	t.Helper()
+
+	jsonBody, err := json.Marshal(map[string]string{"duration": duration.String()})
+	require.NoError(t, err)
+
+	resp, err := ctx.client.Post(ctx.baseURL+"/clock/advance", "application/json", bytes.NewBuffer(jsonBody))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode, "advance clock should return 200")
+}
+
+// takeSnapshot takes a snapshot of all of the server's data
+func takeSnapshot(t *testing.T, ctx *testContext) json.RawMessage {
+	t.Helper()
+
+	resp, err := ctx.client.Get(ctx.baseURL + "/admin/snapshot")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode, "take snapshot should return 200")
+	snapshot, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return snapshot
+}
+
+// restoreSnapshot puts back the server's data as it was when the snapshot was taken.
+// Restoring ends event streams, as clearing does, so the person's watches stop.
+func restoreSnapshot(t *testing.T, ctx *testContext, snapshot json.RawMessage) {
+	t.Helper()
+
+	req, err := http.NewRequest("PUT", ctx.baseURL+"/admin/snapshot", bytes.NewReader(snapshot))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := ctx.client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusNoContent, resp.StatusCode, "restore snapshot should return 204")
+	for _, w := range ctx.watches {
+		w.stop()
+	}
+	ctx.watches = make(map[string]*watch)
+}
+
+// followLatestActivationLink follows the link in the most recent message sent to a person,
+// as they would from the top of their inbox
+func followLatestActivationLink(t *testing.T, ctx *testContext, name string) error {
+	t.Helper()
+
+	resp, err := ctx.client.Get(ctx.baseURL + "/outbox/" + url.PathEscape(name))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode, "get outbox should return 200")
+
+	var messages []struct {
+		Link string `json:"link"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&messages)
+	require.NoError(t, err)
+	require.NotEmpty(t, messages, "no messages have been sent to %s", name)
+
+	// The link is for the front end, so pick out the token the API needs from it
+	link, err := url.Parse(messages[len(messages)-1].Link)
+	require.NoError(t, err)
+
+	jsonBody, err := json.Marshal(map[string]string{"token": link.Query().Get("token")})
+	require.NoError(t, err)
+
+	activateResp, err := ctx.client.Post(ctx.baseURL+"/accounts/"+url.PathEscape(name)+"/activate", "application/json", bytes.NewBuffer(jsonBody))
+	require.NoError(t, err)
+	defer activateResp.Body.Close()
+
+	if activateResp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(activateResp.Body)
+		var errorResp struct {
+			Error string `json:"error"`
+			Code  string `json:"code"`
+		}
+		_ = json.Unmarshal(body, &errorResp)
+		if domainErr := entities.ErrorFromCode(errorResp.Code); domainErr != nil {
+			return fmt.Errorf("activate failed with status %d: %w", activateResp.StatusCode, domainErr)
+		}
+		return fmt.Errorf("activate failed with status %d: %s", activateResp.StatusCode, string(body))
+	}
+
+	// Activating also signs in the device the link was followed on
+	ctx.keepSession(t, activateResp.Body, name, usualDevice)
+	return nil
+}
+
+// project is the part of the API's representation of a project that the tests look at
+type project struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	State     string    `json:"state"`
+}
+
+// archive is the part of a downloaded archive of a person's data that tests look at
+type archive struct {
+	Version  int       `json:"version"`
+	Projects []project `json:"projects"`
+}
+
+// getProjects gets all of a person's projects that are not archived, following the link
+// from each page to the next
+func getProjects(t *testing.T, ctx *testContext, name string) []project {
+	t.Helper()
+	return getProjectsFrom(t, ctx, name, ctx.baseURL+"/accounts/"+name+"/projects?limit=100")
+}
+
+// getProjectsFrom gets all of a person's projects from the page at pageURL on
+func getProjectsFrom(t *testing.T, ctx *testContext, name string, pageURL string) []project {
+	t.Helper()
+
+	var projects []project
+	for pageURL != "" {
+		var page []project
+		page, pageURL = getProjectPage(t, ctx, name, pageURL)
+		projects = append(projects, page...)
+	}
+	return projects
+}
+
+// getProjectPage gets the page of a person's projects at pageURL, along with the URL of
+// the next page from the Link header, or "" if it is the last page
+func getProjectPage(t *testing.T, ctx *testContext, name string, pageURL string) ([]project, string) {
+	t.Helper()
+
+	req, err := http.NewRequest("GET", pageURL, nil)
+	require.NoError(t, err)
+	ctx.authorize(req, name, usualDevice)
+
+	resp, err := ctx.client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.NotEqual(t, http.StatusNotFound, resp.StatusCode, "account should exist")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var projects []project
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	err = json.Unmarshal(body, &projects)
+	require.NoError(t, err)
+
+	for _, link := range strings.Split(resp.Header.Get("Link"), ",") {
+		target, params, _ := strings.Cut(link, ";")
+		if strings.Contains(params, `rel="next"`) {
+			next, err := resp.Request.URL.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+			require.NoError(t, err)
+			return projects, next.String()
+		}
+	}
+	return projects, ""
+}
+
+// findProjectCalled finds one of a person's projects by name, archived or not, or
+// returns nil if there is none
+func findProjectCalled(t *testing.T, ctx *testContext, name string, projectName string) *project {
+	t.Helper()
+	for _, p := range getProjectsFrom(t, ctx, name, ctx.baseURL+"/accounts/"+name+"/projects?limit=100&includeArchived=true") {
+		if p.Name == projectName {
+			return &p
+		}
+	}
+	return nil
+}
+
+type invitation struct {
+	ProjectID   string `json:"projectId"`
+	ProjectName string `json:"projectName"`
+	Owner       string `json:"owner"`
+	Role        string `json:"role"`
+}
+
+// findInvitationTo finds a person's unanswered invitation to share the project with the
+// given name, or returns nil if there is none
+func findInvitationTo(t *testing.T, ctx *testContext, name string, projectName string) *invitation {
+	t.Helper()
+
+	req, err := http.NewRequest("GET", ctx.baseURL+"/accounts/"+name+"/invitations", nil)
+	require.NoError(t, err)
+	ctx.authorize(req, name, usualDevice)
+
+	resp, err := ctx.client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var invitations []invitation
+	err = json.NewDecoder(resp.Body).Decode(&invitations)
+	require.NoError(t, err)
+
+	for _, i := range invitations {
+		if i.ProjectName == projectName {
+			return &i
+		}
+	}
+	return nil
+}
+
+// getOrganisationProjects gets an organisation's projects, acting as a person, or the
+// error they are refused with
+func getOrganisationProjects(t *testing.T, ctx *testContext, name string, orgName string) ([]project, error) {
+	t.Helper()
+
+	req, err := http.NewRequest("GET", ctx.baseURL+"/orgs/"+orgName+"/projects", nil)
+	require.NoError(t, err)
+	ctx.authorize(req, name, usualDevice)
+
+	resp, err := ctx.client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errorResp struct {
+			Error string `json:"error"`
+			Code  string `json:"code"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&errorResp)
+		if domainErr := entities.ErrorFromCode(errorResp.Code); domainErr != nil {
+			return nil, fmt.Errorf("get organisation projects failed with status %d: %w", resp.StatusCode, domainErr)
+		}
+		return nil, fmt.Errorf("get organisation projects failed with status %d: %s", resp.StatusCode, errorResp.Error)
+	}
+
+	var projects []project
+	err = json.NewDecoder(resp.Body).Decode(&projects)
+	require.NoError(t, err)
+	return projects, nil
+}
+
+// findOrganisationProjectCalled finds one of an organisation's projects by name, as one
+// of its members would, or returns nil if there is none
+func findOrganisationProjectCalled(t *testing.T, ctx *testContext, name string, orgName string, projectName string) *project {
+	t.Helper()
+	projects, err := getOrganisationProjects(t, ctx, name, orgName)
+	require.NoError(t, err)
+	for _, p := range projects {
+		if p.Name == projectName {
+			return &p
+		}
+	}
+	return nil
+}
+
+// roleIn returns the role a person has in the organisation, or "" if they do not belong to it
+func roleIn(t *testing.T, ctx *testContext, name string, orgName string) string {
+	t.Helper()
+
+	req, err := http.NewRequest("GET", ctx.baseURL+"/orgs", nil)
+	require.NoError(t, err)
+	ctx.authorize(req, name, usualDevice)
+
+	resp, err := ctx.client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var memberships []struct {
+		Organisation string `json:"organisation"`
+		Role         string `json:"role"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&memberships)
+	require.NoError(t, err)
+
+	for _, m := range memberships {
+		if m.Organisation == orgName {
+			return m.Role
+		}
+	}
+	return ""
+}
+
+// seesProjectCalled reports whether a person's list of projects, which leaves out
+// archived ones, has one with the given name
+func seesProjectCalled(t *testing.T, ctx *testContext, name string, projectName string) bool {
+	t.Helper()
+	for _, p := range getProjects(t, ctx, name) {
+		if p.Name == projectName {
+			return true
+		}
+	}
+	return false
+}
+
+// usualDevice is the device a person signed up on, which they use unless a test says otherwise
+const usualDevice = ""
+
+// keepSession keeps the session token from a response that signed a person in on one of their devices
+func (ctx *testContext) keepSession(t *testing.T, r io.Reader, name string, device string) {
+	t.Helper()
+
+	var session struct {
+		Token string `json:"token"`
+	}
+	err := json.NewDecoder(r).Decode(&session)
+	require.NoError(t, err)
+	require.NotEmpty(t, session.Token, "signing in should start a session")
+
+	ctx.sessions[sessionKey{name: name, device: device}] = session.Token
+}
+
+// authorize sends the session token a person has on one of their devices with a request, if they have one
+func (ctx *testContext) authorize(req *http.Request, name string, device string) {
+	if token, ok := ctx.sessions[sessionKey{name: name, device: device}]; ok {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+}
+
+func (ctx *testContext) getLastError(name string) error {
+	return ctx.lastErrors[name]
+}
+
+func (ctx *testContext) setLastError(name string, err error) {
+	ctx.lastErrors[name] = err
+}
+
+func (ctx *testContext) clearAll() {
+	req, err := http.NewRequest("DELETE", ctx.baseURL+"/clear", nil)
+	if err != nil {
+		return
+	}
+
+	resp, err := ctx.client.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	for _, w := range ctx.watches {
+		w.stop()
+	}
+	ctx.lastErrors = make(map[string]error)
+	ctx.sessions = make(map[sessionKey]string)
+	ctx.watches = make(map[string]*watch)
+}
+
+func theServerRestarts(t *testing.T, ctx *testContext) {
+	t.Helper()
+	err := server.Restart()
+	require.NoError(t, err)
 }
@@ -0,0 +1,54 @@
+package features_test
+
+import (
+	"testing"
+)
+
+func TestStartFromABaseline(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUpWithProjects(t, ctx, "Sue", 2)
+
+	// Then
+	personShouldSeeProjects(t, ctx, "Sue", 2)
+	personShouldSeeTheProjectCalled(t, ctx, "Sue", "Project 2")
+}
+
+func TestChangeWhatTheBaselineHolds(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUpWithProjects(t, ctx, "Sue", 2)
+
+	// When
+	personCreatesAProjectCalled(t, ctx, "Sue", "Extra")
+
+	// Then
+	personShouldSeeProjects(t, ctx, "Sue", 3)
+}
+
+func TestLetTimePassAfterStartingFromABaseline(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUpWithProjects(t, ctx, "Sue", 2)
+
+	// When
+	daysHavePassed(t, ctx, 2)
+
+	// Then
+	personShouldNotBeAuthenticated(t, ctx, "Sue")
+}
+
+func TestStartFromTheBaselineAsItWasTaken(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUpWithProjects(t, ctx, "Sue", 2)
+
+	// Then
+	personShouldBeAuthenticated(t, ctx, "Sue")
+	personShouldSeeProjects(t, ctx, "Sue", 2)
+	personShouldNotSeeTheProjectCalled(t, ctx, "Sue", "Extra")
+}
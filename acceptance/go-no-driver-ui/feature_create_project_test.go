@@ -15,6 +15,20 @@ func TestCreateOneProject(t *testing.T) {
 
 	// Then
 	personShouldSeeTheirProject(t, ctx, "Sue")
+	anEventShouldHaveBeenPublishedFor(t, ctx, "ProjectCreated", "Sue")
+}
+
+func TestCreateANamedProject(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+
+	// When
+	personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+
+	// Then
+	personShouldSeeTheProjectCalled(t, ctx, "Sue", "Roadmap")
 }
 
 func TestTryToSeeSomeoneElsesProject(t *testing.T) {
@@ -30,3 +44,32 @@ func TestTryToSeeSomeoneElsesProject(t *testing.T) {
 	// Then
 	personShouldNotSeeAnyProjects(t, ctx, "Bob")
 }
+
+func TestTryToOpenSomeoneElsesProjects(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Bob")
+	personCreatesAProject(t, ctx, "Sue")
+
+	// When
+	personTriesToOpenTheProjectsOf(t, ctx, "Bob", "Sue")
+
+	// Then
+	personShouldBeRefusedAccess(t, ctx, "Bob")
+}
+
+func TestStillSeeProjectsAfterSigningInAgain(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personCreatesAProject(t, ctx, "Sue")
+
+	// When
+	personSignsIn(t, ctx, "Sue")
+
+	// Then
+	personShouldSeeTheirProject(t, ctx, "Sue")
+}
@@ -0,0 +1,51 @@
+package features_test
+
+import (
+	"testing"
+)
+
+func TestBeNotifiedWhenAProjectIsCreated(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personWatchesTheirAccount(t, ctx, "Sue")
+
+	// When
+	personCreatesAProject(t, ctx, "Sue")
+
+	// Then
+	personShouldBeNotifiedThatTheirProjectWasCreated(t, ctx, "Sue")
+}
+
+func TestBeNotifiedOfSigningInOnAnotherDevice(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personWatchesTheirAccount(t, ctx, "Sue")
+
+	// When
+	personSignsInOnTheirDevice(t, ctx, "Sue", "phone")
+
+	// Then
+	personShouldBeNotifiedThatTheySignedIn(t, ctx, "Sue")
+}
+
+func TestCatchUpOnNotificationsMissedWhileNotWatching(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personWatchesTheirAccount(t, ctx, "Sue")
+	personCreatesAProject(t, ctx, "Sue")
+	personShouldBeNotifiedThatTheirProjectWasCreated(t, ctx, "Sue")
+	personStopsWatchingTheirAccount(t, ctx, "Sue")
+
+	// When
+	personCreatesAProject(t, ctx, "Sue")
+	personWatchesTheirAccount(t, ctx, "Sue")
+
+	// Then
+	personShouldHaveBeenNotifiedThatProjectsWereCreated(t, ctx, "Sue", 2)
+}
@@ -0,0 +1,107 @@
+package features_test
+
+import (
+	"testing"
+)
+
+func TestMembersCanCreateProjects(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Bob")
+	personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+	personAddsToTheOrganisationAs(t, ctx, "Sue", "Bob", "Acme", "member")
+
+	// When
+	personCreatesTheProjectInTheOrganisation(t, ctx, "Bob", "Roadmap", "Acme")
+
+	// Then
+	personShouldSeeTheProjectInTheOrganisation(t, ctx, "Sue", "Roadmap", "Acme")
+}
+
+func TestMembersCannotDeleteProjects(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Bob")
+	personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+	personAddsToTheOrganisationAs(t, ctx, "Sue", "Bob", "Acme", "member")
+	personCreatesTheProjectInTheOrganisation(t, ctx, "Sue", "Roadmap", "Acme")
+
+	// When
+	personTriesToDeleteTheProjectInTheOrganisation(t, ctx, "Bob", "Roadmap", "Acme")
+
+	// Then
+	personShouldBeRefusedAccess(t, ctx, "Bob")
+	personShouldSeeTheProjectInTheOrganisation(t, ctx, "Sue", "Roadmap", "Acme")
+}
+
+func TestAdminsCanDeleteProjects(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Bob")
+	personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+	personAddsToTheOrganisationAs(t, ctx, "Sue", "Bob", "Acme", "admin")
+	personCreatesTheProjectInTheOrganisation(t, ctx, "Sue", "Roadmap", "Acme")
+
+	// When
+	personDeletesTheProjectInTheOrganisation(t, ctx, "Bob", "Roadmap", "Acme")
+
+	// Then
+	personShouldNotSeeTheProjectInTheOrganisation(t, ctx, "Sue", "Roadmap", "Acme")
+}
+
+func TestAdminsCannotMakeOtherAdmins(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Bob")
+	personHasSignedUp(t, ctx, "Tanya")
+	personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+	personAddsToTheOrganisationAs(t, ctx, "Sue", "Bob", "Acme", "admin")
+
+	// When
+	personTriesToAddToTheOrganisationAs(t, ctx, "Bob", "Tanya", "Acme", "admin")
+
+	// Then
+	personShouldBeRefusedAccess(t, ctx, "Bob")
+	personShouldNotBelongToTheOrganisation(t, ctx, "Tanya", "Acme")
+}
+
+func TestOnlyOwnersCanDeleteTheOrganisation(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Bob")
+	personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+	personAddsToTheOrganisationAs(t, ctx, "Sue", "Bob", "Acme", "admin")
+
+	// When
+	personTriesToDeleteTheOrganisation(t, ctx, "Bob", "Acme")
+
+	// Then
+	personShouldBeRefusedAccess(t, ctx, "Bob")
+	personShouldBeOfTheOrganisation(t, ctx, "Bob", "admin", "Acme")
+}
+
+func TestOthersCannotSeeAnOrganisationsProjects(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personHasSignedUp(t, ctx, "Tanya")
+	personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+	personCreatesTheProjectInTheOrganisation(t, ctx, "Sue", "Roadmap", "Acme")
+
+	// When
+	personTriesToLookAtTheProjectsInTheOrganisation(t, ctx, "Tanya", "Acme")
+
+	// Then
+	personShouldSeeAnErrorTellingThemThereIsNoSuchOrganisation(t, ctx, "Tanya")
+}
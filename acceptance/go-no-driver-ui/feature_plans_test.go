@@ -0,0 +1,77 @@
+package features_test
+
+import (
+	"testing"
+)
+
+func TestNewAccountsStartOnTheFreePlan(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+
+	// Then
+	personShouldBeOnThePlan(t, ctx, "Sue", "free")
+}
+
+func TestFreeAccountsAreToldToUpgradeWhenTheyReachTheirQuota(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personIsOnThePlanWithProjects(t, ctx, "Sue", "free", 3)
+
+	// When
+	personTriesToCreateAProject(t, ctx, "Sue")
+
+	// Then
+	personShouldBeToldToUpgrade(t, ctx, "Sue")
+	personShouldSeeProjects(t, ctx, "Sue", 3)
+}
+
+func TestUpgradingLiftsTheLimit(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personIsOnThePlanWithProjects(t, ctx, "Sue", "free", 3)
+
+	// When
+	personMovesToThePlan(t, ctx, "Sue", "pro")
+	personCreatesAProject(t, ctx, "Sue")
+
+	// Then
+	personShouldBeOnThePlan(t, ctx, "Sue", "pro")
+	personShouldSeeProjects(t, ctx, "Sue", 4)
+}
+
+func TestDeletingAProjectMakesRoomForAnother(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personIsOnThePlanWithProjects(t, ctx, "Sue", "free", 3)
+
+	// When
+	personDeletesTheProject(t, ctx, "Sue", "Project 1")
+	personCreatesAProject(t, ctx, "Sue")
+
+	// Then
+	personShouldSeeProjects(t, ctx, "Sue", 3)
+}
+
+func TestMovingBackToTheFreePlanKeepsTheProjects(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personIsOnThePlanWithProjects(t, ctx, "Sue", "pro", 5)
+
+	// When
+	personMovesToThePlan(t, ctx, "Sue", "free")
+
+	// Then
+	personShouldSeeProjects(t, ctx, "Sue", 5)
+
+	// When
+	personTriesToCreateAProject(t, ctx, "Sue")
+
+	// Then
+	personShouldBeToldToUpgrade(t, ctx, "Sue")
+}
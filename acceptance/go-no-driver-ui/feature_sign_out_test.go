@@ -0,0 +1,60 @@
+package features_test
+
+import (
+	"testing"
+)
+
+func TestSignOut(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+
+	// When
+	personSignsOut(t, ctx, "Sue")
+
+	// Then
+	personShouldNotBeAuthenticated(t, ctx, "Sue")
+}
+
+func TestSignInOnASecondDevice(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+
+	// When
+	personSignsInOnTheirDevice(t, ctx, "Sue", "phone")
+
+	// Then
+	personShouldBeAuthenticatedOnTheirDevice(t, ctx, "Sue", "phone")
+	personShouldBeAuthenticated(t, ctx, "Sue")
+}
+
+func TestSignOutOnOneDeviceButStaySignedInOnAnother(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+	personSignsInOnTheirDevice(t, ctx, "Sue", "phone")
+
+	// When
+	personSignsOutOnTheirDevice(t, ctx, "Sue", "phone")
+
+	// Then
+	personShouldNotBeAuthenticatedOnTheirDevice(t, ctx, "Sue", "phone")
+	personShouldBeAuthenticated(t, ctx, "Sue")
+}
+
+func TestSignOutOnADeviceThatIsNotSignedIn(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+
+	// When
+	personTriesToSignOutOnTheirDevice(t, ctx, "Sue", "phone")
+
+	// Then
+	personShouldSeeAnErrorTellingThemToSignIn(t, ctx, "Sue")
+}
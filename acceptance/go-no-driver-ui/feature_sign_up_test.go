@@ -16,6 +16,20 @@ func TestSignUp(t *testing.T) {
 	// Then
 	personTriesToSignIn(t, ctx, "Sue")
 	personShouldBeAuthenticated(t, ctx, "Sue")
+	anEventShouldHaveBeenPublishedFor(t, ctx, "AccountActivated", "Sue")
+}
+
+func TestActivationLinkOnlyWorksOnce(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+
+	// When
+	personFollowsTheirActivationLinkAgain(t, ctx, "Sue")
+
+	// Then
+	personShouldSeeAnErrorTellingThemTheActivationLinkIsNotValid(t, ctx, "Sue")
 }
 
 func TestSignInBeforeActivation(t *testing.T) {
@@ -41,3 +55,41 @@ func TestNewPersonCannotSeeProjects(t *testing.T) {
 	// Then
 	personShouldNotSeeAnyProjects(t, ctx, "Sue")
 }
+
+func TestTryToSignUpWithANameThatIsAlreadyTaken(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+
+	// When
+	personTriesToSignUpAs(t, ctx, "Bob", "Sue")
+
+	// Then
+	personShouldSeeAnErrorTellingThemTheNameIsAlreadyTaken(t, ctx, "Bob")
+	personShouldBeAuthenticated(t, ctx, "Sue")
+}
+
+func TestTryToSignUpWithAWeakPassword(t *testing.T) {
+	ctx := setupTest(t)
+
+	// When
+	personTriesToSignUpWithThePassword(t, ctx, "Bob", "secret")
+
+	// Then
+	personShouldSeeAnErrorTellingThemThePasswordIsTooWeak(t, ctx, "Bob")
+	noEventShouldHaveBeenPublishedFor(t, ctx, "AccountCreated", "Bob")
+}
+
+func TestTryToSignInWithTheWrongPassword(t *testing.T) {
+	ctx := setupTest(t)
+
+	// Given
+	personHasSignedUp(t, ctx, "Sue")
+
+	// When
+	personTriesToSignInWithThePassword(t, ctx, "Sue", "wrong-password-1")
+
+	// Then
+	personShouldSeeAnErrorTellingThemTheNameOrPasswordIsWrong(t, ctx, "Sue")
+}
@@ -4,6 +4,7 @@ go 1.24.0
 
 require (
 	github.com/playwright-community/playwright-go v0.5200.0
+	github.com/sirockin/cucumber-screenplay-go/back-end v0.0.0-00010101000000-000000000000
 	github.com/stretchr/testify v1.11.1
 )
 
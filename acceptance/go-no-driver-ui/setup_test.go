@@ -22,6 +22,20 @@ type testContext struct {
 	page        playwright.Page
 	frontendURL string
 	lastErrors  map[string]error
+	// devices holds a page for each other device a person uses, in a browser context of
+	// its own so that its sessions are separate
+	devices map[string]playwright.Page
+	// watching holds a page showing each person's notifications, opened next to their
+	// usual page, and notified the kinds of event shown on such pages since closed
+	watching map[string]playwright.Page
+	notified map[string][]string
+	// receivers holds the service each person registers as their webhook
+	receivers map[string]*webhookReceiver
+	// shownPages holds the names on each page of projects a person was last shown
+	shownPages map[string][][]string
+	// downloads holds the names of the projects in the archive of their data each person
+	// last downloaded
+	downloads map[string][]string
 }
 
 func newTestContext(t *testing.T, frontendURL string) *testContext {
@@ -58,6 +72,12 @@ func newTestContext(t *testing.T, frontendURL string) *testContext {
 		page:        page,
 		frontendURL: frontendURL,
 		lastErrors:  make(map[string]error),
+		devices:     make(map[string]playwright.Page),
+		watching:    make(map[string]playwright.Page),
+		notified:    make(map[string][]string),
+		receivers:   make(map[string]*webhookReceiver),
+		shownPages:  make(map[string][][]string),
+		downloads:   make(map[string][]string),
 	}
 
 	t.Cleanup(func() {
@@ -75,7 +95,7 @@ func newTestContext(t *testing.T, frontendURL string) *testContext {
 // and returns the server URL and a cleanup function.
 func startServerExecutable() (string, func()) {
 	// Start the server process using root makefile target
-	cmd := exec.Command("make", "run-backend")
+	cmd := exec.Command("make", "run-backend", "SERVER_ARGS=--test-mode")
 
 	// Set working directory to project root
 	projectRoot, err := filepath.Abs("../..")
@@ -203,7 +223,7 @@ func logServerOutput(prefix string, pipe io.ReadCloser) {
 // Start back end and front end services by calling `make run` and return the front end URL and cleanup function
 func startFrontAndBackend() (string, string, func()) {
 	// Start both back end and front end services
-	cmd := exec.Command("make", "run")
+	cmd := exec.Command("make", "run", "SERVER_ARGS=--test-mode")
 
 	// Set working directory to project root
 	projectRoot, err := filepath.Abs("../..")
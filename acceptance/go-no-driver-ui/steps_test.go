@@ -1,15 +1,28 @@
 package features_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/playwright-community/playwright-go"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/webhooks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// defaultPassword is used when the test does not care what a password is
+const defaultPassword = "correct-horse-1"
+
 func personHasCreatedAnAccount(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
 
@@ -27,6 +40,10 @@ func personHasCreatedAnAccount(t *testing.T, ctx *testContext, name string) {
 	err = ctx.page.Fill("input[name='name']", name)
 	require.NoError(t, err, "failed to fill name field")
 
+	// Fill in the password field
+	err = ctx.page.Fill("input[name='password']", defaultPassword)
+	require.NoError(t, err, "failed to fill password field")
+
 	// Click create account button
 	err = ctx.page.Click("button[type='submit']")
 	require.NoError(t, err, "failed to click create account button")
@@ -45,6 +62,38 @@ func personHasSignedUp(t *testing.T, ctx *testContext, name string) {
 	personActivatesTheirAccount(t, ctx, name)
 }
 
+// baselines holds the snapshots that tests start from, as shown on the snapshot page
+// along with the browser's sessions. Each one is built the first time it is needed and
+// kept for the whole run.
+var baselines = make(map[string]string)
+
+// personHasSignedUpWithProjects starts the test from a baseline in which the person has
+// signed up and created count projects, which must fit in the free plan. The baseline is
+// built the first time it is needed and restored after that, replacing everything, so it
+// must be the first step.
+func personHasSignedUpWithProjects(t *testing.T, ctx *testContext, name string, count int) {
+	t.Helper()
+	personIsOnThePlanWithProjects(t, ctx, name, "free", count)
+}
+
+// personIsOnThePlanWithProjects starts the test from a baseline in which the person has
+// signed up, moved to the plan and created count projects, as personHasSignedUpWithProjects does
+func personIsOnThePlanWithProjects(t *testing.T, ctx *testContext, name string, plan string, count int) {
+	t.Helper()
+	key := fmt.Sprintf("%s on the %s plan with %d projects", name, plan, count)
+	if snapshot, ok := baselines[key]; ok {
+		restoreSnapshot(t, ctx, snapshot)
+		return
+	}
+	personHasSignedUp(t, ctx, name)
+	personSignsIn(t, ctx, name)
+	personMovesToThePlan(t, ctx, name, plan)
+	for i := 1; i <= count; i++ {
+		personCreatesAProjectCalled(t, ctx, name, fmt.Sprintf("Project %d", i))
+	}
+	baselines[key] = takeSnapshot(t, ctx)
+}
+
 func getAccount(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
 
@@ -61,32 +110,44 @@ func getAccount(t *testing.T, ctx *testContext, name string) {
 
 func personShouldBeAuthenticated(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
+	assert.True(t, isAuthenticated(t, ctx, name, usualDevice), "person %s should be authenticated", name)
+}
 
-	// Navigate to account page and check authentication status
-	_, err := ctx.page.Goto(ctx.frontendURL + "/account/" + name)
-	require.NoError(t, err)
+func personShouldNotBeAuthenticated(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	assert.False(t, isAuthenticated(t, ctx, name, usualDevice), "person %s should not be authenticated", name)
+}
 
-	// Check if authenticated indicator is visible
-	authenticated, err := ctx.page.IsVisible(".status-authenticated")
-	require.NoError(t, err)
+func personShouldBeAuthenticatedOnTheirDevice(t *testing.T, ctx *testContext, name string, device string) {
+	t.Helper()
+	assert.True(t, isAuthenticated(t, ctx, name, device), "person %s should be authenticated on their %s", name, device)
+}
 
-	assert.True(t, authenticated, "person %s should be authenticated", name)
+func personShouldNotBeAuthenticatedOnTheirDevice(t *testing.T, ctx *testContext, name string, device string) {
+	t.Helper()
+	assert.False(t, isAuthenticated(t, ctx, name, device), "person %s should not be authenticated on their %s", name, device)
 }
 
-func personShouldNotBeAuthenticated(t *testing.T, ctx *testContext, name string) {
+// isAuthenticated checks whether the browser on one of a person's devices is signed in to their account
+func isAuthenticated(t *testing.T, ctx *testContext, name string, device string) bool {
 	t.Helper()
+	page := ctx.pageOn(t, device)
 
 	// Navigate to account page and check authentication status
-	_, err := ctx.page.Goto(ctx.frontendURL + "/account/" + name)
+	_, err := page.Goto(ctx.frontendURL + "/account/" + name)
 	require.NoError(t, err)
 
+	// The status is shown along with the rest of the account, once both have loaded
+	_, err = page.WaitForSelector(".account-info", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "account not found: %s", name)
+
 	// Check if authenticated indicator is visible
-	authenticated, err := ctx.page.IsVisible(".status-authenticated")
-	if err != nil {
-		authenticated = false
-	}
+	authenticated, err := page.IsVisible(".status-authenticated")
+	require.NoError(t, err)
 
-	assert.False(t, authenticated, "person %s should not be authenticated", name)
+	return authenticated
 }
 
 func personShouldNotSeeAnyProjects(t *testing.T, ctx *testContext, name string) {
@@ -129,26 +190,191 @@ func personShouldSeeTheirProject(t *testing.T, ctx *testContext, name string) {
 	assert.Len(t, projectElements, 1, "person %s should see exactly one project", name)
 }
 
+func personShouldSeeProjects(t *testing.T, ctx *testContext, name string, count int) {
+	t.Helper()
+
+	// Navigate to projects page
+	_, err := ctx.page.Goto(ctx.frontendURL + "/account/" + name + "/projects")
+	require.NoError(t, err, "failed to navigate to projects page")
+
+	// Wait for projects list
+	_, err = ctx.page.WaitForSelector(".projects-list", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "projects list not found")
+
+	// Count project items
+	projectElements, err := ctx.page.QuerySelectorAll(".project-item")
+	require.NoError(t, err, "failed to find project items")
+
+	assert.Len(t, projectElements, count, "person %s should see %d projects", name, count)
+}
+
 func personShouldSeeAnErrorTellingThemToActivateTheAccount(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
 	lastError := ctx.getLastError(name)
-	expectedText := "you need to activate your account"
-	require.NotNil(t, lastError, "expected error containing text '%s' but there is no error", expectedText)
-	assert.Contains(t, lastError.Error(), expectedText, "expected error text containing '%s'", expectedText)
+	require.Error(t, lastError, "expected an error but there is no error")
+	var shown *shownError
+	require.ErrorAs(t, lastError, &shown)
+	assert.Equal(t, "account_not_activated", shown.code, "expected an error telling %s to activate the account", name)
+}
+
+func personShouldSeeAnErrorTellingThemTheActivationLinkIsNotValid(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	var shown *shownError
+	require.ErrorAs(t, lastError, &shown)
+	assert.Equal(t, "invalid_activation", shown.code, "expected an error telling %s the activation link is not valid", name)
+}
+
+func personShouldSeeAnErrorTellingThemTheActivationLinkHasExpired(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	var shown *shownError
+	require.ErrorAs(t, lastError, &shown)
+	assert.Equal(t, "activation_expired", shown.code, "expected an error telling %s the activation link has expired", name)
+}
+
+func personShouldSeeAnErrorTellingThemThePasswordIsTooWeak(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	var shown *shownError
+	require.ErrorAs(t, lastError, &shown)
+	assert.Equal(t, "weak_password", shown.code, "expected an error telling %s the password is too weak", name)
+}
+
+func personShouldSeeAnErrorTellingThemTheNameOrPasswordIsWrong(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	var shown *shownError
+	require.ErrorAs(t, lastError, &shown)
+	assert.Equal(t, "wrong_credentials", shown.code, "expected an error telling %s the name or password is wrong", name)
+}
+
+func personShouldSeeAnErrorTellingThemTheAccountIsLocked(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	var shown *shownError
+	require.ErrorAs(t, lastError, &shown)
+	assert.Equal(t, "account_locked", shown.code, "expected an error telling %s the account is locked", name)
+}
+
+func personShouldSeeAnErrorTellingThemToSignIn(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	var shown *shownError
+	require.ErrorAs(t, lastError, &shown)
+	assert.Equal(t, "not_signed_in", shown.code, "expected an error telling %s to sign in", name)
+}
+
+func personShouldBeRefusedAccess(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	var shown *shownError
+	require.ErrorAs(t, lastError, &shown)
+	assert.Equal(t, "access_denied", shown.code, "expected %s to be refused access", name)
+}
+
+func personShouldSeeAnErrorTellingThemTheNameIsAlreadyTaken(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	var shown *shownError
+	require.ErrorAs(t, lastError, &shown)
+	assert.Equal(t, "account_exists", shown.code, "expected an error telling %s the name is already taken", name)
+}
+
+func personTriesToSignUpAs(t *testing.T, ctx *testContext, name string, accountName string) {
+	t.Helper()
+	trySigningUp(t, ctx, name, accountName, defaultPassword)
+}
+
+func personTriesToSignUpWithThePassword(t *testing.T, ctx *testContext, name string, password string) {
+	t.Helper()
+	trySigningUp(t, ctx, name, name, password)
+}
+
+// trySigningUp creates an account, recording any error against the person who tried
+func trySigningUp(t *testing.T, ctx *testContext, name string, accountName string, password string) {
+	t.Helper()
+
+	// Navigate to the account creation page
+	_, err := ctx.page.Goto(ctx.frontendURL + "/signup")
+	require.NoError(t, err, "failed to navigate to signup page")
+
+	// Wait for page to load
+	_, err = ctx.page.WaitForSelector("input[name='name']", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "signup form not found")
+
+	// Fill in the name field
+	err = ctx.page.Fill("input[name='name']", accountName)
+	require.NoError(t, err, "failed to fill name field")
+
+	// Fill in the password field
+	err = ctx.page.Fill("input[name='password']", password)
+	require.NoError(t, err, "failed to fill password field")
+
+	// Click create account button
+	err = ctx.page.Click("button[type='submit']")
+	require.NoError(t, err, "failed to click create account button")
+
+	// Wait for success or error message
+	_, err = ctx.page.WaitForSelector(".success, .message, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "account creation timed out")
+
+	errorVisible, _ := ctx.page.IsVisible(".error")
+	if errorVisible {
+		errorText, _ := ctx.page.TextContent(".error")
+		code, _ := ctx.page.GetAttribute(".error", "data-error-code")
+		ctx.setLastError(name, &shownError{code: code, message: errorText})
+		return
+	}
+
+	ctx.setLastError(name, nil)
 }
 
 func personTriesToSignIn(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
+	personTriesToSignInWithThePassword(t, ctx, name, defaultPassword)
+}
+
+func personTriesToSignInWithThePassword(t *testing.T, ctx *testContext, name string, password string) {
+	t.Helper()
+	trySigningIn(t, ctx, name, usualDevice, password)
+}
+
+func personTriesToSignInWithThePasswordTimes(t *testing.T, ctx *testContext, name string, password string, times int) {
+	t.Helper()
+	for i := 0; i < times; i++ {
+		trySigningIn(t, ctx, name, usualDevice, password)
+	}
+}
+
+// trySigningIn signs in on one of a person's devices, recording any error against them
+func trySigningIn(t *testing.T, ctx *testContext, name string, device string, password string) {
+	t.Helper()
+	page := ctx.pageOn(t, device)
 
 	// Navigate to login page
-	_, err := ctx.page.Goto(ctx.frontendURL + "/login")
+	_, err := page.Goto(ctx.frontendURL + "/login")
 	if err != nil {
 		ctx.setLastError(name, fmt.Errorf("failed to navigate to login page: %w", err))
 		return
 	}
 
 	// Wait for login form
-	_, err = ctx.page.WaitForSelector("input[name='name']", playwright.PageWaitForSelectorOptions{
+	_, err = page.WaitForSelector("input[name='name']", playwright.PageWaitForSelectorOptions{
 		Timeout: playwright.Float(5000),
 	})
 	if err != nil {
@@ -157,14 +383,21 @@ func personTriesToSignIn(t *testing.T, ctx *testContext, name string) {
 	}
 
 	// Fill in the name field
-	err = ctx.page.Fill("input[name='name']", name)
+	err = page.Fill("input[name='name']", name)
 	if err != nil {
 		ctx.setLastError(name, fmt.Errorf("failed to fill name field: %w", err))
 		return
 	}
 
+	// Fill in the password field
+	err = page.Fill("input[name='password']", password)
+	if err != nil {
+		ctx.setLastError(name, fmt.Errorf("failed to fill password field: %w", err))
+		return
+	}
+
 	// Click login button
-	err = ctx.page.Click("button[type='submit']")
+	err = page.Click("button[type='submit']")
 	if err != nil {
 		ctx.setLastError(name, fmt.Errorf("failed to click login button: %w", err))
 		return
@@ -172,10 +405,100 @@ func personTriesToSignIn(t *testing.T, ctx *testContext, name string) {
 
 	// Check for error message
 	time.Sleep(1 * time.Second) // Give time for response
+	errorVisible, _ := page.IsVisible(".error")
+	if errorVisible {
+		errorText, _ := page.TextContent(".error")
+		code, _ := page.GetAttribute(".error", "data-error-code")
+		ctx.setLastError(name, &shownError{code: code, message: errorText})
+		return
+	}
+
+	ctx.setLastError(name, nil)
+}
+
+func personSignsIn(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	personTriesToSignIn(t, ctx, name)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to sign in", name)
+}
+
+func personSignsInOnTheirDevice(t *testing.T, ctx *testContext, name string, device string) {
+	t.Helper()
+	trySigningIn(t, ctx, name, device, defaultPassword)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to sign in on their %s", name, device)
+}
+
+func personSignsOut(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	personSignsOutOnTheirDevice(t, ctx, name, usualDevice)
+}
+
+func personSignsOutOnTheirDevice(t *testing.T, ctx *testContext, name string, device string) {
+	t.Helper()
+	personTriesToSignOutOnTheirDevice(t, ctx, name, device)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to sign out", name)
+}
+
+func personTriesToSignOutOnTheirDevice(t *testing.T, ctx *testContext, name string, device string) {
+	t.Helper()
+	page := ctx.pageOn(t, device)
+
+	// Navigate to account page, which offers to sign out of the account
+	_, err := page.Goto(ctx.frontendURL + "/account/" + name)
+	require.NoError(t, err, "failed to navigate to account page")
+
+	_, err = page.WaitForSelector(".account-info", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "account not found: %s", name)
+
+	// The button is only offered to a browser that is signed in, just as the API
+	// refuses to sign out a client that is not
+	signOut, err := page.QuerySelector("button.sign-out")
+	require.NoError(t, err)
+	if signOut == nil {
+		ctx.setLastError(name, &shownError{code: "not_signed_in", message: "there is no sign out button"})
+		return
+	}
+
+	err = signOut.Click()
+	require.NoError(t, err, "failed to click sign out button")
+
+	// Wait for the outcome
+	_, err = page.WaitForSelector(".signed-out, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "sign out timed out")
+
+	errorVisible, _ := page.IsVisible(".error")
+	if errorVisible {
+		errorText, _ := page.TextContent(".error")
+		code, _ := page.GetAttribute(".error", "data-error-code")
+		ctx.setLastError(name, &shownError{code: code, message: errorText})
+		return
+	}
+
+	ctx.setLastError(name, nil)
+}
+
+func personTriesToOpenTheProjectsOf(t *testing.T, ctx *testContext, name string, owner string) {
+	t.Helper()
+
+	// The browser may be signed in to both accounts, so say which one to act as
+	_, err := ctx.page.Goto(ctx.frontendURL + "/account/" + owner + "/projects?as=" + url.QueryEscape(name))
+	require.NoError(t, err, "failed to navigate to projects page")
+
+	// Wait for the projects to load, or for the reason they could not
+	_, err = ctx.page.WaitForSelector(".projects-list, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "projects page timed out")
+
 	errorVisible, _ := ctx.page.IsVisible(".error")
 	if errorVisible {
 		errorText, _ := ctx.page.TextContent(".error")
-		ctx.setLastError(name, fmt.Errorf("%s", errorText))
+		code, _ := ctx.page.GetAttribute(".error", "data-error-code")
+		ctx.setLastError(name, &shownError{code: code, message: errorText})
 		return
 	}
 
@@ -184,16 +507,25 @@ func personTriesToSignIn(t *testing.T, ctx *testContext, name string) {
 
 func personCreatesAProject(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
+	personCreatesAProjectCalled(t, ctx, name, "My project")
+}
+
+func personCreatesAProjectCalled(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
 
 	// Navigate to projects page
 	_, err := ctx.page.Goto(ctx.frontendURL + "/account/" + name + "/projects")
 	require.NoError(t, err, "failed to navigate to projects page")
 
-	// Wait for create project button
-	_, err = ctx.page.WaitForSelector("button.create-project", playwright.PageWaitForSelectorOptions{
+	// Wait for project name input
+	_, err = ctx.page.WaitForSelector("input[name='project-name']", playwright.PageWaitForSelectorOptions{
 		Timeout: playwright.Float(5000),
 	})
-	require.NoError(t, err, "create project button not found")
+	require.NoError(t, err, "project name input not found")
+
+	// Fill in the project name
+	err = ctx.page.Fill("input[name='project-name']", projectName)
+	require.NoError(t, err, "failed to fill project name")
 
 	// Click create project button
 	err = ctx.page.Click("button.create-project")
@@ -206,55 +538,1488 @@ func personCreatesAProject(t *testing.T, ctx *testContext, name string) {
 	require.NoError(t, err, "project creation failed or timed out")
 }
 
-func personActivatesTheirAccount(t *testing.T, ctx *testContext, name string) {
+func personTriesToCreateAProject(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
-	getAccount(t, ctx, name)
 
-	// Navigate to activation page
-	_, err := ctx.page.Goto(ctx.frontendURL + "/activate/" + name)
-	require.NoError(t, err, "failed to navigate to activation page")
+	// Navigate to projects page
+	_, err := ctx.page.Goto(ctx.frontendURL + "/account/" + name + "/projects")
+	require.NoError(t, err, "failed to navigate to projects page")
 
-	// Wait for activation button
-	_, err = ctx.page.WaitForSelector("button.activate", playwright.PageWaitForSelectorOptions{
+	// Wait for project name input
+	_, err = ctx.page.WaitForSelector("input[name='project-name']", playwright.PageWaitForSelectorOptions{
 		Timeout: playwright.Float(5000),
 	})
-	require.NoError(t, err, "activation button not found")
+	require.NoError(t, err, "project name input not found")
 
-	// Click activate button
-	err = ctx.page.Click("button.activate")
-	require.NoError(t, err, "failed to click activate button")
+	err = ctx.page.Fill("input[name='project-name']", "My project")
+	require.NoError(t, err, "failed to fill project name")
+
+	err = ctx.page.Click("button.create-project")
+	require.NoError(t, err, "failed to click create project button")
+
+	// Wait for success or error message
+	_, err = ctx.page.WaitForSelector(".project-created, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "project creation timed out")
+
+	errorVisible, _ := ctx.page.IsVisible(".error")
+	if errorVisible {
+		errorText, _ := ctx.page.TextContent(".error")
+		code, _ := ctx.page.GetAttribute(".error", "data-error-code")
+		ctx.setLastError(name, &shownError{code: code, message: errorText})
+		return
+	}
+
+	ctx.setLastError(name, nil)
+}
+
+func personMovesToThePlan(t *testing.T, ctx *testContext, name string, plan string) {
+	t.Helper()
+	if planOf(t, ctx, name) == plan {
+		return // The account page only offers to move to the other plan
+	}
+
+	// planOf has left the browser on the account page
+	err := ctx.page.Click(fmt.Sprintf("button.change-plan[data-plan=%q]", plan))
+	require.NoError(t, err, "failed to click change plan button")
 
 	// Wait for success message
-	_, err = ctx.page.WaitForSelector(".success", playwright.PageWaitForSelectorOptions{
+	_, err = ctx.page.WaitForSelector(".plan-changed", playwright.PageWaitForSelectorOptions{
 		Timeout: playwright.Float(5000),
 	})
-	require.NoError(t, err, "activation failed or timed out")
+	require.NoError(t, err, "plan change failed or timed out")
 }
 
-func (ctx *testContext) getLastError(name string) error {
-	return ctx.lastErrors[name]
+func personShouldBeOnThePlan(t *testing.T, ctx *testContext, name string, plan string) {
+	t.Helper()
+	assert.Equal(t, plan, planOf(t, ctx, name), "person %s should be on the %s plan", name, plan)
 }
 
-func (ctx *testContext) setLastError(name string, err error) {
-	ctx.lastErrors[name] = err
+func personShouldBeToldToUpgrade(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	var shown *shownError
+	require.ErrorAs(t, lastError, &shown)
+	assert.Equal(t, "quota_exceeded", shown.code, "expected an error telling %s to upgrade", name)
 }
 
-func (ctx *testContext) clearAll() {
-	// Navigate to the clear admin page
-	_, err := ctx.page.Goto(ctx.frontendURL + "/admin/clear")
-	if err != nil {
-		return
+func personDownloadsTheirData(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	openAccountPage(t, ctx, name)
+
+	download, err := ctx.page.ExpectDownload(func() error {
+		return ctx.page.Click("button.export-account")
+	}, playwright.PageExpectDownloadOptions{Timeout: playwright.Float(5000)})
+	require.NoError(t, err, "download failed or timed out")
+	assert.Equal(t, name+"-export.json", download.SuggestedFilename(), "the archive should be downloaded as a file named for the account")
+
+	path, err := download.Path()
+	require.NoError(t, err, "failed to save download")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err, "failed to read download")
+
+	var archive struct {
+		Projects []struct {
+			Name string `json:"name"`
+		} `json:"projects"`
+	}
+	require.NoError(t, json.Unmarshal(content, &archive), "download should be a JSON archive")
+	var names []string
+	for _, project := range archive.Projects {
+		names = append(names, project.Name)
 	}
+	ctx.downloads[name] = names
+}
 
-	// Wait for the clear button to be available
-	_, err = ctx.page.WaitForSelector("button", playwright.PageWaitForSelectorOptions{
+func personsDownloadShouldHoldTheProjects(t *testing.T, ctx *testContext, name string, projectNames ...string) {
+	t.Helper()
+	assert.Equal(t, projectNames, ctx.downloads[name], "person %s's download should hold the projects", name)
+}
+
+func personClosesTheirAccount(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	personTriesToCloseTheirAccount(t, ctx, name)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to close their account", name)
+}
+
+func personTriesToCloseTheirAccount(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	openAccountPage(t, ctx, name)
+
+	// Closing is confirmed before it is done
+	err := ctx.page.Click("button.close-account")
+	require.NoError(t, err, "failed to click close account button")
+	err = ctx.page.Click("button.confirm-close-account")
+	require.NoError(t, err, "failed to confirm closing the account")
+
+	// Wait for success or error message
+	_, err = ctx.page.WaitForSelector(".account-closed, .error", playwright.PageWaitForSelectorOptions{
 		Timeout: playwright.Float(5000),
 	})
-	if err != nil {
-		return
-	}
+	require.NoError(t, err, "closing the account timed out")
 
-	// Click the clear button
+	errorVisible, _ := ctx.page.IsVisible(".error")
+	if errorVisible {
+		errorText, _ := ctx.page.TextContent(".error")
+		code, _ := ctx.page.GetAttribute(".error", "data-error-code")
+		ctx.setLastError(name, &shownError{code: code, message: errorText})
+		return
+	}
+
+	ctx.setLastError(name, nil)
+}
+
+func personsAccountShouldBeClosed(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	openAccountPage(t, ctx, name)
+
+	closed, err := ctx.page.IsVisible(".status-closed")
+	require.NoError(t, err, "failed to check account status")
+	assert.True(t, closed, "person %s's account should be closed", name)
+}
+
+// openAccountPage opens a person's account page, waiting for it to show the account
+func openAccountPage(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+
+	_, err := ctx.page.Goto(ctx.frontendURL + "/account/" + name)
+	require.NoError(t, err, "failed to navigate to account page")
+
+	_, err = ctx.page.WaitForSelector(".account-info", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "account not found: %s", name)
+}
+
+// planOf returns the plan shown on a person's account page, leaving the browser there
+func planOf(t *testing.T, ctx *testContext, name string) string {
+	t.Helper()
+
+	_, err := ctx.page.Goto(ctx.frontendURL + "/account/" + name)
+	require.NoError(t, err, "failed to navigate to account page")
+
+	_, err = ctx.page.WaitForSelector(".account-plan", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "account not found: %s", name)
+
+	plan, err := ctx.page.GetAttribute(".account-plan", "data-plan")
+	require.NoError(t, err, "failed to read plan")
+	return plan
+}
+
+func personRenamesTheProject(t *testing.T, ctx *testContext, name string, projectName string, newName string) {
+	t.Helper()
+	openProjectCalled(t, ctx, name, projectName)
+
+	// Fill in the new name
+	err := ctx.page.Fill(".project-details input[name='project-name']", newName)
+	require.NoError(t, err, "failed to fill project name")
+
+	// Click rename button
+	err = ctx.page.Click("button.rename-project")
+	require.NoError(t, err, "failed to click rename project button")
+
+	// Wait for success message
+	_, err = ctx.page.WaitForSelector(".project-updated", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "project rename failed or timed out")
+}
+
+func personDeletesTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	openProjectCalled(t, ctx, name, projectName)
+
+	// Click delete button
+	err := ctx.page.Click("button.delete-project")
+	require.NoError(t, err, "failed to click delete project button")
+
+	// Wait for success message
+	_, err = ctx.page.WaitForSelector(".project-deleted", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "project deletion failed or timed out")
+}
+
+func personArchivesTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	personTriesToArchiveTheProject(t, ctx, name, projectName)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to archive the project %s", name, projectName)
+}
+
+func personRestoresTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	personTriesToRestoreTheProject(t, ctx, name, projectName)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to restore the project %s", name, projectName)
+}
+
+func personTriesToArchiveTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	openProjectCalled(t, ctx, name, projectName)
+	tryChangingProject(t, ctx, name, "button.archive-project")
+}
+
+func personTriesToRestoreTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	openProjectCalled(t, ctx, name, projectName)
+	tryChangingProject(t, ctx, name, "button.restore-project")
+}
+
+func personTriesToRenameTheProject(t *testing.T, ctx *testContext, name string, projectName string, newName string) {
+	t.Helper()
+	openProjectCalled(t, ctx, name, projectName)
+
+	// Fill in the new name
+	err := ctx.page.Fill(".project-details input[name='project-name']", newName)
+	require.NoError(t, err, "failed to fill project name")
+	tryChangingProject(t, ctx, name, "button.rename-project")
+}
+
+// tryChangingProject clicks a button that changes the project on show, recording any
+// error shown against the person
+func tryChangingProject(t *testing.T, ctx *testContext, name string, button string) {
+	t.Helper()
+
+	err := ctx.page.Click(button)
+	require.NoError(t, err, "failed to click %s", button)
+
+	// Wait for success or error message
+	_, err = ctx.page.WaitForSelector(".project-updated, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "project change timed out")
+
+	errorVisible, _ := ctx.page.IsVisible(".error")
+	if errorVisible {
+		errorText, _ := ctx.page.TextContent(".error")
+		code, _ := ctx.page.GetAttribute(".error", "data-error-code")
+		ctx.setLastError(name, &shownError{code: code, message: errorText})
+		return
+	}
+
+	ctx.setLastError(name, nil)
+}
+
+func personInvitesToTheProjectAs(t *testing.T, ctx *testContext, name string, invitee string, projectName string, role string) {
+	t.Helper()
+	personTriesToInviteToTheProjectAs(t, ctx, name, invitee, projectName, role)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to invite %s to the project %s", name, invitee, projectName)
+}
+
+func personTriesToInviteToTheProjectAs(t *testing.T, ctx *testContext, name string, invitee string, projectName string, role string) {
+	t.Helper()
+	openProjectCalled(t, ctx, name, projectName)
+
+	// Fill in who to invite and the role they are to have
+	err := ctx.page.Fill("input[name='invitee']", invitee)
+	require.NoError(t, err, "failed to fill invitee")
+	_, err = ctx.page.SelectOption("select[name='invite-role']", playwright.SelectOptionValues{Values: playwright.StringSlice(role)})
+	require.NoError(t, err, "failed to choose the role to invite as")
+	tryChangingProject(t, ctx, name, "button.invite-to-project")
+}
+
+func personAcceptsTheInvitationToTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	answerInvitation(t, ctx, name, projectName, "button.accept-invitation")
+}
+
+func personDeclinesTheInvitationToTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	answerInvitation(t, ctx, name, projectName, "button.decline-invitation")
+}
+
+// answerInvitation clicks a button answering a person's invitation to the project with
+// the given name
+func answerInvitation(t *testing.T, ctx *testContext, name string, projectName string, button string) {
+	t.Helper()
+	projectID := invitationsByProjectName(t, ctx, name)[projectName]
+	require.NotEmpty(t, projectID, "person %s should have an invitation to %s", name, projectName)
+
+	err := ctx.page.Click(fmt.Sprintf(".invitation[data-project-id=%q] %s", projectID, button))
+	require.NoError(t, err, "failed to click %s", button)
+
+	// Wait for success message
+	_, err = ctx.page.WaitForSelector(".invitation-answered", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "answering the invitation failed or timed out")
+}
+
+func personShouldSeeAnInvitationToTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	assert.Contains(t, invitationsByProjectName(t, ctx, name), projectName, "person %s should see an invitation to %s", name, projectName)
+}
+
+func personShouldNotSeeAnInvitationToTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	assert.NotContains(t, invitationsByProjectName(t, ctx, name), projectName, "person %s should not see an invitation to %s", name, projectName)
+}
+
+func personShouldSeeAnErrorTellingThemTheyAlreadyShareTheProject(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	var shown *shownError
+	require.ErrorAs(t, lastError, &shown)
+	assert.Equal(t, "already_member", shown.code, "expected an error telling %s they already share the project", name)
+}
+
+// invitationsByProjectName returns the IDs of the projects a person is shown invitations
+// to, keyed by project name
+func invitationsByProjectName(t *testing.T, ctx *testContext, name string) map[string]string {
+	t.Helper()
+
+	_, err := ctx.page.Goto(ctx.frontendURL + "/account/" + name + "/invitations")
+	require.NoError(t, err, "failed to navigate to invitations page")
+
+	_, err = ctx.page.WaitForSelector(".invitations-list", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "invitations list not found")
+
+	invitationElements, err := ctx.page.QuerySelectorAll(".invitation")
+	require.NoError(t, err, "failed to query invitations")
+
+	ids := make(map[string]string, len(invitationElements))
+	for _, element := range invitationElements {
+		id, err := element.GetAttribute("data-project-id")
+		require.NoError(t, err, "failed to read invitation's project ID")
+		nameElement, err := element.QuerySelector(".invitation-project")
+		require.NoError(t, err, "failed to find invitation's project name")
+		require.NotNil(t, nameElement, "invitation's project name not found")
+		text, err := nameElement.TextContent()
+		require.NoError(t, err, "failed to read invitation's project name")
+		ids[text] = id
+	}
+	return ids
+}
+
+func personShouldSeeTheArchivedProjectCalled(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	found, ok := projectsIncludingArchived(t, ctx, name)[projectName]
+	require.True(t, ok, "person %s should see an archived project called %s", name, projectName)
+	assert.Equal(t, "archived", found.state, "person %s's project called %s should be archived", name, projectName)
+}
+
+func personShouldNotSeeTheArchivedProjectCalled(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	found, ok := projectsIncludingArchived(t, ctx, name)[projectName]
+	assert.False(t, ok && found.state == "archived", "person %s should not see an archived project called %s", name, projectName)
+}
+
+func personShouldSeeAnErrorTellingThemTheProjectIsArchived(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	var shown *shownError
+	require.ErrorAs(t, lastError, &shown)
+	assert.Equal(t, "project_archived", shown.code, "expected an error telling %s the project is archived", name)
+}
+
+func personShouldSeeAnErrorTellingThemTheProjectIsNotArchived(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	var shown *shownError
+	require.ErrorAs(t, lastError, &shown)
+	assert.Equal(t, "project_not_archived", shown.code, "expected an error telling %s the project is not archived", name)
+}
+
+func personShouldSeeTheProjectCalled(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	assert.Contains(t, projectIDsByName(t, ctx, name), projectName, "person %s should see a project called %s", name, projectName)
+}
+
+func personShouldNotSeeTheProjectCalled(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	assert.NotContains(t, projectIDsByName(t, ctx, name), projectName, "person %s should not see a project called %s", name, projectName)
+}
+
+// projectIDsByName returns the IDs of the projects shown on a person's projects page, keyed by name
+func projectIDsByName(t *testing.T, ctx *testContext, name string) map[string]string {
+	t.Helper()
+
+	// Navigate to projects page
+	_, err := ctx.page.Goto(ctx.frontendURL + "/account/" + name + "/projects")
+	require.NoError(t, err, "failed to navigate to projects page")
+
+	// Wait for projects list
+	_, err = ctx.page.WaitForSelector(".projects-list", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "projects list not found")
+
+	projectElements, err := ctx.page.QuerySelectorAll(".project-item")
+	require.NoError(t, err, "failed to query project items")
+
+	ids := make(map[string]string, len(projectElements))
+	for _, element := range projectElements {
+		id, err := element.GetAttribute("data-project-id")
+		require.NoError(t, err, "failed to read project ID")
+		nameElement, err := element.QuerySelector(".project-name")
+		require.NoError(t, err, "failed to find project name")
+		require.NotNil(t, nameElement, "project name not found")
+		text, err := nameElement.TextContent()
+		require.NoError(t, err, "failed to read project name")
+		ids[text] = id
+	}
+	return ids
+}
+
+// shownProject is a project as shown in a list of projects
+type shownProject struct {
+	id    string
+	state string
+}
+
+// projectsIncludingArchived returns the projects a person is shown when they choose to
+// include archived ones in their list, keyed by name
+func projectsIncludingArchived(t *testing.T, ctx *testContext, name string) map[string]shownProject {
+	t.Helper()
+	openProjectFilters(t, ctx, name)
+	require.NoError(t, ctx.page.Check("input[name='include-archived']"), "failed to choose to include archived projects")
+	applyProjectFilters(t, ctx)
+
+	projectElements, err := ctx.page.QuerySelectorAll(".project-item")
+	require.NoError(t, err, "failed to query project items")
+
+	projects := make(map[string]shownProject, len(projectElements))
+	for _, element := range projectElements {
+		id, err := element.GetAttribute("data-project-id")
+		require.NoError(t, err, "failed to read project ID")
+		state, err := element.GetAttribute("data-state")
+		require.NoError(t, err, "failed to read project state")
+		nameElement, err := element.QuerySelector(".project-name")
+		require.NoError(t, err, "failed to find project name")
+		require.NotNil(t, nameElement, "project name not found")
+		text, err := nameElement.TextContent()
+		require.NoError(t, err, "failed to read project name")
+		projects[text] = shownProject{id: id, state: state}
+	}
+	return projects
+}
+
+// openProjectCalled opens the details page of the person's project with the given name,
+// looking for it among their archived projects too
+func openProjectCalled(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	projectID := projectsIncludingArchived(t, ctx, name)[projectName].id
+	require.NotEmpty(t, projectID, "person %s should have a project called %s", name, projectName)
+
+	_, err := ctx.page.Goto(ctx.frontendURL + "/account/" + name + "/projects/" + projectID)
+	require.NoError(t, err, "failed to navigate to project page")
+
+	_, err = ctx.page.WaitForSelector(".project-details", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "project details not found")
+}
+
+func personCreatesTheOrganisation(t *testing.T, ctx *testContext, name string, orgName string) {
+	t.Helper()
+	personTriesToCreateTheOrganisation(t, ctx, name, orgName)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to create the organisation %s", name, orgName)
+}
+
+func personTriesToCreateTheOrganisation(t *testing.T, ctx *testContext, name string, orgName string) {
+	t.Helper()
+
+	// Navigate to organisations page
+	_, err := ctx.page.Goto(ctx.frontendURL + "/account/" + name + "/orgs")
+	require.NoError(t, err, "failed to navigate to organisations page")
+
+	// Wait for organisation name input
+	_, err = ctx.page.WaitForSelector("input[name='org-name']", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "organisation name input not found")
+
+	// Fill in the organisation name
+	err = ctx.page.Fill("input[name='org-name']", orgName)
+	require.NoError(t, err, "failed to fill organisation name")
+
+	// Click create organisation button
+	err = ctx.page.Click("button.create-organisation")
+	require.NoError(t, err, "failed to click create organisation button")
+
+	// Wait for success or error message
+	_, err = ctx.page.WaitForSelector(".organisation-created, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "organisation creation timed out")
+
+	errorVisible, _ := ctx.page.IsVisible(".error")
+	if errorVisible {
+		errorText, _ := ctx.page.TextContent(".error")
+		code, _ := ctx.page.GetAttribute(".error", "data-error-code")
+		ctx.setLastError(name, &shownError{code: code, message: errorText})
+		return
+	}
+
+	ctx.setLastError(name, nil)
+}
+
+func personAddsToTheOrganisationAs(t *testing.T, ctx *testContext, name string, member string, orgName string, role string) {
+	t.Helper()
+	personTriesToAddToTheOrganisationAs(t, ctx, name, member, orgName, role)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to add %s to the organisation %s", name, member, orgName)
+}
+
+func personTriesToAddToTheOrganisationAs(t *testing.T, ctx *testContext, name string, member string, orgName string, role string) {
+	t.Helper()
+	openOrganisation(t, ctx, name, orgName)
+
+	// Fill in who to add and the role they are to have
+	err := ctx.page.Fill("input[name='member']", member)
+	require.NoError(t, err, "failed to fill member")
+	_, err = ctx.page.SelectOption("select[name='member-role']", playwright.SelectOptionValues{Values: playwright.StringSlice(role)})
+	require.NoError(t, err, "failed to choose the member's role")
+	tryChangingOrganisation(t, ctx, name, "button.set-member")
+}
+
+func personRemovesFromTheOrganisation(t *testing.T, ctx *testContext, name string, member string, orgName string) {
+	t.Helper()
+	personTriesToRemoveFromTheOrganisation(t, ctx, name, member, orgName)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to remove %s from the organisation %s", name, member, orgName)
+}
+
+func personTriesToRemoveFromTheOrganisation(t *testing.T, ctx *testContext, name string, member string, orgName string) {
+	t.Helper()
+	openOrganisation(t, ctx, name, orgName)
+	tryChangingOrganisation(t, ctx, name, fmt.Sprintf(".member[data-account=%q] button.remove-member", member))
+}
+
+func personTriesToDeleteTheOrganisation(t *testing.T, ctx *testContext, name string, orgName string) {
+	t.Helper()
+	openOrganisation(t, ctx, name, orgName)
+	tryChangingOrganisation(t, ctx, name, "button.delete-organisation")
+}
+
+func personShouldBeOfTheOrganisation(t *testing.T, ctx *testContext, name string, role string, orgName string) {
+	t.Helper()
+	assert.Equal(t, role, rolesByOrganisation(t, ctx, name)[orgName], "person %s should be %s of %s", name, role, orgName)
+}
+
+func personShouldNotBelongToTheOrganisation(t *testing.T, ctx *testContext, name string, orgName string) {
+	t.Helper()
+	assert.NotContains(t, rolesByOrganisation(t, ctx, name), orgName, "person %s should not belong to %s", name, orgName)
+}
+
+func personShouldSeeAnErrorTellingThemTheOrganisationMustKeepAnOwner(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	var shown *shownError
+	require.ErrorAs(t, lastError, &shown)
+	assert.Equal(t, "last_owner", shown.code, "expected an error telling %s the organisation must keep an owner", name)
+}
+
+func personShouldSeeAnErrorTellingThemTheOrganisationAlreadyExists(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	var shown *shownError
+	require.ErrorAs(t, lastError, &shown)
+	assert.Equal(t, "organisation_exists", shown.code, "expected an error telling %s the organisation already exists", name)
+}
+
+func personShouldSeeAnErrorTellingThemThereIsNoSuchOrganisation(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	var shown *shownError
+	require.ErrorAs(t, lastError, &shown)
+	assert.Equal(t, "organisation_not_found", shown.code, "expected an error telling %s there is no such organisation", name)
+}
+
+func personCreatesTheProjectInTheOrganisation(t *testing.T, ctx *testContext, name string, projectName string, orgName string) {
+	t.Helper()
+	openOrganisation(t, ctx, name, orgName)
+
+	// Fill in the project name
+	err := ctx.page.Fill("input[name='org-project-name']", projectName)
+	require.NoError(t, err, "failed to fill project name")
+	tryChangingOrganisation(t, ctx, name, "button.create-org-project")
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to create a project in the organisation %s", name, orgName)
+}
+
+func personDeletesTheProjectInTheOrganisation(t *testing.T, ctx *testContext, name string, projectName string, orgName string) {
+	t.Helper()
+	personTriesToDeleteTheProjectInTheOrganisation(t, ctx, name, projectName, orgName)
+	require.NoError(t, ctx.getLastError(name), "person %s should be able to delete the project %s", name, projectName)
+}
+
+func personTriesToDeleteTheProjectInTheOrganisation(t *testing.T, ctx *testContext, name string, projectName string, orgName string) {
+	t.Helper()
+	projectID := organisationProjectIDsByName(t, ctx, name, orgName)[projectName]
+	require.NotEmpty(t, projectID, "person %s should see a project called %s in %s", name, projectName, orgName)
+	tryChangingOrganisation(t, ctx, name, fmt.Sprintf(".project-item[data-project-id=%q] button.delete-org-project", projectID))
+}
+
+func personTriesToLookAtTheProjectsInTheOrganisation(t *testing.T, ctx *testContext, name string, orgName string) {
+	t.Helper()
+
+	_, err := ctx.page.Goto(ctx.frontendURL + "/account/" + name + "/orgs/" + orgName)
+	require.NoError(t, err, "failed to navigate to organisation page")
+
+	// Wait for the organisation to load, or for the reason it could not
+	_, err = ctx.page.WaitForSelector(".organisation-details, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "organisation page timed out")
+
+	errorVisible, _ := ctx.page.IsVisible(".error")
+	if errorVisible {
+		errorText, _ := ctx.page.TextContent(".error")
+		code, _ := ctx.page.GetAttribute(".error", "data-error-code")
+		ctx.setLastError(name, &shownError{code: code, message: errorText})
+		return
+	}
+
+	ctx.setLastError(name, nil)
+}
+
+func personShouldSeeTheProjectInTheOrganisation(t *testing.T, ctx *testContext, name string, projectName string, orgName string) {
+	t.Helper()
+	assert.Contains(t, organisationProjectIDsByName(t, ctx, name, orgName), projectName, "person %s should see a project called %s in %s", name, projectName, orgName)
+}
+
+func personShouldNotSeeTheProjectInTheOrganisation(t *testing.T, ctx *testContext, name string, projectName string, orgName string) {
+	t.Helper()
+	assert.NotContains(t, organisationProjectIDsByName(t, ctx, name, orgName), projectName, "person %s should not see a project called %s in %s", name, projectName, orgName)
+}
+
+// tryChangingOrganisation clicks a button that changes the organisation on show, recording
+// any error shown against the person
+func tryChangingOrganisation(t *testing.T, ctx *testContext, name string, button string) {
+	t.Helper()
+
+	err := ctx.page.Click(button)
+	require.NoError(t, err, "failed to click %s", button)
+
+	// Wait for success or error message
+	_, err = ctx.page.WaitForSelector(".organisation-updated, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "organisation change timed out")
+
+	errorVisible, _ := ctx.page.IsVisible(".error")
+	if errorVisible {
+		errorText, _ := ctx.page.TextContent(".error")
+		code, _ := ctx.page.GetAttribute(".error", "data-error-code")
+		ctx.setLastError(name, &shownError{code: code, message: errorText})
+		return
+	}
+
+	ctx.setLastError(name, nil)
+}
+
+// openOrganisation opens the page of an organisation the person belongs to
+func openOrganisation(t *testing.T, ctx *testContext, name string, orgName string) {
+	t.Helper()
+
+	_, err := ctx.page.Goto(ctx.frontendURL + "/account/" + name + "/orgs/" + orgName)
+	require.NoError(t, err, "failed to navigate to organisation page")
+
+	_, err = ctx.page.WaitForSelector(".organisation-details", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "organisation details not found")
+}
+
+// rolesByOrganisation returns the roles a person is shown on their organisations page,
+// keyed by organisation name
+func rolesByOrganisation(t *testing.T, ctx *testContext, name string) map[string]string {
+	t.Helper()
+
+	_, err := ctx.page.Goto(ctx.frontendURL + "/account/" + name + "/orgs")
+	require.NoError(t, err, "failed to navigate to organisations page")
+
+	_, err = ctx.page.WaitForSelector(".organisations-list", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "organisations list not found")
+
+	membershipElements, err := ctx.page.QuerySelectorAll(".membership")
+	require.NoError(t, err, "failed to query memberships")
+
+	roles := make(map[string]string, len(membershipElements))
+	for _, element := range membershipElements {
+		orgName, err := element.GetAttribute("data-organisation")
+		require.NoError(t, err, "failed to read membership's organisation")
+		role, err := element.GetAttribute("data-role")
+		require.NoError(t, err, "failed to read membership's role")
+		roles[orgName] = role
+	}
+	return roles
+}
+
+// organisationProjectIDsByName returns the IDs of the projects shown on an organisation's
+// page, keyed by name
+func organisationProjectIDsByName(t *testing.T, ctx *testContext, name string, orgName string) map[string]string {
+	t.Helper()
+	openOrganisation(t, ctx, name, orgName)
+
+	projectElements, err := ctx.page.QuerySelectorAll(".org-projects-list .project-item")
+	require.NoError(t, err, "failed to query project items")
+
+	ids := make(map[string]string, len(projectElements))
+	for _, element := range projectElements {
+		id, err := element.GetAttribute("data-project-id")
+		require.NoError(t, err, "failed to read project ID")
+		nameElement, err := element.QuerySelector(".project-name")
+		require.NoError(t, err, "failed to find project name")
+		require.NotNil(t, nameElement, "project name not found")
+		text, err := nameElement.TextContent()
+		require.NoError(t, err, "failed to read project name")
+		ids[text] = id
+	}
+	return ids
+}
+
+func personPagesThroughTheirProjects(t *testing.T, ctx *testContext, name string, limit int) {
+	t.Helper()
+	openProjectFilters(t, ctx, name)
+	_, err := ctx.page.SelectOption("select[name='limit']", playwright.SelectOptionValues{Values: playwright.StringSlice(strconv.Itoa(limit))})
+	require.NoError(t, err, "failed to choose how many projects a page holds")
+	applyProjectFilters(t, ctx)
+
+	var pages [][]string
+	for {
+		pages = append(pages, projectNamesShown(t, ctx))
+		next, err := ctx.page.QuerySelector(".next-page")
+		require.NoError(t, err, "failed to look for the next page")
+		if next == nil {
+			break
+		}
+		cursor, err := next.GetAttribute("data-cursor")
+		require.NoError(t, err, "failed to read the next page's cursor")
+		require.NoError(t, next.Click(), "failed to go to the next page")
+		_, err = ctx.page.WaitForSelector(fmt.Sprintf(".projects-list[data-cursor=%q]", cursor), playwright.PageWaitForSelectorOptions{
+			Timeout: playwright.Float(5000),
+		})
+		require.NoError(t, err, "next page of projects not shown")
+	}
+	ctx.shownPages[name] = pages
+}
+
+func personListsTheirProjectsByName(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	openProjectFilters(t, ctx, name)
+	_, err := ctx.page.SelectOption("select[name='sort']", playwright.SelectOptionValues{Values: playwright.StringSlice("name")})
+	require.NoError(t, err, "failed to choose to sort by name")
+	applyProjectFilters(t, ctx)
+	ctx.shownPages[name] = [][]string{projectNamesShown(t, ctx)}
+}
+
+func personListsTheirProjectsWhoseNamesStartWith(t *testing.T, ctx *testContext, name string, prefix string) {
+	t.Helper()
+	openProjectFilters(t, ctx, name)
+	require.NoError(t, ctx.page.Fill("input[name='name-prefix']", prefix), "failed to fill in the start of the name")
+	applyProjectFilters(t, ctx)
+	ctx.shownPages[name] = [][]string{projectNamesShown(t, ctx)}
+}
+
+func personListsTheirProjectsCreatedMoreThanMinutesAfter(t *testing.T, ctx *testContext, name string, minutes int, projectName string) {
+	t.Helper()
+	openProjectFilters(t, ctx, name)
+	element, err := ctx.page.QuerySelector(fmt.Sprintf(".project-item:has(.project-name:text-is(%q))", projectName))
+	require.NoError(t, err, "failed to find project %s", projectName)
+	require.NotNil(t, element, "person %s should have a project called %s", name, projectName)
+	createdAt, err := element.GetAttribute("data-created-at")
+	require.NoError(t, err, "failed to read when project %s was created", projectName)
+	created, err := time.Parse(time.RFC3339, createdAt)
+	require.NoError(t, err, "failed to parse when project %s was created", projectName)
+
+	// The browser shows times to the minute in its own time zone, which is the same as ours
+	from := created.Add(time.Duration(minutes) * time.Minute).Local().Format("2006-01-02T15:04")
+	require.NoError(t, ctx.page.Fill("input[name='created-from']", from), "failed to fill in the earliest creation time")
+	applyProjectFilters(t, ctx)
+	ctx.shownPages[name] = [][]string{projectNamesShown(t, ctx)}
+}
+
+func personShouldHaveBeenShownPagesOfProjects(t *testing.T, ctx *testContext, name string, count int) {
+	t.Helper()
+	assert.Len(t, ctx.shownPages[name], count, "person %s should have been shown %d pages of projects", name, count)
+}
+
+func personShouldHaveBeenShownAllProjectsOnceEach(t *testing.T, ctx *testContext, name string, count int) {
+	t.Helper()
+	var shown []string
+	for _, page := range ctx.shownPages[name] {
+		shown = append(shown, page...)
+	}
+	expected := make([]string, count)
+	for i := range expected {
+		expected[i] = fmt.Sprintf("Project %d", i+1)
+	}
+	assert.Equal(t, expected, shown, "person %s should have been shown all %d projects once each, oldest first", name, count)
+}
+
+func personShouldHaveBeenShownTheProjects(t *testing.T, ctx *testContext, name string, projectNames ...string) {
+	t.Helper()
+	var shown []string
+	for _, page := range ctx.shownPages[name] {
+		shown = append(shown, page...)
+	}
+	assert.Equal(t, projectNames, shown, "person %s should have been shown the projects %v", name, projectNames)
+}
+
+// openProjectFilters opens a person's projects page, ready to choose which to list
+func openProjectFilters(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+
+	_, err := ctx.page.Goto(ctx.frontendURL + "/account/" + name + "/projects")
+	require.NoError(t, err, "failed to navigate to projects page")
+
+	_, err = ctx.page.WaitForSelector(".projects-list", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "projects list not found")
+}
+
+// applyProjectFilters lists the projects chosen, waiting for the first page of them
+func applyProjectFilters(t *testing.T, ctx *testContext) {
+	t.Helper()
+
+	// The list is cleared as soon as the filters are applied, so the one waited for is new
+	require.NoError(t, ctx.page.Click(".apply-filters"), "failed to apply the filters")
+	_, err := ctx.page.WaitForSelector(`.projects-list[data-cursor=""]`, playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "projects list not found")
+}
+
+// projectNamesShown returns the names of the projects on the page shown, in order
+func projectNamesShown(t *testing.T, ctx *testContext) []string {
+	t.Helper()
+	nameElements, err := ctx.page.QuerySelectorAll(".projects-list .project-name")
+	require.NoError(t, err, "failed to query project names")
+
+	names := make([]string, 0, len(nameElements))
+	for _, element := range nameElements {
+		text, err := element.TextContent()
+		require.NoError(t, err, "failed to read project name")
+		names = append(names, text)
+	}
+	return names
+}
+
+func personActivatesTheirAccount(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	getAccount(t, ctx, name)
+	err := followLatestActivationLink(t, ctx, name)
+	require.NoError(t, err, "person %s should be able to activate their account", name)
+}
+
+func personFollowsTheirActivationLinkAgain(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	err := followLatestActivationLink(t, ctx, name)
+	ctx.setLastError(name, err)
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func personTriesToActivateTheirAccount(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	err := followLatestActivationLink(t, ctx, name)
+	ctx.setLastError(name, err)
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func daysHavePassed(t *testing.T, ctx *testContext, days int) {
+	t.Helper()
+	advanceClock(t, ctx, time.Duration(days)*24*time.Hour)
+}
+
+func minutesHavePassed(t *testing.T, ctx *testContext, minutes int) {
+	t.Helper()
+	advanceClock(t, ctx, time.Duration(minutes)*time.Minute)
+}
+
+func anEventShouldHaveBeenPublishedFor(t *testing.T, ctx *testContext, kind string, name string) {
+	t.Helper()
+	assert.True(t, hasPublished(t, ctx, kind, name), "expected a %s event to have been published for %s", kind, name)
+}
+
+func noEventShouldHaveBeenPublishedFor(t *testing.T, ctx *testContext, kind string, name string) {
+	t.Helper()
+	assert.False(t, hasPublished(t, ctx, kind, name), "expected no %s event to have been published for %s", kind, name)
+}
+
+// hasPublished reports whether an event of the given kind has been published about a
+// person, as listed on the events admin page
+func hasPublished(t *testing.T, ctx *testContext, kind string, name string) bool {
+	t.Helper()
+
+	// Navigate to the events page
+	_, err := ctx.page.Goto(ctx.frontendURL + "/admin/events/" + name)
+	require.NoError(t, err, "failed to navigate to events page")
+
+	// Wait for events list
+	_, err = ctx.page.WaitForSelector(".events-list", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "events list not found")
+
+	kinds, err := ctx.page.QuerySelectorAll(".event .event-kind")
+	require.NoError(t, err, "failed to query events")
+	for _, element := range kinds {
+		text, err := element.TextContent()
+		require.NoError(t, err, "failed to read event kind")
+		if text == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func personWatchesTheirAccount(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	if _, ok := ctx.watching[name]; ok {
+		return
+	}
+
+	// Watch from a page of its own, so the usual page stays free for other things
+	page, err := ctx.context.NewPage()
+	require.NoError(t, err, "failed to open notifications page")
+	_, err = page.Goto(ctx.frontendURL + "/account/" + name + "/notifications")
+	require.NoError(t, err, "failed to navigate to notifications page")
+
+	// Wait until the page has caught up
+	_, err = page.WaitForSelector(".watching", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "notifications page did not start watching")
+
+	ctx.watching[name] = page
+}
+
+func personStopsWatchingTheirAccount(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	page, ok := ctx.watching[name]
+	require.True(t, ok, "person %s should be watching their account", name)
+
+	ctx.notified[name] = append(ctx.notified[name], notificationKinds(t, page)...)
+	delete(ctx.watching, name)
+	require.NoError(t, page.Close(), "failed to close notifications page")
+}
+
+func personShouldBeNotifiedThatTheirProjectWasCreated(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	shouldBeNotifiedOf(t, ctx, name, "ProjectCreated")
+}
+
+func personShouldBeNotifiedThatTheySignedIn(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	shouldBeNotifiedOf(t, ctx, name, "Authenticated")
+}
+
+func personShouldHaveBeenNotifiedThatProjectsWereCreated(t *testing.T, ctx *testContext, name string, count int) {
+	t.Helper()
+	kinds := ctx.notified[name]
+	if page, ok := ctx.watching[name]; ok {
+		kinds = append(kinds, notificationKinds(t, page)...)
+	}
+	created := 0
+	for _, kind := range kinds {
+		if kind == "ProjectCreated" {
+			created++
+		}
+	}
+	assert.Equal(t, count, created, "person %s should have been notified that %d projects were created", name, count)
+}
+
+// shouldBeNotifiedOf waits for a notification of the given kind to be shown on the
+// person's notifications page
+func shouldBeNotifiedOf(t *testing.T, ctx *testContext, name string, kind string) {
+	t.Helper()
+	page, ok := ctx.watching[name]
+	require.True(t, ok, "person %s should be watching their account", name)
+
+	_, err := page.WaitForSelector(".notifications-list .event[data-kind='"+kind+"']", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	assert.NoError(t, err, "person %s should be notified of %s", name, kind)
+}
+
+// notificationKinds lists the kinds of the notifications shown on a notifications page
+func notificationKinds(t *testing.T, page playwright.Page) []string {
+	t.Helper()
+	elements, err := page.QuerySelectorAll(".notifications-list .event .event-kind")
+	require.NoError(t, err, "failed to query notifications")
+	kinds := make([]string, 0, len(elements))
+	for _, element := range elements {
+		text, err := element.TextContent()
+		require.NoError(t, err, "failed to read notification kind")
+		kinds = append(kinds, text)
+	}
+	return kinds
+}
+
+func personRegistersAWebhook(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	receiver := ctx.receiver(t, name)
+
+	// Navigate to the webhooks page
+	_, err := ctx.page.Goto(ctx.frontendURL + "/account/" + url.PathEscape(name) + "/webhooks")
+	require.NoError(t, err, "failed to navigate to webhooks page")
+
+	// Wait for the form to register a webhook
+	_, err = ctx.page.WaitForSelector("input[name='webhook-url']", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "webhook form not found")
+
+	err = ctx.page.Fill("input[name='webhook-url']", receiver.url())
+	require.NoError(t, err, "failed to fill webhook URL field")
+
+	err = ctx.page.Fill("input[name='webhook-secret']", receiver.secret)
+	require.NoError(t, err, "failed to fill webhook secret field")
+
+	err = ctx.page.Click("button.register-webhook")
+	require.NoError(t, err, "failed to click register webhook button")
+
+	// Wait for success message
+	_, err = ctx.page.WaitForSelector(".webhook-registered", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "webhook registration failed or timed out")
+}
+
+func personHasCreatedAnAccountWithAWebhook(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	receiver := ctx.receiver(t, name)
+
+	// Navigate to the account creation page
+	_, err := ctx.page.Goto(ctx.frontendURL + "/signup")
+	require.NoError(t, err, "failed to navigate to signup page")
+
+	// Wait for page to load
+	_, err = ctx.page.WaitForSelector("input[name='name']", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "signup form not found")
+
+	err = ctx.page.Fill("input[name='name']", name)
+	require.NoError(t, err, "failed to fill name field")
+
+	err = ctx.page.Fill("input[name='password']", defaultPassword)
+	require.NoError(t, err, "failed to fill password field")
+
+	// Fill in the optional webhook to register along with the account
+	err = ctx.page.Fill("input[name='webhook-url']", receiver.url())
+	require.NoError(t, err, "failed to fill webhook URL field")
+
+	err = ctx.page.Fill("input[name='webhook-secret']", receiver.secret)
+	require.NoError(t, err, "failed to fill webhook secret field")
+
+	err = ctx.page.Click("button[type='submit']")
+	require.NoError(t, err, "failed to click create account button")
+
+	// Wait for success message
+	_, err = ctx.page.WaitForSelector(".success", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "account creation failed or timed out")
+}
+
+func personsWebhookGoesDown(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	ctx.receiver(t, name).setDown(true)
+}
+
+func personsWebhookComesBackUp(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	ctx.receiver(t, name).setDown(false)
+}
+
+func personsWebhookShouldBeSentASignedPayload(t *testing.T, ctx *testContext, name string, kind string) {
+	t.Helper()
+	receiver := ctx.receiver(t, name)
+	var accepted receivedPayload
+	require.Eventually(t, func() bool {
+		var ok bool
+		accepted, ok = receiver.accepted(kind)
+		return ok
+	}, 5*time.Second, 20*time.Millisecond, "person %s's webhook should be sent a %s payload", name, kind)
+	assert.True(t, accepted.signed, "the %s payload should be signed with the webhook's secret", kind)
+	assert.Equal(t, name, accepted.payload.Account)
+}
+
+func theDeliveryToPersonsWebhookShouldHaveSucceededAfter(t *testing.T, ctx *testContext, name string, attempts int) {
+	t.Helper()
+	latest := awaitDeliveryToWebhook(t, ctx, name, attempts)
+	assert.Equal(t, "delivered", latest.status)
+	assert.Equal(t, attempts, latest.attempts)
+}
+
+func theDeliveryToPersonsWebhookShouldBeWaitingToBeRetried(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	latest := awaitDeliveryToWebhook(t, ctx, name, 1)
+	assert.Equal(t, "pending", latest.status)
+	assert.Equal(t, http.StatusServiceUnavailable, latest.responseStatus)
+}
+
+// shownDelivery is what the webhook page shows of a delivery to the webhook
+type shownDelivery struct {
+	status         string
+	attempts       int
+	responseStatus int
+}
+
+// awaitDeliveryToWebhook waits for the latest delivery shown on the page of the webhook a
+// person registered to have been attempted the given number of times. The page shows the
+// deliveries as they were when it loaded, so it is loaded afresh each time.
+func awaitDeliveryToWebhook(t *testing.T, ctx *testContext, name string, attempts int) shownDelivery {
+	t.Helper()
+	webhookPage := ctx.frontendURL + "/account/" + url.PathEscape(name) + "/webhooks/" + url.PathEscape(findWebhookAt(t, ctx, name, ctx.receiver(t, name).url()))
+	var latest shownDelivery
+	require.Eventually(t, func() bool {
+		_, err := ctx.page.Goto(webhookPage)
+		require.NoError(t, err, "failed to navigate to webhook page")
+		_, err = ctx.page.WaitForSelector(".deliveries-list", playwright.PageWaitForSelectorOptions{
+			Timeout: playwright.Float(5000),
+		})
+		require.NoError(t, err, "deliveries list not found")
+
+		elements, err := ctx.page.QuerySelectorAll(".delivery")
+		require.NoError(t, err, "failed to query deliveries")
+		if len(elements) == 0 {
+			return false
+		}
+		element := elements[len(elements)-1]
+		latest.status, err = element.GetAttribute("data-status")
+		require.NoError(t, err, "failed to read delivery status")
+		latest.attempts = numberAttribute(t, element, "data-attempts")
+		latest.responseStatus = numberAttribute(t, element, "data-response-status")
+		return latest.attempts >= attempts
+	}, 5*time.Second, 100*time.Millisecond, "the delivery to person %s's webhook should be attempted %d times", name, attempts)
+	return latest
+}
+
+// findWebhookAt finds the ID of the person's webhook with the given URL in their list of webhooks
+func findWebhookAt(t *testing.T, ctx *testContext, name string, webhookURL string) string {
+	t.Helper()
+
+	_, err := ctx.page.Goto(ctx.frontendURL + "/account/" + url.PathEscape(name) + "/webhooks")
+	require.NoError(t, err, "failed to navigate to webhooks page")
+
+	_, err = ctx.page.WaitForSelector(".webhooks-list", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "webhooks list not found")
+
+	elements, err := ctx.page.QuerySelectorAll(".webhook")
+	require.NoError(t, err, "failed to query webhooks")
+	for _, element := range elements {
+		urlElement, err := element.QuerySelector(".webhook-url")
+		require.NoError(t, err, "failed to find webhook URL")
+		text, err := urlElement.TextContent()
+		require.NoError(t, err, "failed to read webhook URL")
+		if text == webhookURL {
+			id, err := element.GetAttribute("data-webhook-id")
+			require.NoError(t, err, "failed to read webhook id")
+			return id
+		}
+	}
+	require.Failf(t, "webhook not found", "person %s should have registered a webhook at %s", name, webhookURL)
+	return ""
+}
+
+// numberAttribute reads a number the front end has tagged an element with, taking a missing one as 0
+func numberAttribute(t *testing.T, element playwright.ElementHandle, attribute string) int {
+	t.Helper()
+	text, err := element.GetAttribute(attribute)
+	require.NoError(t, err, "failed to read %s", attribute)
+	if text == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(text)
+	require.NoError(t, err, "invalid %s", attribute)
+	return n
+}
+
+// receiver returns the service a person registers as their webhook, starting it if need
+// be and stopping it when the test is over
+func (ctx *testContext) receiver(t *testing.T, name string) *webhookReceiver {
+	if r, ok := ctx.receivers[name]; ok {
+		return r
+	}
+	r := &webhookReceiver{secret: "secret-of-" + strings.ToLower(name) + "-for-webhooks"}
+	r.server = httptest.NewServer(http.HandlerFunc(r.receive))
+	t.Cleanup(r.server.Close)
+	ctx.receivers[name] = r
+	return r
+}
+
+// webhookReceiver is a person's own service, which checks that what is posted to it is
+// signed with their secret and can be taken down to refuse it
+type webhookReceiver struct {
+	server *httptest.Server
+	secret string
+
+	mu       sync.Mutex
+	received []receivedPayload
+	down     bool
+}
+
+type receivedPayload struct {
+	payload  webhooks.Payload
+	signed   bool
+	accepted bool
+}
+
+func (r *webhookReceiver) url() string {
+	return r.server.URL + "/webhook"
+}
+
+func (r *webhookReceiver) setDown(down bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.down = down
+}
+
+// accepted returns the first payload about an event of the given kind that the receiver accepted
+func (r *webhookReceiver) accepted(kind string) (receivedPayload, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, received := range r.received {
+		if received.accepted && string(received.payload.Kind) == kind {
+			return received, true
+		}
+	}
+	return receivedPayload{}, false
+}
+
+func (r *webhookReceiver) receive(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, "Failed to read payload", http.StatusBadRequest)
+		return
+	}
+	var payload webhooks.Payload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.received = append(r.received, receivedPayload{
+		payload:  payload,
+		signed:   webhooks.Verify(r.secret, body, req.Header.Get(webhooks.SignatureHeader)),
+		accepted: !r.down,
+	})
+	if r.down {
+		http.Error(w, "Down", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// advanceClock moves the server's clock on through the clock admin page, which only
+// works while the server runs in test mode
+func advanceClock(t *testing.T, ctx *testContext, duration time.Duration) {
+	t.Helper()
+
+	_, err := ctx.page.Goto(ctx.frontendURL + "/admin/clock")
+	require.NoError(t, err, "failed to navigate to clock page")
+
+	_, err = ctx.page.WaitForSelector("input[name='duration']", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "clock form not found")
+
+	err = ctx.page.Fill("input[name='duration']", duration.String())
+	require.NoError(t, err, "failed to fill duration field")
+
+	err = ctx.page.Click("button.advance-clock")
+	require.NoError(t, err, "failed to click advance clock button")
+
+	// Wait for the new time to be shown
+	_, err = ctx.page.WaitForSelector(".success", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "clock was not advanced")
+}
+
+// takeSnapshot takes a snapshot of all data and the browser's sessions from the snapshot
+// page, returning it as the page shows it
+func takeSnapshot(t *testing.T, ctx *testContext) string {
+	t.Helper()
+
+	openSnapshotPage(t, ctx)
+
+	err := ctx.page.Click("button.take-snapshot")
+	require.NoError(t, err, "failed to click take snapshot button")
+	awaitSnapshotResult(t, ctx)
+
+	snapshot, err := ctx.page.InputValue("textarea[name='snapshot']")
+	require.NoError(t, err, "failed to read snapshot")
+	require.NotEmpty(t, snapshot, "no snapshot was shown")
+	return snapshot
+}
+
+// restoreSnapshot puts back all data and the browser's sessions as they were when the
+// snapshot was taken. Other devices and notification pages start afresh, as they do
+// after clearing all data.
+func restoreSnapshot(t *testing.T, ctx *testContext, snapshot string) {
+	t.Helper()
+
+	openSnapshotPage(t, ctx)
+
+	err := ctx.page.Fill("textarea[name='snapshot']", snapshot)
+	require.NoError(t, err, "failed to fill snapshot field")
+
+	err = ctx.page.Click("button.restore-snapshot")
+	require.NoError(t, err, "failed to click restore snapshot button")
+	awaitSnapshotResult(t, ctx)
+
+	ctx.startAfresh()
+}
+
+func openSnapshotPage(t *testing.T, ctx *testContext) {
+	t.Helper()
+
+	_, err := ctx.page.Goto(ctx.frontendURL + "/admin/snapshot")
+	require.NoError(t, err, "failed to navigate to snapshot page")
+
+	_, err = ctx.page.WaitForSelector("textarea[name='snapshot']", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "snapshot form not found")
+}
+
+// awaitSnapshotResult waits for the snapshot page to show whether it succeeded, failing
+// the test with the page's error if it did not
+func awaitSnapshotResult(t *testing.T, ctx *testContext) {
+	t.Helper()
+
+	result, err := ctx.page.WaitForSelector(".success, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "snapshot page showed no result")
+
+	class, err := result.GetAttribute("class")
+	require.NoError(t, err, "failed to read snapshot result")
+	if class != "success" {
+		text, _ := result.TextContent()
+		require.Fail(t, "snapshot failed", text)
+	}
+}
+
+// followLatestActivationLink follows the link in the most recent message sent to a person,
+// as they would from the top of their inbox
+func followLatestActivationLink(t *testing.T, ctx *testContext, name string) error {
+	t.Helper()
+
+	// Navigate to the outbox page
+	_, err := ctx.page.Goto(ctx.frontendURL + "/admin/outbox/" + name)
+	require.NoError(t, err, "failed to navigate to outbox page")
+
+	// Wait for messages list
+	_, err = ctx.page.WaitForSelector(".messages-list", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "messages list not found")
+
+	links, err := ctx.page.QuerySelectorAll(".message a.message-link")
+	require.NoError(t, err, "failed to query message links")
+	require.NotEmpty(t, links, "no messages have been sent to %s", name)
+
+	link, err := links[len(links)-1].GetAttribute("href")
+	require.NoError(t, err, "failed to read message link")
+
+	// Navigate to the page the link points to
+	_, err = ctx.page.Goto(ctx.frontendURL + link)
+	require.NoError(t, err, "failed to navigate to activation page")
+
+	// Wait for activation button
+	_, err = ctx.page.WaitForSelector("button.activate", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "activation button not found")
+
+	// Click activate button
+	err = ctx.page.Click("button.activate")
+	require.NoError(t, err, "failed to click activate button")
+
+	// Wait for the outcome
+	_, err = ctx.page.WaitForSelector(".success, .error", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	require.NoError(t, err, "activation timed out")
+
+	errorVisible, _ := ctx.page.IsVisible(".error")
+	if errorVisible {
+		errorText, _ := ctx.page.TextContent(".error")
+		code, _ := ctx.page.GetAttribute(".error", "data-error-code")
+		return &shownError{code: code, message: errorText}
+	}
+	return nil
+}
+
+// shownError is an error message shown by the front end, along with the API error
+// code that the front end tags it with
+type shownError struct {
+	code    string
+	message string
+}
+
+func (e *shownError) Error() string {
+	return e.message
+}
+
+// usualDevice is the device a person signed up on, which they use unless a test says otherwise
+const usualDevice = ""
+
+// pageOn returns the page for one of a person's devices, opening a browser context for it
+// the first time it is used
+func (ctx *testContext) pageOn(t *testing.T, device string) playwright.Page {
+	t.Helper()
+	if device == usualDevice {
+		return ctx.page
+	}
+	if page, exists := ctx.devices[device]; exists {
+		return page
+	}
+
+	context, err := ctx.browser.NewContext()
+	require.NoError(t, err, "failed to create browser context")
+	page, err := context.NewPage()
+	require.NoError(t, err, "failed to create page")
+
+	ctx.devices[device] = page
+	return page
+}
+
+func (ctx *testContext) getLastError(name string) error {
+	return ctx.lastErrors[name]
+}
+
+func (ctx *testContext) setLastError(name string, err error) {
+	ctx.lastErrors[name] = err
+}
+
+func (ctx *testContext) clearAll() {
+	// Navigate to the clear admin page
+	_, err := ctx.page.Goto(ctx.frontendURL + "/admin/clear")
+	if err != nil {
+		return
+	}
+
+	// Wait for the clear button to be available
+	_, err = ctx.page.WaitForSelector("button", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(5000),
+	})
+	if err != nil {
+		return
+	}
+
+	// Click the clear button
 	err = ctx.page.Click("button")
 	if err != nil {
 		return
@@ -265,5 +2030,20 @@ func (ctx *testContext) clearAll() {
 		Timeout: playwright.Float(5000),
 	})
 
+	ctx.startAfresh()
+}
+
+// startAfresh forgets what people have seen and closes their other devices and
+// notification pages, for when all data has been replaced
+func (ctx *testContext) startAfresh() {
 	ctx.lastErrors = make(map[string]error)
+	for _, page := range ctx.devices {
+		_ = page.Context().Close()
+	}
+	ctx.devices = make(map[string]playwright.Page)
+	for _, page := range ctx.watching {
+		_ = page.Close()
+	}
+	ctx.watching = make(map[string]playwright.Page)
+	ctx.notified = make(map[string][]string)
 }
@@ -0,0 +1,35 @@
+package features_test
+
+// TestStartFromABaseline tests that a test can start from a baseline instead of building it
+func (s *FeatureSuite) TestStartFromABaseline() {
+	s.
+		given().personHasSignedUpWithProjects("Sue", 2).
+		then().personShouldSeeProjects("Sue", 2).
+		and().personShouldSeeTheProjectCalled("Sue", "Project 2")
+}
+
+// TestChangeWhatTheBaselineHolds tests that what a baseline holds can be changed like anything else
+func (s *FeatureSuite) TestChangeWhatTheBaselineHolds() {
+	s.
+		given().personHasSignedUpWithProjects("Sue", 2).
+		when().personCreatesAProjectCalled("Sue", "Extra").
+		then().personShouldSeeProjects("Sue", 3)
+}
+
+// TestLetTimePassAfterStartingFromABaseline tests that sessions in a baseline expire as usual
+func (s *FeatureSuite) TestLetTimePassAfterStartingFromABaseline() {
+	s.
+		given().personHasSignedUpWithProjects("Sue", 2).
+		when().daysHavePassed(2).
+		then().personShouldNotBeAuthenticated("Sue")
+}
+
+// TestStartFromTheBaselineAsItWasTaken tests that changes and time passing in other tests
+// do not carry over to the next test that starts from the same baseline
+func (s *FeatureSuite) TestStartFromTheBaselineAsItWasTaken() {
+	s.
+		given().personHasSignedUpWithProjects("Sue", 2).
+		then().personShouldBeAuthenticated("Sue").
+		and().personShouldSeeProjects("Sue", 2).
+		and().personShouldNotSeeTheProjectCalled("Sue", "Extra")
+}
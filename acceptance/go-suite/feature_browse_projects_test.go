@@ -0,0 +1,46 @@
+package features_test
+
+import "github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+
+// TestSueHas120ProjectsAndPagesThroughThem tests that paging through projects shows
+// every one of them once, in the order they were created
+func (s *FeatureSuite) TestSueHas120ProjectsAndPagesThroughThem() {
+	s.
+		given().personIsOnThePlanWithProjects("Sue", entities.PlanPro, 120).
+		when().personPagesThroughTheirProjects("Sue", 50).
+		then().personShouldHaveBeenShownPagesOfProjects("Sue", 3).
+		and().personShouldHaveBeenShownAllProjectsOnceEach("Sue", 120)
+}
+
+// TestSortProjectsByName tests that projects can be listed alphabetically, ignoring case
+func (s *FeatureSuite) TestSortProjectsByName() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personCreatesAProjectCalled("Sue", "Roadmap").
+		and().personCreatesAProjectCalled("Sue", "budget").
+		and().personCreatesAProjectCalled("Sue", "Hiring").
+		when().personListsTheirProjectsByName("Sue").
+		then().personShouldHaveBeenShownTheProjects("Sue", "budget", "Hiring", "Roadmap")
+}
+
+// TestFindProjectsByTheStartOfTheirName tests that projects can be filtered by a name prefix
+func (s *FeatureSuite) TestFindProjectsByTheStartOfTheirName() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personCreatesAProjectCalled("Sue", "Roadmap").
+		and().personCreatesAProjectCalled("Sue", "Budget").
+		and().personCreatesAProjectCalled("Sue", "Road trip").
+		when().personListsTheirProjectsWhoseNamesStartWith("Sue", "road").
+		then().personShouldHaveBeenShownTheProjects("Sue", "Roadmap", "Road trip")
+}
+
+// TestFindProjectsByWhenTheyWereCreated tests that projects can be filtered by when they were created
+func (s *FeatureSuite) TestFindProjectsByWhenTheyWereCreated() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personCreatesAProjectCalled("Sue", "Old").
+		and().minutesHavePassed(30).
+		and().personCreatesAProjectCalled("Sue", "New").
+		when().personListsTheirProjectsCreatedMoreThanMinutesAfter("Sue", 10, "Old").
+		then().personShouldHaveBeenShownTheProjects("Sue", "New")
+}
@@ -0,0 +1,73 @@
+package features_test
+
+import (
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+)
+
+// TestDownloadMyData tests that account holders can download their own projects
+func (s *FeatureSuite) TestDownloadMyData() {
+	s.
+		given().personHasSignedUpWithProjects("Sue", 2).
+		when().personDownloadsTheirData("Sue").
+		then().personsDownloadShouldHoldTheProjects("Sue", "Project 1", "Project 2")
+}
+
+// TestCloseAnAccount tests that closing an account signs it out on every device
+func (s *FeatureSuite) TestCloseAnAccount() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personSignsInOnTheirDevice("Sue", "phone").
+		when().personClosesTheirAccount("Sue").
+		then().personsAccountShouldBeClosed("Sue").
+		and().personShouldNotBeAuthenticated("Sue").
+		and().personShouldNotBeAuthenticatedOnTheirDevice("Sue", "phone").
+		and().anEventShouldHaveBeenPublishedFor(events.AccountClosed, "Sue")
+}
+
+// TestProjectsGoWhenTheirOwnerClosesTheAccount tests that closing an account deletes
+// its projects, even those shared with others
+func (s *FeatureSuite) TestProjectsGoWhenTheirOwnerClosesTheAccount() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personHasSignedUp("Bob").
+		and().personCreatesAProjectCalled("Sue", "Roadmap").
+		and().personInvitesToTheProjectAs("Sue", "Bob", "Roadmap", entities.RoleViewer).
+		and().personAcceptsTheInvitationToTheProject("Bob", "Roadmap").
+		when().personClosesTheirAccount("Sue").
+		then().personShouldNotSeeTheProjectCalled("Bob", "Roadmap")
+}
+
+// TestAClosedAccountCannotBeSignedInTo tests that signing in to a closed account is refused
+func (s *FeatureSuite) TestAClosedAccountCannotBeSignedInTo() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personClosesTheirAccount("Sue").
+		when().personTriesToSignIn("Sue").
+		then().personShouldSeeAnErrorTellingThemTheNameOrPasswordIsWrong("Sue")
+}
+
+// TestTheNameOfAClosedAccountStaysTaken tests that no one else can sign up as the holder
+// of a closed account
+func (s *FeatureSuite) TestTheNameOfAClosedAccountStaysTaken() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personClosesTheirAccount("Sue").
+		when().personTriesToSignUpAs("Bob", "Sue").
+		then().personShouldSeeAnErrorTellingThemTheNameIsAlreadyTaken("Bob")
+}
+
+// TestTheLastOwnerOfAnOrganisationHandsItOverFirst tests that an account cannot be closed
+// while it is the only owner of an organisation
+func (s *FeatureSuite) TestTheLastOwnerOfAnOrganisationHandsItOverFirst() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personHasSignedUp("Bob").
+		and().personCreatesTheOrganisation("Sue", "Acme").
+		when().personTriesToCloseTheirAccount("Sue").
+		then().personShouldSeeAnErrorTellingThemTheOrganisationMustKeepAnOwner("Sue").
+		and().personShouldBeAuthenticated("Sue").
+		when().personAddsToTheOrganisationAs("Sue", "Bob", "Acme", entities.OrgRoleOwner).
+		and().personClosesTheirAccount("Sue").
+		then().personShouldBeOfTheOrganisation("Bob", entities.OrgRoleOwner, "Acme")
+}
@@ -1,11 +1,25 @@
 package features_test
 
+import (
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+)
+
 // TestCreateOneProject tests creating a single project
 func (s *FeatureSuite) TestCreateOneProject() {
 	s.
 		given().personHasSignedUp("Sue").
 		when().personCreatesAProject("Sue").
-		then().personShouldSeeTheirProject("Sue")
+		then().personShouldSeeTheirProject("Sue").
+		and().anEventShouldHaveBeenPublishedFor(events.ProjectCreated, "Sue")
+}
+
+// TestCreateANamedProject tests that a project can be found by its name
+func (s *FeatureSuite) TestCreateANamedProject() {
+	s.
+		given().personHasSignedUp("Sue").
+		when().personCreatesAProjectCalled("Sue", "Roadmap").
+		then().personShouldSeeTheProjectCalled("Sue", "Roadmap")
 }
 
 // TestTryToSeeSomeoneElsesProject tests that users cannot see other users' projects
@@ -16,3 +30,41 @@ func (s *FeatureSuite) TestTryToSeeSomeoneElsesProject() {
 		when().personCreatesAProject("Sue").
 		then().personShouldNotSeeAnyProjects("Bob")
 }
+
+// TestTryToOpenSomeoneElsesProjects tests that users are refused access to other users' projects
+func (s *FeatureSuite) TestTryToOpenSomeoneElsesProjects() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personHasSignedUp("Bob").
+		and().personCreatesAProject("Sue").
+		when().personTriesToOpenTheProjectsOf("Bob", "Sue").
+		then().personShouldBeRefusedAccess("Bob")
+}
+
+// TestCreateSeveralProjectsAtTheSameTime tests that concurrent project creation loses no projects
+func (s *FeatureSuite) TestCreateSeveralProjectsAtTheSameTime() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personMovesToThePlan("Sue", entities.PlanPro).
+		when().personCreatesProjectsAtTheSameTime("Sue", 10).
+		then().personShouldSeeProjects("Sue", 10)
+}
+
+// TestStillSeeProjectsAfterSigningInAgain tests that projects stay with their owner across sign-ins
+func (s *FeatureSuite) TestStillSeeProjectsAfterSigningInAgain() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personCreatesAProject("Sue").
+		when().personSignsIn("Sue").
+		then().personShouldSeeTheirProject("Sue")
+}
+
+// TestStillSeeProjectsAfterTheServerRestarts tests that projects are kept when the server restarts
+func (s *FeatureSuite) TestStillSeeProjectsAfterTheServerRestarts() {
+	s.skipUnlessServerCanRestart()
+	s.
+		given().personHasSignedUp("Sue").
+		and().personCreatesAProject("Sue").
+		when().theServerRestarts().
+		then().personShouldSeeTheirProject("Sue")
+}
@@ -0,0 +1,41 @@
+package features_test
+
+import (
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+)
+
+// TestActivateAnAccountJustBeforeTheLinkExpires tests that activation links work for a week
+func (s *FeatureSuite) TestActivateAnAccountJustBeforeTheLinkExpires() {
+	s.
+		given().personHasCreatedAnAccount("Tanya").
+		and().daysHavePassed(6).
+		when().personActivatesTheirAccount("Tanya").
+		then().personShouldBeAuthenticated("Tanya")
+}
+
+// TestTryToActivateAnAccountAfterTheLinkHasExpired tests that activation links stop working after a week
+func (s *FeatureSuite) TestTryToActivateAnAccountAfterTheLinkHasExpired() {
+	s.
+		given().personHasCreatedAnAccount("Tanya").
+		and().daysHavePassed(8).
+		when().personTriesToActivateTheirAccount("Tanya").
+		then().personShouldSeeAnErrorTellingThemTheActivationLinkHasExpired("Tanya").
+		and().noEventShouldHaveBeenPublishedFor(events.AccountActivated, "Tanya")
+}
+
+// TestSignUpAgainWithTheNameOfAnAccountThatExpired tests that names of expired accounts are free again
+func (s *FeatureSuite) TestSignUpAgainWithTheNameOfAnAccountThatExpired() {
+	s.
+		given().personHasCreatedAnAccount("Tanya").
+		and().daysHavePassed(8).
+		when().personHasSignedUp("Tanya").
+		then().personShouldBeAuthenticated("Tanya")
+}
+
+// TestSessionTimesOut tests that sessions stop being accepted after a day
+func (s *FeatureSuite) TestSessionTimesOut() {
+	s.
+		given().personHasSignedUp("Sue").
+		when().daysHavePassed(2).
+		then().personShouldNotBeAuthenticated("Sue")
+}
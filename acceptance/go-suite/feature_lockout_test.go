@@ -0,0 +1,44 @@
+package features_test
+
+import (
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+)
+
+// TestAccountIsLockedAfterRepeatedFailedSignIns tests that too many wrong passwords lock the account
+func (s *FeatureSuite) TestAccountIsLockedAfterRepeatedFailedSignIns() {
+	s.
+		given().personHasSignedUp("Bob").
+		when().personTriesToSignInWithThePasswordTimes("Bob", "wrong-password-1", 5).
+		then().personShouldSeeAnErrorTellingThemTheAccountIsLocked("Bob")
+}
+
+// TestTryToSignInWithTheRightPasswordWhileLocked tests that a locked account refuses even the right password
+func (s *FeatureSuite) TestTryToSignInWithTheRightPasswordWhileLocked() {
+	s.
+		given().personHasSignedUp("Bob").
+		and().personTriesToSignInWithThePasswordTimes("Bob", "wrong-password-1", 5).
+		when().personTriesToSignIn("Bob").
+		then().personShouldSeeAnErrorTellingThemTheAccountIsLocked("Bob").
+		and().noEventShouldHaveBeenPublishedFor(events.Authenticated, "Bob")
+}
+
+// TestSignInAgainOnceTheLockoutIsOver tests that accounts are unlocked after the cooldown
+func (s *FeatureSuite) TestSignInAgainOnceTheLockoutIsOver() {
+	s.
+		given().personHasSignedUp("Bob").
+		and().personTriesToSignInWithThePasswordTimes("Bob", "wrong-password-1", 5).
+		and().minutesHavePassed(20).
+		when().personSignsIn("Bob").
+		then().personShouldBeAuthenticated("Bob").
+		and().anEventShouldHaveBeenPublishedFor(events.Authenticated, "Bob")
+}
+
+// TestFailedSignInsAreForgottenAfterAWhile tests that only failures close together lock the account
+func (s *FeatureSuite) TestFailedSignInsAreForgottenAfterAWhile() {
+	s.
+		given().personHasSignedUp("Bob").
+		and().personTriesToSignInWithThePasswordTimes("Bob", "wrong-password-1", 4).
+		and().minutesHavePassed(20).
+		when().personTriesToSignInWithThePassword("Bob", "wrong-password-1").
+		then().personShouldSeeAnErrorTellingThemTheNameOrPasswordIsWrong("Bob")
+}
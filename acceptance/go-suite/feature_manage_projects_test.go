@@ -0,0 +1,22 @@
+package features_test
+
+// TestRenameAProject tests that a project can be renamed
+func (s *FeatureSuite) TestRenameAProject() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personCreatesAProjectCalled("Sue", "Roadmap").
+		when().personRenamesTheProject("Sue", "Roadmap", "Plan").
+		then().personShouldSeeTheProjectCalled("Sue", "Plan").
+		and().personShouldNotSeeTheProjectCalled("Sue", "Roadmap")
+}
+
+// TestDeleteAProject tests that deleting a project leaves the owner's other projects alone
+func (s *FeatureSuite) TestDeleteAProject() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personCreatesAProjectCalled("Sue", "Roadmap").
+		and().personCreatesAProjectCalled("Sue", "Budget").
+		when().personDeletesTheProject("Sue", "Roadmap").
+		then().personShouldNotSeeTheProjectCalled("Sue", "Roadmap").
+		and().personShouldSeeTheProjectCalled("Sue", "Budget")
+}
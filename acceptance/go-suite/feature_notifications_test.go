@@ -0,0 +1,32 @@
+package features_test
+
+// TestBeNotifiedWhenAProjectIsCreated tests that watching an account shows new projects as they are created
+func (s *FeatureSuite) TestBeNotifiedWhenAProjectIsCreated() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personWatchesTheirAccount("Sue").
+		when().personCreatesAProject("Sue").
+		then().personShouldBeNotifiedThatTheirProjectWasCreated("Sue")
+}
+
+// TestBeNotifiedOfSigningInOnAnotherDevice tests that watching an account shows sign-ins from elsewhere
+func (s *FeatureSuite) TestBeNotifiedOfSigningInOnAnotherDevice() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personWatchesTheirAccount("Sue").
+		when().personSignsInOnTheirDevice("Sue", "phone").
+		then().personShouldBeNotifiedThatTheySignedIn("Sue")
+}
+
+// TestCatchUpOnNotificationsMissedWhileNotWatching tests that watching again catches up without repeating anything
+func (s *FeatureSuite) TestCatchUpOnNotificationsMissedWhileNotWatching() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personWatchesTheirAccount("Sue").
+		and().personCreatesAProject("Sue").
+		and().personShouldBeNotifiedThatTheirProjectWasCreated("Sue").
+		and().personStopsWatchingTheirAccount("Sue").
+		when().personCreatesAProject("Sue").
+		and().personWatchesTheirAccount("Sue").
+		then().personShouldHaveBeenNotifiedThatProjectsWereCreated("Sue", 2)
+}
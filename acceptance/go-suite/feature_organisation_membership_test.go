@@ -0,0 +1,63 @@
+package features_test
+
+import "github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+
+// TestCreateAnOrganisation tests that whoever creates an organisation owns it
+func (s *FeatureSuite) TestCreateAnOrganisation() {
+	s.
+		given().personHasSignedUp("Sue").
+		when().personCreatesTheOrganisation("Sue", "Acme").
+		then().personShouldBeOfTheOrganisation("Sue", entities.OrgRoleOwner, "Acme")
+}
+
+// TestAddAMember tests that an owner can add someone to the organisation
+func (s *FeatureSuite) TestAddAMember() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personHasSignedUp("Bob").
+		and().personCreatesTheOrganisation("Sue", "Acme").
+		when().personAddsToTheOrganisationAs("Sue", "Bob", "Acme", entities.OrgRoleMember).
+		then().personShouldBeOfTheOrganisation("Bob", entities.OrgRoleMember, "Acme")
+}
+
+// TestMakeAMemberAnAdmin tests that an owner can change a member's role
+func (s *FeatureSuite) TestMakeAMemberAnAdmin() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personHasSignedUp("Bob").
+		and().personCreatesTheOrganisation("Sue", "Acme").
+		and().personAddsToTheOrganisationAs("Sue", "Bob", "Acme", entities.OrgRoleMember).
+		when().personAddsToTheOrganisationAs("Sue", "Bob", "Acme", entities.OrgRoleAdmin).
+		then().personShouldBeOfTheOrganisation("Bob", entities.OrgRoleAdmin, "Acme")
+}
+
+// TestRemoveAMember tests that a removed member no longer belongs to the organisation
+func (s *FeatureSuite) TestRemoveAMember() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personHasSignedUp("Bob").
+		and().personCreatesTheOrganisation("Sue", "Acme").
+		and().personAddsToTheOrganisationAs("Sue", "Bob", "Acme", entities.OrgRoleMember).
+		when().personRemovesFromTheOrganisation("Sue", "Bob", "Acme").
+		then().personShouldNotBelongToTheOrganisation("Bob", "Acme")
+}
+
+// TestAnOrganisationKeepsAnOwner tests that the last owner cannot leave
+func (s *FeatureSuite) TestAnOrganisationKeepsAnOwner() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personCreatesTheOrganisation("Sue", "Acme").
+		when().personTriesToRemoveFromTheOrganisation("Sue", "Sue", "Acme").
+		then().personShouldSeeAnErrorTellingThemTheOrganisationMustKeepAnOwner("Sue").
+		and().personShouldBeOfTheOrganisation("Sue", entities.OrgRoleOwner, "Acme")
+}
+
+// TestCreateAnOrganisationWhoseNameIsTaken tests that organisation names are unique
+func (s *FeatureSuite) TestCreateAnOrganisationWhoseNameIsTaken() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personHasSignedUp("Bob").
+		and().personCreatesTheOrganisation("Sue", "Acme").
+		when().personTriesToCreateTheOrganisation("Bob", "Acme").
+		then().personShouldSeeAnErrorTellingThemTheOrganisationAlreadyExists("Bob")
+}
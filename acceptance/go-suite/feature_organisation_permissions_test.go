@@ -0,0 +1,75 @@
+package features_test
+
+import "github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+
+// TestMembersCanCreateProjects tests that a member's project belongs to the whole organisation
+func (s *FeatureSuite) TestMembersCanCreateProjects() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personHasSignedUp("Bob").
+		and().personCreatesTheOrganisation("Sue", "Acme").
+		and().personAddsToTheOrganisationAs("Sue", "Bob", "Acme", entities.OrgRoleMember).
+		when().personCreatesTheProjectInTheOrganisation("Bob", "Roadmap", "Acme").
+		then().personShouldSeeTheProjectInTheOrganisation("Sue", "Roadmap", "Acme")
+}
+
+// TestMembersCannotDeleteProjects tests that deleting projects takes an admin
+func (s *FeatureSuite) TestMembersCannotDeleteProjects() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personHasSignedUp("Bob").
+		and().personCreatesTheOrganisation("Sue", "Acme").
+		and().personAddsToTheOrganisationAs("Sue", "Bob", "Acme", entities.OrgRoleMember).
+		and().personCreatesTheProjectInTheOrganisation("Sue", "Roadmap", "Acme").
+		when().personTriesToDeleteTheProjectInTheOrganisation("Bob", "Roadmap", "Acme").
+		then().personShouldBeRefusedAccess("Bob").
+		and().personShouldSeeTheProjectInTheOrganisation("Sue", "Roadmap", "Acme")
+}
+
+// TestAdminsCanDeleteProjects tests that an admin can delete the organisation's projects
+func (s *FeatureSuite) TestAdminsCanDeleteProjects() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personHasSignedUp("Bob").
+		and().personCreatesTheOrganisation("Sue", "Acme").
+		and().personAddsToTheOrganisationAs("Sue", "Bob", "Acme", entities.OrgRoleAdmin).
+		and().personCreatesTheProjectInTheOrganisation("Sue", "Roadmap", "Acme").
+		when().personDeletesTheProjectInTheOrganisation("Bob", "Roadmap", "Acme").
+		then().personShouldNotSeeTheProjectInTheOrganisation("Sue", "Roadmap", "Acme")
+}
+
+// TestAdminsCannotMakeOtherAdmins tests that only owners hand out the admin role
+func (s *FeatureSuite) TestAdminsCannotMakeOtherAdmins() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personHasSignedUp("Bob").
+		and().personHasSignedUp("Tanya").
+		and().personCreatesTheOrganisation("Sue", "Acme").
+		and().personAddsToTheOrganisationAs("Sue", "Bob", "Acme", entities.OrgRoleAdmin).
+		when().personTriesToAddToTheOrganisationAs("Bob", "Tanya", "Acme", entities.OrgRoleAdmin).
+		then().personShouldBeRefusedAccess("Bob").
+		and().personShouldNotBelongToTheOrganisation("Tanya", "Acme")
+}
+
+// TestOnlyOwnersCanDeleteTheOrganisation tests that an admin cannot delete the organisation
+func (s *FeatureSuite) TestOnlyOwnersCanDeleteTheOrganisation() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personHasSignedUp("Bob").
+		and().personCreatesTheOrganisation("Sue", "Acme").
+		and().personAddsToTheOrganisationAs("Sue", "Bob", "Acme", entities.OrgRoleAdmin).
+		when().personTriesToDeleteTheOrganisation("Bob", "Acme").
+		then().personShouldBeRefusedAccess("Bob").
+		and().personShouldBeOfTheOrganisation("Bob", entities.OrgRoleAdmin, "Acme")
+}
+
+// TestOthersCannotSeeAnOrganisationsProjects tests that the organisation is hidden from outsiders
+func (s *FeatureSuite) TestOthersCannotSeeAnOrganisationsProjects() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personHasSignedUp("Tanya").
+		and().personCreatesTheOrganisation("Sue", "Acme").
+		and().personCreatesTheProjectInTheOrganisation("Sue", "Roadmap", "Acme").
+		when().personTriesToLookAtTheProjectsInTheOrganisation("Tanya", "Acme").
+		then().personShouldSeeAnErrorTellingThemThereIsNoSuchOrganisation("Tanya")
+}
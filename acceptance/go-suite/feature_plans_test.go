@@ -0,0 +1,50 @@
+package features_test
+
+import "github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+
+// TestNewAccountsStartOnTheFreePlan tests that accounts are on the free plan until they move
+func (s *FeatureSuite) TestNewAccountsStartOnTheFreePlan() {
+	s.
+		given().personHasSignedUp("Sue").
+		then().personShouldBeOnThePlan("Sue", entities.PlanFree)
+}
+
+// TestFreeAccountsAreToldToUpgradeWhenTheyReachTheirQuota tests that the free plan's
+// quota is enforced, and that nothing is created beyond it
+func (s *FeatureSuite) TestFreeAccountsAreToldToUpgradeWhenTheyReachTheirQuota() {
+	s.
+		given().personIsOnThePlanWithProjects("Sue", entities.PlanFree, 3).
+		when().personTriesToCreateAProject("Sue").
+		then().personShouldBeToldToUpgrade("Sue").
+		and().personShouldSeeProjects("Sue", 3)
+}
+
+// TestUpgradingLiftsTheLimit tests that the pro plan allows more projects than the free one
+func (s *FeatureSuite) TestUpgradingLiftsTheLimit() {
+	s.
+		given().personIsOnThePlanWithProjects("Sue", entities.PlanFree, 3).
+		when().personMovesToThePlan("Sue", entities.PlanPro).
+		and().personCreatesAProject("Sue").
+		then().personShouldBeOnThePlan("Sue", entities.PlanPro).
+		and().personShouldSeeProjects("Sue", 4)
+}
+
+// TestDeletingAProjectMakesRoomForAnother tests that deleted projects no longer count
+func (s *FeatureSuite) TestDeletingAProjectMakesRoomForAnother() {
+	s.
+		given().personIsOnThePlanWithProjects("Sue", entities.PlanFree, 3).
+		when().personDeletesTheProject("Sue", "Project 1").
+		and().personCreatesAProject("Sue").
+		then().personShouldSeeProjects("Sue", 3)
+}
+
+// TestMovingBackToTheFreePlanKeepsTheProjects tests that moving to a smaller plan removes
+// nothing, but allows nothing more until the account is back under its quota
+func (s *FeatureSuite) TestMovingBackToTheFreePlanKeepsTheProjects() {
+	s.
+		given().personIsOnThePlanWithProjects("Sue", entities.PlanPro, 5).
+		when().personMovesToThePlan("Sue", entities.PlanFree).
+		then().personShouldSeeProjects("Sue", 5).
+		when().personTriesToCreateAProject("Sue").
+		then().personShouldBeToldToUpgrade("Sue")
+}
@@ -0,0 +1,65 @@
+package features_test
+
+// TestArchiveAFinishedProject tests that an archived project is kept out of the way but not lost
+func (s *FeatureSuite) TestArchiveAFinishedProject() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personCreatesAProjectCalled("Sue", "Roadmap").
+		and().personCreatesAProjectCalled("Sue", "Budget").
+		when().personArchivesTheProject("Sue", "Roadmap").
+		then().personShouldNotSeeTheProjectCalled("Sue", "Roadmap").
+		and().personShouldSeeTheArchivedProjectCalled("Sue", "Roadmap").
+		and().personShouldSeeTheProjectCalled("Sue", "Budget")
+}
+
+// TestRestoreAnArchivedProject tests that a restored project is active again
+func (s *FeatureSuite) TestRestoreAnArchivedProject() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personCreatesAProjectCalled("Sue", "Roadmap").
+		and().personArchivesTheProject("Sue", "Roadmap").
+		when().personRestoresTheProject("Sue", "Roadmap").
+		then().personShouldSeeTheProjectCalled("Sue", "Roadmap").
+		and().personShouldNotSeeTheArchivedProjectCalled("Sue", "Roadmap")
+}
+
+// TestDeleteAnArchivedProjectForGood tests that a deleted project is gone, archived or not
+func (s *FeatureSuite) TestDeleteAnArchivedProjectForGood() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personCreatesAProjectCalled("Sue", "Roadmap").
+		and().personArchivesTheProject("Sue", "Roadmap").
+		when().personDeletesTheProject("Sue", "Roadmap").
+		then().personShouldNotSeeTheProjectCalled("Sue", "Roadmap").
+		and().personShouldNotSeeTheArchivedProjectCalled("Sue", "Roadmap")
+}
+
+// TestArchiveAProjectThatIsAlreadyArchived tests that a project cannot be archived twice
+func (s *FeatureSuite) TestArchiveAProjectThatIsAlreadyArchived() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personCreatesAProjectCalled("Sue", "Roadmap").
+		and().personArchivesTheProject("Sue", "Roadmap").
+		when().personTriesToArchiveTheProject("Sue", "Roadmap").
+		then().personShouldSeeAnErrorTellingThemTheProjectIsArchived("Sue")
+}
+
+// TestRenameAnArchivedProject tests that an archived project cannot be changed
+func (s *FeatureSuite) TestRenameAnArchivedProject() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personCreatesAProjectCalled("Sue", "Roadmap").
+		and().personArchivesTheProject("Sue", "Roadmap").
+		when().personTriesToRenameTheProject("Sue", "Roadmap", "Plan").
+		then().personShouldSeeAnErrorTellingThemTheProjectIsArchived("Sue").
+		and().personShouldSeeTheArchivedProjectCalled("Sue", "Roadmap")
+}
+
+// TestRestoreAProjectThatIsNotArchived tests that only archived projects can be restored
+func (s *FeatureSuite) TestRestoreAProjectThatIsNotArchived() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personCreatesAProjectCalled("Sue", "Roadmap").
+		when().personTriesToRestoreTheProject("Sue", "Roadmap").
+		then().personShouldSeeAnErrorTellingThemTheProjectIsNotArchived("Sue")
+}
@@ -0,0 +1,88 @@
+package features_test
+
+import "github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+
+// TestShareAProject tests that someone who accepts an invitation to a project sees it
+func (s *FeatureSuite) TestShareAProject() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personHasSignedUp("Bob").
+		and().personCreatesAProjectCalled("Sue", "Roadmap").
+		when().personInvitesToTheProjectAs("Sue", "Bob", "Roadmap", entities.RoleViewer).
+		and().personAcceptsTheInvitationToTheProject("Bob", "Roadmap").
+		then().personShouldSeeTheProjectCalled("Bob", "Roadmap")
+}
+
+// TestSeeASharedProjectOnlyOnceTheInvitationIsAccepted tests that an invitation gives no access by itself
+func (s *FeatureSuite) TestSeeASharedProjectOnlyOnceTheInvitationIsAccepted() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personHasSignedUp("Bob").
+		and().personCreatesAProjectCalled("Sue", "Roadmap").
+		when().personInvitesToTheProjectAs("Sue", "Bob", "Roadmap", entities.RoleEditor).
+		then().personShouldSeeAnInvitationToTheProject("Bob", "Roadmap").
+		and().personShouldNotSeeTheProjectCalled("Bob", "Roadmap")
+}
+
+// TestDeclineAnInvitation tests that a declined invitation is gone and gives no access
+func (s *FeatureSuite) TestDeclineAnInvitation() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personHasSignedUp("Bob").
+		and().personCreatesAProjectCalled("Sue", "Roadmap").
+		and().personInvitesToTheProjectAs("Sue", "Bob", "Roadmap", entities.RoleEditor).
+		when().personDeclinesTheInvitationToTheProject("Bob", "Roadmap").
+		then().personShouldNotSeeAnInvitationToTheProject("Bob", "Roadmap").
+		and().personShouldNotSeeTheProjectCalled("Bob", "Roadmap")
+}
+
+// TestEditorsCanRenameASharedProject tests that an editor's changes are seen by the owner
+func (s *FeatureSuite) TestEditorsCanRenameASharedProject() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personHasSignedUp("Bob").
+		and().personCreatesAProjectCalled("Sue", "Roadmap").
+		and().personInvitesToTheProjectAs("Sue", "Bob", "Roadmap", entities.RoleEditor).
+		and().personAcceptsTheInvitationToTheProject("Bob", "Roadmap").
+		when().personRenamesTheProject("Bob", "Roadmap", "Plan").
+		then().personShouldSeeTheProjectCalled("Sue", "Plan")
+}
+
+// TestViewersCannotRenameASharedProject tests that a viewer can only look
+func (s *FeatureSuite) TestViewersCannotRenameASharedProject() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personHasSignedUp("Bob").
+		and().personCreatesAProjectCalled("Sue", "Roadmap").
+		and().personInvitesToTheProjectAs("Sue", "Bob", "Roadmap", entities.RoleViewer).
+		and().personAcceptsTheInvitationToTheProject("Bob", "Roadmap").
+		when().personTriesToRenameTheProject("Bob", "Roadmap", "Plan").
+		then().personShouldBeRefusedAccess("Bob").
+		and().personShouldSeeTheProjectCalled("Sue", "Roadmap")
+}
+
+// TestOnlyTheOwnerCanShareAProject tests that members cannot pass a project on
+func (s *FeatureSuite) TestOnlyTheOwnerCanShareAProject() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personHasSignedUp("Bob").
+		and().personHasSignedUp("Tanya").
+		and().personCreatesAProjectCalled("Sue", "Roadmap").
+		and().personInvitesToTheProjectAs("Sue", "Bob", "Roadmap", entities.RoleEditor).
+		and().personAcceptsTheInvitationToTheProject("Bob", "Roadmap").
+		when().personTriesToInviteToTheProjectAs("Bob", "Tanya", "Roadmap", entities.RoleViewer).
+		then().personShouldBeRefusedAccess("Bob").
+		and().personShouldNotSeeAnInvitationToTheProject("Tanya", "Roadmap")
+}
+
+// TestInviteSomeoneWhoAlreadySharesTheProject tests that members cannot be invited twice
+func (s *FeatureSuite) TestInviteSomeoneWhoAlreadySharesTheProject() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personHasSignedUp("Bob").
+		and().personCreatesAProjectCalled("Sue", "Roadmap").
+		and().personInvitesToTheProjectAs("Sue", "Bob", "Roadmap", entities.RoleViewer).
+		and().personAcceptsTheInvitationToTheProject("Bob", "Roadmap").
+		when().personTriesToInviteToTheProjectAs("Sue", "Bob", "Roadmap", entities.RoleEditor).
+		then().personShouldSeeAnErrorTellingThemTheyAlreadyShareTheProject("Sue")
+}
@@ -0,0 +1,36 @@
+package features_test
+
+// TestSignOut tests that signing out ends the session
+func (s *FeatureSuite) TestSignOut() {
+	s.
+		given().personHasSignedUp("Sue").
+		when().personSignsOut("Sue").
+		then().personShouldNotBeAuthenticated("Sue")
+}
+
+// TestSignInOnASecondDevice tests that each device gets a session of its own
+func (s *FeatureSuite) TestSignInOnASecondDevice() {
+	s.
+		given().personHasSignedUp("Sue").
+		when().personSignsInOnTheirDevice("Sue", "phone").
+		then().personShouldBeAuthenticatedOnTheirDevice("Sue", "phone").
+		and().personShouldBeAuthenticated("Sue")
+}
+
+// TestSignOutOnOneDeviceButStaySignedInOnAnother tests that signing out only ends the session on that device
+func (s *FeatureSuite) TestSignOutOnOneDeviceButStaySignedInOnAnother() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personSignsInOnTheirDevice("Sue", "phone").
+		when().personSignsOutOnTheirDevice("Sue", "phone").
+		then().personShouldNotBeAuthenticatedOnTheirDevice("Sue", "phone").
+		and().personShouldBeAuthenticated("Sue")
+}
+
+// TestTryToSignOutOnADeviceThatIsNotSignedIn tests that there must be a session to sign out of
+func (s *FeatureSuite) TestTryToSignOutOnADeviceThatIsNotSignedIn() {
+	s.
+		given().personHasSignedUp("Sue").
+		when().personTriesToSignOutOnTheirDevice("Sue", "phone").
+		then().personShouldSeeAnErrorTellingThemToSignIn("Sue")
+}
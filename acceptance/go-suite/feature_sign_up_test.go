@@ -1,11 +1,24 @@
 package features_test
 
+import (
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+)
+
 // TestSuccessfulSignUp tests successful account activation and authentication
 func (s *FeatureSuite) TestSuccessfulSignUp() {
 	s.
 		given().personHasCreatedAnAccount("Tanya").
 		when().personActivatesTheirAccount("Tanya").
-		then().personShouldBeAuthenticated("Tanya")
+		then().personShouldBeAuthenticated("Tanya").
+		and().anEventShouldHaveBeenPublishedFor(events.AccountActivated, "Tanya")
+}
+
+// TestTryToActivateAnAccountWithALinkThatHasAlreadyBeenUsed tests that activation links only work once
+func (s *FeatureSuite) TestTryToActivateAnAccountWithALinkThatHasAlreadyBeenUsed() {
+	s.
+		given().personHasSignedUp("Tanya").
+		when().personFollowsTheirActivationLinkAgain("Tanya").
+		then().personShouldSeeAnErrorTellingThemTheActivationLinkIsNotValid("Tanya")
 }
 
 // TestTryToSignInWithoutActivatingAccount tests sign-in failure without account activation
@@ -16,3 +29,28 @@ func (s *FeatureSuite) TestTryToSignInWithoutActivatingAccount() {
 		then().personShouldNotBeAuthenticated("Bob").
 		and().personShouldSeeAnErrorTellingThemToActivateTheAccount("Bob")
 }
+
+// TestTryToSignUpWithANameThatIsAlreadyTaken tests that an existing account cannot be signed up for again
+func (s *FeatureSuite) TestTryToSignUpWithANameThatIsAlreadyTaken() {
+	s.
+		given().personHasSignedUp("Sue").
+		when().personTriesToSignUpAs("Bob", "Sue").
+		then().personShouldSeeAnErrorTellingThemTheNameIsAlreadyTaken("Bob").
+		and().personShouldBeAuthenticated("Sue")
+}
+
+// TestTryToSignUpWithAWeakPassword tests that passwords that are easy to guess are refused
+func (s *FeatureSuite) TestTryToSignUpWithAWeakPassword() {
+	s.
+		when().personTriesToSignUpWithThePassword("Bob", "secret").
+		then().personShouldSeeAnErrorTellingThemThePasswordIsTooWeak("Bob").
+		and().noEventShouldHaveBeenPublishedFor(events.AccountCreated, "Bob")
+}
+
+// TestTryToSignInWithTheWrongPassword tests that signing in checks the password
+func (s *FeatureSuite) TestTryToSignInWithTheWrongPassword() {
+	s.
+		given().personHasSignedUp("Sue").
+		when().personTriesToSignInWithThePassword("Sue", "wrong-password-1").
+		then().personShouldSeeAnErrorTellingThemTheNameOrPasswordIsWrong("Sue")
+}
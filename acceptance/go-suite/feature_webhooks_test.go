@@ -0,0 +1,35 @@
+package features_test
+
+import "github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+
+// TestBeToldWhenAProjectIsCreated tests that creating a project posts a signed payload to the account's webhook
+func (s *FeatureSuite) TestBeToldWhenAProjectIsCreated() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personRegistersAWebhook("Sue").
+		when().personCreatesAProject("Sue").
+		then().personsWebhookShouldBeSentASignedPayload("Sue", events.ProjectCreated).
+		and().theDeliveryToPersonsWebhookShouldHaveSucceededAfter("Sue", 1)
+}
+
+// TestBeToldWhenAnAccountIsActivated tests that a webhook registered on signing up is told of the activation
+func (s *FeatureSuite) TestBeToldWhenAnAccountIsActivated() {
+	s.
+		given().personHasCreatedAnAccountWithAWebhook("Sue").
+		when().personActivatesTheirAccount("Sue").
+		then().personsWebhookShouldBeSentASignedPayload("Sue", events.AccountActivated)
+}
+
+// TestRetryADeliveryThatFailed tests that a delivery the webhook refused is tried again later
+func (s *FeatureSuite) TestRetryADeliveryThatFailed() {
+	s.
+		given().personHasSignedUp("Sue").
+		and().personRegistersAWebhook("Sue").
+		and().personsWebhookGoesDown("Sue").
+		when().personCreatesAProject("Sue").
+		then().theDeliveryToPersonsWebhookShouldBeWaitingToBeRetried("Sue").
+		when().personsWebhookComesBackUp("Sue").
+		and().minutesHavePassed(2).
+		then().personsWebhookShouldBeSentASignedPayload("Sue", events.ProjectCreated).
+		and().theDeliveryToPersonsWebhookShouldHaveSucceededAfter("Sue", 2)
+}
@@ -3,6 +3,7 @@ package features_test
 import (
 	"testing"
 
+	domaindriver "github.com/sirockin/cucumber-screenplay-go/acceptance/driver/domain"
 	httpdriver "github.com/sirockin/cucumber-screenplay-go/acceptance/driver/http"
 	uidriver "github.com/sirockin/cucumber-screenplay-go/acceptance/driver/ui"
 	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/testhelpers"
@@ -10,16 +11,26 @@ import (
 )
 
 func TestDomain(t *testing.T) {
-	suite.Run(t, NewFeatureSuite(testhelpers.NewDomainTestDriver()))
+	suite.Run(t, NewFeatureSuite(domaindriver.New(), nil))
+}
+
+// TestInProcess tests against an HTTP server running in the test process, so that
+// the race detector also covers the server and domain code
+func TestInProcess(t *testing.T) {
+	serverURL := testhelpers.NewInProcessServer(t)
+
+	httpDriver := httpdriver.New(serverURL)
+
+	suite.Run(t, NewFeatureSuite(httpDriver, nil))
 }
 
 // TestBackEnd tests against the actual running server executable
 func TestBackEnd(t *testing.T) {
-	serverURL := startServerExecutable(t)
+	server := startServerExecutable(t)
 
-	httpDriver := httpdriver.New(serverURL)
+	httpDriver := httpdriver.New(server.url)
 
-	suite.Run(t, NewFeatureSuite(httpDriver))
+	suite.Run(t, NewFeatureSuite(httpDriver, server))
 }
 
 // TestFrontEnd tests against both frontend and API running in containers using UI automation
@@ -28,5 +39,5 @@ func TestFrontEnd(t *testing.T) {
 
 	uiDriver := uidriver.New(t, frontendURL)
 
-	suite.Run(t, NewFeatureSuite(uiDriver))
+	suite.Run(t, NewFeatureSuite(uiDriver, nil))
 }
@@ -1,18 +1,59 @@
 package features_test
 
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/webhooks"
+)
+
+// defaultPassword is used when the test does not care what a password is
+const defaultPassword = "correct-horse-1"
+
 func (s *FeatureSuite) personHasCreatedAnAccount(name string) *FeatureSuite {
-	err := s.driver.CreateAccount(name)
+	err := s.driver.CreateAccount(name, defaultPassword)
 	s.Require().NoError(err)
 	return s
 }
 
 func (s *FeatureSuite) personHasSignedUp(name string) *FeatureSuite {
-	err := s.driver.CreateAccount(name)
+	err := s.driver.CreateAccount(name, defaultPassword)
 	s.Require().NoError(err)
 	_, err = s.driver.GetAccount(name)
 	s.Require().NoError(err)
-	err = s.driver.Activate(name)
+	err = s.followLatestActivationLink(name)
+	s.Require().NoError(err)
+	return s
+}
+
+// personHasSignedUpWithProjects starts the test from a baseline in which the person has
+// signed up and created count projects, which must fit in the free plan. The baseline is
+// built the first time it is needed and restored after that, replacing everything, so it
+// must be the first step.
+func (s *FeatureSuite) personHasSignedUpWithProjects(name string, count int) *FeatureSuite {
+	return s.personIsOnThePlanWithProjects(name, entities.PlanFree, count)
+}
+
+// personIsOnThePlanWithProjects starts the test from a baseline in which the person has
+// signed up, moved to the plan and created count projects, as personHasSignedUpWithProjects does
+func (s *FeatureSuite) personIsOnThePlanWithProjects(name string, plan entities.Plan, count int) *FeatureSuite {
+	key := fmt.Sprintf("%s on the %s plan with %d projects", name, plan, count)
+	if snapshot, ok := s.baselines[key]; ok {
+		s.Require().NoError(s.driver.Restore(snapshot))
+		return s
+	}
+	s.personHasSignedUp(name)
+	s.personMovesToThePlan(name, plan)
+	for i := 0; i < count; i++ {
+		s.personCreatesAProjectCalled(name, fmt.Sprintf("Project %d", i+1))
+	}
+	snapshot, err := s.driver.Snapshot()
 	s.Require().NoError(err)
+	s.baselines[key] = snapshot
 	return s
 }
 
@@ -28,6 +69,18 @@ func (s *FeatureSuite) personShouldNotBeAuthenticated(name string) *FeatureSuite
 	return s
 }
 
+func (s *FeatureSuite) personShouldBeAuthenticatedOnTheirDevice(name string, device string) *FeatureSuite {
+	actual := s.driver.OnDevice(device).IsAuthenticated(name)
+	s.Assert().True(actual, "person %s should be authenticated on their %s", name, device)
+	return s
+}
+
+func (s *FeatureSuite) personShouldNotBeAuthenticatedOnTheirDevice(name string, device string) *FeatureSuite {
+	actual := s.driver.OnDevice(device).IsAuthenticated(name)
+	s.Assert().False(actual, "person %s should not be authenticated on their %s", name, device)
+	return s
+}
+
 func (s *FeatureSuite) personShouldNotSeeAnyProjects(name string) *FeatureSuite {
 	projects, err := s.driver.GetProjects(name)
 	s.Require().NoError(err)
@@ -42,30 +95,781 @@ func (s *FeatureSuite) personShouldSeeTheirProject(name string) *FeatureSuite {
 	return s
 }
 
+func (s *FeatureSuite) personShouldSeeProjects(name string, count int) *FeatureSuite {
+	projects, err := s.driver.GetProjects(name)
+	s.Require().NoError(err)
+	s.Assert().Len(projects, count, "person %s should see %d projects", name, count)
+	return s
+}
+
 func (s *FeatureSuite) personShouldSeeAnErrorTellingThemToActivateTheAccount(name string) *FeatureSuite {
 	lastError := s.getLastError(name)
-	expectedText := "you need to activate your account"
-	s.Require().NotNil(lastError, "expected error containing text '%s' but there is no error", expectedText)
-	s.Assert().Contains(lastError.Error(), expectedText, "expected error text containing '%s'", expectedText)
+	s.Require().Error(lastError, "expected an error but there is no error")
+	s.Assert().ErrorIs(lastError, entities.ErrAccountNotActivated)
+	return s
+}
+
+func (s *FeatureSuite) personShouldSeeAnErrorTellingThemTheActivationLinkIsNotValid(name string) *FeatureSuite {
+	lastError := s.getLastError(name)
+	s.Require().Error(lastError, "expected an error but there is no error")
+	s.Assert().ErrorIs(lastError, entities.ErrInvalidActivation)
+	return s
+}
+
+func (s *FeatureSuite) personShouldSeeAnErrorTellingThemTheActivationLinkHasExpired(name string) *FeatureSuite {
+	lastError := s.getLastError(name)
+	s.Require().Error(lastError, "expected an error but there is no error")
+	s.Assert().ErrorIs(lastError, entities.ErrActivationExpired)
+	return s
+}
+
+func (s *FeatureSuite) personShouldSeeAnErrorTellingThemThePasswordIsTooWeak(name string) *FeatureSuite {
+	lastError := s.getLastError(name)
+	s.Require().Error(lastError, "expected an error but there is no error")
+	s.Assert().ErrorIs(lastError, entities.ErrWeakPassword)
+	return s
+}
+
+func (s *FeatureSuite) personShouldSeeAnErrorTellingThemTheNameOrPasswordIsWrong(name string) *FeatureSuite {
+	lastError := s.getLastError(name)
+	s.Require().Error(lastError, "expected an error but there is no error")
+	s.Assert().ErrorIs(lastError, entities.ErrWrongCredentials)
+	return s
+}
+
+func (s *FeatureSuite) personShouldSeeAnErrorTellingThemTheAccountIsLocked(name string) *FeatureSuite {
+	lastError := s.getLastError(name)
+	s.Require().Error(lastError, "expected an error but there is no error")
+	s.Assert().ErrorIs(lastError, entities.ErrAccountLocked)
+	return s
+}
+
+func (s *FeatureSuite) personShouldSeeAnErrorTellingThemToSignIn(name string) *FeatureSuite {
+	lastError := s.getLastError(name)
+	s.Require().Error(lastError, "expected an error but there is no error")
+	s.Assert().ErrorIs(lastError, entities.ErrNotSignedIn)
+	return s
+}
+
+func (s *FeatureSuite) personShouldBeRefusedAccess(name string) *FeatureSuite {
+	lastError := s.getLastError(name)
+	s.Require().Error(lastError, "expected an error but there is no error")
+	s.Assert().ErrorIs(lastError, entities.ErrAccessDenied)
+	return s
+}
+
+func (s *FeatureSuite) personShouldSeeAnErrorTellingThemTheNameIsAlreadyTaken(name string) *FeatureSuite {
+	lastError := s.getLastError(name)
+	s.Require().Error(lastError, "expected an error but there is no error")
+	s.Assert().ErrorIs(lastError, entities.ErrAccountExists)
 	return s
 }
 
+func (s *FeatureSuite) personTriesToSignUpAs(name string, accountName string) *FeatureSuite {
+	err := s.driver.CreateAccount(accountName, defaultPassword)
+	s.setLastError(name, err)
+	return s // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *FeatureSuite) personTriesToSignUpWithThePassword(name string, password string) *FeatureSuite {
+	err := s.driver.CreateAccount(name, password)
+	s.setLastError(name, err)
+	return s // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *FeatureSuite) personTriesToSignInWithThePassword(name string, password string) *FeatureSuite {
+	err := s.driver.Authenticate(name, password)
+	s.setLastError(name, err)
+	return s // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *FeatureSuite) personTriesToSignInWithThePasswordTimes(name string, password string, times int) *FeatureSuite {
+	for i := 0; i < times; i++ {
+		err := s.driver.Authenticate(name, password)
+		s.setLastError(name, err)
+	}
+	return s // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
 func (s *FeatureSuite) personTriesToSignIn(name string) *FeatureSuite {
-	err := s.driver.Authenticate(name)
+	err := s.driver.Authenticate(name, defaultPassword)
+	s.setLastError(name, err)
+	return s // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *FeatureSuite) personSignsIn(name string) *FeatureSuite {
+	err := s.driver.Authenticate(name, defaultPassword)
+	s.Require().NoError(err)
+	return s
+}
+
+func (s *FeatureSuite) personSignsInOnTheirDevice(name string, device string) *FeatureSuite {
+	err := s.driver.OnDevice(device).Authenticate(name, defaultPassword)
+	s.Require().NoError(err)
+	return s
+}
+
+func (s *FeatureSuite) personSignsOut(name string) *FeatureSuite {
+	err := s.driver.SignOut(name)
+	s.Require().NoError(err)
+	return s
+}
+
+func (s *FeatureSuite) personSignsOutOnTheirDevice(name string, device string) *FeatureSuite {
+	err := s.driver.OnDevice(device).SignOut(name)
+	s.Require().NoError(err)
+	return s
+}
+
+func (s *FeatureSuite) personTriesToSignOutOnTheirDevice(name string, device string) *FeatureSuite {
+	err := s.driver.OnDevice(device).SignOut(name)
+	s.setLastError(name, err)
+	return s // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *FeatureSuite) personTriesToOpenTheProjectsOf(name string, owner string) *FeatureSuite {
+	_, err := s.driver.GetProjectsOf(name, owner)
 	s.setLastError(name, err)
 	return s // The step succeeds even if the result is bad to allow the next step to check the error
 }
 
 func (s *FeatureSuite) personCreatesAProject(name string) *FeatureSuite {
-	err := s.driver.CreateProject(name)
+	return s.personCreatesAProjectCalled(name, defaultProjectName)
+}
+
+func (s *FeatureSuite) personTriesToCreateAProject(name string) *FeatureSuite {
+	_, err := s.driver.CreateProject(name, defaultProjectName)
+	s.setLastError(name, err)
+	return s // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *FeatureSuite) personMovesToThePlan(name string, plan entities.Plan) *FeatureSuite {
+	s.Require().NoError(s.driver.ChangePlan(name, plan))
+	return s
+}
+
+func (s *FeatureSuite) personShouldBeOnThePlan(name string, plan entities.Plan) *FeatureSuite {
+	account, err := s.driver.GetAccount(name)
+	s.Require().NoError(err)
+	s.Assert().Equal(plan, account.Plan(), "person %s should be on the %s plan", name, plan)
+	return s
+}
+
+func (s *FeatureSuite) personShouldBeToldToUpgrade(name string) *FeatureSuite {
+	lastError := s.getLastError(name)
+	s.Require().Error(lastError, "expected an error but there is no error")
+	s.Assert().ErrorIs(lastError, entities.ErrQuotaExceeded)
+	return s
+}
+
+func (s *FeatureSuite) personDownloadsTheirData(name string) *FeatureSuite {
+	archive, err := s.driver.ExportAccount(name)
 	s.Require().NoError(err)
+	s.downloads[name] = archive
+	return s
+}
+
+func (s *FeatureSuite) personsDownloadShouldHoldTheProjects(name string, projectNames ...string) *FeatureSuite {
+	var held []string
+	for _, project := range s.downloads[name].Projects {
+		held = append(held, project.Name)
+	}
+	s.Assert().Equal(projectNames, held, "person %s's download should hold the projects", name)
+	return s
+}
+
+func (s *FeatureSuite) personClosesTheirAccount(name string) *FeatureSuite {
+	s.Require().NoError(s.driver.CloseAccount(name))
+	return s
+}
+
+func (s *FeatureSuite) personTriesToCloseTheirAccount(name string) *FeatureSuite {
+	s.setLastError(name, s.driver.CloseAccount(name))
+	return s // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *FeatureSuite) personsAccountShouldBeClosed(name string) *FeatureSuite {
+	account, err := s.driver.GetAccount(name)
+	s.Require().NoError(err)
+	s.Assert().True(account.IsClosed(), "person %s's account should be closed", name)
+	return s
+}
+
+func (s *FeatureSuite) personCreatesAProjectCalled(name string, projectName string) *FeatureSuite {
+	_, err := s.driver.CreateProject(name, projectName)
+	s.Require().NoError(err)
+	return s
+}
+
+func (s *FeatureSuite) personRenamesTheProject(name string, projectName string, newName string) *FeatureSuite {
+	project := s.findProjectCalled(name, projectName)
+	s.Require().NotNil(project, "person %s should have a project called %s", name, projectName)
+	_, err := s.driver.RenameProject(name, project.ID(), newName)
+	s.Require().NoError(err)
+	return s
+}
+
+func (s *FeatureSuite) personDeletesTheProject(name string, projectName string) *FeatureSuite {
+	project := s.findProjectCalled(name, projectName)
+	s.Require().NotNil(project, "person %s should have a project called %s", name, projectName)
+	err := s.driver.DeleteProject(name, project.ID())
+	s.Require().NoError(err)
+	return s
+}
+
+func (s *FeatureSuite) personArchivesTheProject(name string, projectName string) *FeatureSuite {
+	s.Require().NoError(s.archiveProject(name, projectName))
+	return s
+}
+
+func (s *FeatureSuite) personRestoresTheProject(name string, projectName string) *FeatureSuite {
+	s.Require().NoError(s.restoreProject(name, projectName))
+	return s
+}
+
+func (s *FeatureSuite) personTriesToArchiveTheProject(name string, projectName string) *FeatureSuite {
+	s.setLastError(name, s.archiveProject(name, projectName))
+	return s // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *FeatureSuite) personTriesToRestoreTheProject(name string, projectName string) *FeatureSuite {
+	s.setLastError(name, s.restoreProject(name, projectName))
+	return s // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *FeatureSuite) personTriesToRenameTheProject(name string, projectName string, newName string) *FeatureSuite {
+	project := s.findProjectCalled(name, projectName)
+	s.Require().NotNil(project, "person %s should have a project called %s", name, projectName)
+	_, err := s.driver.RenameProject(name, project.ID(), newName)
+	s.setLastError(name, err)
+	return s // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *FeatureSuite) personShouldSeeTheProjectCalled(name string, projectName string) *FeatureSuite {
+	s.Assert().True(s.seesProjectCalled(name, projectName), "person %s should see a project called %s", name, projectName)
+	return s
+}
+
+func (s *FeatureSuite) personShouldNotSeeTheProjectCalled(name string, projectName string) *FeatureSuite {
+	s.Assert().False(s.seesProjectCalled(name, projectName), "person %s should not see a project called %s", name, projectName)
+	return s
+}
+
+func (s *FeatureSuite) personShouldSeeTheArchivedProjectCalled(name string, projectName string) *FeatureSuite {
+	project := s.findProjectCalled(name, projectName)
+	s.Require().NotNil(project, "person %s should see an archived project called %s", name, projectName)
+	s.Assert().True(project.IsArchived(), "person %s's project called %s should be archived", name, projectName)
+	return s
+}
+
+func (s *FeatureSuite) personShouldNotSeeTheArchivedProjectCalled(name string, projectName string) *FeatureSuite {
+	project := s.findProjectCalled(name, projectName)
+	s.Assert().False(project != nil && project.IsArchived(), "person %s should not see an archived project called %s", name, projectName)
+	return s
+}
+
+func (s *FeatureSuite) personShouldSeeAnErrorTellingThemTheProjectIsArchived(name string) *FeatureSuite {
+	lastError := s.getLastError(name)
+	s.Require().Error(lastError, "expected an error but there is no error")
+	s.Assert().ErrorIs(lastError, entities.ErrProjectArchived)
+	return s
+}
+
+func (s *FeatureSuite) personShouldSeeAnErrorTellingThemTheProjectIsNotArchived(name string) *FeatureSuite {
+	lastError := s.getLastError(name)
+	s.Require().Error(lastError, "expected an error but there is no error")
+	s.Assert().ErrorIs(lastError, entities.ErrProjectNotArchived)
+	return s
+}
+
+func (s *FeatureSuite) personInvitesToTheProjectAs(name string, invitee string, projectName string, role entities.Role) *FeatureSuite {
+	s.Require().NoError(s.inviteToProject(name, invitee, projectName, role))
+	return s
+}
+
+func (s *FeatureSuite) personTriesToInviteToTheProjectAs(name string, invitee string, projectName string, role entities.Role) *FeatureSuite {
+	s.setLastError(name, s.inviteToProject(name, invitee, projectName, role))
+	return s // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *FeatureSuite) personAcceptsTheInvitationToTheProject(name string, projectName string) *FeatureSuite {
+	invitation := s.findInvitationTo(name, projectName)
+	s.Require().NotNil(invitation, "person %s should have an invitation to %s", name, projectName)
+	s.Require().NoError(s.driver.AcceptInvitation(name, invitation.ProjectID))
+	return s
+}
+
+func (s *FeatureSuite) personDeclinesTheInvitationToTheProject(name string, projectName string) *FeatureSuite {
+	invitation := s.findInvitationTo(name, projectName)
+	s.Require().NotNil(invitation, "person %s should have an invitation to %s", name, projectName)
+	s.Require().NoError(s.driver.DeclineInvitation(name, invitation.ProjectID))
+	return s
+}
+
+func (s *FeatureSuite) personShouldSeeAnInvitationToTheProject(name string, projectName string) *FeatureSuite {
+	s.Assert().NotNil(s.findInvitationTo(name, projectName), "person %s should see an invitation to %s", name, projectName)
+	return s
+}
+
+func (s *FeatureSuite) personShouldNotSeeAnInvitationToTheProject(name string, projectName string) *FeatureSuite {
+	s.Assert().Nil(s.findInvitationTo(name, projectName), "person %s should not see an invitation to %s", name, projectName)
+	return s
+}
+
+func (s *FeatureSuite) personShouldSeeAnErrorTellingThemTheyAlreadyShareTheProject(name string) *FeatureSuite {
+	lastError := s.getLastError(name)
+	s.Require().Error(lastError, "expected an error but there is no error")
+	s.Assert().ErrorIs(lastError, entities.ErrAlreadyMember)
+	return s
+}
+
+func (s *FeatureSuite) personCreatesTheOrganisation(name string, orgName string) *FeatureSuite {
+	s.Require().NoError(s.driver.CreateOrganisation(name, orgName))
+	return s
+}
+
+func (s *FeatureSuite) personTriesToCreateTheOrganisation(name string, orgName string) *FeatureSuite {
+	s.setLastError(name, s.driver.CreateOrganisation(name, orgName))
+	return s // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *FeatureSuite) personAddsToTheOrganisationAs(name string, member string, orgName string, role entities.OrgRole) *FeatureSuite {
+	s.Require().NoError(s.driver.SetOrganisationMember(name, orgName, member, role))
+	return s
+}
+
+func (s *FeatureSuite) personTriesToAddToTheOrganisationAs(name string, member string, orgName string, role entities.OrgRole) *FeatureSuite {
+	s.setLastError(name, s.driver.SetOrganisationMember(name, orgName, member, role))
+	return s // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *FeatureSuite) personRemovesFromTheOrganisation(name string, member string, orgName string) *FeatureSuite {
+	s.Require().NoError(s.driver.RemoveOrganisationMember(name, orgName, member))
+	return s
+}
+
+func (s *FeatureSuite) personTriesToRemoveFromTheOrganisation(name string, member string, orgName string) *FeatureSuite {
+	s.setLastError(name, s.driver.RemoveOrganisationMember(name, orgName, member))
+	return s // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *FeatureSuite) personTriesToDeleteTheOrganisation(name string, orgName string) *FeatureSuite {
+	s.setLastError(name, s.driver.DeleteOrganisation(name, orgName))
+	return s // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *FeatureSuite) personShouldBeOfTheOrganisation(name string, role entities.OrgRole, orgName string) *FeatureSuite {
+	s.Assert().Equal(role, s.roleIn(name, orgName), "person %s should be %s of %s", name, role, orgName)
+	return s
+}
+
+func (s *FeatureSuite) personShouldNotBelongToTheOrganisation(name string, orgName string) *FeatureSuite {
+	s.Assert().Empty(s.roleIn(name, orgName), "person %s should not belong to %s", name, orgName)
+	return s
+}
+
+func (s *FeatureSuite) personShouldSeeAnErrorTellingThemTheOrganisationMustKeepAnOwner(name string) *FeatureSuite {
+	lastError := s.getLastError(name)
+	s.Require().Error(lastError, "expected an error but there is no error")
+	s.Assert().ErrorIs(lastError, entities.ErrLastOwner)
+	return s
+}
+
+func (s *FeatureSuite) personShouldSeeAnErrorTellingThemTheOrganisationAlreadyExists(name string) *FeatureSuite {
+	lastError := s.getLastError(name)
+	s.Require().Error(lastError, "expected an error but there is no error")
+	s.Assert().ErrorIs(lastError, entities.ErrOrganisationExists)
+	return s
+}
+
+func (s *FeatureSuite) personShouldSeeAnErrorTellingThemThereIsNoSuchOrganisation(name string) *FeatureSuite {
+	lastError := s.getLastError(name)
+	s.Require().Error(lastError, "expected an error but there is no error")
+	s.Assert().ErrorIs(lastError, entities.ErrOrganisationNotFound)
+	return s
+}
+
+func (s *FeatureSuite) personCreatesTheProjectInTheOrganisation(name string, projectName string, orgName string) *FeatureSuite {
+	_, err := s.driver.CreateOrganisationProject(name, orgName, projectName)
+	s.Require().NoError(err)
+	return s
+}
+
+func (s *FeatureSuite) personDeletesTheProjectInTheOrganisation(name string, projectName string, orgName string) *FeatureSuite {
+	s.Require().NoError(s.deleteOrganisationProject(name, projectName, orgName))
+	return s
+}
+
+func (s *FeatureSuite) personTriesToDeleteTheProjectInTheOrganisation(name string, projectName string, orgName string) *FeatureSuite {
+	s.setLastError(name, s.deleteOrganisationProject(name, projectName, orgName))
+	return s // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *FeatureSuite) personTriesToLookAtTheProjectsInTheOrganisation(name string, orgName string) *FeatureSuite {
+	_, err := s.driver.GetOrganisationProjects(name, orgName)
+	s.setLastError(name, err)
+	return s // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *FeatureSuite) personShouldSeeTheProjectInTheOrganisation(name string, projectName string, orgName string) *FeatureSuite {
+	s.Assert().NotNil(s.findOrganisationProjectCalled(name, orgName, projectName), "person %s should see a project called %s in %s", name, projectName, orgName)
+	return s
+}
+
+func (s *FeatureSuite) personShouldNotSeeTheProjectInTheOrganisation(name string, projectName string, orgName string) *FeatureSuite {
+	s.Assert().Nil(s.findOrganisationProjectCalled(name, orgName, projectName), "person %s should not see a project called %s in %s", name, projectName, orgName)
+	return s
+}
+
+func (s *FeatureSuite) personPagesThroughTheirProjects(name string, limit int) *FeatureSuite {
+	var pages []entities.ProjectPage
+	query := entities.ProjectQuery{Limit: limit}
+	for {
+		page, err := s.driver.ListProjects(name, query)
+		s.Require().NoError(err)
+		pages = append(pages, page)
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	s.shownPages[name] = pages
+	return s
+}
+
+func (s *FeatureSuite) personListsTheirProjectsByName(name string) *FeatureSuite {
+	return s.personListsTheirProjects(name, entities.ProjectQuery{Sort: entities.SortByName})
+}
+
+func (s *FeatureSuite) personListsTheirProjectsWhoseNamesStartWith(name string, prefix string) *FeatureSuite {
+	return s.personListsTheirProjects(name, entities.ProjectQuery{NamePrefix: prefix})
+}
+
+func (s *FeatureSuite) personListsTheirProjectsCreatedMoreThanMinutesAfter(name string, minutes int, projectName string) *FeatureSuite {
+	project := s.findProjectCalled(name, projectName)
+	s.Require().NotNil(project, "person %s should have a project called %s", name, projectName)
+	return s.personListsTheirProjects(name, entities.ProjectQuery{CreatedFrom: project.CreatedAt().Add(time.Duration(minutes) * time.Minute)})
+}
+
+// personListsTheirProjects lists the first page of a person's projects that query chooses
+func (s *FeatureSuite) personListsTheirProjects(name string, query entities.ProjectQuery) *FeatureSuite {
+	page, err := s.driver.ListProjects(name, query)
+	s.Require().NoError(err)
+	s.shownPages[name] = []entities.ProjectPage{page}
+	return s
+}
+
+func (s *FeatureSuite) personShouldHaveBeenShownPagesOfProjects(name string, count int) *FeatureSuite {
+	s.Assert().Len(s.shownPages[name], count, "person %s should have been shown %d pages of projects", name, count)
+	return s
+}
+
+// personShouldHaveBeenShownAllProjectsOnceEach expects the projects made by signing up
+// with count projects, in the order they were created
+func (s *FeatureSuite) personShouldHaveBeenShownAllProjectsOnceEach(name string, count int) *FeatureSuite {
+	names := make([]string, count)
+	for i := range names {
+		names[i] = fmt.Sprintf("Project %d", i+1)
+	}
+	return s.personShouldHaveBeenShownTheProjects(name, names...)
+}
+
+func (s *FeatureSuite) personShouldHaveBeenShownTheProjects(name string, projectNames ...string) *FeatureSuite {
+	var shown []string
+	for _, page := range s.shownPages[name] {
+		for _, project := range page.Projects {
+			shown = append(shown, project.Name())
+		}
+	}
+	s.Assert().Equal(projectNames, shown, "person %s should have been shown the projects in order", name)
+	return s
+}
+
+func (s *FeatureSuite) personCreatesProjectsAtTheSameTime(name string, count int) *FeatureSuite {
+	var wg sync.WaitGroup
+	errs := make([]error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = s.driver.CreateProject(name, fmt.Sprintf("Project %d", i+1))
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		s.Require().NoError(err)
+	}
 	return s
 }
 
 func (s *FeatureSuite) personActivatesTheirAccount(name string) *FeatureSuite {
 	_, err := s.driver.GetAccount(name)
 	s.Require().NoError(err)
-	err = s.driver.Activate(name)
+	err = s.followLatestActivationLink(name)
+	s.Require().NoError(err)
+	return s
+}
+
+func (s *FeatureSuite) personFollowsTheirActivationLinkAgain(name string) *FeatureSuite {
+	err := s.followLatestActivationLink(name)
+	s.setLastError(name, err)
+	return s // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *FeatureSuite) personTriesToActivateTheirAccount(name string) *FeatureSuite {
+	err := s.followLatestActivationLink(name)
+	s.setLastError(name, err)
+	return s // The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func (s *FeatureSuite) daysHavePassed(days int) *FeatureSuite {
+	err := s.driver.AdvanceClock(time.Duration(days) * 24 * time.Hour)
+	s.Require().NoError(err)
+	return s
+}
+
+func (s *FeatureSuite) minutesHavePassed(minutes int) *FeatureSuite {
+	err := s.driver.AdvanceClock(time.Duration(minutes) * time.Minute)
+	s.Require().NoError(err)
+	return s
+}
+
+func (s *FeatureSuite) anEventShouldHaveBeenPublishedFor(kind events.Kind, name string) *FeatureSuite {
+	s.Assert().True(s.hasPublished(kind, name), "expected a %s event to have been published for %s", kind, name)
+	return s
+}
+
+func (s *FeatureSuite) noEventShouldHaveBeenPublishedFor(kind events.Kind, name string) *FeatureSuite {
+	s.Assert().False(s.hasPublished(kind, name), "expected no %s event to have been published for %s", kind, name)
+	return s
+}
+
+// hasPublished reports whether an event of the given kind has been published about a person
+func (s *FeatureSuite) hasPublished(kind events.Kind, name string) bool {
+	published, err := s.driver.GetEvents(name)
+	s.Require().NoError(err)
+	for _, event := range published {
+		if event.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *FeatureSuite) personWatchesTheirAccount(name string) *FeatureSuite {
+	err := s.driver.WatchAccount(name)
 	s.Require().NoError(err)
 	return s
 }
+
+func (s *FeatureSuite) personStopsWatchingTheirAccount(name string) *FeatureSuite {
+	err := s.driver.StopWatchingAccount(name)
+	s.Require().NoError(err)
+	return s
+}
+
+func (s *FeatureSuite) personShouldBeNotifiedThatTheirProjectWasCreated(name string) *FeatureSuite {
+	err := s.driver.AwaitNotification(name, events.ProjectCreated)
+	s.Require().NoError(err, "person %s should be notified that their project was created", name)
+	return s
+}
+
+func (s *FeatureSuite) personShouldBeNotifiedThatTheySignedIn(name string) *FeatureSuite {
+	err := s.driver.AwaitNotification(name, events.Authenticated)
+	s.Require().NoError(err, "person %s should be notified that they signed in", name)
+	return s
+}
+
+func (s *FeatureSuite) personShouldHaveBeenNotifiedThatProjectsWereCreated(name string, count int) *FeatureSuite {
+	notified, err := s.driver.GetNotifications(name)
+	s.Require().NoError(err)
+	created := 0
+	for _, event := range notified {
+		if event.Kind == events.ProjectCreated {
+			created++
+		}
+	}
+	s.Assert().Equal(count, created, "person %s should have been notified that %d projects were created", name, count)
+	return s
+}
+
+func (s *FeatureSuite) personRegistersAWebhook(name string) *FeatureSuite {
+	receiver := s.receiver(name)
+	_, err := s.driver.RegisterWebhook(name, receiver.URL(), receiver.Secret())
+	s.Require().NoError(err)
+	return s
+}
+
+func (s *FeatureSuite) personHasCreatedAnAccountWithAWebhook(name string) *FeatureSuite {
+	receiver := s.receiver(name)
+	err := s.driver.CreateAccountWithWebhook(name, defaultPassword, receiver.URL(), receiver.Secret())
+	s.Require().NoError(err)
+	return s
+}
+
+func (s *FeatureSuite) personsWebhookGoesDown(name string) *FeatureSuite {
+	s.receiver(name).SetDown(true)
+	return s
+}
+
+func (s *FeatureSuite) personsWebhookComesBackUp(name string) *FeatureSuite {
+	s.receiver(name).SetDown(false)
+	return s
+}
+
+func (s *FeatureSuite) personsWebhookShouldBeSentASignedPayload(name string, kind events.Kind) *FeatureSuite {
+	received, err := s.receiver(name).Await(kind)
+	s.Require().NoError(err, "person %s's webhook should be sent a %s payload", name, kind)
+	s.Assert().True(received.Signed, "the %s payload should be signed with the webhook's secret", kind)
+	s.Assert().Equal(name, received.Payload.Account)
+	return s
+}
+
+func (s *FeatureSuite) theDeliveryToPersonsWebhookShouldHaveSucceededAfter(name string, attempts int) *FeatureSuite {
+	delivery := s.awaitDeliveryToWebhook(name, attempts)
+	s.Assert().Equal(webhooks.StatusDelivered, delivery.Status)
+	s.Assert().Equal(attempts, delivery.Attempts)
+	return s
+}
+
+func (s *FeatureSuite) theDeliveryToPersonsWebhookShouldBeWaitingToBeRetried(name string) *FeatureSuite {
+	delivery := s.awaitDeliveryToWebhook(name, 1)
+	s.Assert().Equal(webhooks.StatusPending, delivery.Status)
+	s.Assert().Equal(http.StatusServiceUnavailable, delivery.ResponseStatus)
+	return s
+}
+
+func (s *FeatureSuite) theServerRestarts() *FeatureSuite {
+	err := s.server.Restart()
+	s.Require().NoError(err)
+	return s
+}
+
+// defaultProjectName is used when the test does not care what a project is called
+const defaultProjectName = "My project"
+
+// findProjectCalled finds one of a person's projects by name, archived or not, as they
+// would in a list of projects that includes archived ones, or returns nil if there is none
+func (s *FeatureSuite) findProjectCalled(name string, projectName string) *entities.Project {
+	query := entities.ProjectQuery{Limit: entities.MaxProjectLimit, IncludeArchived: true}
+	for {
+		page, err := s.driver.ListProjects(name, query)
+		s.Require().NoError(err)
+		for _, project := range page.Projects {
+			if project.Name() == projectName {
+				return &project
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		query.Cursor = page.NextCursor
+	}
+}
+
+// seesProjectCalled reports whether a person's list of projects, which leaves out
+// archived ones, has one with the given name
+func (s *FeatureSuite) seesProjectCalled(name string, projectName string) bool {
+	projects, err := s.driver.GetProjects(name)
+	s.Require().NoError(err)
+	for _, project := range projects {
+		if project.Name() == projectName {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *FeatureSuite) archiveProject(name string, projectName string) error {
+	project := s.findProjectCalled(name, projectName)
+	s.Require().NotNil(project, "person %s should have a project called %s", name, projectName)
+	_, err := s.driver.ArchiveProject(name, project.ID())
+	return err
+}
+
+func (s *FeatureSuite) restoreProject(name string, projectName string) error {
+	project := s.findProjectCalled(name, projectName)
+	s.Require().NotNil(project, "person %s should have a project called %s", name, projectName)
+	_, err := s.driver.RestoreProject(name, project.ID())
+	return err
+}
+
+func (s *FeatureSuite) inviteToProject(name string, invitee string, projectName string, role entities.Role) error {
+	project := s.findProjectCalled(name, projectName)
+	s.Require().NotNil(project, "person %s should have a project called %s", name, projectName)
+	return s.driver.InviteToProject(name, project.ID(), invitee, role)
+}
+
+// findInvitationTo finds a person's unanswered invitation to share the project with the
+// given name, or returns nil if there is none
+func (s *FeatureSuite) findInvitationTo(name string, projectName string) *entities.Invitation {
+	invitations, err := s.driver.GetInvitations(name)
+	s.Require().NoError(err)
+	for _, invitation := range invitations {
+		if invitation.ProjectName == projectName {
+			return &invitation
+		}
+	}
+	return nil
+}
+
+func (s *FeatureSuite) deleteOrganisationProject(name string, projectName string, orgName string) error {
+	project := s.findOrganisationProjectCalled(name, orgName, projectName)
+	s.Require().NotNil(project, "person %s should see a project called %s in %s", name, projectName, orgName)
+	return s.driver.DeleteOrganisationProject(name, orgName, project.ID())
+}
+
+// findOrganisationProjectCalled finds one of an organisation's projects by name, as one
+// of its members would, or returns nil if there is none
+func (s *FeatureSuite) findOrganisationProjectCalled(name string, orgName string, projectName string) *entities.Project {
+	projects, err := s.driver.GetOrganisationProjects(name, orgName)
+	s.Require().NoError(err)
+	for _, project := range projects {
+		if project.Name() == projectName {
+			return &project
+		}
+	}
+	return nil
+}
+
+// roleIn returns the role a person has in the organisation, or "" if they do not belong to it
+func (s *FeatureSuite) roleIn(name string, orgName string) entities.OrgRole {
+	memberships, err := s.driver.GetOrganisations(name)
+	s.Require().NoError(err)
+	for _, membership := range memberships {
+		if membership.Organisation == orgName {
+			return membership.Role
+		}
+	}
+	return ""
+}
+
+// awaitDeliveryToWebhook waits for the latest delivery to the webhook a person registered
+// to have been attempted the given number of times, finding the webhook by its URL as they
+// would in their list of webhooks
+func (s *FeatureSuite) awaitDeliveryToWebhook(name string, attempts int) webhooks.Delivery {
+	registered, err := s.driver.GetWebhooks(name)
+	s.Require().NoError(err)
+	for _, webhook := range registered {
+		if webhook.URL() == s.receiver(name).URL() {
+			delivery, err := s.driver.AwaitWebhookDelivery(name, webhook.ID(), attempts)
+			s.Require().NoError(err)
+			return delivery
+		}
+	}
+	s.Require().Failf("webhook not found", "%s has not registered a webhook", name)
+	return webhooks.Delivery{}
+}
+
+// followLatestActivationLink follows the link in the most recent message sent to a person,
+// as they would from the top of their inbox
+func (s *FeatureSuite) followLatestActivationLink(name string) error {
+	messages, err := s.driver.GetMessages(name)
+	if err != nil {
+		return err
+	}
+	if len(messages) == 0 {
+		return fmt.Errorf("no messages have been sent to %s", name)
+	}
+	return s.driver.FollowActivationLink(messages[len(messages)-1].Link)
+}
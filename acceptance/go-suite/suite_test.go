@@ -2,13 +2,31 @@ package features_test
 
 import (
 	"github.com/sirockin/cucumber-screenplay-go/acceptance/driver"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/export"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/testhelpers"
 	"github.com/stretchr/testify/suite"
 )
 
+// serverRestarter restarts the system under test without losing its data
+type serverRestarter interface {
+	Restart() error
+}
+
 type FeatureSuite struct {
 	suite.Suite
 	driver     driver.TestDriver
+	server     serverRestarter
 	lastErrors map[string]error
+	// receivers are the local endpoints that people have registered as their webhooks
+	receivers map[string]*testhelpers.WebhookReceiver
+	// shownPages holds the pages of projects each person was last shown
+	shownPages map[string][]entities.ProjectPage
+	// downloads holds the archive of their data each person last downloaded
+	downloads map[string]export.Archive
+	// baselines holds the snapshots that tests start from, built the first time each
+	// one is needed and kept for the whole run
+	baselines map[string]testhelpers.Snapshot
 }
 
 func (s *FeatureSuite) getLastError(name string) error {
@@ -25,12 +43,41 @@ func (s *FeatureSuite) setLastError(name string, err error) {
 	s.lastErrors[name] = err
 }
 
+// receiver returns the endpoint to register as a person's webhook, starting it if need be
+func (s *FeatureSuite) receiver(name string) *testhelpers.WebhookReceiver {
+	if s.receivers == nil {
+		s.receivers = make(map[string]*testhelpers.WebhookReceiver)
+	}
+	if s.receivers[name] == nil {
+		s.receivers[name] = testhelpers.NewWebhookReceiver()
+	}
+	return s.receivers[name]
+}
+
 // SetupTest is called before each test method
 func (s *FeatureSuite) SetupTest() {
 	s.lastErrors = make(map[string]error)
+	s.shownPages = make(map[string][]entities.ProjectPage)
+	s.downloads = make(map[string]export.Archive)
 	s.driver.ClearAll()
 }
 
+// TearDownTest is called after each test method
+func (s *FeatureSuite) TearDownTest() {
+	for _, receiver := range s.receivers {
+		receiver.Close()
+	}
+	s.receivers = nil
+}
+
+// skipUnlessServerCanRestart skips tests that need to restart the system under test
+// when running against a layer that cannot be restarted
+func (s *FeatureSuite) skipUnlessServerCanRestart() {
+	if s.server == nil {
+		s.T().Skip("the system under test cannot be restarted")
+	}
+}
+
 // Gherkin keyword methods for chaining
 func (s *FeatureSuite) given() *FeatureSuite {
 	return s
@@ -48,10 +95,13 @@ func (s *FeatureSuite) then() *FeatureSuite {
 	return s
 }
 
-// NewFeatureSuite creates a new test suite instance
-func NewFeatureSuite(driver driver.TestDriver) *FeatureSuite {
+// NewFeatureSuite creates a new test suite instance. Tests that restart the
+// system under test are skipped if server is nil.
+func NewFeatureSuite(driver driver.TestDriver, server serverRestarter) *FeatureSuite {
 	s := &FeatureSuite{
-		driver: driver,
+		driver:    driver,
+		server:    server,
+		baselines: make(map[string]testhelpers.Snapshot),
 	}
 	return s
 }
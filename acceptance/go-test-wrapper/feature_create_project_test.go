@@ -2,6 +2,9 @@ package features_test
 
 import (
 	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
 )
 
 func TestCreateOneProject(t *testing.T) {
@@ -14,6 +17,20 @@ func TestCreateOneProject(t *testing.T) {
 
 		// Then
 		personShouldSeeTheirProject(t, ctx, "Sue")
+		anEventShouldHaveBeenPublishedFor(t, ctx, events.ProjectCreated, "Sue")
+	})
+}
+
+func TestCreateANamedProject(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+
+		// When
+		personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+
+		// Then
+		personShouldSeeTheProjectCalled(t, ctx, "Sue", "Roadmap")
 	})
 }
 
@@ -30,3 +47,62 @@ func TestTryToSeeSomeoneElsesProject(t *testing.T) {
 		personShouldNotSeeAnyProjects(t, ctx, "Bob")
 	})
 }
+
+func TestTryToOpenSomeoneElsesProjects(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personHasSignedUp(t, ctx, "Bob")
+		personCreatesAProject(t, ctx, "Sue")
+
+		// When
+		personTriesToOpenTheProjectsOf(t, ctx, "Bob", "Sue")
+
+		// Then
+		personShouldBeRefusedAccess(t, ctx, "Bob")
+	})
+}
+
+func TestCreateSeveralProjectsAtTheSameTime(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personMovesToThePlan(t, ctx, "Sue", entities.PlanPro)
+
+		// When
+		personCreatesProjectsAtTheSameTime(t, ctx, "Sue", 10)
+
+		// Then
+		personShouldSeeProjects(t, ctx, "Sue", 10)
+	})
+}
+
+func TestStillSeeProjectsAfterSigningInAgain(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personCreatesAProject(t, ctx, "Sue")
+
+		// When
+		personSignsIn(t, ctx, "Sue")
+
+		// Then
+		personShouldSeeTheirProject(t, ctx, "Sue")
+	})
+}
+
+func TestStillSeeProjectsAfterTheServerRestarts(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		skipUnlessServerCanRestart(t, ctx)
+
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personCreatesAProject(t, ctx, "Sue")
+
+		// When
+		theServerRestarts(t, ctx)
+
+		// Then
+		personShouldSeeTheirProject(t, ctx, "Sue")
+	})
+}
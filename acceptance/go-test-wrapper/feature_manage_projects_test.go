@@ -0,0 +1,36 @@
+package features_test
+
+import (
+	"testing"
+)
+
+func TestRenameAProject(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+
+		// When
+		personRenamesTheProject(t, ctx, "Sue", "Roadmap", "Plan")
+
+		// Then
+		personShouldSeeTheProjectCalled(t, ctx, "Sue", "Plan")
+		personShouldNotSeeTheProjectCalled(t, ctx, "Sue", "Roadmap")
+	})
+}
+
+func TestDeleteAProject(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+		personCreatesAProjectCalled(t, ctx, "Sue", "Budget")
+
+		// When
+		personDeletesTheProject(t, ctx, "Sue", "Roadmap")
+
+		// Then
+		personShouldNotSeeTheProjectCalled(t, ctx, "Sue", "Roadmap")
+		personShouldSeeTheProjectCalled(t, ctx, "Sue", "Budget")
+	})
+}
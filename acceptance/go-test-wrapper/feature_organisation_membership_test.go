@@ -0,0 +1,97 @@
+package features_test
+
+import (
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+func TestCreateAnOrganisation(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+
+		// When
+		personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+
+		// Then
+		personShouldBeOfTheOrganisation(t, ctx, "Sue", entities.OrgRoleOwner, "Acme")
+	})
+}
+
+func TestAddAMember(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personHasSignedUp(t, ctx, "Bob")
+		personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+
+		// When
+		personAddsToTheOrganisationAs(t, ctx, "Sue", "Bob", "Acme", entities.OrgRoleMember)
+
+		// Then
+		personShouldBeOfTheOrganisation(t, ctx, "Bob", entities.OrgRoleMember, "Acme")
+	})
+}
+
+func TestMakeAMemberAnAdmin(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personHasSignedUp(t, ctx, "Bob")
+		personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+		personAddsToTheOrganisationAs(t, ctx, "Sue", "Bob", "Acme", entities.OrgRoleMember)
+
+		// When
+		personAddsToTheOrganisationAs(t, ctx, "Sue", "Bob", "Acme", entities.OrgRoleAdmin)
+
+		// Then
+		personShouldBeOfTheOrganisation(t, ctx, "Bob", entities.OrgRoleAdmin, "Acme")
+	})
+}
+
+func TestRemoveAMember(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personHasSignedUp(t, ctx, "Bob")
+		personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+		personAddsToTheOrganisationAs(t, ctx, "Sue", "Bob", "Acme", entities.OrgRoleMember)
+
+		// When
+		personRemovesFromTheOrganisation(t, ctx, "Sue", "Bob", "Acme")
+
+		// Then
+		personShouldNotBelongToTheOrganisation(t, ctx, "Bob", "Acme")
+	})
+}
+
+func TestAnOrganisationKeepsAnOwner(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+
+		// When
+		personTriesToRemoveFromTheOrganisation(t, ctx, "Sue", "Sue", "Acme")
+
+		// Then
+		personShouldSeeAnErrorTellingThemTheOrganisationMustKeepAnOwner(t, ctx, "Sue")
+		personShouldBeOfTheOrganisation(t, ctx, "Sue", entities.OrgRoleOwner, "Acme")
+	})
+}
+
+func TestCreateAnOrganisationWhoseNameIsTaken(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personHasSignedUp(t, ctx, "Bob")
+		personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+
+		// When
+		personTriesToCreateTheOrganisation(t, ctx, "Bob", "Acme")
+
+		// Then
+		personShouldSeeAnErrorTellingThemTheOrganisationAlreadyExists(t, ctx, "Bob")
+	})
+}
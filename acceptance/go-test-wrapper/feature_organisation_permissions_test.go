@@ -0,0 +1,109 @@
+package features_test
+
+import (
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+func TestMembersCanCreateProjects(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personHasSignedUp(t, ctx, "Bob")
+		personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+		personAddsToTheOrganisationAs(t, ctx, "Sue", "Bob", "Acme", entities.OrgRoleMember)
+
+		// When
+		personCreatesTheProjectInTheOrganisation(t, ctx, "Bob", "Roadmap", "Acme")
+
+		// Then
+		personShouldSeeTheProjectInTheOrganisation(t, ctx, "Sue", "Roadmap", "Acme")
+	})
+}
+
+func TestMembersCannotDeleteProjects(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personHasSignedUp(t, ctx, "Bob")
+		personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+		personAddsToTheOrganisationAs(t, ctx, "Sue", "Bob", "Acme", entities.OrgRoleMember)
+		personCreatesTheProjectInTheOrganisation(t, ctx, "Sue", "Roadmap", "Acme")
+
+		// When
+		personTriesToDeleteTheProjectInTheOrganisation(t, ctx, "Bob", "Roadmap", "Acme")
+
+		// Then
+		personShouldBeRefusedAccess(t, ctx, "Bob")
+		personShouldSeeTheProjectInTheOrganisation(t, ctx, "Sue", "Roadmap", "Acme")
+	})
+}
+
+func TestAdminsCanDeleteProjects(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personHasSignedUp(t, ctx, "Bob")
+		personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+		personAddsToTheOrganisationAs(t, ctx, "Sue", "Bob", "Acme", entities.OrgRoleAdmin)
+		personCreatesTheProjectInTheOrganisation(t, ctx, "Sue", "Roadmap", "Acme")
+
+		// When
+		personDeletesTheProjectInTheOrganisation(t, ctx, "Bob", "Roadmap", "Acme")
+
+		// Then
+		personShouldNotSeeTheProjectInTheOrganisation(t, ctx, "Sue", "Roadmap", "Acme")
+	})
+}
+
+func TestAdminsCannotMakeOtherAdmins(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personHasSignedUp(t, ctx, "Bob")
+		personHasSignedUp(t, ctx, "Tanya")
+		personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+		personAddsToTheOrganisationAs(t, ctx, "Sue", "Bob", "Acme", entities.OrgRoleAdmin)
+
+		// When
+		personTriesToAddToTheOrganisationAs(t, ctx, "Bob", "Tanya", "Acme", entities.OrgRoleAdmin)
+
+		// Then
+		personShouldBeRefusedAccess(t, ctx, "Bob")
+		personShouldNotBelongToTheOrganisation(t, ctx, "Tanya", "Acme")
+	})
+}
+
+func TestOnlyOwnersCanDeleteTheOrganisation(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personHasSignedUp(t, ctx, "Bob")
+		personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+		personAddsToTheOrganisationAs(t, ctx, "Sue", "Bob", "Acme", entities.OrgRoleAdmin)
+
+		// When
+		personTriesToDeleteTheOrganisation(t, ctx, "Bob", "Acme")
+
+		// Then
+		personShouldBeRefusedAccess(t, ctx, "Bob")
+		personShouldBeOfTheOrganisation(t, ctx, "Bob", entities.OrgRoleAdmin, "Acme")
+	})
+}
+
+func TestOthersCannotSeeAnOrganisationsProjects(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personHasSignedUp(t, ctx, "Tanya")
+		personCreatesTheOrganisation(t, ctx, "Sue", "Acme")
+		personCreatesTheProjectInTheOrganisation(t, ctx, "Sue", "Roadmap", "Acme")
+
+		// When
+		personTriesToLookAtTheProjectsInTheOrganisation(t, ctx, "Tanya", "Acme")
+
+		// Then
+		personShouldSeeAnErrorTellingThemThereIsNoSuchOrganisation(t, ctx, "Tanya")
+	})
+}
@@ -0,0 +1,79 @@
+package features_test
+
+import (
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+func TestNewAccountsStartOnTheFreePlan(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+
+		// Then
+		personShouldBeOnThePlan(t, ctx, "Sue", entities.PlanFree)
+	})
+}
+
+func TestFreeAccountsAreToldToUpgradeWhenTheyReachTheirQuota(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personIsOnThePlanWithProjects(t, ctx, "Sue", entities.PlanFree, 3)
+
+		// When
+		personTriesToCreateAProject(t, ctx, "Sue")
+
+		// Then
+		personShouldBeToldToUpgrade(t, ctx, "Sue")
+		personShouldSeeProjects(t, ctx, "Sue", 3)
+	})
+}
+
+func TestUpgradingLiftsTheLimit(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personIsOnThePlanWithProjects(t, ctx, "Sue", entities.PlanFree, 3)
+
+		// When
+		personMovesToThePlan(t, ctx, "Sue", entities.PlanPro)
+		personCreatesAProject(t, ctx, "Sue")
+
+		// Then
+		personShouldBeOnThePlan(t, ctx, "Sue", entities.PlanPro)
+		personShouldSeeProjects(t, ctx, "Sue", 4)
+	})
+}
+
+func TestDeletingAProjectMakesRoomForAnother(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personIsOnThePlanWithProjects(t, ctx, "Sue", entities.PlanFree, 3)
+
+		// When
+		personDeletesTheProject(t, ctx, "Sue", "Project 1")
+		personCreatesAProject(t, ctx, "Sue")
+
+		// Then
+		personShouldSeeProjects(t, ctx, "Sue", 3)
+	})
+}
+
+func TestMovingBackToTheFreePlanKeepsTheProjects(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personIsOnThePlanWithProjects(t, ctx, "Sue", entities.PlanPro, 5)
+
+		// When
+		personMovesToThePlan(t, ctx, "Sue", entities.PlanFree)
+
+		// Then
+		personShouldSeeProjects(t, ctx, "Sue", 5)
+
+		// When
+		personTriesToCreateAProject(t, ctx, "Sue")
+
+		// Then
+		personShouldBeToldToUpgrade(t, ctx, "Sue")
+	})
+}
@@ -0,0 +1,99 @@
+package features_test
+
+import (
+	"testing"
+)
+
+func TestArchiveAFinishedProject(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+		personCreatesAProjectCalled(t, ctx, "Sue", "Budget")
+
+		// When
+		personArchivesTheProject(t, ctx, "Sue", "Roadmap")
+
+		// Then
+		personShouldNotSeeTheProjectCalled(t, ctx, "Sue", "Roadmap")
+		personShouldSeeTheArchivedProjectCalled(t, ctx, "Sue", "Roadmap")
+		personShouldSeeTheProjectCalled(t, ctx, "Sue", "Budget")
+	})
+}
+
+func TestRestoreAnArchivedProject(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+		personArchivesTheProject(t, ctx, "Sue", "Roadmap")
+
+		// When
+		personRestoresTheProject(t, ctx, "Sue", "Roadmap")
+
+		// Then
+		personShouldSeeTheProjectCalled(t, ctx, "Sue", "Roadmap")
+		personShouldNotSeeTheArchivedProjectCalled(t, ctx, "Sue", "Roadmap")
+	})
+}
+
+func TestDeleteAnArchivedProjectForGood(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+		personArchivesTheProject(t, ctx, "Sue", "Roadmap")
+
+		// When
+		personDeletesTheProject(t, ctx, "Sue", "Roadmap")
+
+		// Then
+		personShouldNotSeeTheProjectCalled(t, ctx, "Sue", "Roadmap")
+		personShouldNotSeeTheArchivedProjectCalled(t, ctx, "Sue", "Roadmap")
+	})
+}
+
+func TestArchiveAProjectThatIsAlreadyArchived(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+		personArchivesTheProject(t, ctx, "Sue", "Roadmap")
+
+		// When
+		personTriesToArchiveTheProject(t, ctx, "Sue", "Roadmap")
+
+		// Then
+		personShouldSeeAnErrorTellingThemTheProjectIsArchived(t, ctx, "Sue")
+	})
+}
+
+func TestRenameAnArchivedProject(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+		personArchivesTheProject(t, ctx, "Sue", "Roadmap")
+
+		// When
+		personTriesToRenameTheProject(t, ctx, "Sue", "Roadmap", "Plan")
+
+		// Then
+		personShouldSeeAnErrorTellingThemTheProjectIsArchived(t, ctx, "Sue")
+		personShouldSeeTheArchivedProjectCalled(t, ctx, "Sue", "Roadmap")
+	})
+}
+
+func TestRestoreAProjectThatIsNotArchived(t *testing.T) {
+	withTestContext(t, func(t *testing.T, ctx *testContext) {
+		// Given
+		personHasSignedUp(t, ctx, "Sue")
+		personCreatesAProjectCalled(t, ctx, "Sue", "Roadmap")
+
+		// When
+		personTriesToRestoreTheProject(t, ctx, "Sue", "Roadmap")
+
+		// Then
+		personShouldSeeAnErrorTellingThemTheProjectIsNotArchived(t, ctx, "Sue")
+	})
+}
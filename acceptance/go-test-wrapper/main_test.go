@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	domaindriver "github.com/sirockin/cucumber-screenplay-go/acceptance/driver/domain"
 	httpdriver "github.com/sirockin/cucumber-screenplay-go/acceptance/driver/http"
 	uidriver "github.com/sirockin/cucumber-screenplay-go/acceptance/driver/ui"
 	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/testhelpers"
@@ -13,16 +14,19 @@ import (
 var (
 	serverURL   string
 	frontendURL string
+	// backEnd is the server executable started for back-end tests, which can be restarted
+	backEnd *serverExecutable
 )
 
 func TestMain(m *testing.M) {
 	cleanup := func() {}
 
 	switch os.Getenv("TEST_TYPE") {
-	case "application":
-		// No setup needed for application tests
+	case "application", "in-process":
+		// No setup needed for application tests; in-process servers are started per test
 	case "back-end":
-		serverURL, cleanup = startServerExecutable()
+		backEnd, cleanup = startServerExecutable()
+		serverURL = backEnd.url
 	default:
 		frontendURL, serverURL, cleanup = startFrontAndBackend()
 	}
@@ -37,12 +41,13 @@ func TestMain(m *testing.M) {
 // based on environment variables to control which tests run
 func withTestContext(t *testing.T, testFn func(t *testing.T, ctx *testContext)) {
 	runApplication := os.Getenv("TEST_TYPE") == "application" || os.Getenv("TEST_TYPE") == ""
+	runInProcess := os.Getenv("TEST_TYPE") == "in-process" || os.Getenv("TEST_TYPE") == ""
 	runBackEnd := os.Getenv("TEST_TYPE") == "back-end" || os.Getenv("TEST_TYPE") == ""
 	runFrontEnd := os.Getenv("TEST_TYPE") == "front-end" || os.Getenv("TEST_TYPE") == ""
 
 	if runApplication {
 		t.Run("Application", func(t *testing.T) {
-			ctx := newTestContext(testhelpers.NewDomainTestDriver())
+			ctx := newTestContext("Application", domaindriver.New())
 			t.Cleanup(func() {
 				ctx.clearAll()
 			})
@@ -50,10 +55,21 @@ func withTestContext(t *testing.T, testFn func(t *testing.T, ctx *testContext))
 		})
 	}
 
+	if runInProcess {
+		t.Run("HTTPInProcess", func(t *testing.T) {
+			httpDriver := httpdriver.New(testhelpers.NewInProcessServer(t))
+			ctx := newTestContext("HTTPInProcess", httpDriver)
+			testFn(t, ctx)
+		})
+	}
+
 	if runBackEnd {
 		t.Run("HTTPExecutable", func(t *testing.T) {
 			httpDriver := httpdriver.New(serverURL)
-			ctx := newTestContext(httpDriver)
+			ctx := newTestContext("HTTPExecutable", httpDriver)
+			if backEnd != nil {
+				ctx.server = backEnd
+			}
 			t.Cleanup(func() {
 				ctx.clearAll()
 			})
@@ -65,7 +81,7 @@ func withTestContext(t *testing.T, testFn func(t *testing.T, ctx *testContext))
 		t.Run("FrontEnd", func(t *testing.T) {
 			uiDriver := uidriver.New(t, frontendURL)
 
-			ctx := newTestContext(uiDriver)
+			ctx := newTestContext("FrontEnd", uiDriver)
 			// Clear at start to ensure clean state
 			ctx.clearAll()
 			// Extra wait to ensure clear fully propagates before test starts
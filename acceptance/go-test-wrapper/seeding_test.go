@@ -0,0 +1,52 @@
+package features_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	httpdriver "github.com/sirockin/cucumber-screenplay-go/acceptance/driver/http"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/testhelpers"
+)
+
+// TestSeededAccounts checks that a driver finds the accounts a server was seeded with,
+// although it works in a namespace of its own, and still does after clearing its data
+func TestSeededAccounts(t *testing.T) {
+	if testType := os.Getenv("TEST_TYPE"); testType != "" && testType != "in-process" {
+		t.Skip("seeding is only checked against an in-process server")
+	}
+	path := filepath.Join(t.TempDir(), "fixtures.yaml")
+	fixture := `accounts:
+  - name: Sue
+    password: correct-horse-1
+    activated: true
+    projects:
+      - Roadmap
+      - Launch plan
+`
+	if err := os.WriteFile(path, []byte(fixture), 0o600); err != nil {
+		t.Fatalf("failed to write fixtures: %v", err)
+	}
+	driver := httpdriver.New(testhelpers.NewInProcessServerWithFixtures(t, path))
+
+	for _, stage := range []string{"at first", "after clearing"} {
+		account, err := driver.GetAccount("Sue")
+		if err != nil {
+			t.Fatalf("%s: expected Sue's account but got %v", stage, err)
+		}
+		if !account.IsActivated() {
+			t.Fatalf("%s: expected Sue's account to be activated", stage)
+		}
+		if err := driver.Authenticate("Sue", "correct-horse-1"); err != nil {
+			t.Fatalf("%s: expected Sue to sign in but got %v", stage, err)
+		}
+		projects, err := driver.GetProjects("Sue")
+		if err != nil {
+			t.Fatalf("%s: expected Sue's projects but got %v", stage, err)
+		}
+		if len(projects) != 2 || projects[0].Name() != "Roadmap" || projects[1].Name() != "Launch plan" {
+			t.Fatalf("%s: expected Sue's projects to be Roadmap and Launch plan but got %d projects", stage, len(projects))
+		}
+		driver.ClearAll()
+	}
+}
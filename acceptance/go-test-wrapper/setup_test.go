@@ -2,6 +2,7 @@ package features_test
 
 import (
 	"bufio"
+	"fmt"
 	"io"
 	"log"
 	"net/http"
@@ -13,17 +14,56 @@ import (
 	"time"
 )
 
+// serverExecutable is the actual server executable running as a child process.
+// It keeps its data in a directory that outlives the process, so that it can be
+// restarted mid-test without losing anything.
+type serverExecutable struct {
+	url     string
+	dataDir string
+	cmd     *exec.Cmd
+}
+
 // startServerExecutable builds and starts the actual server executable using the root makefile
-// and returns the server URL and a cleanup function.
-func startServerExecutable() (string, func()) {
+// and returns the server and a cleanup function.
+func startServerExecutable() (*serverExecutable, func()) {
+	dataDir, err := os.MkdirTemp("", "server-data-")
+	if err != nil {
+		log.Printf("Failed to create data directory: %v", err)
+		os.Exit(1)
+	}
+
+	server := &serverExecutable{
+		url:     "http://localhost:8080",
+		dataDir: dataDir,
+	}
+	if err := server.start(); err != nil {
+		log.Print(err)
+		os.Exit(1)
+	}
+
+	// Create cleanup function
+	cleanup := func() {
+		server.stop()
+		os.RemoveAll(dataDir)
+	}
+
+	return server, cleanup
+}
+
+// Restart stops the server executable and starts it again with the same data
+func (s *serverExecutable) Restart() error {
+	s.stop()
+	return s.start()
+}
+
+func (s *serverExecutable) start() error {
 	// Start the server process using root makefile target
-	cmd := exec.Command("make", "run-backend")
+	cmd := exec.Command("make", "run-backend", "SERVER_ARGS=--test-mode --data-dir="+s.dataDir)
 
 	// Set working directory to project root
 	projectRoot, err := filepath.Abs("../..")
 	if err != nil {
-		log.Printf("Failed to get project root path: %v", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to get project root path: %w", err)
 	}
 	cmd.Dir = projectRoot
 
@@ -33,63 +73,63 @@ func startServerExecutable() (string, func()) {
 	// Capture server output for debugging
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
-		log.Printf("Failed to create stdout pipe: %v", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to create stdout pipe: %w", err)
 	}
 
 	stderr, err := cmd.StderrPipe()
 	if err != nil {
-		log.Printf("Failed to create stderr pipe: %v", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to create stderr pipe: %w", err)
 	}
 
 	// Start the server
 	if err := cmd.Start(); err != nil {
-		log.Printf("Failed to start server: %v", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to start server: %w", err)
 	}
+	s.cmd = cmd
 
 	// Monitor server output in background
 	go logServerOutput("STDOUT", stdout)
 	go logServerOutput("STDERR", stderr)
 
 	// Wait for server to be ready
-	serverURL := "http://localhost:8080"
-	waitForServerReady(serverURL)
+	if err := pollServerReady(s.url, 30*time.Second); err != nil {
+		return err
+	}
 
-	log.Printf("Server started successfully at %s (PID: %d)", serverURL, cmd.Process.Pid)
+	log.Printf("Server started successfully at %s (PID: %d)", s.url, cmd.Process.Pid)
+	return nil
+}
 
-	// Create cleanup function
-	cleanup := func() {
-		log.Printf("Shutting down server (PID: %d)", cmd.Process.Pid)
+func (s *serverExecutable) stop() {
+	log.Printf("Shutting down server (PID: %d)", s.cmd.Process.Pid)
 
-		// Kill the entire process group to ensure cleanup
-		syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	// Kill the entire process group to ensure cleanup
+	syscall.Kill(-s.cmd.Process.Pid, syscall.SIGKILL)
 
-		// Give the process time to shut down
-		done := make(chan error, 1)
-		go func() {
-			done <- cmd.Wait()
-		}()
+	// Give the process time to shut down
+	done := make(chan error, 1)
+	go func() {
+		done <- s.cmd.Wait()
+	}()
 
-		select {
-		case <-done:
-			log.Printf("Server shut down gracefully")
-		case <-time.After(5 * time.Second):
-			log.Printf("Server didn't shut down gracefully")
-		}
+	select {
+	case <-done:
+		log.Printf("Server shut down gracefully")
+	case <-time.After(5 * time.Second):
+		log.Printf("Server didn't shut down gracefully")
 	}
-
-	return serverURL, cleanup
-}
-
-// waitForServerReady waits for the server to be ready to accept connections
-func waitForServerReady(serverURL string) {
-	waitForServerReadyWithTimeout(serverURL, 30*time.Second)
 }
 
 // waitForServerReadyWithTimeout waits for the server to be ready with a custom timeout
 func waitForServerReadyWithTimeout(serverURL string, timeout time.Duration) {
+	if err := pollServerReady(serverURL, timeout); err != nil {
+		log.Print(err)
+		os.Exit(1)
+	}
+}
+
+// pollServerReady polls the server until it accepts connections, returning an error if it does not within the timeout
+func pollServerReady(serverURL string, timeout time.Duration) error {
 	client := &http.Client{Timeout: 5 * time.Second}
 
 	// Determine which endpoint to check based on URL
@@ -115,7 +155,7 @@ func waitForServerReadyWithTimeout(serverURL string, timeout time.Duration) {
 				consecutiveSuccesses++
 				if consecutiveSuccesses >= requiredSuccesses {
 					log.Printf("Server is ready at %s after %d attempts (%.1fs)", serverURL, attempt, time.Since(deadline.Add(-timeout)).Seconds())
-					return
+					return nil
 				}
 				// Don't sleep as long between successful checks
 				time.Sleep(500 * time.Millisecond)
@@ -127,8 +167,7 @@ func waitForServerReadyWithTimeout(serverURL string, timeout time.Duration) {
 		time.Sleep(2 * time.Second)
 	}
 
-	log.Printf("Server at %s did not become ready within %v (tried %d times)", serverURL, timeout, attempt)
-	os.Exit(1)
+	return fmt.Errorf("server at %s did not become ready within %v (tried %d times)", serverURL, timeout, attempt)
 }
 
 // logServerOutput logs server output for debugging
@@ -145,7 +184,7 @@ func logServerOutput(prefix string, pipe io.ReadCloser) {
 // Start back end and front end services by calling `make run` and return the front end URL and cleanup function
 func startFrontAndBackend() (string, string, func()) {
 	// Start both back end and front end services
-	cmd := exec.Command("make", "run")
+	cmd := exec.Command("make", "run", "SERVER_ARGS=--test-mode")
 
 	// Set working directory to project root
 	projectRoot, err := filepath.Abs("../..")
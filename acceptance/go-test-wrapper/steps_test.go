@@ -1,39 +1,108 @@
 package features_test
 
 import (
+	"fmt"
+	"net/http"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/sirockin/cucumber-screenplay-go/acceptance/driver"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/export"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/testhelpers"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/webhooks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// serverRestarter restarts the system under test without losing its data
+type serverRestarter interface {
+	Restart() error
+}
+
 type testContext struct {
-	driver     driver.TestDriver
+	// layer names the layer of the system under test that driver drives
+	layer  string
+	driver driver.TestDriver
+	// server is nil if the system under test cannot be restarted
+	server     serverRestarter
 	lastErrors map[string]error
+	// receivers are the local endpoints that people have registered as their webhooks
+	receivers map[string]*testhelpers.WebhookReceiver
+	// shownPages holds the pages of projects each person was last shown
+	shownPages map[string][]entities.ProjectPage
+	// downloads holds the archive of their data each person last downloaded
+	downloads map[string]export.Archive
 }
 
-func newTestContext(testDriver driver.TestDriver) *testContext {
+func newTestContext(layer string, testDriver driver.TestDriver) *testContext {
 	return &testContext{
+		layer:      layer,
 		driver:     testDriver,
 		lastErrors: make(map[string]error),
+		receivers:  make(map[string]*testhelpers.WebhookReceiver),
+		shownPages: make(map[string][]entities.ProjectPage),
+		downloads:  make(map[string]export.Archive),
 	}
 }
 
+// defaultPassword is used when the test does not care what a password is
+const defaultPassword = "correct-horse-1"
+
+// baselines holds the snapshots that tests start from, for each layer. Each one is built
+// the first time it is needed and kept for the whole run, to be restored into whichever
+// system under test the layer's next test runs against.
+var (
+	baselinesMu sync.Mutex
+	baselines   = make(map[string]testhelpers.Snapshot)
+)
+
 func personHasCreatedAnAccount(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
-	err := ctx.driver.CreateAccount(name)
+	err := ctx.driver.CreateAccount(name, defaultPassword)
 	require.NoError(t, err)
 }
 
 func personHasSignedUp(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
-	err := ctx.driver.CreateAccount(name)
+	err := ctx.driver.CreateAccount(name, defaultPassword)
 	require.NoError(t, err)
 	_, err = ctx.driver.GetAccount(name)
 	require.NoError(t, err)
-	err = ctx.driver.Activate(name)
+	err = followLatestActivationLink(ctx, name)
+	require.NoError(t, err)
+}
+
+// personHasSignedUpWithProjects starts the test from a baseline in which the person has
+// signed up and created count projects, which must fit in the free plan. The baseline is
+// built the first time it is needed and restored after that, replacing everything, so it
+// must be the first step.
+func personHasSignedUpWithProjects(t *testing.T, ctx *testContext, name string, count int) {
+	t.Helper()
+	personIsOnThePlanWithProjects(t, ctx, name, entities.PlanFree, count)
+}
+
+// personIsOnThePlanWithProjects starts the test from a baseline in which the person has
+// signed up, moved to the plan and created count projects, as personHasSignedUpWithProjects does
+func personIsOnThePlanWithProjects(t *testing.T, ctx *testContext, name string, plan entities.Plan, count int) {
+	t.Helper()
+	baselinesMu.Lock()
+	defer baselinesMu.Unlock()
+	key := fmt.Sprintf("%s: %s on the %s plan with %d projects", ctx.layer, name, plan, count)
+	if snapshot, ok := baselines[key]; ok {
+		require.NoError(t, ctx.driver.Restore(snapshot))
+		return
+	}
+	personHasSignedUp(t, ctx, name)
+	personMovesToThePlan(t, ctx, name, plan)
+	for i := 0; i < count; i++ {
+		personCreatesAProjectCalled(t, ctx, name, fmt.Sprintf("Project %d", i+1))
+	}
+	snapshot, err := ctx.driver.Snapshot()
 	require.NoError(t, err)
+	baselines[key] = snapshot
 }
 
 func personShouldBeAuthenticated(t *testing.T, ctx *testContext, name string) {
@@ -48,6 +117,18 @@ func personShouldNotBeAuthenticated(t *testing.T, ctx *testContext, name string)
 	assert.False(t, actual, "person %s should not be authenticated", name)
 }
 
+func personShouldBeAuthenticatedOnTheirDevice(t *testing.T, ctx *testContext, name string, device string) {
+	t.Helper()
+	actual := ctx.driver.OnDevice(device).IsAuthenticated(name)
+	assert.True(t, actual, "person %s should be authenticated on their %s", name, device)
+}
+
+func personShouldNotBeAuthenticatedOnTheirDevice(t *testing.T, ctx *testContext, name string, device string) {
+	t.Helper()
+	actual := ctx.driver.OnDevice(device).IsAuthenticated(name)
+	assert.False(t, actual, "person %s should not be authenticated on their %s", name, device)
+}
+
 func personShouldNotSeeAnyProjects(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
 	projects, err := ctx.driver.GetProjects(name)
@@ -62,35 +143,594 @@ func personShouldSeeTheirProject(t *testing.T, ctx *testContext, name string) {
 	assert.Len(t, projects, 1, "person %s should see exactly one project", name)
 }
 
+func personShouldSeeProjects(t *testing.T, ctx *testContext, name string, count int) {
+	t.Helper()
+	projects, err := ctx.driver.GetProjects(name)
+	require.NoError(t, err)
+	assert.Len(t, projects, count, "person %s should see %d projects", name, count)
+}
+
 func personShouldSeeAnErrorTellingThemToActivateTheAccount(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
 	lastError := ctx.getLastError(name)
-	expectedText := "you need to activate your account"
-	require.NotNil(t, lastError, "expected error containing text '%s' but there is no error", expectedText)
-	assert.Contains(t, lastError.Error(), expectedText, "expected error text containing '%s'", expectedText)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrAccountNotActivated)
+}
+
+func personShouldSeeAnErrorTellingThemTheActivationLinkIsNotValid(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrInvalidActivation)
+}
+
+func personShouldSeeAnErrorTellingThemTheActivationLinkHasExpired(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrActivationExpired)
+}
+
+func personShouldSeeAnErrorTellingThemThePasswordIsTooWeak(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrWeakPassword)
+}
+
+func personShouldSeeAnErrorTellingThemTheNameOrPasswordIsWrong(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrWrongCredentials)
+}
+
+func personShouldSeeAnErrorTellingThemTheAccountIsLocked(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrAccountLocked)
+}
+
+func personShouldSeeAnErrorTellingThemToSignIn(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrNotSignedIn)
+}
+
+func personShouldBeRefusedAccess(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrAccessDenied)
+}
+
+func personShouldSeeAnErrorTellingThemTheNameIsAlreadyTaken(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrAccountExists)
+}
+
+func personTriesToSignUpAs(t *testing.T, ctx *testContext, name string, accountName string) {
+	t.Helper()
+	err := ctx.driver.CreateAccount(accountName, defaultPassword)
+	ctx.setLastError(name, err)
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func personTriesToSignUpWithThePassword(t *testing.T, ctx *testContext, name string, password string) {
+	t.Helper()
+	err := ctx.driver.CreateAccount(name, password)
+	ctx.setLastError(name, err)
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func personTriesToSignInWithThePassword(t *testing.T, ctx *testContext, name string, password string) {
+	t.Helper()
+	err := ctx.driver.Authenticate(name, password)
+	ctx.setLastError(name, err)
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func personTriesToSignInWithThePasswordTimes(t *testing.T, ctx *testContext, name string, password string, times int) {
+	t.Helper()
+	for i := 0; i < times; i++ {
+		err := ctx.driver.Authenticate(name, password)
+		ctx.setLastError(name, err)
+	}
+	// The step succeeds even if the result is bad to allow the next step to check the error
 }
 
 func personTriesToSignIn(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
-	err := ctx.driver.Authenticate(name)
+	err := ctx.driver.Authenticate(name, defaultPassword)
+	ctx.setLastError(name, err)
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func personSignsIn(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	err := ctx.driver.Authenticate(name, defaultPassword)
+	require.NoError(t, err)
+}
+
+func personSignsInOnTheirDevice(t *testing.T, ctx *testContext, name string, device string) {
+	t.Helper()
+	err := ctx.driver.OnDevice(device).Authenticate(name, defaultPassword)
+	require.NoError(t, err)
+}
+
+func personSignsOut(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	err := ctx.driver.SignOut(name)
+	require.NoError(t, err)
+}
+
+func personSignsOutOnTheirDevice(t *testing.T, ctx *testContext, name string, device string) {
+	t.Helper()
+	err := ctx.driver.OnDevice(device).SignOut(name)
+	require.NoError(t, err)
+}
+
+func personTriesToSignOutOnTheirDevice(t *testing.T, ctx *testContext, name string, device string) {
+	t.Helper()
+	err := ctx.driver.OnDevice(device).SignOut(name)
+	ctx.setLastError(name, err)
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func personTriesToOpenTheProjectsOf(t *testing.T, ctx *testContext, name string, owner string) {
+	t.Helper()
+	_, err := ctx.driver.GetProjectsOf(name, owner)
 	ctx.setLastError(name, err)
 	// The step succeeds even if the result is bad to allow the next step to check the error
 }
 
 func personCreatesAProject(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
-	err := ctx.driver.CreateProject(name)
+	personCreatesAProjectCalled(t, ctx, name, defaultProjectName)
+}
+
+func personTriesToCreateAProject(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	_, err := ctx.driver.CreateProject(name, defaultProjectName)
+	ctx.setLastError(name, err)
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func personMovesToThePlan(t *testing.T, ctx *testContext, name string, plan entities.Plan) {
+	t.Helper()
+	require.NoError(t, ctx.driver.ChangePlan(name, plan))
+}
+
+func personShouldBeOnThePlan(t *testing.T, ctx *testContext, name string, plan entities.Plan) {
+	t.Helper()
+	account, err := ctx.driver.GetAccount(name)
+	require.NoError(t, err)
+	assert.Equal(t, plan, account.Plan(), "person %s should be on the %s plan", name, plan)
+}
+
+func personShouldBeToldToUpgrade(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrQuotaExceeded)
+}
+
+func personDownloadsTheirData(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	archive, err := ctx.driver.ExportAccount(name)
+	require.NoError(t, err)
+	ctx.downloads[name] = archive
+}
+
+func personsDownloadShouldHoldTheProjects(t *testing.T, ctx *testContext, name string, projectNames ...string) {
+	t.Helper()
+	var held []string
+	for _, project := range ctx.downloads[name].Projects {
+		held = append(held, project.Name)
+	}
+	assert.Equal(t, projectNames, held, "person %s's download should hold the projects", name)
+}
+
+func personClosesTheirAccount(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	require.NoError(t, ctx.driver.CloseAccount(name))
+}
+
+func personTriesToCloseTheirAccount(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	ctx.setLastError(name, ctx.driver.CloseAccount(name))
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func personsAccountShouldBeClosed(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	account, err := ctx.driver.GetAccount(name)
+	require.NoError(t, err)
+	assert.True(t, account.IsClosed(), "person %s's account should be closed", name)
+}
+
+func personCreatesAProjectCalled(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	_, err := ctx.driver.CreateProject(name, projectName)
+	require.NoError(t, err)
+}
+
+func personRenamesTheProject(t *testing.T, ctx *testContext, name string, projectName string, newName string) {
+	t.Helper()
+	project := findProjectCalled(t, ctx, name, projectName)
+	require.NotNil(t, project, "person %s should have a project called %s", name, projectName)
+	_, err := ctx.driver.RenameProject(name, project.ID(), newName)
+	require.NoError(t, err)
+}
+
+func personDeletesTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	project := findProjectCalled(t, ctx, name, projectName)
+	require.NotNil(t, project, "person %s should have a project called %s", name, projectName)
+	err := ctx.driver.DeleteProject(name, project.ID())
+	require.NoError(t, err)
+}
+
+func personArchivesTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	require.NoError(t, archiveProject(t, ctx, name, projectName))
+}
+
+func personRestoresTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	require.NoError(t, restoreProject(t, ctx, name, projectName))
+}
+
+func personTriesToArchiveTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	ctx.setLastError(name, archiveProject(t, ctx, name, projectName))
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func personTriesToRestoreTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	ctx.setLastError(name, restoreProject(t, ctx, name, projectName))
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func personTriesToRenameTheProject(t *testing.T, ctx *testContext, name string, projectName string, newName string) {
+	t.Helper()
+	project := findProjectCalled(t, ctx, name, projectName)
+	require.NotNil(t, project, "person %s should have a project called %s", name, projectName)
+	_, err := ctx.driver.RenameProject(name, project.ID(), newName)
+	ctx.setLastError(name, err)
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func personShouldSeeTheProjectCalled(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	assert.True(t, seesProjectCalled(t, ctx, name, projectName), "person %s should see a project called %s", name, projectName)
+}
+
+func personShouldNotSeeTheProjectCalled(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	assert.False(t, seesProjectCalled(t, ctx, name, projectName), "person %s should not see a project called %s", name, projectName)
+}
+
+func personShouldSeeTheArchivedProjectCalled(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	project := findProjectCalled(t, ctx, name, projectName)
+	require.NotNil(t, project, "person %s should see an archived project called %s", name, projectName)
+	assert.True(t, project.IsArchived(), "person %s's project called %s should be archived", name, projectName)
+}
+
+func personShouldNotSeeTheArchivedProjectCalled(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	project := findProjectCalled(t, ctx, name, projectName)
+	assert.False(t, project != nil && project.IsArchived(), "person %s should not see an archived project called %s", name, projectName)
+}
+
+func personShouldSeeAnErrorTellingThemTheProjectIsArchived(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrProjectArchived)
+}
+
+func personShouldSeeAnErrorTellingThemTheProjectIsNotArchived(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrProjectNotArchived)
+}
+
+func personInvitesToTheProjectAs(t *testing.T, ctx *testContext, name string, invitee string, projectName string, role entities.Role) {
+	t.Helper()
+	require.NoError(t, inviteToProject(t, ctx, name, invitee, projectName, role))
+}
+
+func personTriesToInviteToTheProjectAs(t *testing.T, ctx *testContext, name string, invitee string, projectName string, role entities.Role) {
+	t.Helper()
+	ctx.setLastError(name, inviteToProject(t, ctx, name, invitee, projectName, role))
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func personAcceptsTheInvitationToTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	invitation := findInvitationTo(t, ctx, name, projectName)
+	require.NotNil(t, invitation, "person %s should have an invitation to %s", name, projectName)
+	require.NoError(t, ctx.driver.AcceptInvitation(name, invitation.ProjectID))
+}
+
+func personDeclinesTheInvitationToTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	invitation := findInvitationTo(t, ctx, name, projectName)
+	require.NotNil(t, invitation, "person %s should have an invitation to %s", name, projectName)
+	require.NoError(t, ctx.driver.DeclineInvitation(name, invitation.ProjectID))
+}
+
+func personShouldSeeAnInvitationToTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	assert.NotNil(t, findInvitationTo(t, ctx, name, projectName), "person %s should see an invitation to %s", name, projectName)
+}
+
+func personShouldNotSeeAnInvitationToTheProject(t *testing.T, ctx *testContext, name string, projectName string) {
+	t.Helper()
+	assert.Nil(t, findInvitationTo(t, ctx, name, projectName), "person %s should not see an invitation to %s", name, projectName)
+}
+
+func personShouldSeeAnErrorTellingThemTheyAlreadyShareTheProject(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrAlreadyMember)
+}
+
+func personCreatesTheOrganisation(t *testing.T, ctx *testContext, name string, orgName string) {
+	t.Helper()
+	require.NoError(t, ctx.driver.CreateOrganisation(name, orgName))
+}
+
+func personTriesToCreateTheOrganisation(t *testing.T, ctx *testContext, name string, orgName string) {
+	t.Helper()
+	ctx.setLastError(name, ctx.driver.CreateOrganisation(name, orgName))
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func personAddsToTheOrganisationAs(t *testing.T, ctx *testContext, name string, member string, orgName string, role entities.OrgRole) {
+	t.Helper()
+	require.NoError(t, ctx.driver.SetOrganisationMember(name, orgName, member, role))
+}
+
+func personTriesToAddToTheOrganisationAs(t *testing.T, ctx *testContext, name string, member string, orgName string, role entities.OrgRole) {
+	t.Helper()
+	ctx.setLastError(name, ctx.driver.SetOrganisationMember(name, orgName, member, role))
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func personRemovesFromTheOrganisation(t *testing.T, ctx *testContext, name string, member string, orgName string) {
+	t.Helper()
+	require.NoError(t, ctx.driver.RemoveOrganisationMember(name, orgName, member))
+}
+
+func personTriesToRemoveFromTheOrganisation(t *testing.T, ctx *testContext, name string, member string, orgName string) {
+	t.Helper()
+	ctx.setLastError(name, ctx.driver.RemoveOrganisationMember(name, orgName, member))
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func personTriesToDeleteTheOrganisation(t *testing.T, ctx *testContext, name string, orgName string) {
+	t.Helper()
+	ctx.setLastError(name, ctx.driver.DeleteOrganisation(name, orgName))
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func personShouldBeOfTheOrganisation(t *testing.T, ctx *testContext, name string, role entities.OrgRole, orgName string) {
+	t.Helper()
+	assert.Equal(t, role, roleIn(t, ctx, name, orgName), "person %s should be %s of %s", name, role, orgName)
+}
+
+func personShouldNotBelongToTheOrganisation(t *testing.T, ctx *testContext, name string, orgName string) {
+	t.Helper()
+	assert.Empty(t, roleIn(t, ctx, name, orgName), "person %s should not belong to %s", name, orgName)
+}
+
+func personShouldSeeAnErrorTellingThemTheOrganisationMustKeepAnOwner(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrLastOwner)
+}
+
+func personShouldSeeAnErrorTellingThemTheOrganisationAlreadyExists(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrOrganisationExists)
+}
+
+func personShouldSeeAnErrorTellingThemThereIsNoSuchOrganisation(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	lastError := ctx.getLastError(name)
+	require.Error(t, lastError, "expected an error but there is no error")
+	assert.ErrorIs(t, lastError, entities.ErrOrganisationNotFound)
+}
+
+func personCreatesTheProjectInTheOrganisation(t *testing.T, ctx *testContext, name string, projectName string, orgName string) {
+	t.Helper()
+	_, err := ctx.driver.CreateOrganisationProject(name, orgName, projectName)
+	require.NoError(t, err)
+}
+
+func personDeletesTheProjectInTheOrganisation(t *testing.T, ctx *testContext, name string, projectName string, orgName string) {
+	t.Helper()
+	require.NoError(t, deleteOrganisationProject(t, ctx, name, projectName, orgName))
+}
+
+func personTriesToDeleteTheProjectInTheOrganisation(t *testing.T, ctx *testContext, name string, projectName string, orgName string) {
+	t.Helper()
+	ctx.setLastError(name, deleteOrganisationProject(t, ctx, name, projectName, orgName))
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func personTriesToLookAtTheProjectsInTheOrganisation(t *testing.T, ctx *testContext, name string, orgName string) {
+	t.Helper()
+	_, err := ctx.driver.GetOrganisationProjects(name, orgName)
+	ctx.setLastError(name, err)
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func personShouldSeeTheProjectInTheOrganisation(t *testing.T, ctx *testContext, name string, projectName string, orgName string) {
+	t.Helper()
+	assert.NotNil(t, findOrganisationProjectCalled(t, ctx, name, orgName, projectName), "person %s should see a project called %s in %s", name, projectName, orgName)
+}
+
+func personShouldNotSeeTheProjectInTheOrganisation(t *testing.T, ctx *testContext, name string, projectName string, orgName string) {
+	t.Helper()
+	assert.Nil(t, findOrganisationProjectCalled(t, ctx, name, orgName, projectName), "person %s should not see a project called %s in %s", name, projectName, orgName)
+}
+
+func personPagesThroughTheirProjects(t *testing.T, ctx *testContext, name string, limit int) {
+	t.Helper()
+	var pages []entities.ProjectPage
+	query := entities.ProjectQuery{Limit: limit}
+	for {
+		page, err := ctx.driver.ListProjects(name, query)
+		require.NoError(t, err)
+		pages = append(pages, page)
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	ctx.shownPages[name] = pages
+}
+
+func personListsTheirProjectsByName(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	personListsTheirProjects(t, ctx, name, entities.ProjectQuery{Sort: entities.SortByName})
+}
+
+func personListsTheirProjectsWhoseNamesStartWith(t *testing.T, ctx *testContext, name string, prefix string) {
+	t.Helper()
+	personListsTheirProjects(t, ctx, name, entities.ProjectQuery{NamePrefix: prefix})
+}
+
+func personListsTheirProjectsCreatedMoreThanMinutesAfter(t *testing.T, ctx *testContext, name string, minutes int, projectName string) {
+	t.Helper()
+	project := findProjectCalled(t, ctx, name, projectName)
+	require.NotNil(t, project, "person %s should have a project called %s", name, projectName)
+	personListsTheirProjects(t, ctx, name, entities.ProjectQuery{CreatedFrom: project.CreatedAt().Add(time.Duration(minutes) * time.Minute)})
+}
+
+// personListsTheirProjects lists the first page of a person's projects that query chooses
+func personListsTheirProjects(t *testing.T, ctx *testContext, name string, query entities.ProjectQuery) {
+	t.Helper()
+	page, err := ctx.driver.ListProjects(name, query)
 	require.NoError(t, err)
+	ctx.shownPages[name] = []entities.ProjectPage{page}
+}
+
+func personShouldHaveBeenShownPagesOfProjects(t *testing.T, ctx *testContext, name string, count int) {
+	t.Helper()
+	assert.Len(t, ctx.shownPages[name], count, "person %s should have been shown %d pages of projects", name, count)
+}
+
+// personShouldHaveBeenShownAllProjectsOnceEach expects the projects made by signing up
+// with count projects, in the order they were created
+func personShouldHaveBeenShownAllProjectsOnceEach(t *testing.T, ctx *testContext, name string, count int) {
+	t.Helper()
+	names := make([]string, count)
+	for i := range names {
+		names[i] = fmt.Sprintf("Project %d", i+1)
+	}
+	personShouldHaveBeenShownTheProjects(t, ctx, name, names...)
+}
+
+func personShouldHaveBeenShownTheProjects(t *testing.T, ctx *testContext, name string, projectNames ...string) {
+	t.Helper()
+	var shown []string
+	for _, page := range ctx.shownPages[name] {
+		for _, project := range page.Projects {
+			shown = append(shown, project.Name())
+		}
+	}
+	assert.Equal(t, projectNames, shown, "person %s should have been shown the projects in order", name)
+}
+
+func personCreatesProjectsAtTheSameTime(t *testing.T, ctx *testContext, name string, count int) {
+	t.Helper()
+	var wg sync.WaitGroup
+	errs := make([]error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = ctx.driver.CreateProject(name, fmt.Sprintf("Project %d", i+1))
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
 }
 
 func personActivatesTheirAccount(t *testing.T, ctx *testContext, name string) {
 	t.Helper()
 	_, err := ctx.driver.GetAccount(name)
 	require.NoError(t, err)
-	err = ctx.driver.Activate(name)
+	err = followLatestActivationLink(ctx, name)
 	require.NoError(t, err)
 }
 
+func personFollowsTheirActivationLinkAgain(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	err := followLatestActivationLink(ctx, name)
+	ctx.setLastError(name, err)
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+// followLatestActivationLink follows the link in the most recent message sent to a person,
+// as they would from the top of their inbox
+func followLatestActivationLink(ctx *testContext, name string) error {
+	messages, err := ctx.driver.GetMessages(name)
+	if err != nil {
+		return err
+	}
+	if len(messages) == 0 {
+		return fmt.Errorf("no messages have been sent to %s", name)
+	}
+	return ctx.driver.FollowActivationLink(messages[len(messages)-1].Link)
+}
+
+// awaitDeliveryToWebhook waits for the latest delivery to the webhook a person registered
+// to have been attempted the given number of times, finding the webhook by its URL as they
+// would in their list of webhooks
+func awaitDeliveryToWebhook(t *testing.T, ctx *testContext, name string, attempts int) webhooks.Delivery {
+	t.Helper()
+	registered, err := ctx.driver.GetWebhooks(name)
+	require.NoError(t, err)
+	for _, webhook := range registered {
+		if webhook.URL() == ctx.receiver(t, name).URL() {
+			delivery, err := ctx.driver.AwaitWebhookDelivery(name, webhook.ID(), attempts)
+			require.NoError(t, err)
+			return delivery
+		}
+	}
+	require.Failf(t, "webhook not found", "%s has not registered a webhook", name)
+	return webhooks.Delivery{}
+}
+
+// receiver returns the endpoint to register as a person's webhook, starting it if need
+// be and closing it when the test is over
+func (ctx *testContext) receiver(t *testing.T, name string) *testhelpers.WebhookReceiver {
+	if ctx.receivers[name] == nil {
+		receiver := testhelpers.NewWebhookReceiver()
+		t.Cleanup(receiver.Close)
+		ctx.receivers[name] = receiver
+	}
+	return ctx.receivers[name]
+}
+
 func (ctx *testContext) getLastError(name string) error {
 	return ctx.lastErrors[name]
 }
@@ -103,3 +743,251 @@ func (ctx *testContext) clearAll() {
 	ctx.driver.ClearAll()
 	ctx.lastErrors = make(map[string]error)
 }
+
+// skipUnlessServerCanRestart skips tests that need to restart the system under test
+// when running against a layer that cannot be restarted
+func skipUnlessServerCanRestart(t *testing.T, ctx *testContext) {
+	t.Helper()
+	if ctx.server == nil {
+		t.Skip("the system under test cannot be restarted")
+	}
+}
+
+func personTriesToActivateTheirAccount(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	err := followLatestActivationLink(ctx, name)
+	ctx.setLastError(name, err)
+	// The step succeeds even if the result is bad to allow the next step to check the error
+}
+
+func daysHavePassed(t *testing.T, ctx *testContext, days int) {
+	t.Helper()
+	err := ctx.driver.AdvanceClock(time.Duration(days) * 24 * time.Hour)
+	require.NoError(t, err)
+}
+
+func minutesHavePassed(t *testing.T, ctx *testContext, minutes int) {
+	t.Helper()
+	err := ctx.driver.AdvanceClock(time.Duration(minutes) * time.Minute)
+	require.NoError(t, err)
+}
+
+func anEventShouldHaveBeenPublishedFor(t *testing.T, ctx *testContext, kind events.Kind, name string) {
+	t.Helper()
+	assert.True(t, hasPublished(t, ctx, kind, name), "expected a %s event to have been published for %s", kind, name)
+}
+
+func noEventShouldHaveBeenPublishedFor(t *testing.T, ctx *testContext, kind events.Kind, name string) {
+	t.Helper()
+	assert.False(t, hasPublished(t, ctx, kind, name), "expected no %s event to have been published for %s", kind, name)
+}
+
+// hasPublished reports whether an event of the given kind has been published about a person
+func hasPublished(t *testing.T, ctx *testContext, kind events.Kind, name string) bool {
+	t.Helper()
+	published, err := ctx.driver.GetEvents(name)
+	require.NoError(t, err)
+	for _, event := range published {
+		if event.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func personWatchesTheirAccount(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	err := ctx.driver.WatchAccount(name)
+	require.NoError(t, err)
+}
+
+func personStopsWatchingTheirAccount(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	err := ctx.driver.StopWatchingAccount(name)
+	require.NoError(t, err)
+}
+
+func personShouldBeNotifiedThatTheirProjectWasCreated(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	err := ctx.driver.AwaitNotification(name, events.ProjectCreated)
+	require.NoError(t, err, "person %s should be notified that their project was created", name)
+}
+
+func personShouldBeNotifiedThatTheySignedIn(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	err := ctx.driver.AwaitNotification(name, events.Authenticated)
+	require.NoError(t, err, "person %s should be notified that they signed in", name)
+}
+
+func personShouldHaveBeenNotifiedThatProjectsWereCreated(t *testing.T, ctx *testContext, name string, count int) {
+	t.Helper()
+	notified, err := ctx.driver.GetNotifications(name)
+	require.NoError(t, err)
+	created := 0
+	for _, event := range notified {
+		if event.Kind == events.ProjectCreated {
+			created++
+		}
+	}
+	assert.Equal(t, count, created, "person %s should have been notified that %d projects were created", name, count)
+}
+
+func personRegistersAWebhook(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	receiver := ctx.receiver(t, name)
+	_, err := ctx.driver.RegisterWebhook(name, receiver.URL(), receiver.Secret())
+	require.NoError(t, err)
+}
+
+func personHasCreatedAnAccountWithAWebhook(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	receiver := ctx.receiver(t, name)
+	err := ctx.driver.CreateAccountWithWebhook(name, defaultPassword, receiver.URL(), receiver.Secret())
+	require.NoError(t, err)
+}
+
+func personsWebhookGoesDown(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	ctx.receiver(t, name).SetDown(true)
+}
+
+func personsWebhookComesBackUp(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	ctx.receiver(t, name).SetDown(false)
+}
+
+func personsWebhookShouldBeSentASignedPayload(t *testing.T, ctx *testContext, name string, kind events.Kind) {
+	t.Helper()
+	received, err := ctx.receiver(t, name).Await(kind)
+	require.NoError(t, err, "person %s's webhook should be sent a %s payload", name, kind)
+	assert.True(t, received.Signed, "the %s payload should be signed with the webhook's secret", kind)
+	assert.Equal(t, name, received.Payload.Account)
+}
+
+func theDeliveryToPersonsWebhookShouldHaveSucceededAfter(t *testing.T, ctx *testContext, name string, attempts int) {
+	t.Helper()
+	delivery := awaitDeliveryToWebhook(t, ctx, name, attempts)
+	assert.Equal(t, webhooks.StatusDelivered, delivery.Status)
+	assert.Equal(t, attempts, delivery.Attempts)
+}
+
+func theDeliveryToPersonsWebhookShouldBeWaitingToBeRetried(t *testing.T, ctx *testContext, name string) {
+	t.Helper()
+	delivery := awaitDeliveryToWebhook(t, ctx, name, 1)
+	assert.Equal(t, webhooks.StatusPending, delivery.Status)
+	assert.Equal(t, http.StatusServiceUnavailable, delivery.ResponseStatus)
+}
+
+func theServerRestarts(t *testing.T, ctx *testContext) {
+	t.Helper()
+	err := ctx.server.Restart()
+	require.NoError(t, err)
+}
+
+// defaultProjectName is used when the test does not care what a project is called
+const defaultProjectName = "My project"
+
+// findProjectCalled finds one of a person's projects by name, archived or not, as they
+// would in a list of projects that includes archived ones, or returns nil if there is none
+func findProjectCalled(t *testing.T, ctx *testContext, name string, projectName string) *entities.Project {
+	t.Helper()
+	query := entities.ProjectQuery{Limit: entities.MaxProjectLimit, IncludeArchived: true}
+	for {
+		page, err := ctx.driver.ListProjects(name, query)
+		require.NoError(t, err)
+		for _, project := range page.Projects {
+			if project.Name() == projectName {
+				return &project
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		query.Cursor = page.NextCursor
+	}
+}
+
+// seesProjectCalled reports whether a person's list of projects, which leaves out
+// archived ones, has one with the given name
+func seesProjectCalled(t *testing.T, ctx *testContext, name string, projectName string) bool {
+	t.Helper()
+	projects, err := ctx.driver.GetProjects(name)
+	require.NoError(t, err)
+	for _, project := range projects {
+		if project.Name() == projectName {
+			return true
+		}
+	}
+	return false
+}
+
+func archiveProject(t *testing.T, ctx *testContext, name string, projectName string) error {
+	t.Helper()
+	project := findProjectCalled(t, ctx, name, projectName)
+	require.NotNil(t, project, "person %s should have a project called %s", name, projectName)
+	_, err := ctx.driver.ArchiveProject(name, project.ID())
+	return err
+}
+
+func restoreProject(t *testing.T, ctx *testContext, name string, projectName string) error {
+	t.Helper()
+	project := findProjectCalled(t, ctx, name, projectName)
+	require.NotNil(t, project, "person %s should have a project called %s", name, projectName)
+	_, err := ctx.driver.RestoreProject(name, project.ID())
+	return err
+}
+
+func inviteToProject(t *testing.T, ctx *testContext, name string, invitee string, projectName string, role entities.Role) error {
+	t.Helper()
+	project := findProjectCalled(t, ctx, name, projectName)
+	require.NotNil(t, project, "person %s should have a project called %s", name, projectName)
+	return ctx.driver.InviteToProject(name, project.ID(), invitee, role)
+}
+
+func deleteOrganisationProject(t *testing.T, ctx *testContext, name string, projectName string, orgName string) error {
+	t.Helper()
+	project := findOrganisationProjectCalled(t, ctx, name, orgName, projectName)
+	require.NotNil(t, project, "person %s should see a project called %s in %s", name, projectName, orgName)
+	return ctx.driver.DeleteOrganisationProject(name, orgName, project.ID())
+}
+
+// findOrganisationProjectCalled finds one of an organisation's projects by name, as one
+// of its members would, or returns nil if there is none
+func findOrganisationProjectCalled(t *testing.T, ctx *testContext, name string, orgName string, projectName string) *entities.Project {
+	t.Helper()
+	projects, err := ctx.driver.GetOrganisationProjects(name, orgName)
+	require.NoError(t, err)
+	for _, project := range projects {
+		if project.Name() == projectName {
+			return &project
+		}
+	}
+	return nil
+}
+
+// roleIn returns the role a person has in the organisation, or "" if they do not belong to it
+func roleIn(t *testing.T, ctx *testContext, name string, orgName string) entities.OrgRole {
+	t.Helper()
+	memberships, err := ctx.driver.GetOrganisations(name)
+	require.NoError(t, err)
+	for _, membership := range memberships {
+		if membership.Organisation == orgName {
+			return membership.Role
+		}
+	}
+	return ""
+}
+
+// findInvitationTo finds a person's unanswered invitation to share the project with the
+// given name, or returns nil if there is none
+func findInvitationTo(t *testing.T, ctx *testContext, name string, projectName string) *entities.Invitation {
+	t.Helper()
+	invitations, err := ctx.driver.GetInvitations(name)
+	require.NoError(t, err)
+	for _, invitation := range invitations {
+		if invitation.ProjectName == projectName {
+			return &invitation
+		}
+	}
+	return nil
+}
@@ -3,23 +3,110 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/sirockin/cucumber-screenplay-go/back-end/internal/domain/application"
 	httpserver "github.com/sirockin/cucumber-screenplay-go/back-end/internal/http"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/clock/manual"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events/eventlog"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events/feed"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/fixtures"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/notifier/logger"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/notifier/outbox"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/repository"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/repository/file"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/repository/memory"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/webhooks"
 )
 
 func main() {
 	port := flag.Int("port", 8080, "port to run server on")
+	dataDir := flag.String("data-dir", "", "directory to persist data in (default: keep data in memory only)")
+	testMode := flag.Bool("test-mode", false, "read activation links and other messages through GET /outbox/{name}, use a clock that only moves when advanced through POST /clock/advance, keep events to read through GET /events/{name}, and take and restore snapshots of all data through /admin/snapshot, give each namespace named in the X-Test-Namespace header a data set of its own, and post to webhooks on this machine or its network")
+	seed := flag.String("seed", "", "YAML or JSON file of accounts and projects to add when the server starts")
 	flag.Parse()
 
-	// Create domain application service
-	appService := application.New()
+	if *testMode {
+		log.Printf("Running in test mode")
+	}
 
-	// Create HTTP server wrapping the service
-	httpServer := httpserver.NewServer(appService)
+	// Keep data in memory unless told where to persist it. Data kept by an earlier run
+	// has already been seeded.
+	repositories := memory.NewRepositories()
+	isNew := true
+	if *dataDir != "" {
+		isNew = !hasData(*dataDir)
+		store, err := file.Open(*dataDir)
+		if err != nil {
+			log.Fatalf("Failed to open data directory: %v", err)
+		}
+		repositories = store.Repositories()
+		log.Printf("Persisting data in %s", *dataDir)
+	}
+
+	var seedFixtures fixtures.Fixtures
+	if *seed != "" {
+		var err error
+		seedFixtures, err = fixtures.Load(*seed)
+		if err != nil {
+			log.Fatalf("Failed to load fixtures:\n%v", err)
+		}
+	}
+
+	var serverOptions []httpserver.Option
+	if *testMode {
+		// Give each namespace that tests name a data set of its own, kept alongside the
+		// rest of the data, and seeded when it is first opened as the server is
+		serverOptions = append(serverOptions, httpserver.WithNamespaces(
+			func(namespace string) (*httpserver.Server, io.Closer, error) {
+				if *dataDir == "" {
+					namespaceService, namespaceServer, bus := newServer(memory.NewRepositories(), true, namespace)
+					closer := closeFunc(func() error {
+						bus.Close()
+						return nil
+					})
+					return seedNamespace(namespaceService, namespaceServer, closer, seedFixtures)
+				}
+				dir := namespaceDir(*dataDir, namespace)
+				isNew := !hasData(dir)
+				store, err := file.Open(dir)
+				if err != nil {
+					return nil, nil, err
+				}
+				namespaceService, namespaceServer, bus := newServer(store.Repositories(), true, namespace)
+				closer := closeFunc(func() error {
+					bus.Close()
+					return store.Close()
+				})
+				if !isNew {
+					return namespaceServer, closer, nil
+				}
+				return seedNamespace(namespaceService, namespaceServer, closer, seedFixtures)
+			},
+			func(namespace string) error {
+				if *dataDir == "" {
+					return nil
+				}
+				return os.RemoveAll(namespaceDir(*dataDir, namespace))
+			},
+		))
+	}
+	appService, httpServer, _ := newServer(repositories, *testMode, "", serverOptions...)
+
+	if *seed != "" && isNew {
+		if err := appService.Seed(seedFixtures); err != nil {
+			log.Fatalf("Failed to seed from %s: %v", *seed, err)
+		}
+		log.Printf("Seeded %d accounts from %s", len(seedFixtures.Accounts), *seed)
+	} else if *seed != "" {
+		log.Printf("Not seeding from %s, as %s already holds data", *seed, *dataDir)
+	}
 
 	// Start server
 	addr := fmt.Sprintf(":%d", *port)
@@ -27,12 +114,50 @@ func main() {
 	log.Printf("API endpoints:")
 	log.Printf("  POST   /accounts")
 	log.Printf("  GET    /accounts/{name}")
+	log.Printf("  DELETE /accounts/{name}")
+	log.Printf("  GET    /accounts/{name}/export")
+	log.Printf("  PUT    /accounts/{name}/plan")
 	log.Printf("  POST   /accounts/{name}/activate")
 	log.Printf("  POST   /accounts/{name}/authenticate")
 	log.Printf("  GET    /accounts/{name}/authentication-status")
+	log.Printf("  GET    /accounts/{name}/events")
 	log.Printf("  GET    /accounts/{name}/projects")
 	log.Printf("  POST   /accounts/{name}/projects")
-	log.Printf("  DELETE /clear")
+	log.Printf("  GET    /accounts/{name}/projects/{id}")
+	log.Printf("  PATCH  /accounts/{name}/projects/{id}")
+	log.Printf("  DELETE /accounts/{name}/projects/{id}")
+	log.Printf("  POST   /accounts/{name}/projects/{id}/archive")
+	log.Printf("  POST   /accounts/{name}/projects/{id}/restore")
+	log.Printf("  POST   /accounts/{name}/projects/{id}/invitations")
+	log.Printf("  GET    /accounts/{name}/invitations")
+	log.Printf("  POST   /accounts/{name}/invitations/{projectId}/accept")
+	log.Printf("  POST   /accounts/{name}/invitations/{projectId}/decline")
+	log.Printf("  GET    /orgs")
+	log.Printf("  POST   /orgs")
+	log.Printf("  GET    /orgs/{org}")
+	log.Printf("  DELETE /orgs/{org}")
+	log.Printf("  GET    /orgs/{org}/members")
+	log.Printf("  PUT    /orgs/{org}/members/{account}")
+	log.Printf("  DELETE /orgs/{org}/members/{account}")
+	log.Printf("  GET    /orgs/{org}/projects")
+	log.Printf("  POST   /orgs/{org}/projects")
+	log.Printf("  GET    /orgs/{org}/projects/{id}")
+	log.Printf("  PATCH  /orgs/{org}/projects/{id}")
+	log.Printf("  DELETE /orgs/{org}/projects/{id}")
+	log.Printf("  GET    /accounts/{name}/webhooks")
+	log.Printf("  POST   /accounts/{name}/webhooks")
+	log.Printf("  GET    /accounts/{name}/webhooks/{id}")
+	log.Printf("  DELETE /accounts/{name}/webhooks/{id}")
+	log.Printf("  GET    /accounts/{name}/webhooks/{id}/deliveries")
+	log.Printf("  POST   /sessions/current/sign-out")
+	if *testMode {
+		log.Printf("  DELETE /clear")
+		log.Printf("  GET    /outbox/{name}")
+		log.Printf("  POST   /clock/advance")
+		log.Printf("  GET    /events/{name}")
+		log.Printf("  GET    /admin/snapshot")
+		log.Printf("  PUT    /admin/snapshot")
+	}
 
 	server := &http.Server{
 		Addr:         addr,
@@ -45,3 +170,81 @@ func main() {
 		log.Fatalf("Server failed: %v", err)
 	}
 }
+
+// hasData reports whether dir holds anything, such as data kept there by an earlier run
+func hasData(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	return err == nil && len(entries) > 0
+}
+
+// namespaceDir is the directory a namespace's data is kept in, within the data directory
+func namespaceDir(dataDir string, namespace string) string {
+	return filepath.Join(dataDir, "namespaces", namespace)
+}
+
+// seedNamespace seeds a namespace that has just been opened, closing it again if
+// seeding fails
+func seedNamespace(appService *application.Service, server *httpserver.Server, closer io.Closer, f fixtures.Fixtures) (*httpserver.Server, io.Closer, error) {
+	if err := appService.Seed(f); err != nil {
+		server.Close()
+		_ = closer.Close()
+		return nil, nil, err
+	}
+	return server, closer, nil
+}
+
+// closeFunc lets a function be returned as an io.Closer
+type closeFunc func() error
+
+func (f closeFunc) Close() error {
+	return f()
+}
+
+// newServer assembles a domain that stores its data in repositories, and a server for
+// it, along with the bus that carries its events, to be closed once the server is.
+// Events are logged along with the namespace they happened in, if any.
+func newServer(repositories repository.Repositories, testMode bool, namespace string, serverOptions ...httpserver.Option) (*application.Service, *httpserver.Server, *events.Bus) {
+	// Log events without holding up the requests that caused them
+	bus := events.NewBus()
+	bus.SubscribeBuffered(func(event events.Event) {
+		if namespace != "" {
+			log.Printf("Event in %s: %s %s", namespace, event.Kind, event.Account)
+			return
+		}
+		log.Printf("Event: %s %s", event.Kind, event.Account)
+	}, 100)
+	// Keep recent events for account holders to follow, and catch up on after reconnecting
+	recent := feed.New(1000)
+	bus.Subscribe(recent.Record)
+	options := []application.Option{application.WithPublisher(bus)}
+	serverOptions = append(serverOptions, httpserver.WithEventFeed(recent))
+	var webhookOptions []webhooks.Option
+	if !testMode {
+		// There is no email to send messages by, so log them, activation links and all,
+		// for whoever runs the server to pass on
+		options = append(options, application.WithNotifier(logger.New(log.Default())))
+	} else {
+		// Messages are kept in an outbox rather than emailed, to be read back through
+		// /outbox
+		messages := outbox.New()
+		testClock := manual.New(time.Now())
+		options = append(options, application.WithNotifier(messages), application.WithClock(testClock))
+		// Tests receive webhooks on the same machine
+		webhookOptions = append(webhookOptions, webhooks.WithClock(testClock), webhooks.WithPrivateDestinations())
+		serverOptions = append(serverOptions, httpserver.WithTestClock(testClock), httpserver.WithOutbox(messages), httpserver.WithClearing())
+		published := eventlog.New()
+		bus.Subscribe(published.Record)
+		serverOptions = append(serverOptions, httpserver.WithEventLog(published))
+	}
+
+	// Create domain application service
+	appService := application.NewWithRepositories(repositories, options...)
+
+	// Post events to the webhooks account holders register, retrying deliveries that fail
+	deliveries := webhooks.New(appService.WebhooksFor, webhookOptions...)
+	bus.Subscribe(deliveries.Record)
+	serverOptions = append(serverOptions, httpserver.WithWebhookDeliveries(deliveries))
+
+	// Create HTTP server wrapping the service
+	return appService, httpserver.NewServer(appService, serverOptions...), bus
+}
@@ -1,54 +1,247 @@
 package application
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"slices"
+	"sync"
+	"time"
 
+	"github.com/sirockin/cucumber-screenplay-go/back-end/internal/domain/passwords"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/clock"
 	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/notifier"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/repository"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/repository/memory"
 )
 
-// Service provides business operations for the application
+const (
+	// sessionLifetime is how long a session is accepted for after it starts
+	sessionLifetime = 24 * time.Hour
+	// activationPeriod is how long an account holder has to follow their activation
+	// link. After that the account expires, and its name can be signed up for again.
+	activationPeriod = 7 * 24 * time.Hour
+)
+
+// SessionToken is given to a client when a session starts. The client presents Value
+// to act as the account until ExpiresAt, or until it signs out.
+type SessionToken struct {
+	Value     string
+	ExpiresAt time.Time
+}
+
+// Service provides business operations for the application.
+// It is safe for concurrent use.
 type Service struct {
-	accounts map[string]*entities.Account
-	projects map[entities.Account][]entities.Project
+	mu            sync.RWMutex
+	accounts      repository.AccountRepository
+	projects      repository.ProjectRepository
+	sessions      repository.SessionRepository
+	webhooks      repository.WebhookRepository
+	organisations repository.OrganisationRepository
+	notifier      notifier.Notifier
+	events        events.Publisher
+	hasher        passwords.Hasher
+	clock         clock.Clock
+	lockout       LockoutPolicy
+	quotas        Quotas
+}
+
+// Option configures optional dependencies of a Service
+type Option func(*Service)
+
+// WithNotifier sends messages to account holders through n. Without it, messages
+// are discarded, so accounts cannot be activated.
+func WithNotifier(n notifier.Notifier) Option {
+	return func(d *Service) {
+		d.notifier = n
+	}
+}
+
+// WithPublisher publishes what happens, such as accounts being created, through p.
+// Events are published once the change has been made and the service is ready for
+// more calls, so subscribers may call back into it.
+func WithPublisher(p events.Publisher) Option {
+	return func(d *Service) {
+		d.events = p
+	}
+}
+
+// WithPasswordHasher hashes new passwords with h instead of passwords.Default
+func WithPasswordHasher(h passwords.Hasher) Option {
+	return func(d *Service) {
+		d.hasher = h
+	}
+}
+
+// WithClock tells the time with c instead of clock.System, so that tests can make it pass
+func WithClock(c clock.Clock) Option {
+	return func(d *Service) {
+		d.clock = c
+	}
+}
+
+// New creates a new service with in-memory storage
+func New(options ...Option) *Service {
+	return NewWithRepositories(memory.NewRepositories(), options...)
 }
 
-// New creates a new service
-func New() *Service {
-	d := &Service{}
-	d.ClearAll()
+// NewWithRepositories creates a new service that stores its data in the given repositories
+func NewWithRepositories(repositories repository.Repositories, options ...Option) *Service {
+	d := &Service{
+		accounts:      repositories.Accounts,
+		projects:      repositories.Projects,
+		sessions:      repositories.Sessions,
+		webhooks:      repositories.Webhooks,
+		organisations: repositories.Organisations,
+		notifier:      notifier.Discard,
+		events:        events.Discard,
+		hasher:        passwords.Default,
+		clock:         clock.System,
+		lockout:       DefaultLockoutPolicy,
+		quotas:        DefaultQuotas,
+	}
+	for _, option := range options {
+		option(d)
+	}
 	return d
 }
 
 // ClearAll removes all data
-func (d *Service) ClearAll() {
-	d.accounts = make(map[string]*entities.Account)
-	d.projects = make(map[entities.Account][]entities.Project)
+func (d *Service) ClearAll() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.clearAll()
+}
+
+// clearAll removes all data. The caller must hold the write lock.
+func (d *Service) clearAll() error {
+	if err := d.sessions.Clear(); err != nil {
+		return err
+	}
+	if err := d.webhooks.Clear(); err != nil {
+		return err
+	}
+	if err := d.organisations.Clear(); err != nil {
+		return err
+	}
+	if err := d.projects.Clear(); err != nil {
+		return err
+	}
+	return d.accounts.Clear()
 }
 
-// CreateAccount creates a new account
-func (d *Service) CreateAccount(name string) error {
-	d.accounts[name] = entities.NewAccount(name)
+// CreateAccount creates a new account protected by password, refusing names that are
+// already taken and passwords that are too weak, and sends the account holder a link
+// to activate it. The name of an account that expired before it was activated is free
+// to be taken again. Any webhooks given are registered along with the account, so that
+// they are told when it is activated.
+func (d *Service) CreateAccount(name string, password string, webhooks ...WebhookTarget) error {
+	if err := d.createAccount(name, password, webhooks); err != nil {
+		return err
+	}
+	d.publish(events.Event{Kind: events.AccountCreated, Account: name})
+	return nil
+}
+
+func (d *Service) createAccount(name string, password string, webhooks []WebhookTarget) error {
+	if err := passwords.CheckStrength(name, password); err != nil {
+		return err
+	}
+	for _, target := range webhooks {
+		if err := checkWebhookTarget(target); err != nil {
+			return err
+		}
+	}
+	// Hashing is slow, so do it before taking the lock
+	hash, err := d.hasher.Hash(password)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	id, err := newID()
+	if err != nil {
+		return err
+	}
+	token, err := newID()
+	if err != nil {
+		return err
+	}
+	account := entities.NewAccount(id, name)
+	account.SetActivationToken(token)
+	account.SetPasswordHash(hash)
+	account.SetCreatedAt(d.clock.Now().UTC())
+	if err := d.addAccount(*account); err != nil {
+		return err
+	}
+	for _, target := range webhooks {
+		if _, err := d.addWebhook(*account, target); err != nil {
+			return err
+		}
+	}
+	return d.sendActivationLink(name, token)
+}
+
+// sendActivationLink sends the holder of a new account the link to activate it with
+func (d *Service) sendActivationLink(name string, token string) error {
+	if err := d.notifier.Send(notifier.Message{
+		To:      name,
+		Subject: "Activate your account",
+		Body:    "Follow the link to confirm your email and activate your account.",
+		Link:    notifier.ActivationLink(name, token),
+	}); err != nil {
+		return fmt.Errorf("failed to send activation link to %s: %w", name, err)
+	}
 	return nil
 }
 
 // GetAccount retrieves an account by name
 func (d *Service) GetAccount(name string) (entities.Account, error) {
-	account, exists := d.accounts[name]
-	if !exists {
-		return entities.Account{}, fmt.Errorf("Account not found: %s", name)
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.accounts.Get(name)
+}
+
+// Activate activates an account and also signs in the client that activated it. The
+// token must be the one sent to the account holder, and can only be used once and
+// before the account expires.
+func (d *Service) Activate(name string, token string) (SessionToken, error) {
+	session, err := d.activate(name, token)
+	if err != nil {
+		return SessionToken{}, err
 	}
-	return *account, nil
+	d.publish(events.Event{Kind: events.AccountActivated, Account: name})
+	return session, nil
 }
 
-// Activate activates an account and also authenticates the user
-func (d *Service) Activate(name string) error {
-	account := d.accounts[name]
-	if account == nil {
-		return fmt.Errorf("account not found: %s", name)
+func (d *Service) activate(name string, token string) (SessionToken, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	account, err := d.accounts.Get(name)
+	if err != nil {
+		return SessionToken{}, err
+	}
+	expected := account.ActivationToken()
+	if expected == "" || subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+		return SessionToken{}, fmt.Errorf("%w: %s", entities.ErrInvalidActivation, name)
 	}
+	if d.hasExpired(account) {
+		return SessionToken{}, fmt.Errorf("%w: %s", entities.ErrActivationExpired, name)
+	}
+	account.SetActivationToken("")
 	account.SetActivated(true)
-	account.SetAuthenticated(true) // Activation also authenticates the user
-	return nil
+	if err := d.accounts.Update(account); err != nil {
+		return SessionToken{}, err
+	}
+	return d.startSession(account)
 }
 
 // IsActivated checks if an account is activated
@@ -60,43 +253,402 @@ func (d *Service) IsActivated(name string) bool {
 	return account.IsActivated()
 }
 
-// Authenticate authenticates an account (requires activation first)
-func (d *Service) Authenticate(name string) error {
-	account := d.accounts[name]
-	if account == nil {
-		return fmt.Errorf("account not found: %s", name)
+// Authenticate signs a client in to an account with its password (requires activation
+// first), starting a new session. Each client has its own session, so signing in does
+// not affect any other client. Missing and closed accounts are reported as wrong
+// credentials, so that which names are taken, and which accounts have been closed, is
+// not revealed. Too many failed sign-ins lock the account for a while, as set by the
+// LockoutPolicy.
+func (d *Service) Authenticate(name string, password string) (SessionToken, error) {
+	session, err := d.authenticate(name, password)
+	if err != nil {
+		return SessionToken{}, err
+	}
+	d.publish(events.Event{Kind: events.Authenticated, Account: name})
+	return session, nil
+}
+
+func (d *Service) authenticate(name string, password string) (SessionToken, error) {
+	account, err := d.GetAccount(name)
+	if err != nil && !errors.Is(err, entities.ErrAccountNotFound) {
+		return SessionToken{}, err
+	}
+	// A closed account has no password left to verify, so take as long over it as
+	// over a missing one
+	if err != nil || account.IsClosed() {
+		d.hasher.VerifyNothing(password)
+		return SessionToken{}, fmt.Errorf("%w: %s", entities.ErrWrongCredentials, name)
+	}
+	if account.IsLocked(d.clock.Now()) {
+		return SessionToken{}, fmt.Errorf("%w: %s", entities.ErrAccountLocked, name)
+	}
+	// Hashing is slow, so verify without holding the lock
+	ok, err := passwords.Verify(password, account.PasswordHash())
+	if err != nil {
+		return SessionToken{}, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !ok {
+		locked, err := d.recordFailedSignIn(name)
+		if err != nil {
+			return SessionToken{}, err
+		}
+		if locked {
+			return SessionToken{}, fmt.Errorf("%w: %s", entities.ErrAccountLocked, name)
+		}
+		return SessionToken{}, fmt.Errorf("%w: %s", entities.ErrWrongCredentials, name)
+	}
+	// Other attempts may have failed, or the account been closed, while the password
+	// was being verified
+	account, err = d.accounts.Get(name)
+	if err != nil {
+		return SessionToken{}, err
+	}
+	if account.IsClosed() {
+		return SessionToken{}, fmt.Errorf("%w: %s", entities.ErrWrongCredentials, name)
 	}
 	if !account.IsActivated() {
-		return fmt.Errorf("%s, you need to activate your account", name)
+		return SessionToken{}, fmt.Errorf("%s, %w", name, entities.ErrAccountNotActivated)
 	}
-	account.SetAuthenticated(true)
-	return nil
+	if account.IsLocked(d.clock.Now()) {
+		return SessionToken{}, fmt.Errorf("%w: %s", entities.ErrAccountLocked, name)
+	}
+	if err := d.clearFailedSignIns(account); err != nil {
+		return SessionToken{}, err
+	}
+	return d.startSession(account)
 }
 
-// IsAuthenticated checks if an account is authenticated
-func (d *Service) IsAuthenticated(name string) bool {
-	account, err := d.GetAccount(name)
+// IsAuthenticated checks if the session with the given token is signed in to the named account
+func (d *Service) IsAuthenticated(name string, token string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	account, err := d.accounts.Get(name)
+	if err != nil {
+		return false
+	}
+	session, err := d.session(token)
 	if err != nil {
 		return false
 	}
-	return account.IsAuthenticated()
+	return session.AccountID() == account.ID()
 }
 
-// GetProjects retrieves projects for an account
-func (d *Service) GetProjects(name string) ([]entities.Project, error) {
-	account, err := d.GetAccount(name)
+// SignOut ends the session with the given token. Other sessions for the same
+// account are not affected.
+func (d *Service) SignOut(token string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, err := d.session(token); err != nil {
+		return err
+	}
+	return d.sessions.Delete(sessionID(token))
+}
+
+// Authorize checks that the session with the given token is signed in to the named
+// account, as it must be to act for the account holder or follow the account's events
+func (d *Service) Authorize(token string, name string) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	_, err := d.authorize(token, name)
+	return err
+}
+
+// GetProjects retrieves the projects an account owns, followed by those shared with it,
+// leaving out archived ones. Only the account holder may see them, so token must be for
+// one of their sessions.
+func (d *Service) GetProjects(token string, name string) ([]entities.Project, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	account, err := d.authorize(token, name)
 	if err != nil {
 		return nil, err
 	}
-	return d.projects[account], nil
+	projects, err := d.visibleProjects(account.ID())
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(projects, func(p entities.Project) bool { return p.IsArchived() }), nil
 }
 
-// CreateProject creates a project for an account
-func (d *Service) CreateProject(name string) error {
-	account, err := d.GetAccount(name)
+// CreateProject creates a named project for an account, on behalf of the account
+// holder signed in with token
+func (d *Service) CreateProject(token string, name string, projectName string) (entities.Project, error) {
+	project, err := d.createProject(token, name, projectName)
 	if err != nil {
+		return entities.Project{}, err
+	}
+	d.publish(events.Event{Kind: events.ProjectCreated, Account: name, ProjectID: project.ID()})
+	return project, nil
+}
+
+func (d *Service) createProject(token string, name string, projectName string) (entities.Project, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	account, err := d.authorize(token, name)
+	if err != nil {
+		return entities.Project{}, err
+	}
+	if err := d.checkProjectQuota(account); err != nil {
+		return entities.Project{}, err
+	}
+	project, err := d.newProject(projectName, account.ID())
+	if err != nil {
+		return entities.Project{}, err
+	}
+	if err := d.projects.Add(project); err != nil {
+		return entities.Project{}, err
+	}
+	return project, nil
+}
+
+// newProject makes a project created now, numbered after every project there has been
+func (d *Service) newProject(projectName string, ownerID string) (entities.Project, error) {
+	id, err := newID()
+	if err != nil {
+		return entities.Project{}, err
+	}
+	sequence, err := d.projects.NextSequence()
+	if err != nil {
+		return entities.Project{}, err
+	}
+	project := entities.NewProject(id, projectName, ownerID, d.clock.Now().UTC())
+	project.SetSequence(sequence)
+	return *project, nil
+}
+
+// GetProject retrieves a project that an account owns or shares, on behalf of the
+// account holder signed in with token
+func (d *Service) GetProject(token string, name string, projectID string) (entities.Project, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.projectFor(token, name, projectID, entities.RoleOwner, entities.RoleEditor, entities.RoleViewer)
+}
+
+// RenameProject changes the name of a project that an account owns or shares as an
+// editor, on behalf of the account holder signed in with token. Archived projects
+// cannot be renamed.
+func (d *Service) RenameProject(token string, name string, projectID string, projectName string) (entities.Project, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	project, err := d.projectFor(token, name, projectID, entities.RoleOwner, entities.RoleEditor)
+	if err != nil {
+		return entities.Project{}, err
+	}
+	if project.IsArchived() {
+		return entities.Project{}, fmt.Errorf("%w: %s", entities.ErrProjectArchived, projectID)
+	}
+	project.SetName(projectName)
+	if err := d.projects.Update(project); err != nil {
+		return entities.Project{}, err
+	}
+	return project, nil
+}
+
+// DeleteProject deletes one of an account's projects for good, whether it is active or
+// archived, on behalf of the account holder signed in with token. Members the project is
+// shared with may not delete it.
+func (d *Service) DeleteProject(token string, name string, projectID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, err := d.projectFor(token, name, projectID, entities.RoleOwner); err != nil {
 		return err
 	}
-	d.projects[account] = append(d.projects[account], entities.Project{})
+	return d.projects.Delete(projectID)
+}
+
+// authorize returns the named account if the session with the given token is signed in
+// to it. Callers who are not signed in get entities.ErrNotSignedIn, and those signed in
+// to another account get entities.ErrAccessDenied. The caller must hold the lock.
+func (d *Service) authorize(token string, name string) (entities.Account, error) {
+	session, err := d.session(token)
+	if err != nil {
+		return entities.Account{}, err
+	}
+	account, err := d.accounts.Get(name)
+	if err != nil {
+		return entities.Account{}, err
+	}
+	if session.AccountID() != account.ID() {
+		return entities.Account{}, fmt.Errorf("%w to the account of %s", entities.ErrAccessDenied, name)
+	}
+	return account, nil
+}
+
+// signedInAccount returns the account that the session with the given token is signed
+// in to, for requests that do not name the account they act for. The caller must hold
+// the lock.
+func (d *Service) signedInAccount(token string) (entities.Account, error) {
+	session, err := d.session(token)
+	if err != nil {
+		return entities.Account{}, err
+	}
+	accounts, err := d.accounts.List()
+	if err != nil {
+		return entities.Account{}, err
+	}
+	i := slices.IndexFunc(accounts, func(a entities.Account) bool { return a.ID() == session.AccountID() })
+	if i < 0 {
+		return entities.Account{}, entities.ErrNotSignedIn
+	}
+	return accounts[i], nil
+}
+
+// accountNames returns the names of all accounts, keyed by ID. The caller must hold the lock.
+func (d *Service) accountNames() (map[string]string, error) {
+	accounts, err := d.accounts.List()
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(accounts))
+	for _, account := range accounts {
+		names[account.ID()] = account.Name()
+	}
+	return names, nil
+}
+
+// projectFor returns the project if the session with the given token is signed in to
+// the named account, and the account has one of the roles in the project. Projects the
+// account has no access to are reported as not found, so their existence is not revealed,
+// while those it has no role for are refused with entities.ErrAccessDenied. The caller
+// must hold the lock.
+func (d *Service) projectFor(token string, name string, projectID string, roles ...entities.Role) (entities.Project, error) {
+	account, err := d.authorize(token, name)
+	if err != nil {
+		return entities.Project{}, err
+	}
+	project, err := d.projects.Get(projectID)
+	if err != nil {
+		return entities.Project{}, err
+	}
+	role := project.RoleOf(account.ID())
+	if role == "" {
+		return entities.Project{}, fmt.Errorf("%w: %s", entities.ErrProjectNotFound, projectID)
+	}
+	if !slices.Contains(roles, role) {
+		return entities.Project{}, fmt.Errorf("%w to do that as %s of project %s", entities.ErrAccessDenied, role, projectID)
+	}
+	return project, nil
+}
+
+// publish publishes the event, stamped with the time it happened. The caller must not
+// hold the lock, so that subscribers can call back into the service.
+func (d *Service) publish(event events.Event) {
+	event.OccurredAt = d.clock.Now().UTC()
+	d.events.Publish(event)
+}
+
+// addAccount stores a new account, replacing an account with the same name if it has
+// expired. The caller must hold the write lock.
+func (d *Service) addAccount(account entities.Account) error {
+	existing, err := d.accounts.Get(account.Name())
+	if errors.Is(err, entities.ErrAccountNotFound) {
+		return d.accounts.Add(account)
+	}
+	if err != nil {
+		return err
+	}
+	if !d.hasExpired(existing) {
+		return fmt.Errorf("%w: %s", entities.ErrAccountExists, account.Name())
+	}
+	// An account that was never activated has no sessions or projects to leave behind,
+	// but may have had webhooks registered along with it
+	if err := d.deleteWebhooks(existing); err != nil {
+		return err
+	}
+	return d.accounts.Update(account)
+}
+
+// hasExpired reports whether the account was not activated in time
+func (d *Service) hasExpired(account entities.Account) bool {
+	if account.IsActivated() {
+		return false
+	}
+	return !d.clock.Now().Before(account.CreatedAt().Add(activationPeriod))
+}
+
+// startSession starts a new session for the account. The caller must hold the write lock.
+func (d *Service) startSession(account entities.Account) (SessionToken, error) {
+	if err := d.sweepSessions(); err != nil {
+		return SessionToken{}, err
+	}
+	token, err := newToken()
+	if err != nil {
+		return SessionToken{}, err
+	}
+	expiresAt := d.clock.Now().UTC().Add(sessionLifetime)
+	if err := d.sessions.Add(*entities.NewSession(sessionID(token), account.ID(), expiresAt)); err != nil {
+		return SessionToken{}, err
+	}
+	return SessionToken{Value: token, ExpiresAt: expiresAt}, nil
+}
+
+// sweepSessions deletes every session that has expired, so that sessions do not pile up
+// in storage. The caller must hold the write lock.
+func (d *Service) sweepSessions() error {
+	sessions, err := d.sessions.List()
+	if err != nil {
+		return err
+	}
+	now := d.clock.Now()
+	for _, session := range sessions {
+		if !session.IsExpired(now) {
+			continue
+		}
+		if err := d.sessions.Delete(session.ID()); err != nil && !errors.Is(err, entities.ErrSessionNotFound) {
+			return err
+		}
+	}
 	return nil
 }
+
+// session returns the unexpired session with the given token, or entities.ErrNotSignedIn.
+// A session found to have expired is deleted. That is safe under the read lock, as
+// nothing relies on expired sessions, and another reader deleting it first does no harm.
+// The caller must hold the lock.
+func (d *Service) session(token string) (entities.Session, error) {
+	if token == "" {
+		return entities.Session{}, entities.ErrNotSignedIn
+	}
+	session, err := d.sessions.Get(sessionID(token))
+	if errors.Is(err, entities.ErrSessionNotFound) {
+		return entities.Session{}, entities.ErrNotSignedIn
+	}
+	if err != nil {
+		return entities.Session{}, err
+	}
+	if session.IsExpired(d.clock.Now()) {
+		if err := d.sessions.Delete(session.ID()); err != nil && !errors.Is(err, entities.ErrSessionNotFound) {
+			return entities.Session{}, err
+		}
+		return entities.Session{}, entities.ErrNotSignedIn
+	}
+	return session, nil
+}
+
+// sessionID derives the ID a session is stored under from its token, so that
+// stored sessions cannot be used to sign in
+func sessionID(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// newToken generates a random, unguessable session token
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// newID generates a random identifier
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,107 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+)
+
+// CloseAccount closes an account for good, on behalf of the account holder signed in
+// with token. Its projects are deleted, it stops sharing other accounts' projects and
+// belonging to organisations, its webhooks are removed and all of its sessions are ended,
+// so that nothing but its name is left. The name stays taken, so that no one else can
+// sign up as the account holder, and signing in to it is refused with
+// entities.ErrWrongCredentials, as if it did not exist. An account that is the last
+// owner of an organisation may not be closed until it has made someone else an owner or
+// deleted the organisation, which is refused with entities.ErrLastOwner.
+func (d *Service) CloseAccount(token string, name string) error {
+	if err := d.closeAccount(token, name); err != nil {
+		return err
+	}
+	d.publish(events.Event{Kind: events.AccountClosed, Account: name})
+	return nil
+}
+
+func (d *Service) closeAccount(token string, name string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	account, err := d.authorize(token, name)
+	if err != nil {
+		return err
+	}
+	organisations, err := d.organisations.List()
+	if err != nil {
+		return err
+	}
+	for _, organisation := range organisations {
+		if organisation.RoleOf(account.ID()) == entities.OrgRoleOwner && organisation.Owners() == 1 {
+			return fmt.Errorf("%w: %s is the last owner of %s", entities.ErrLastOwner, name, organisation.Name())
+		}
+	}
+
+	for _, organisation := range organisations {
+		if organisation.RoleOf(account.ID()) == "" {
+			continue
+		}
+		organisation.RemoveMember(account.ID())
+		if err := d.organisations.Update(organisation); err != nil {
+			return err
+		}
+	}
+	if err := d.deleteProjects(account); err != nil {
+		return err
+	}
+	if err := d.deleteWebhooks(account); err != nil {
+		return err
+	}
+	if err := d.endSessions(account); err != nil {
+		return err
+	}
+	account.SetPasswordHash("")
+	account.SetActivationToken("")
+	account.SetClosedAt(d.clock.Now().UTC())
+	return d.accounts.Update(account)
+}
+
+// deleteProjects deletes the account's own projects, and removes it from those it shares
+// or has been invited to share. The caller must hold the write lock.
+func (d *Service) deleteProjects(account entities.Account) error {
+	projects, err := d.projects.List()
+	if err != nil {
+		return err
+	}
+	for _, project := range projects {
+		if project.OwnerID() == account.ID() {
+			if err := d.projects.Delete(project.ID()); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, ok := project.Member(account.ID()); ok {
+			project.RemoveMember(account.ID())
+			if err := d.projects.Update(project); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// endSessions ends every session signed in to the account, on every client. The caller
+// must hold the write lock.
+func (d *Service) endSessions(account entities.Account) error {
+	sessions, err := d.sessions.List()
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if session.AccountID() != account.ID() {
+			continue
+		}
+		if err := d.sessions.Delete(session.ID()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
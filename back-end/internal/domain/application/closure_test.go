@@ -0,0 +1,114 @@
+package application_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+func TestCloseAccount(t *testing.T) {
+	t.Run("RemovesProjectsAndSessions", func(t *testing.T) {
+		service := newServiceWithProjects(t, "Roadmap", "Budget")
+		bob := service.signIn(t, "Bob")
+		roadmap := listProjects(t, service, entities.ProjectQuery{}).Projects[0]
+		service.share(t, bob, "Bob", roadmap.ID(), entities.RoleEditor)
+		launch := service.shareWithSue(t, bob, "Bob", "Launch")
+		other, err := service.Authenticate("Sue", testPassword)
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+
+		if err := service.CloseAccount(service.token, "Sue"); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		if service.IsAuthenticated("Sue", service.token) || service.IsAuthenticated("Sue", other.Value) {
+			t.Errorf("expected every session to have ended")
+		}
+		expectVisibleProjects(t, service, bob, "Bob", "Launch")
+		project, err := service.GetProject(bob, "Bob", launch.ID())
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		if members := project.Members(); len(members) != 0 {
+			t.Errorf("expected Sue to stop sharing Bob's project but got %+v", members)
+		}
+		account, err := service.GetAccount("Sue")
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		if !account.IsClosed() || account.PasswordHash() != "" {
+			t.Errorf("expected the account to be closed with nothing left to sign in with but got %+v", account)
+		}
+	})
+
+	t.Run("RefusesSignInsAndKeepsTheNameTaken", func(t *testing.T) {
+		service := newServiceWithProjects(t)
+		bob := service.signIn(t, "Bob")
+		launch, err := service.CreateProject(bob, "Bob", "Launch")
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		if err := service.CloseAccount(service.token, "Sue"); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+
+		for _, password := range []string{testPassword, "wrong-password-1"} {
+			if _, err := service.Authenticate("Sue", password); !errors.Is(err, entities.ErrWrongCredentials) {
+				t.Errorf("expected signing in with %q to be refused as wrong credentials but got %v", password, err)
+			}
+		}
+		if err := service.CreateAccount("Sue", testPassword); !errors.Is(err, entities.ErrAccountExists) {
+			t.Errorf("expected the name to stay taken but got %v", err)
+		}
+		if err := service.InviteToProject(bob, "Bob", launch.ID(), "Sue", entities.RoleViewer); !errors.Is(err, entities.ErrAccountNotFound) {
+			t.Errorf("expected a closed account not to be invited but got %v", err)
+		}
+		if err := service.CloseAccount(service.token, "Sue"); !errors.Is(err, entities.ErrNotSignedIn) {
+			t.Errorf("expected closing again to need a session but got %v", err)
+		}
+	})
+
+	t.Run("LeavesOrganisationsOnceAnotherOwnerIsMade", func(t *testing.T) {
+		service := newServiceWithProjects(t)
+		service.createOrganisation(t, "Acme")
+		bob := service.signIn(t, "Bob")
+		service.join(t, "Acme", "Bob", entities.OrgRoleMember)
+
+		if err := service.CloseAccount(service.token, "Sue"); !errors.Is(err, entities.ErrLastOwner) {
+			t.Fatalf("expected the last owner to be refused but got %v", err)
+		}
+		service.join(t, "Acme", "Bob", entities.OrgRoleOwner)
+		if err := service.CloseAccount(service.token, "Sue"); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		members, err := service.GetOrganisationMembers(bob, "Acme")
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		if len(members) != 1 || members[0].Account != "Bob" {
+			t.Errorf("expected only Bob to be left but got %+v", members)
+		}
+	})
+
+	t.Run("RefusesOtherAccounts", func(t *testing.T) {
+		service := newServiceWithProjects(t, "Roadmap")
+		bob := service.signIn(t, "Bob")
+		if err := service.CloseAccount(bob, "Sue"); !errors.Is(err, entities.ErrAccessDenied) {
+			t.Errorf("expected another account to be refused but got %v", err)
+		}
+		expectVisibleProjects(t, service, service.token, "Sue", "Roadmap")
+	})
+}
+
+// shareWithSue has the named account, signed in with token, create the named project
+// and share it with Sue as an editor, returning the project
+func (s testService) shareWithSue(t *testing.T, token string, name string, projectName string) entities.Project {
+	t.Helper()
+	project, err := s.CreateProject(token, name, projectName)
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	s.shareBetween(t, token, name, project.ID(), s.token, "Sue", entities.RoleEditor)
+	return project
+}
@@ -0,0 +1,119 @@
+package application
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/export"
+)
+
+// ExportAccount gathers everything kept about an account into an archive, on behalf of
+// the account holder signed in with token, so that they can take a copy of their data
+func (d *Service) ExportAccount(token string, name string) (export.Archive, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	account, err := d.authorize(token, name)
+	if err != nil {
+		return export.Archive{}, err
+	}
+	names, err := d.accountNames()
+	if err != nil {
+		return export.Archive{}, err
+	}
+	archive := export.Archive{
+		Version:    export.Version,
+		ExportedAt: d.clock.Now().UTC(),
+		Account: export.Account{
+			ID:        account.ID(),
+			Name:      account.Name(),
+			Activated: account.IsActivated(),
+			Plan:      account.Plan(),
+			CreatedAt: account.CreatedAt(),
+		},
+		Projects:       []export.Project{},
+		SharedProjects: []export.SharedProject{},
+		Invitations:    []export.Invitation{},
+		Organisations:  []export.Membership{},
+		Webhooks:       []export.Webhook{},
+		Sessions:       []export.Session{},
+	}
+
+	projects, err := d.visibleProjects(account.ID())
+	if err != nil {
+		return export.Archive{}, err
+	}
+	for _, project := range projects {
+		if project.OwnerID() != account.ID() {
+			archive.SharedProjects = append(archive.SharedProjects, export.SharedProject{
+				ID:        project.ID(),
+				Name:      project.Name(),
+				Owner:     names[project.OwnerID()],
+				Role:      project.RoleOf(account.ID()),
+				State:     project.State(),
+				CreatedAt: project.CreatedAt(),
+			})
+			continue
+		}
+		members := []export.Member{}
+		for _, member := range project.Members() {
+			members = append(members, export.Member{
+				Account:   names[member.AccountID],
+				Role:      member.Role,
+				Accepted:  member.Accepted,
+				InvitedAt: member.InvitedAt,
+			})
+		}
+		archive.Projects = append(archive.Projects, export.Project{
+			ID:        project.ID(),
+			Name:      project.Name(),
+			State:     project.State(),
+			CreatedAt: project.CreatedAt(),
+			Members:   members,
+		})
+	}
+
+	invitations, err := d.pendingInvitations(account)
+	if err != nil {
+		return export.Archive{}, err
+	}
+	for _, invitation := range invitations {
+		archive.Invitations = append(archive.Invitations, export.Invitation{
+			ProjectID:   invitation.ProjectID,
+			ProjectName: invitation.ProjectName,
+			Owner:       invitation.Owner,
+			Role:        invitation.Role,
+			InvitedAt:   invitation.InvitedAt,
+		})
+	}
+
+	organisations, err := d.organisations.List()
+	if err != nil {
+		return export.Archive{}, err
+	}
+	for _, organisation := range organisations {
+		if role := organisation.RoleOf(account.ID()); role != "" {
+			archive.Organisations = append(archive.Organisations, export.Membership{Organisation: organisation.Name(), Role: role})
+		}
+	}
+	slices.SortFunc(archive.Organisations, func(a, b export.Membership) int { return strings.Compare(a.Organisation, b.Organisation) })
+
+	webhooks, err := d.webhooks.ListByOwner(account.ID())
+	if err != nil {
+		return export.Archive{}, err
+	}
+	for _, webhook := range webhooks {
+		archive.Webhooks = append(archive.Webhooks, export.Webhook{ID: webhook.ID(), URL: webhook.URL(), CreatedAt: webhook.CreatedAt()})
+	}
+
+	sessions, err := d.sessions.List()
+	if err != nil {
+		return export.Archive{}, err
+	}
+	for _, session := range sessions {
+		if session.AccountID() == account.ID() && !session.IsExpired(d.clock.Now()) {
+			archive.Sessions = append(archive.Sessions, export.Session{ExpiresAt: session.ExpiresAt()})
+		}
+	}
+	slices.SortFunc(archive.Sessions, func(a, b export.Session) int { return a.ExpiresAt.Compare(b.ExpiresAt) })
+	return archive, nil
+}
@@ -0,0 +1,80 @@
+package application_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/internal/domain/application"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/export"
+)
+
+func TestExportAccount(t *testing.T) {
+	t.Run("HoldsEverythingAboutTheAccount", func(t *testing.T) {
+		service := newServiceWithProjects(t)
+		service.createOrganisation(t, "Acme")
+		service.createProjectsAfter(t, 0, "Roadmap", "Budget")
+		bob := service.signIn(t, "Bob")
+		service.signIn(t, "Tanya")
+		roadmap := listProjects(t, service, entities.ProjectQuery{}).Projects[0]
+		service.share(t, bob, "Bob", roadmap.ID(), entities.RoleViewer)
+		if err := service.InviteToProject(service.token, "Sue", roadmap.ID(), "Tanya", entities.RoleEditor); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		service.shareWithSue(t, bob, "Bob", "Launch")
+		hiring, err := service.CreateProject(bob, "Bob", "Hiring")
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		if err := service.InviteToProject(bob, "Bob", hiring.ID(), "Sue", entities.RoleViewer); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		if _, err := service.RegisterWebhook(service.token, "Sue", application.WebhookTarget{URL: "https://example.com/hook", Secret: "a-secret-of-some-length"}); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+
+		archive, err := service.ExportAccount(service.token, "Sue")
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		if archive.Version != export.Version || archive.Account.Name != "Sue" || !archive.Account.Activated ||
+			archive.Account.Plan != entities.PlanPro {
+			t.Errorf("expected Sue's account but got %+v", archive.Account)
+		}
+		if len(archive.Projects) != 2 || archive.Projects[0].Name != "Roadmap" || archive.Projects[1].Name != "Budget" {
+			t.Fatalf("expected Sue's own projects but got %+v", archive.Projects)
+		}
+		members := archive.Projects[0].Members
+		if len(members) != 2 || members[0] != (export.Member{Account: "Bob", Role: entities.RoleViewer, Accepted: true, InvitedAt: members[0].InvitedAt}) ||
+			members[1].Account != "Tanya" || members[1].Accepted {
+			t.Errorf("expected Bob and Tanya to be members of Roadmap but got %+v", members)
+		}
+		if len(archive.SharedProjects) != 1 || archive.SharedProjects[0].Name != "Launch" || archive.SharedProjects[0].Owner != "Bob" ||
+			archive.SharedProjects[0].Role != entities.RoleEditor {
+			t.Errorf("expected Bob's project shared with Sue but got %+v", archive.SharedProjects)
+		}
+		if len(archive.Invitations) != 1 || archive.Invitations[0].ProjectName != "Hiring" {
+			t.Errorf("expected Bob's invitation but got %+v", archive.Invitations)
+		}
+		if len(archive.Organisations) != 1 || archive.Organisations[0] != (export.Membership{Organisation: "Acme", Role: entities.OrgRoleOwner}) {
+			t.Errorf("expected Sue to own Acme but got %+v", archive.Organisations)
+		}
+		if len(archive.Webhooks) != 1 || archive.Webhooks[0].URL != "https://example.com/hook" {
+			t.Errorf("expected Sue's webhook but got %+v", archive.Webhooks)
+		}
+		if len(archive.Sessions) != 1 {
+			t.Errorf("expected Sue's session but got %+v", archive.Sessions)
+		}
+	})
+
+	t.Run("RefusesOtherAccounts", func(t *testing.T) {
+		service := newServiceWithProjects(t, "Roadmap")
+		bob := service.signIn(t, "Bob")
+		if _, err := service.ExportAccount(bob, "Sue"); !errors.Is(err, entities.ErrAccessDenied) {
+			t.Errorf("expected another account to be refused but got %v", err)
+		}
+		if _, err := service.ExportAccount("", "Sue"); !errors.Is(err, entities.ErrNotSignedIn) {
+			t.Errorf("expected an anonymous caller to be refused but got %v", err)
+		}
+	})
+}
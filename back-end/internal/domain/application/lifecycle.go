@@ -0,0 +1,54 @@
+package application
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+// projectTransitions holds the states a project may move to from each state it can be
+// in, and the error that refuses any other move. Deleting a project is allowed from any
+// state, and takes it out of the lifecycle altogether.
+var projectTransitions = map[entities.ProjectState]struct {
+	to      []entities.ProjectState
+	refused error
+}{
+	entities.ProjectActive:   {to: []entities.ProjectState{entities.ProjectArchived}, refused: entities.ErrProjectNotArchived},
+	entities.ProjectArchived: {to: []entities.ProjectState{entities.ProjectActive}, refused: entities.ErrProjectArchived},
+}
+
+// ArchiveProject archives one of an account's finished projects, on behalf of the
+// account holder signed in with token. Archived projects are left out of listings
+// unless asked for, and cannot be changed until they are restored. Archiving a
+// project that is already archived is refused with entities.ErrProjectArchived.
+func (d *Service) ArchiveProject(token string, name string, projectID string) (entities.Project, error) {
+	return d.moveProject(token, name, projectID, entities.ProjectArchived)
+}
+
+// RestoreProject makes one of an account's archived projects active again, on behalf
+// of the account holder signed in with token. Restoring a project that is not archived
+// is refused with entities.ErrProjectNotArchived.
+func (d *Service) RestoreProject(token string, name string, projectID string) (entities.Project, error) {
+	return d.moveProject(token, name, projectID, entities.ProjectActive)
+}
+
+// moveProject moves one of an account's projects to the state to, if its lifecycle
+// allows it to go there from the state it is in. Only the owner may move a project.
+func (d *Service) moveProject(token string, name string, projectID string, to entities.ProjectState) (entities.Project, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	project, err := d.projectFor(token, name, projectID, entities.RoleOwner)
+	if err != nil {
+		return entities.Project{}, err
+	}
+	transitions := projectTransitions[project.State()]
+	if !slices.Contains(transitions.to, to) {
+		return entities.Project{}, fmt.Errorf("%w: %s", transitions.refused, projectID)
+	}
+	project.SetState(to)
+	if err := d.projects.Update(project); err != nil {
+		return entities.Project{}, err
+	}
+	return project, nil
+}
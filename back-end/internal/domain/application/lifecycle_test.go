@@ -0,0 +1,74 @@
+package application_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+func TestProjectLifecycle(t *testing.T) {
+	t.Run("ArchivesAndRestoresProject", func(t *testing.T) {
+		service := newServiceWithProjects(t, "Roadmap", "Budget")
+		roadmap := listProjects(t, service, entities.ProjectQuery{}).Projects[0]
+
+		archived, err := service.ArchiveProject(service.token, "Sue", roadmap.ID())
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		if archived.State() != entities.ProjectArchived {
+			t.Fatalf("expected the project to be archived but it is %s", archived.State())
+		}
+		expectNames(t, listProjects(t, service, entities.ProjectQuery{}).Projects, "Budget")
+		expectNames(t, listProjects(t, service, entities.ProjectQuery{IncludeArchived: true}).Projects, "Roadmap, Budget")
+		projects, err := service.GetProjects(service.token, "Sue")
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		expectNames(t, projects, "Budget")
+
+		restored, err := service.RestoreProject(service.token, "Sue", roadmap.ID())
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		if restored.State() != entities.ProjectActive {
+			t.Fatalf("expected the project to be active but it is %s", restored.State())
+		}
+		expectNames(t, listProjects(t, service, entities.ProjectQuery{}).Projects, "Roadmap, Budget")
+	})
+
+	t.Run("RefusesInvalidTransitions", func(t *testing.T) {
+		service := newServiceWithProjects(t, "Roadmap", "Budget")
+		projects := listProjects(t, service, entities.ProjectQuery{}).Projects
+		roadmap, budget := projects[0], projects[1]
+		if _, err := service.ArchiveProject(service.token, "Sue", roadmap.ID()); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+
+		if _, err := service.ArchiveProject(service.token, "Sue", roadmap.ID()); !errors.Is(err, entities.ErrProjectArchived) {
+			t.Errorf("expected archiving twice to be refused but got %v", err)
+		}
+		if _, err := service.RenameProject(service.token, "Sue", roadmap.ID(), "Plan"); !errors.Is(err, entities.ErrProjectArchived) {
+			t.Errorf("expected renaming an archived project to be refused but got %v", err)
+		}
+		if _, err := service.RestoreProject(service.token, "Sue", budget.ID()); !errors.Is(err, entities.ErrProjectNotArchived) {
+			t.Errorf("expected restoring an active project to be refused but got %v", err)
+		}
+	})
+
+	t.Run("DeletesArchivedProjectForGood", func(t *testing.T) {
+		service := newServiceWithProjects(t, "Roadmap")
+		roadmap := listProjects(t, service, entities.ProjectQuery{}).Projects[0]
+		if _, err := service.ArchiveProject(service.token, "Sue", roadmap.ID()); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+
+		if err := service.DeleteProject(service.token, "Sue", roadmap.ID()); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		expectNames(t, listProjects(t, service, entities.ProjectQuery{IncludeArchived: true}).Projects, "")
+		if _, err := service.RestoreProject(service.token, "Sue", roadmap.ID()); !errors.Is(err, entities.ErrProjectNotFound) {
+			t.Errorf("expected a deleted project not to be found but got %v", err)
+		}
+	})
+}
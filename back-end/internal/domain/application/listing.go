@@ -0,0 +1,141 @@
+package application
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+// ListProjects lists a page of the projects an account owns or shares, chosen and ordered
+// by query. Only the account holder may see them, so token must be for one of their
+// sessions.
+func (d *Service) ListProjects(token string, name string, query entities.ProjectQuery) (entities.ProjectPage, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	account, err := d.authorize(token, name)
+	if err != nil {
+		return entities.ProjectPage{}, err
+	}
+	projects, err := d.visibleProjects(account.ID())
+	if err != nil {
+		return entities.ProjectPage{}, err
+	}
+	return listProjects(projects, query)
+}
+
+// cursor marks the last project on a page, so that the next page can start after it
+// even if projects have been added or removed in between. No two projects have the
+// same cursor, as the sequence and ID that end it are unique.
+type cursor struct {
+	Sort      entities.ProjectSort `json:"sort"`
+	ID        string               `json:"id"`
+	Name      string               `json:"name,omitempty"`
+	CreatedAt time.Time            `json:"createdAt,omitzero"`
+	Sequence  uint64               `json:"sequence,omitempty"`
+}
+
+func (c cursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	var c cursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || json.Unmarshal(data, &c) != nil || c.ID == "" {
+		return cursor{}, fmt.Errorf("%w: cursor is not one we gave out", entities.ErrInvalidQuery)
+	}
+	return c, nil
+}
+
+// listProjects picks out the page of projects that query asks for. Projects come in the
+// order they were created, which breaks ties between those that sort the same.
+func listProjects(projects []entities.Project, query entities.ProjectQuery) (entities.ProjectPage, error) {
+	limit := query.Limit
+	if limit == 0 {
+		limit = entities.DefaultProjectLimit
+	}
+	if limit < 0 || limit > entities.MaxProjectLimit {
+		return entities.ProjectPage{}, fmt.Errorf("%w: limit must be between 1 and %d", entities.ErrInvalidQuery, entities.MaxProjectLimit)
+	}
+	sort := query.Sort
+	if sort == "" {
+		sort = entities.SortByCreated
+	}
+	var key func(entities.Project) cursor
+	switch sort {
+	case entities.SortByCreated:
+		key = func(p entities.Project) cursor {
+			return cursor{Sort: sort, ID: p.ID(), CreatedAt: p.CreatedAt(), Sequence: p.Sequence()}
+		}
+	case entities.SortByName:
+		key = func(p entities.Project) cursor {
+			return cursor{Sort: sort, ID: p.ID(), Name: p.Name(), Sequence: p.Sequence()}
+		}
+	default:
+		return entities.ProjectPage{}, fmt.Errorf("%w: sort must be %s or %s", entities.ErrInvalidQuery, entities.SortByCreated, entities.SortByName)
+	}
+
+	prefix := strings.ToLower(query.NamePrefix)
+	listed := make([]entities.Project, 0, len(projects))
+	for _, project := range projects {
+		createdAt := project.CreatedAt()
+		if (project.IsArchived() && !query.IncludeArchived) ||
+			!strings.HasPrefix(strings.ToLower(project.Name()), prefix) ||
+			(!query.CreatedFrom.IsZero() && createdAt.Before(query.CreatedFrom)) ||
+			(!query.CreatedBefore.IsZero() && !createdAt.Before(query.CreatedBefore)) {
+			continue
+		}
+		listed = append(listed, project)
+	}
+	slices.SortFunc(listed, func(a, b entities.Project) int { return compareKeys(key(a), key(b)) })
+
+	if query.Cursor != "" {
+		after, err := decodeCursor(query.Cursor)
+		if err != nil {
+			return entities.ProjectPage{}, err
+		}
+		if after.Sort != sort {
+			return entities.ProjectPage{}, fmt.Errorf("%w: cursor is for projects sorted by %s", entities.ErrInvalidQuery, after.Sort)
+		}
+		listed = listed[startAfter(listed, key, after):]
+	}
+
+	page := entities.ProjectPage{Projects: listed}
+	if len(listed) > limit {
+		page.Projects = listed[:limit]
+		page.NextCursor = key(listed[limit-1]).encode()
+	}
+	return page, nil
+}
+
+// startAfter returns the index of the first project that sorts after the one the cursor
+// marks. That is the project after it, or if it has since been deleted, or moved by
+// being renamed, the project after where it was.
+func startAfter(projects []entities.Project, key func(entities.Project) cursor, after cursor) int {
+	for i, project := range projects {
+		if compareKeys(key(project), after) > 0 {
+			return i
+		}
+	}
+	return len(projects)
+}
+
+// compareKeys compares the keys that projects are sorted by, breaking ties by the order
+// the projects were created in and then by ID, for projects created before they were
+// numbered
+func compareKeys(a, b cursor) int {
+	var c int
+	if a.Sort == entities.SortByName {
+		c = cmp.Or(cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)), cmp.Compare(a.Name, b.Name))
+	} else {
+		c = a.CreatedAt.Compare(b.CreatedAt)
+	}
+	return cmp.Or(c, cmp.Compare(a.Sequence, b.Sequence), cmp.Compare(a.ID, b.ID))
+}
@@ -0,0 +1,256 @@
+package application_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/internal/domain/application"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/internal/domain/passwords"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/clock/manual"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/fixtures"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/repository"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/repository/memory"
+)
+
+func TestListProjects(t *testing.T) {
+	t.Run("PagesThroughProjectsOldestFirst", func(t *testing.T) {
+		names := make([]string, 120)
+		for i := range names {
+			names[i] = fmt.Sprintf("Project %d", i+1)
+		}
+		service := newServiceWithProjects(t, names...)
+
+		var pages []string
+		query := entities.ProjectQuery{Limit: 50}
+		for {
+			page, err := service.ListProjects(service.token, "Sue", query)
+			if err != nil {
+				t.Fatalf("expected no error but got %v", err)
+			}
+			pages = append(pages, projectNames(page.Projects))
+			if page.NextCursor == "" {
+				break
+			}
+			query.Cursor = page.NextCursor
+		}
+		if len(pages) != 3 {
+			t.Fatalf("expected 3 pages but got %d", len(pages))
+		}
+		if got := strings.Join(pages, ", "); got != strings.Join(names, ", ") {
+			t.Fatalf("expected every project once, oldest first, but got %s", got)
+		}
+	})
+
+	t.Run("ListsFirstPageByDefault", func(t *testing.T) {
+		names := make([]string, entities.DefaultProjectLimit+1)
+		for i := range names {
+			names[i] = fmt.Sprintf("Project %d", i+1)
+		}
+		service := newServiceWithProjects(t, names...)
+
+		page := listProjects(t, service, entities.ProjectQuery{})
+		if len(page.Projects) != entities.DefaultProjectLimit || page.NextCursor == "" {
+			t.Fatalf("expected a page of %d with more to come but got %d", entities.DefaultProjectLimit, len(page.Projects))
+		}
+	})
+
+	t.Run("SortsByNameIgnoringCase", func(t *testing.T) {
+		service := newServiceWithProjects(t, "beta", "Alpha", "alpine", "Alpha")
+
+		page := listProjects(t, service, entities.ProjectQuery{Sort: entities.SortByName, Limit: 3})
+		expectNames(t, page.Projects, "Alpha, Alpha, alpine")
+		page = listProjects(t, service, entities.ProjectQuery{Sort: entities.SortByName, Limit: 3, Cursor: page.NextCursor})
+		expectNames(t, page.Projects, "beta")
+	})
+
+	t.Run("FiltersByNamePrefix", func(t *testing.T) {
+		service := newServiceWithProjects(t, "beta", "Alpha", "alpine")
+
+		page := listProjects(t, service, entities.ProjectQuery{NamePrefix: "AL"})
+		expectNames(t, page.Projects, "Alpha, alpine")
+	})
+
+	t.Run("FiltersByCreationTime", func(t *testing.T) {
+		service := newServiceWithProjects(t, "Old")
+		start := service.clock.Now()
+		service.createProjectsAfter(t, 2*time.Hour, "New")
+
+		expectNames(t, listProjects(t, service, entities.ProjectQuery{CreatedFrom: start.Add(time.Hour)}).Projects, "New")
+		expectNames(t, listProjects(t, service, entities.ProjectQuery{CreatedBefore: start.Add(time.Hour)}).Projects, "Old")
+		expectNames(t, listProjects(t, service, entities.ProjectQuery{CreatedFrom: start, CreatedBefore: start.Add(2 * time.Hour)}).Projects, "Old")
+	})
+
+	t.Run("CarriesOnAfterProjectsChange", func(t *testing.T) {
+		service := newServiceWithProjects(t, "One")
+		service.createProjectsAfter(t, time.Minute, "Two", "Three", "Four")
+
+		page := listProjects(t, service, entities.ProjectQuery{Limit: 2})
+		expectNames(t, page.Projects, "One, Two")
+		if err := service.DeleteProject(service.token, "Sue", page.Projects[1].ID()); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		service.createProjectsAfter(t, time.Minute, "Five")
+
+		page = listProjects(t, service, entities.ProjectQuery{Limit: 2, Cursor: page.NextCursor})
+		expectNames(t, page.Projects, "Three, Four")
+		page = listProjects(t, service, entities.ProjectQuery{Limit: 2, Cursor: page.NextCursor})
+		expectNames(t, page.Projects, "Five")
+	})
+
+	t.Run("CarriesOnAfterDeletingOneOfProjectsCreatedTogether", func(t *testing.T) {
+		service := newServiceWithProjects(t, "A", "B", "C", "D", "E")
+
+		page := listProjects(t, service, entities.ProjectQuery{Limit: 2})
+		expectNames(t, page.Projects, "A, B")
+		if err := service.DeleteProject(service.token, "Sue", page.Projects[1].ID()); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+
+		page = listProjects(t, service, entities.ProjectQuery{Limit: 2, Cursor: page.NextCursor})
+		expectNames(t, page.Projects, "C, D")
+	})
+
+	t.Run("CarriesOnAfterDeletingTheNewestProjects", func(t *testing.T) {
+		service := newServiceWithProjects(t, "A", "B", "C")
+
+		page := listProjects(t, service, entities.ProjectQuery{Limit: 2})
+		expectNames(t, page.Projects, "A, B")
+		all := listProjects(t, service, entities.ProjectQuery{})
+		for _, project := range all.Projects[1:] {
+			if err := service.DeleteProject(service.token, "Sue", project.ID()); err != nil {
+				t.Fatalf("expected no error but got %v", err)
+			}
+		}
+		// D is numbered after every project there has been, not just those that are left
+		service.createProjectsAfter(t, 0, "D")
+
+		page = listProjects(t, service, entities.ProjectQuery{Limit: 2, Cursor: page.NextCursor})
+		expectNames(t, page.Projects, "D")
+	})
+
+	t.Run("RefusesInvalidQueries", func(t *testing.T) {
+		service := newServiceWithProjects(t, "One", "Two")
+		byName := listProjects(t, service, entities.ProjectQuery{Sort: entities.SortByName, Limit: 1})
+
+		for name, query := range map[string]entities.ProjectQuery{
+			"LimitTooLarge":     {Limit: entities.MaxProjectLimit + 1},
+			"NegativeLimit":     {Limit: -1},
+			"UnknownSort":       {Sort: "size"},
+			"MadeUpCursor":      {Cursor: "not-a-cursor"},
+			"CursorForNameSort": {Cursor: byName.NextCursor},
+		} {
+			if _, err := service.ListProjects(service.token, "Sue", query); !errors.Is(err, entities.ErrInvalidQuery) {
+				t.Errorf("%s: expected an invalid query but got %v", name, err)
+			}
+		}
+	})
+
+	t.Run("RefusesOtherAccounts", func(t *testing.T) {
+		service := newServiceWithProjects(t, "One")
+
+		if _, err := service.ListProjects("", "Sue", entities.ProjectQuery{}); !errors.Is(err, entities.ErrNotSignedIn) {
+			t.Fatalf("expected to need to sign in but got %v", err)
+		}
+	})
+}
+
+// testPassword is the password of every account that tests seed
+const testPassword = "correct-horse-1"
+
+// testService is a service whose clock stands still until advanced, with Sue signed in
+type testService struct {
+	*application.Service
+	clock        *manual.Clock
+	repositories repository.Repositories
+	token        string
+}
+
+// newTestService returns a service seeded with f, built with options besides the test
+// ones, and signed in to the first account that f seeds, which is Sue's
+func newTestService(t *testing.T, f fixtures.Fixtures, options ...application.Option) testService {
+	t.Helper()
+	clock := manual.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	repositories := memory.NewRepositories()
+	options = append([]application.Option{application.WithPasswordHasher(passwords.Hasher{Iterations: 1}), application.WithClock(clock)}, options...)
+	s := testService{Service: application.NewWithRepositories(repositories, options...), clock: clock, repositories: repositories}
+	s.token = s.seed(t, f)
+	return s
+}
+
+// sue is Sue's account, activated and on the given plan, with the named projects
+func sue(plan entities.Plan, projects ...string) fixtures.Account {
+	return fixtures.Account{Name: "Sue", Password: testPassword, Activated: true, Plan: plan, Projects: projects}
+}
+
+// newServiceWithProjects returns a service where Sue has the named projects, all created
+// at the same time. Sue is on the pro plan, so that she can have as many as are needed.
+func newServiceWithProjects(t *testing.T, names ...string) testService {
+	t.Helper()
+	return newTestService(t, fixtures.Fixtures{Accounts: []fixtures.Account{sue(entities.PlanPro, names...)}})
+}
+
+// seed seeds f and signs in to the first account it seeds, returning the session token
+func (s testService) seed(t *testing.T, f fixtures.Fixtures) string {
+	t.Helper()
+	if err := s.Seed(f); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	return s.authenticate(t, f.Accounts[0].Name)
+}
+
+// authenticate signs in to the named account, returning the session token
+func (s testService) authenticate(t *testing.T, name string) string {
+	t.Helper()
+	session, err := s.Authenticate(name, testPassword)
+	if err != nil {
+		t.Fatalf("expected %s to sign in but got %v", name, err)
+	}
+	return session.Value
+}
+
+// advance lets d pass on the service's clock
+func (s testService) advance(t *testing.T, d time.Duration) {
+	t.Helper()
+	if _, err := s.clock.Advance(d); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+}
+
+// createProjectsAfter creates the named projects for Sue, letting d pass before each one
+func (s testService) createProjectsAfter(t *testing.T, d time.Duration, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		s.advance(t, d)
+		if _, err := s.CreateProject(s.token, "Sue", name); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+	}
+}
+
+func listProjects(t *testing.T, service testService, query entities.ProjectQuery) entities.ProjectPage {
+	t.Helper()
+	page, err := service.ListProjects(service.token, "Sue", query)
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	return page
+}
+
+func projectNames(projects []entities.Project) string {
+	names := make([]string, len(projects))
+	for i, project := range projects {
+		names[i] = project.Name()
+	}
+	return strings.Join(names, ", ")
+}
+
+func expectNames(t *testing.T, projects []entities.Project, expected string) {
+	t.Helper()
+	if got := projectNames(projects); got != expected {
+		t.Fatalf("expected projects %s but got %s", expected, got)
+	}
+}
@@ -0,0 +1,69 @@
+package application
+
+import (
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+// LockoutPolicy decides when repeated failed sign-ins lock an account, so that
+// passwords cannot be guessed by trying them one after another
+type LockoutPolicy struct {
+	// MaxFailures is how many sign-ins may fail within Window before the account
+	// is locked. Zero turns lockout off.
+	MaxFailures int
+	Window      time.Duration
+	// Cooldown is how long the account stays locked
+	Cooldown time.Duration
+}
+
+// DefaultLockoutPolicy locks an account for 15 minutes once 5 sign-ins to it
+// have failed within 15 minutes
+var DefaultLockoutPolicy = LockoutPolicy{
+	MaxFailures: 5,
+	Window:      15 * time.Minute,
+	Cooldown:    15 * time.Minute,
+}
+
+// WithLockoutPolicy locks accounts according to p instead of DefaultLockoutPolicy
+func WithLockoutPolicy(p LockoutPolicy) Option {
+	return func(d *Service) {
+		d.lockout = p
+	}
+}
+
+// recordFailedSignIn counts a failed sign-in to the named account and locks the
+// account if too many have failed. It reports whether the account is locked.
+// The caller must hold the write lock.
+func (d *Service) recordFailedSignIn(name string) (bool, error) {
+	account, err := d.accounts.Get(name)
+	if err != nil {
+		return false, err
+	}
+	now := d.clock.Now().UTC()
+	if account.IsLocked(now) {
+		return true, nil
+	}
+	count, since := account.FailedSignIns(), account.FailedSignInsSince()
+	if count == 0 || !now.Before(since.Add(d.lockout.Window)) {
+		count, since = 0, now
+	}
+	count++
+	locked := d.lockout.MaxFailures > 0 && count >= d.lockout.MaxFailures
+	if locked {
+		account.SetLockedUntil(now.Add(d.lockout.Cooldown))
+		count, since = 0, time.Time{}
+	}
+	account.SetFailedSignIns(count, since)
+	return locked, d.accounts.Update(account)
+}
+
+// clearFailedSignIns forgets the account's failed sign-ins once the right password
+// has been given. The caller must hold the write lock.
+func (d *Service) clearFailedSignIns(account entities.Account) error {
+	if account.FailedSignIns() == 0 {
+		return nil
+	}
+	account.SetFailedSignIns(0, time.Time{})
+	return d.accounts.Update(account)
+}
@@ -0,0 +1,325 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+// orgPermission is something that members of an organisation may do only if their role
+// allows it. Each describes what it allows, to explain refusals.
+type orgPermission string
+
+const (
+	// seeOrganisation lets members see the organisation, its members and its projects
+	seeOrganisation orgPermission = "see the organisation"
+	// editOrgProjects lets members create the organisation's projects and rename them
+	editOrgProjects orgPermission = "create or rename its projects"
+	// deleteOrgProjects lets members delete the organisation's projects
+	deleteOrgProjects orgPermission = "delete its projects"
+	// manageMembers lets members add and remove other members whose role is member
+	manageMembers orgPermission = "add or remove members"
+	// manageAdmins lets members give or take away the owner and admin roles
+	manageAdmins orgPermission = "choose its owners and admins"
+	// deleteOrganisation lets members delete the organisation and all of its projects
+	deleteOrganisation orgPermission = "delete the organisation"
+)
+
+// orgPermissions holds the roles in an organisation that are allowed each permission
+var orgPermissions = map[orgPermission][]entities.OrgRole{
+	seeOrganisation:    {entities.OrgRoleOwner, entities.OrgRoleAdmin, entities.OrgRoleMember},
+	editOrgProjects:    {entities.OrgRoleOwner, entities.OrgRoleAdmin, entities.OrgRoleMember},
+	deleteOrgProjects:  {entities.OrgRoleOwner, entities.OrgRoleAdmin},
+	manageMembers:      {entities.OrgRoleOwner, entities.OrgRoleAdmin},
+	manageAdmins:       {entities.OrgRoleOwner},
+	deleteOrganisation: {entities.OrgRoleOwner},
+}
+
+// CreateOrganisation creates a named organisation on behalf of the account holder signed
+// in with token, who becomes its owner. Names that are already taken by another
+// organisation are refused with entities.ErrOrganisationExists.
+func (d *Service) CreateOrganisation(token string, orgName string) (entities.Organisation, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	account, err := d.signedInAccount(token)
+	if err != nil {
+		return entities.Organisation{}, err
+	}
+	id, err := newID()
+	if err != nil {
+		return entities.Organisation{}, err
+	}
+	organisation := entities.NewOrganisation(id, orgName, d.clock.Now().UTC())
+	organisation.SetMember(entities.OrgMember{AccountID: account.ID(), Role: entities.OrgRoleOwner})
+	if err := d.organisations.Add(*organisation); err != nil {
+		return entities.Organisation{}, err
+	}
+	return *organisation, nil
+}
+
+// GetOrganisations retrieves the memberships of the account holder signed in with token,
+// ordered by the name of the organisation
+func (d *Service) GetOrganisations(token string) ([]entities.Membership, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	account, err := d.signedInAccount(token)
+	if err != nil {
+		return nil, err
+	}
+	organisations, err := d.organisations.List()
+	if err != nil {
+		return nil, err
+	}
+	memberships := []entities.Membership{}
+	for _, organisation := range organisations {
+		if role := organisation.RoleOf(account.ID()); role != "" {
+			memberships = append(memberships, entities.Membership{Organisation: organisation.Name(), Account: account.Name(), Role: role})
+		}
+	}
+	slices.SortFunc(memberships, func(a, b entities.Membership) int { return strings.Compare(a.Organisation, b.Organisation) })
+	return memberships, nil
+}
+
+// GetOrganisation retrieves an organisation for one of its members signed in with token
+func (d *Service) GetOrganisation(token string, orgName string) (entities.Organisation, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	organisation, _, err := d.organisationFor(token, orgName, seeOrganisation)
+	return organisation, err
+}
+
+// GetOrganisationMembers retrieves an organisation's members in the order they joined,
+// for one of its members signed in with token
+func (d *Service) GetOrganisationMembers(token string, orgName string) ([]entities.Membership, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	organisation, _, err := d.organisationFor(token, orgName, seeOrganisation)
+	if err != nil {
+		return nil, err
+	}
+	names, err := d.accountNames()
+	if err != nil {
+		return nil, err
+	}
+	members := organisation.Members()
+	memberships := make([]entities.Membership, 0, len(members))
+	for _, member := range members {
+		memberships = append(memberships, entities.Membership{Organisation: orgName, Account: names[member.AccountID], Role: member.Role})
+	}
+	return memberships, nil
+}
+
+// SetOrganisationMember adds the member's account to an organisation with the given
+// role, or changes the role it has, on behalf of one of the organisation's owners or
+// admins signed in with token. Only owners may make others owners or admins, or change
+// the role of one. An organisation's last owner may not stop being one, which is
+// refused with entities.ErrLastOwner.
+func (d *Service) SetOrganisationMember(token string, orgName string, member string, role entities.OrgRole) error {
+	if role != entities.OrgRoleOwner && role != entities.OrgRoleAdmin && role != entities.OrgRoleMember {
+		return fmt.Errorf("%w: %q, as it must be owner, admin or member", entities.ErrInvalidRole, role)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	organisation, actor, err := d.organisationFor(token, orgName, manageMembers)
+	if err != nil {
+		return err
+	}
+	account, err := d.accounts.Get(member)
+	if err != nil {
+		return err
+	}
+	if !account.IsActivated() || account.IsClosed() {
+		return fmt.Errorf("%w: %s", entities.ErrAccountNotFound, member)
+	}
+	if err := changeRole(organisation, actor, organisation.RoleOf(account.ID()), role); err != nil {
+		return err
+	}
+	organisation.SetMember(entities.OrgMember{AccountID: account.ID(), Role: role})
+	return d.organisations.Update(organisation)
+}
+
+// RemoveOrganisationMember removes the member's account from an organisation, on behalf
+// of one of the organisation's owners or admins signed in with token. Only owners may
+// remove owners and admins, and the last owner may not be removed.
+func (d *Service) RemoveOrganisationMember(token string, orgName string, member string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	organisation, actor, err := d.organisationFor(token, orgName, manageMembers)
+	if err != nil {
+		return err
+	}
+	account, err := d.accounts.Get(member)
+	if err != nil && !errors.Is(err, entities.ErrAccountNotFound) {
+		return err
+	}
+	role := organisation.RoleOf(account.ID())
+	if err != nil || role == "" {
+		return fmt.Errorf("%w in organisation %s: %s", entities.ErrAccountNotFound, orgName, member)
+	}
+	if err := changeRole(organisation, actor, role, ""); err != nil {
+		return err
+	}
+	organisation.RemoveMember(account.ID())
+	return d.organisations.Update(organisation)
+}
+
+// DeleteOrganisation deletes an organisation along with all of its projects, on behalf
+// of one of its owners signed in with token
+func (d *Service) DeleteOrganisation(token string, orgName string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	organisation, _, err := d.organisationFor(token, orgName, deleteOrganisation)
+	if err != nil {
+		return err
+	}
+	projects, err := d.projects.ListByOwner(organisation.ID())
+	if err != nil {
+		return err
+	}
+	for _, project := range projects {
+		if err := d.projects.Delete(project.ID()); err != nil {
+			return err
+		}
+	}
+	return d.organisations.Delete(orgName)
+}
+
+// GetOrganisationProjects retrieves an organisation's projects in the order they were
+// created, for one of its members signed in with token
+func (d *Service) GetOrganisationProjects(token string, orgName string) ([]entities.Project, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	organisation, _, err := d.organisationFor(token, orgName, seeOrganisation)
+	if err != nil {
+		return nil, err
+	}
+	return d.projects.ListByOwner(organisation.ID())
+}
+
+// CreateOrganisationProject creates a named project owned by an organisation, on behalf
+// of one of its members signed in with token
+func (d *Service) CreateOrganisationProject(token string, orgName string, projectName string) (entities.Project, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	organisation, _, err := d.organisationFor(token, orgName, editOrgProjects)
+	if err != nil {
+		return entities.Project{}, err
+	}
+	project, err := d.newProject(projectName, organisation.ID())
+	if err != nil {
+		return entities.Project{}, err
+	}
+	if err := d.projects.Add(project); err != nil {
+		return entities.Project{}, err
+	}
+	return project, nil
+}
+
+// GetOrganisationProject retrieves one of an organisation's projects, for one of its
+// members signed in with token
+func (d *Service) GetOrganisationProject(token string, orgName string, projectID string) (entities.Project, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.organisationProject(token, orgName, projectID, seeOrganisation)
+}
+
+// RenameOrganisationProject changes the name of one of an organisation's projects, on
+// behalf of one of its members signed in with token. Archived projects cannot be
+// renamed, as with an account's own projects.
+func (d *Service) RenameOrganisationProject(token string, orgName string, projectID string, projectName string) (entities.Project, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	project, err := d.organisationProject(token, orgName, projectID, editOrgProjects)
+	if err != nil {
+		return entities.Project{}, err
+	}
+	if project.IsArchived() {
+		return entities.Project{}, fmt.Errorf("%w: %s", entities.ErrProjectArchived, projectID)
+	}
+	project.SetName(projectName)
+	if err := d.projects.Update(project); err != nil {
+		return entities.Project{}, err
+	}
+	return project, nil
+}
+
+// DeleteOrganisationProject deletes one of an organisation's projects for good, on
+// behalf of one of its owners or admins signed in with token
+func (d *Service) DeleteOrganisationProject(token string, orgName string, projectID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, err := d.organisationProject(token, orgName, projectID, deleteOrgProjects); err != nil {
+		return err
+	}
+	return d.projects.Delete(projectID)
+}
+
+// organisationFor returns the named organisation, and the role in it of the account
+// that the session with the given token is signed in to, if the role has the
+// permission. Organisations the account is not a member of are reported as not found,
+// so their existence is not revealed, while members whose role does not have the
+// permission are refused with entities.ErrAccessDenied. The caller must hold the lock.
+func (d *Service) organisationFor(token string, orgName string, permission orgPermission) (entities.Organisation, entities.OrgRole, error) {
+	account, err := d.signedInAccount(token)
+	if err != nil {
+		return entities.Organisation{}, "", err
+	}
+	organisation, err := d.organisations.Get(orgName)
+	if err != nil {
+		return entities.Organisation{}, "", err
+	}
+	role := organisation.RoleOf(account.ID())
+	if role == "" {
+		return entities.Organisation{}, "", fmt.Errorf("%w: %s", entities.ErrOrganisationNotFound, orgName)
+	}
+	if err := allow(organisation, role, permission); err != nil {
+		return entities.Organisation{}, "", err
+	}
+	return organisation, role, nil
+}
+
+// organisationProject returns one of the named organisation's projects, if the account
+// that the session with the given token is signed in to has the permission in the
+// organisation. The caller must hold the lock.
+func (d *Service) organisationProject(token string, orgName string, projectID string, permission orgPermission) (entities.Project, error) {
+	organisation, _, err := d.organisationFor(token, orgName, permission)
+	if err != nil {
+		return entities.Project{}, err
+	}
+	project, err := d.projects.Get(projectID)
+	if err != nil {
+		return entities.Project{}, err
+	}
+	if project.OwnerID() != organisation.ID() {
+		return entities.Project{}, fmt.Errorf("%w: %s", entities.ErrProjectNotFound, projectID)
+	}
+	return project, nil
+}
+
+// allow refuses a member of the organisation with entities.ErrAccessDenied unless their
+// role has the permission
+func allow(organisation entities.Organisation, role entities.OrgRole, permission orgPermission) error {
+	if !slices.Contains(orgPermissions[permission], role) {
+		return fmt.Errorf("%w to %s as %s of organisation %s", entities.ErrAccessDenied, permission, role, organisation.Name())
+	}
+	return nil
+}
+
+// changeRole checks that a member of the organisation whose role is actor may change
+// another member's role from one role to another, where "" is not being a member at
+// all. Adding, removing and changing anyone but plain members takes the permission to
+// manage admins, and the last owner must stay one.
+func changeRole(organisation entities.Organisation, actor entities.OrgRole, from entities.OrgRole, to entities.OrgRole) error {
+	if (from != "" && from != entities.OrgRoleMember) || (to != "" && to != entities.OrgRoleMember) {
+		if err := allow(organisation, actor, manageAdmins); err != nil {
+			return err
+		}
+	}
+	if from == entities.OrgRoleOwner && to != entities.OrgRoleOwner && organisation.Owners() == 1 {
+		return fmt.Errorf("%w: %s", entities.ErrLastOwner, organisation.Name())
+	}
+	return nil
+}
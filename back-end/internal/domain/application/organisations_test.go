@@ -0,0 +1,225 @@
+package application_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+func TestOrganisations(t *testing.T) {
+	t.Run("MakesCreatorTheOwner", func(t *testing.T) {
+		service := newServiceWithProjects(t)
+		bob := service.signIn(t, "Bob")
+
+		if _, err := service.CreateOrganisation(service.token, "Acme"); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		expectMemberships(t, service, service.token, "Acme as owner")
+		expectMemberships(t, service, bob, "")
+		if _, err := service.CreateOrganisation(bob, "Acme"); !errors.Is(err, entities.ErrOrganisationExists) {
+			t.Errorf("expected a taken name to be refused but got %v", err)
+		}
+	})
+
+	t.Run("AddsAndRemovesMembers", func(t *testing.T) {
+		service := newServiceWithProjects(t)
+		service.createOrganisation(t, "Acme")
+		bob := service.signIn(t, "Bob")
+
+		if err := service.SetOrganisationMember(service.token, "Acme", "Bob", entities.OrgRoleMember); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		expectMemberships(t, service, bob, "Acme as member")
+		members, err := service.GetOrganisationMembers(bob, "Acme")
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		if len(members) != 2 || members[0].Account != "Sue" || members[1].Account != "Bob" {
+			t.Fatalf("expected members Sue and Bob but got %+v", members)
+		}
+
+		if err := service.SetOrganisationMember(service.token, "Acme", "Bob", entities.OrgRoleAdmin); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		expectMemberships(t, service, bob, "Acme as admin")
+		if err := service.RemoveOrganisationMember(service.token, "Acme", "Bob"); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		expectMemberships(t, service, bob, "")
+		if err := service.RemoveOrganisationMember(service.token, "Acme", "Bob"); !errors.Is(err, entities.ErrAccountNotFound) {
+			t.Errorf("expected removing a non-member to be refused but got %v", err)
+		}
+	})
+
+	t.Run("LetsEachRoleDoOnlyWhatItMay", func(t *testing.T) {
+		service := newServiceWithProjects(t)
+		service.createOrganisation(t, "Acme")
+		admin := service.signIn(t, "Bob")
+		member := service.signIn(t, "Tanya")
+		service.signIn(t, "Zoe")
+		service.join(t, "Acme", "Bob", entities.OrgRoleAdmin)
+		service.join(t, "Acme", "Tanya", entities.OrgRoleMember)
+
+		roadmap, err := service.CreateOrganisationProject(member, "Acme", "Roadmap")
+		if err != nil {
+			t.Fatalf("expected a member to create a project but got %v", err)
+		}
+		if _, err := service.RenameOrganisationProject(member, "Acme", roadmap.ID(), "Plan"); err != nil {
+			t.Errorf("expected a member to rename a project but got %v", err)
+		}
+		if err := service.DeleteOrganisationProject(member, "Acme", roadmap.ID()); !errors.Is(err, entities.ErrAccessDenied) {
+			t.Errorf("expected a member to be refused deleting a project but got %v", err)
+		}
+		if err := service.SetOrganisationMember(member, "Acme", "Zoe", entities.OrgRoleMember); !errors.Is(err, entities.ErrAccessDenied) {
+			t.Errorf("expected a member to be refused adding members but got %v", err)
+		}
+		if err := service.SetOrganisationMember(admin, "Acme", "Zoe", entities.OrgRoleAdmin); !errors.Is(err, entities.ErrAccessDenied) {
+			t.Errorf("expected an admin to be refused making admins but got %v", err)
+		}
+		if err := service.RemoveOrganisationMember(admin, "Acme", "Sue"); !errors.Is(err, entities.ErrAccessDenied) {
+			t.Errorf("expected an admin to be refused removing an owner but got %v", err)
+		}
+		if err := service.DeleteOrganisation(admin, "Acme"); !errors.Is(err, entities.ErrAccessDenied) {
+			t.Errorf("expected an admin to be refused deleting the organisation but got %v", err)
+		}
+		if err := service.SetOrganisationMember(admin, "Acme", "Zoe", entities.OrgRoleMember); err != nil {
+			t.Errorf("expected an admin to add a member but got %v", err)
+		}
+		if err := service.DeleteOrganisationProject(admin, "Acme", roadmap.ID()); err != nil {
+			t.Errorf("expected an admin to delete a project but got %v", err)
+		}
+		expectOrganisationProjects(t, service, member, "Acme", "")
+	})
+
+	t.Run("KeepsAnOwner", func(t *testing.T) {
+		service := newServiceWithProjects(t)
+		service.createOrganisation(t, "Acme")
+		service.signIn(t, "Bob")
+
+		if err := service.RemoveOrganisationMember(service.token, "Acme", "Sue"); !errors.Is(err, entities.ErrLastOwner) {
+			t.Errorf("expected removing the last owner to be refused but got %v", err)
+		}
+		if err := service.SetOrganisationMember(service.token, "Acme", "Sue", entities.OrgRoleAdmin); !errors.Is(err, entities.ErrLastOwner) {
+			t.Errorf("expected demoting the last owner to be refused but got %v", err)
+		}
+		service.join(t, "Acme", "Bob", entities.OrgRoleOwner)
+		if err := service.RemoveOrganisationMember(service.token, "Acme", "Sue"); err != nil {
+			t.Errorf("expected an owner to leave another owner behind but got %v", err)
+		}
+	})
+
+	t.Run("HidesOrganisationFromOutsiders", func(t *testing.T) {
+		service := newServiceWithProjects(t)
+		service.createOrganisation(t, "Acme")
+		tanya := service.signIn(t, "Tanya")
+		roadmap, err := service.CreateOrganisationProject(service.token, "Acme", "Roadmap")
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		if _, err := service.CreateOrganisation(tanya, "Globex"); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+
+		if _, err := service.GetOrganisation(tanya, "Acme"); !errors.Is(err, entities.ErrOrganisationNotFound) {
+			t.Errorf("expected an outsider not to find the organisation but got %v", err)
+		}
+		if _, err := service.GetOrganisationProjects(tanya, "Acme"); !errors.Is(err, entities.ErrOrganisationNotFound) {
+			t.Errorf("expected an outsider not to find the organisation's projects but got %v", err)
+		}
+		if _, err := service.GetOrganisationProject(tanya, "Globex", roadmap.ID()); !errors.Is(err, entities.ErrProjectNotFound) {
+			t.Errorf("expected another organisation's project not to be found but got %v", err)
+		}
+		if _, err := service.GetProject(service.token, "Sue", roadmap.ID()); !errors.Is(err, entities.ErrProjectNotFound) {
+			t.Errorf("expected the organisation's project not to be found among Sue's own but got %v", err)
+		}
+		if _, err := service.GetOrganisations(""); !errors.Is(err, entities.ErrNotSignedIn) {
+			t.Errorf("expected an anonymous caller to be refused but got %v", err)
+		}
+	})
+
+	t.Run("RefusesToRenameArchivedProjects", func(t *testing.T) {
+		service := newServiceWithProjects(t)
+		service.createOrganisation(t, "Acme")
+		roadmap, err := service.CreateOrganisationProject(service.token, "Acme", "Roadmap")
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		// Organisations' projects cannot be archived through the service, so archive it
+		// in the repository
+		roadmap.SetState(entities.ProjectArchived)
+		if err := service.repositories.Projects.Update(roadmap); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+
+		if _, err := service.RenameOrganisationProject(service.token, "Acme", roadmap.ID(), "Plan"); !errors.Is(err, entities.ErrProjectArchived) {
+			t.Fatalf("expected renaming an archived project to be refused but got %v", err)
+		}
+		expectOrganisationProjects(t, service, service.token, "Acme", "Roadmap")
+	})
+
+	t.Run("DeletesOrganisationWithItsProjects", func(t *testing.T) {
+		service := newServiceWithProjects(t)
+		service.createOrganisation(t, "Acme")
+		roadmap, err := service.CreateOrganisationProject(service.token, "Acme", "Roadmap")
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+
+		if err := service.DeleteOrganisation(service.token, "Acme"); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		expectMemberships(t, service, service.token, "")
+		if _, err := service.CreateOrganisation(service.token, "Acme"); err != nil {
+			t.Fatalf("expected the name to be free again but got %v", err)
+		}
+		if _, err := service.GetOrganisationProject(service.token, "Acme", roadmap.ID()); !errors.Is(err, entities.ErrProjectNotFound) {
+			t.Errorf("expected the deleted organisation's project to be gone but got %v", err)
+		}
+	})
+}
+
+// createOrganisation has Sue create the named organisation, which she then owns
+func (s testService) createOrganisation(t *testing.T, name string) {
+	t.Helper()
+	if _, err := s.CreateOrganisation(s.token, name); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+}
+
+// join has Sue add the named account to the organisation with the given role
+func (s testService) join(t *testing.T, orgName string, name string, role entities.OrgRole) {
+	t.Helper()
+	if err := s.SetOrganisationMember(s.token, orgName, name, role); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+}
+
+// expectMemberships checks the organisations that the account signed in with token
+// belongs to, given as "Name as role" separated by commas
+func expectMemberships(t *testing.T, service testService, token string, expected string) {
+	t.Helper()
+	memberships, err := service.GetOrganisations(token)
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	got := ""
+	for i, membership := range memberships {
+		if i > 0 {
+			got += ", "
+		}
+		got += membership.Organisation + " as " + string(membership.Role)
+	}
+	if got != expected {
+		t.Fatalf("expected memberships %q but got %q", expected, got)
+	}
+}
+
+func expectOrganisationProjects(t *testing.T, service testService, token string, orgName string, expected string) {
+	t.Helper()
+	projects, err := service.GetOrganisationProjects(token, orgName)
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	expectNames(t, projects, expected)
+}
@@ -0,0 +1,65 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+// Quota limits what an account on a plan may have
+type Quota struct {
+	// MaxProjects is how many projects the account may own, archived ones included.
+	// Projects it shares, and those of its organisations, do not count. Zero means
+	// there is no limit.
+	MaxProjects int
+}
+
+// Quotas holds the quota of each plan. Plans without one have no limits.
+type Quotas map[entities.Plan]Quota
+
+// DefaultQuotas lets accounts on the free plan own 3 projects, and those on the pro
+// plan as many as they like
+var DefaultQuotas = Quotas{
+	entities.PlanFree: {MaxProjects: 3},
+}
+
+// WithQuotas limits accounts according to q instead of DefaultQuotas
+func WithQuotas(q Quotas) Option {
+	return func(d *Service) {
+		d.quotas = q
+	}
+}
+
+// ChangePlan moves an account to another plan, on behalf of the account holder signed
+// in with token. Moving to a plan with a lower quota keeps what the account already
+// has, but nothing more can be added until it is back under the quota.
+func (d *Service) ChangePlan(token string, name string, plan entities.Plan) error {
+	if plan != entities.PlanFree && plan != entities.PlanPro {
+		return fmt.Errorf("%w: %q, as it must be free or pro", entities.ErrInvalidPlan, plan)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	account, err := d.authorize(token, name)
+	if err != nil {
+		return err
+	}
+	account.SetPlan(plan)
+	return d.accounts.Update(account)
+}
+
+// checkProjectQuota refuses with entities.ErrQuotaExceeded to let an account own
+// another project if its plan's quota does not allow it. The caller must hold the lock.
+func (d *Service) checkProjectQuota(account entities.Account) error {
+	limit := d.quotas[account.Plan()].MaxProjects
+	if limit == 0 {
+		return nil
+	}
+	projects, err := d.projects.ListByOwner(account.ID())
+	if err != nil {
+		return err
+	}
+	if len(projects) >= limit {
+		return fmt.Errorf("%w: the %s plan allows %d projects; upgrade to have more", entities.ErrQuotaExceeded, account.Plan(), limit)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package application_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/internal/domain/application"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/fixtures"
+)
+
+func TestPlans(t *testing.T) {
+	t.Run("LimitsProjectsOnTheFreePlan", func(t *testing.T) {
+		service := newTestService(t, fixtures.Fixtures{Accounts: []fixtures.Account{sue("")}})
+		expectPlan(t, service, entities.PlanFree)
+
+		for _, name := range []string{"Roadmap", "Budget", "Hiring"} {
+			if _, err := service.CreateProject(service.token, "Sue", name); err != nil {
+				t.Fatalf("expected no error but got %v", err)
+			}
+		}
+		if _, err := service.CreateProject(service.token, "Sue", "Launch"); !errors.Is(err, entities.ErrQuotaExceeded) {
+			t.Fatalf("expected a fourth project to be refused but got %v", err)
+		}
+	})
+
+	t.Run("CountsArchivedProjects", func(t *testing.T) {
+		service := newTestService(t, fixtures.Fixtures{Accounts: []fixtures.Account{sue(entities.PlanFree)}})
+		var roadmap entities.Project
+		for _, name := range []string{"Roadmap", "Budget", "Hiring"} {
+			project, err := service.CreateProject(service.token, "Sue", name)
+			if err != nil {
+				t.Fatalf("expected no error but got %v", err)
+			}
+			roadmap = project
+		}
+		if _, err := service.ArchiveProject(service.token, "Sue", roadmap.ID()); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		if _, err := service.CreateProject(service.token, "Sue", "Launch"); !errors.Is(err, entities.ErrQuotaExceeded) {
+			t.Fatalf("expected archived projects to count but got %v", err)
+		}
+		if err := service.DeleteProject(service.token, "Sue", roadmap.ID()); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		if _, err := service.CreateProject(service.token, "Sue", "Launch"); err != nil {
+			t.Fatalf("expected a deleted project to free its place but got %v", err)
+		}
+	})
+
+	t.Run("LiftsLimitOnUpgrade", func(t *testing.T) {
+		service := newTestService(t, fixtures.Fixtures{Accounts: []fixtures.Account{sue(entities.PlanFree)}}, application.WithQuotas(application.Quotas{entities.PlanFree: {MaxProjects: 1}}))
+		if _, err := service.CreateProject(service.token, "Sue", "Roadmap"); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		if _, err := service.CreateProject(service.token, "Sue", "Budget"); !errors.Is(err, entities.ErrQuotaExceeded) {
+			t.Fatalf("expected the configured quota to apply but got %v", err)
+		}
+
+		if err := service.ChangePlan(service.token, "Sue", entities.PlanPro); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		expectPlan(t, service, entities.PlanPro)
+		if _, err := service.CreateProject(service.token, "Sue", "Budget"); err != nil {
+			t.Fatalf("expected the pro plan to have no limit but got %v", err)
+		}
+
+		if err := service.ChangePlan(service.token, "Sue", entities.PlanFree); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		expectProjectCount(t, service, 2)
+		if _, err := service.CreateProject(service.token, "Sue", "Hiring"); !errors.Is(err, entities.ErrQuotaExceeded) {
+			t.Fatalf("expected a downgraded account to be over its quota but got %v", err)
+		}
+	})
+
+	t.Run("RefusesUnknownPlansAndOtherAccounts", func(t *testing.T) {
+		service := newTestService(t, fixtures.Fixtures{Accounts: []fixtures.Account{sue(entities.PlanFree)}})
+		if err := service.ChangePlan(service.token, "Sue", "gold"); !errors.Is(err, entities.ErrInvalidPlan) {
+			t.Errorf("expected an unknown plan to be refused but got %v", err)
+		}
+		if err := service.ChangePlan("", "Sue", entities.PlanPro); !errors.Is(err, entities.ErrNotSignedIn) {
+			t.Errorf("expected an anonymous caller to be refused but got %v", err)
+		}
+		expectPlan(t, service, entities.PlanFree)
+	})
+}
+
+func expectPlan(t *testing.T, service testService, expected entities.Plan) {
+	t.Helper()
+	account, err := service.GetAccount("Sue")
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	if account.Plan() != expected {
+		t.Fatalf("expected Sue to be on the %s plan but got %s", expected, account.Plan())
+	}
+}
+
+func expectProjectCount(t *testing.T, service testService, expected int) {
+	t.Helper()
+	projects, err := service.GetProjects(service.token, "Sue")
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	if len(projects) != expected {
+		t.Fatalf("expected %d projects but got %d", expected, len(projects))
+	}
+}
@@ -0,0 +1,85 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/fixtures"
+)
+
+// Seed adds the accounts and projects in f, so that the service can be demonstrated or
+// explored without signing up by hand. Accounts that are not activated are sent an
+// activation link, as if they had just signed up. If any of the names is already taken,
+// nothing is added. Nothing is published, as nothing has happened to the accounts.
+func (d *Service) Seed(f fixtures.Fixtures) error {
+	// Hashing is slow, so do it before taking the lock
+	accounts := make([]entities.Account, 0, len(f.Accounts))
+	for _, fixture := range f.Accounts {
+		account, err := d.seedAccount(fixture)
+		if err != nil {
+			return err
+		}
+		accounts = append(accounts, account)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, account := range accounts {
+		existing, err := d.accounts.Get(account.Name())
+		if err == nil && !d.hasExpired(existing) {
+			return fmt.Errorf("%w: %s", entities.ErrAccountExists, account.Name())
+		}
+		if err != nil && !errors.Is(err, entities.ErrAccountNotFound) {
+			return err
+		}
+	}
+	for i, account := range accounts {
+		if err := d.addAccount(account); err != nil {
+			return err
+		}
+		for _, projectName := range f.Accounts[i].Projects {
+			project, err := d.newProject(projectName, account.ID())
+			if err != nil {
+				return err
+			}
+			if err := d.projects.Add(project); err != nil {
+				return err
+			}
+		}
+		if !account.IsActivated() {
+			if err := d.sendActivationLink(account.Name(), account.ActivationToken()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// seedAccount makes the account described by a fixture, which has already been checked
+func (d *Service) seedAccount(fixture fixtures.Account) (entities.Account, error) {
+	hash, err := d.hasher.Hash(fixture.Password)
+	if err != nil {
+		return entities.Account{}, err
+	}
+	id, err := newID()
+	if err != nil {
+		return entities.Account{}, err
+	}
+	account := entities.NewAccount(id, fixture.Name)
+	account.SetPasswordHash(hash)
+	account.SetCreatedAt(d.clock.Now().UTC())
+	if fixture.Plan != "" {
+		account.SetPlan(fixture.Plan)
+	}
+	if fixture.Activated {
+		account.SetActivated(true)
+	} else {
+		token, err := newID()
+		if err != nil {
+			return entities.Account{}, err
+		}
+		account.SetActivationToken(token)
+	}
+	return *account, nil
+}
@@ -0,0 +1,40 @@
+package application_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/fixtures"
+)
+
+func TestExpiredSessions(t *testing.T) {
+	t.Run("AreDeletedWhenUsed", func(t *testing.T) {
+		service := newTestService(t, fixtures.Fixtures{Accounts: []fixtures.Account{sue("")}})
+		service.advance(t, 25*time.Hour)
+
+		if service.IsAuthenticated("Sue", service.token) {
+			t.Fatalf("expected the session to have expired")
+		}
+		expectSessions(t, service, 0)
+	})
+
+	t.Run("AreSweptOutAtSignIn", func(t *testing.T) {
+		service := newTestService(t, fixtures.Fixtures{Accounts: []fixtures.Account{sue("")}})
+		service.authenticate(t, "Sue")
+		service.advance(t, 25*time.Hour)
+
+		service.authenticate(t, "Sue")
+		expectSessions(t, service, 1)
+	})
+}
+
+func expectSessions(t *testing.T, service testService, expected int) {
+	t.Helper()
+	sessions, err := service.repositories.Sessions.List()
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	if len(sessions) != expected {
+		t.Fatalf("expected %d stored sessions but got %d", expected, len(sessions))
+	}
+}
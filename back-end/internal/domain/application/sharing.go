@@ -0,0 +1,154 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+// InviteToProject invites the invitee's account to share one of an account's projects
+// with the given role, on behalf of the account holder signed in with token. Only the
+// owner may invite, and the invitee has no access until they accept. Inviting someone
+// again before they have answered changes the role they are invited with, while
+// inviting the owner or a member who has accepted is refused with
+// entities.ErrAlreadyMember.
+func (d *Service) InviteToProject(token string, name string, projectID string, invitee string, role entities.Role) error {
+	if role != entities.RoleViewer && role != entities.RoleEditor {
+		return fmt.Errorf("%w: %q, as it must be viewer or editor", entities.ErrInvalidRole, role)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	project, err := d.projectFor(token, name, projectID, entities.RoleOwner)
+	if err != nil {
+		return err
+	}
+	if project.IsArchived() {
+		return fmt.Errorf("%w: %s", entities.ErrProjectArchived, projectID)
+	}
+	account, err := d.accounts.Get(invitee)
+	if err != nil {
+		return err
+	}
+	if !account.IsActivated() || account.IsClosed() {
+		return fmt.Errorf("%w: %s", entities.ErrAccountNotFound, invitee)
+	}
+	if project.RoleOf(account.ID()) != "" {
+		return fmt.Errorf("%w: %s", entities.ErrAlreadyMember, invitee)
+	}
+	project.SetMember(entities.Member{AccountID: account.ID(), Role: role, InvitedAt: d.clock.Now().UTC()})
+	return d.projects.Update(project)
+}
+
+// GetInvitations retrieves the invitations to share projects that an account has not
+// yet answered, oldest first. Only the account holder may see them, so token must be
+// for one of their sessions.
+func (d *Service) GetInvitations(token string, name string) ([]entities.Invitation, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	account, err := d.authorize(token, name)
+	if err != nil {
+		return nil, err
+	}
+	return d.pendingInvitations(account)
+}
+
+// pendingInvitations returns the invitations to share projects that the account has not
+// yet answered, oldest first. The caller must hold the lock.
+func (d *Service) pendingInvitations(account entities.Account) ([]entities.Invitation, error) {
+	projects, err := d.projects.List()
+	if err != nil {
+		return nil, err
+	}
+	names, err := d.accountNames()
+	if err != nil {
+		return nil, err
+	}
+
+	invitations := []entities.Invitation{}
+	for _, project := range projects {
+		if member, ok := project.Member(account.ID()); ok && !member.Accepted {
+			invitations = append(invitations, entities.Invitation{
+				ProjectID:   project.ID(),
+				ProjectName: project.Name(),
+				Owner:       names[project.OwnerID()],
+				Role:        member.Role,
+				InvitedAt:   member.InvitedAt,
+			})
+		}
+	}
+	slices.SortStableFunc(invitations, func(a, b entities.Invitation) int { return a.InvitedAt.Compare(b.InvitedAt) })
+	return invitations, nil
+}
+
+// AcceptInvitation accepts an account's invitation to share a project, on behalf of the
+// account holder signed in with token, giving the account the role it was invited with
+func (d *Service) AcceptInvitation(token string, name string, projectID string) (entities.Project, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	project, member, err := d.invitation(token, name, projectID)
+	if err != nil {
+		return entities.Project{}, err
+	}
+	member.Accepted = true
+	project.SetMember(member)
+	if err := d.projects.Update(project); err != nil {
+		return entities.Project{}, err
+	}
+	return project, nil
+}
+
+// DeclineInvitation declines an account's invitation to share a project, on behalf of
+// the account holder signed in with token. The owner may invite the account again.
+func (d *Service) DeclineInvitation(token string, name string, projectID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	project, member, err := d.invitation(token, name, projectID)
+	if err != nil {
+		return err
+	}
+	project.RemoveMember(member.AccountID)
+	return d.projects.Update(project)
+}
+
+// invitation returns the project that the named account has been invited to share, and
+// the account's membership, if the session with the given token is signed in to the
+// account and the invitation has not been answered. The caller must hold the lock.
+func (d *Service) invitation(token string, name string, projectID string) (entities.Project, entities.Member, error) {
+	account, err := d.authorize(token, name)
+	if err != nil {
+		return entities.Project{}, entities.Member{}, err
+	}
+	project, err := d.projects.Get(projectID)
+	if err != nil && !errors.Is(err, entities.ErrProjectNotFound) {
+		return entities.Project{}, entities.Member{}, err
+	}
+	member, ok := project.Member(account.ID())
+	if err != nil || !ok || member.Accepted {
+		return entities.Project{}, entities.Member{}, fmt.Errorf("%w: %s", entities.ErrInvitationNotFound, projectID)
+	}
+	return project, member, nil
+}
+
+// visibleProjects returns the projects that the account with the given ID owns, in the
+// order they were added, followed by those shared with it, oldest first. The caller must
+// hold the lock.
+func (d *Service) visibleProjects(accountID string) ([]entities.Project, error) {
+	owned, err := d.projects.ListByOwner(accountID)
+	if err != nil {
+		return nil, err
+	}
+	all, err := d.projects.List()
+	if err != nil {
+		return nil, err
+	}
+	var shared []entities.Project
+	for _, project := range all {
+		if project.OwnerID() != accountID && project.RoleOf(accountID) != "" {
+			shared = append(shared, project)
+		}
+	}
+	slices.SortStableFunc(shared, func(a, b entities.Project) int { return a.CreatedAt().Compare(b.CreatedAt()) })
+	return append(owned, shared...), nil
+}
@@ -0,0 +1,144 @@
+package application_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/fixtures"
+)
+
+func TestShareProjects(t *testing.T) {
+	t.Run("SharesProjectOnceInvitationIsAccepted", func(t *testing.T) {
+		service := newServiceWithProjects(t, "Roadmap")
+		bob := service.signIn(t, "Bob")
+		roadmap := listProjects(t, service, entities.ProjectQuery{}).Projects[0]
+
+		if err := service.InviteToProject(service.token, "Sue", roadmap.ID(), "Bob", entities.RoleViewer); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		expectVisibleProjects(t, service, bob, "Bob", "")
+		invitations, err := service.GetInvitations(bob, "Bob")
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		if len(invitations) != 1 || invitations[0].ProjectName != "Roadmap" || invitations[0].Owner != "Sue" ||
+			invitations[0].Role != entities.RoleViewer {
+			t.Fatalf("expected an invitation from Sue to view Roadmap but got %+v", invitations)
+		}
+
+		if _, err := service.AcceptInvitation(bob, "Bob", roadmap.ID()); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		expectVisibleProjects(t, service, bob, "Bob", "Roadmap")
+		if _, err := service.GetProject(bob, "Bob", roadmap.ID()); err != nil {
+			t.Errorf("expected Bob to open the project but got %v", err)
+		}
+		if invitations, _ := service.GetInvitations(bob, "Bob"); len(invitations) != 0 {
+			t.Errorf("expected no invitations left but got %+v", invitations)
+		}
+	})
+
+	t.Run("ForgetsDeclinedInvitation", func(t *testing.T) {
+		service := newServiceWithProjects(t, "Roadmap")
+		bob := service.signIn(t, "Bob")
+		roadmap := listProjects(t, service, entities.ProjectQuery{}).Projects[0]
+		if err := service.InviteToProject(service.token, "Sue", roadmap.ID(), "Bob", entities.RoleEditor); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+
+		if err := service.DeclineInvitation(bob, "Bob", roadmap.ID()); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		expectVisibleProjects(t, service, bob, "Bob", "")
+		if _, err := service.AcceptInvitation(bob, "Bob", roadmap.ID()); !errors.Is(err, entities.ErrInvitationNotFound) {
+			t.Errorf("expected the declined invitation not to be found but got %v", err)
+		}
+		if _, err := service.GetProject(bob, "Bob", roadmap.ID()); !errors.Is(err, entities.ErrProjectNotFound) {
+			t.Errorf("expected the project not to be found but got %v", err)
+		}
+	})
+
+	t.Run("LetsEachRoleDoOnlyWhatItMay", func(t *testing.T) {
+		service := newServiceWithProjects(t, "Roadmap", "Budget")
+		bob := service.signIn(t, "Bob")
+		projects := listProjects(t, service, entities.ProjectQuery{}).Projects
+		roadmap, budget := projects[0], projects[1]
+		service.share(t, bob, "Bob", roadmap.ID(), entities.RoleViewer)
+		service.share(t, bob, "Bob", budget.ID(), entities.RoleEditor)
+
+		if _, err := service.RenameProject(bob, "Bob", roadmap.ID(), "Plan"); !errors.Is(err, entities.ErrAccessDenied) {
+			t.Errorf("expected a viewer to be refused renaming but got %v", err)
+		}
+		if _, err := service.RenameProject(bob, "Bob", budget.ID(), "Costs"); err != nil {
+			t.Errorf("expected an editor to rename but got %v", err)
+		}
+		if _, err := service.ArchiveProject(bob, "Bob", budget.ID()); !errors.Is(err, entities.ErrAccessDenied) {
+			t.Errorf("expected an editor to be refused archiving but got %v", err)
+		}
+		if err := service.DeleteProject(bob, "Bob", budget.ID()); !errors.Is(err, entities.ErrAccessDenied) {
+			t.Errorf("expected an editor to be refused deleting but got %v", err)
+		}
+		service.signIn(t, "Tanya")
+		if err := service.InviteToProject(bob, "Bob", budget.ID(), "Tanya", entities.RoleViewer); !errors.Is(err, entities.ErrAccessDenied) {
+			t.Errorf("expected an editor to be refused inviting but got %v", err)
+		}
+		expectNames(t, listProjects(t, service, entities.ProjectQuery{}).Projects, "Roadmap, Costs")
+	})
+
+	t.Run("RefusesInvalidInvitations", func(t *testing.T) {
+		service := newServiceWithProjects(t, "Roadmap")
+		bob := service.signIn(t, "Bob")
+		roadmap := listProjects(t, service, entities.ProjectQuery{}).Projects[0]
+
+		if err := service.InviteToProject(service.token, "Sue", roadmap.ID(), "Bob", entities.RoleOwner); !errors.Is(err, entities.ErrInvalidRole) {
+			t.Errorf("expected inviting an owner to be refused but got %v", err)
+		}
+		if err := service.InviteToProject(service.token, "Sue", roadmap.ID(), "Tanya", entities.RoleViewer); !errors.Is(err, entities.ErrAccountNotFound) {
+			t.Errorf("expected inviting a missing account to be refused but got %v", err)
+		}
+		if err := service.InviteToProject(service.token, "Sue", roadmap.ID(), "Sue", entities.RoleViewer); !errors.Is(err, entities.ErrAlreadyMember) {
+			t.Errorf("expected inviting the owner to be refused but got %v", err)
+		}
+		service.share(t, bob, "Bob", roadmap.ID(), entities.RoleViewer)
+		if err := service.InviteToProject(service.token, "Sue", roadmap.ID(), "Bob", entities.RoleEditor); !errors.Is(err, entities.ErrAlreadyMember) {
+			t.Errorf("expected inviting a member again to be refused but got %v", err)
+		}
+	})
+}
+
+// signIn seeds an activated account with the given name and signs in to it, returning
+// the session token
+func (s testService) signIn(t *testing.T, name string) string {
+	t.Helper()
+	return s.seed(t, fixtures.Fixtures{Accounts: []fixtures.Account{{Name: name, Password: testPassword, Activated: true}}})
+}
+
+// share has Sue invite the named account to the project with the given role, and the
+// account accept
+func (s testService) share(t *testing.T, token string, name string, projectID string, role entities.Role) {
+	t.Helper()
+	s.shareBetween(t, s.token, "Sue", projectID, token, name, role)
+}
+
+// shareBetween has the account named owner, signed in with ownerToken, invite the account
+// named member to the project with the given role, and the member, signed in with
+// memberToken, accept
+func (s testService) shareBetween(t *testing.T, ownerToken string, owner string, projectID string, memberToken string, member string, role entities.Role) {
+	t.Helper()
+	if err := s.InviteToProject(ownerToken, owner, projectID, member, role); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	if _, err := s.AcceptInvitation(memberToken, member, projectID); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+}
+
+func expectVisibleProjects(t *testing.T, service testService, token string, name string, expected string) {
+	t.Helper()
+	projects, err := service.GetProjects(token, name)
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	expectNames(t, projects, expected)
+}
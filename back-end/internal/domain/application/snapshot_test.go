@@ -0,0 +1,65 @@
+package application_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/internal/domain/application"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+func TestRestore(t *testing.T) {
+	t.Run("PutsBackWhatWasTaken", func(t *testing.T) {
+		service := newServiceWithProjects(t, "Roadmap", "Budget")
+		snapshot := takeSnapshot(t, service)
+		service.createProjectsAfter(t, 0, "Launch")
+
+		if err := service.Restore(snapshot); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		expectNames(t, listProjects(t, service, entities.ProjectQuery{}).Projects, "Roadmap, Budget")
+	})
+
+	for name, kind := range map[string]string{"Accounts": "accounts", "Projects": "projects", "Sessions": "sessions"} {
+		t.Run("RefusesDuplicate"+name+"LeavingDataAsItWas", func(t *testing.T) {
+			service := newServiceWithProjects(t, "Roadmap", "Budget")
+			data := duplicateFirst(t, takeSnapshot(t, service), kind)
+			service.createProjectsAfter(t, 0, "Launch")
+
+			if err := service.Restore(data); !errors.Is(err, application.ErrInvalidSnapshot) {
+				t.Fatalf("expected an invalid snapshot but got %v", err)
+			}
+			expectNames(t, listProjects(t, service, entities.ProjectQuery{}).Projects, "Roadmap, Budget, Launch")
+		})
+	}
+}
+
+func takeSnapshot(t *testing.T, service testService) []byte {
+	t.Helper()
+	snapshot, err := service.Snapshot()
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	return snapshot
+}
+
+// duplicateFirst adds a copy of the first of the kind of entity in snapshot to the end of
+// them
+func duplicateFirst(t *testing.T, snapshot []byte, kind string) []byte {
+	t.Helper()
+	var fields map[string]json.RawMessage
+	var items []json.RawMessage
+	if err := json.Unmarshal(snapshot, &fields); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	if err := json.Unmarshal(fields[kind], &items); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	fields[kind], _ = json.Marshal(append(items, items[0]))
+	data, err := json.Marshal(fields)
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	return data
+}
@@ -0,0 +1,134 @@
+package application
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+// minWebhookSecretLength is the shortest secret a webhook's payloads may be signed with
+const minWebhookSecretLength = 16
+
+// WebhookTarget is where a webhook posts an account's events to, and the secret that
+// its payloads are signed with
+type WebhookTarget struct {
+	URL    string
+	Secret string
+}
+
+// RegisterWebhook registers a webhook for an account, on behalf of the account holder
+// signed in with token
+func (d *Service) RegisterWebhook(token string, name string, target WebhookTarget) (entities.Webhook, error) {
+	if err := checkWebhookTarget(target); err != nil {
+		return entities.Webhook{}, err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	account, err := d.authorize(token, name)
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+	return d.addWebhook(account, target)
+}
+
+// GetWebhooks lists the webhooks registered for an account, on behalf of the account
+// holder signed in with token
+func (d *Service) GetWebhooks(token string, name string) ([]entities.Webhook, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	account, err := d.authorize(token, name)
+	if err != nil {
+		return nil, err
+	}
+	return d.webhooks.ListByOwner(account.ID())
+}
+
+// GetWebhook retrieves one of an account's webhooks, on behalf of the account holder
+// signed in with token
+func (d *Service) GetWebhook(token string, name string, webhookID string) (entities.Webhook, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.ownedWebhook(token, name, webhookID)
+}
+
+// DeleteWebhook stops one of an account's webhooks being posted to, on behalf of the
+// account holder signed in with token
+func (d *Service) DeleteWebhook(token string, name string, webhookID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, err := d.ownedWebhook(token, name, webhookID); err != nil {
+		return err
+	}
+	return d.webhooks.Delete(webhookID)
+}
+
+// WebhooksFor lists the webhooks that the named account's events are to be posted to.
+// It is for delivering them, so it is not on behalf of anyone and needs no session.
+func (d *Service) WebhooksFor(name string) ([]entities.Webhook, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	account, err := d.accounts.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return d.webhooks.ListByOwner(account.ID())
+}
+
+// addWebhook stores a new webhook for the account. The caller must hold the write lock.
+func (d *Service) addWebhook(account entities.Account, target WebhookTarget) (entities.Webhook, error) {
+	id, err := newID()
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+	webhook := *entities.NewWebhook(id, account.ID(), target.URL, target.Secret, d.clock.Now().UTC())
+	if err := d.webhooks.Add(webhook); err != nil {
+		return entities.Webhook{}, err
+	}
+	return webhook, nil
+}
+
+// deleteWebhooks removes all of the account's webhooks. The caller must hold the write lock.
+func (d *Service) deleteWebhooks(account entities.Account) error {
+	webhooks, err := d.webhooks.ListByOwner(account.ID())
+	if err != nil {
+		return err
+	}
+	for _, webhook := range webhooks {
+		if err := d.webhooks.Delete(webhook.ID()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ownedWebhook returns the webhook if it belongs to the named account and the session
+// with the given token is signed in to that account. Webhooks registered by anyone else
+// are reported as not found. The caller must hold the lock.
+func (d *Service) ownedWebhook(token string, name string, webhookID string) (entities.Webhook, error) {
+	account, err := d.authorize(token, name)
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+	webhook, err := d.webhooks.Get(webhookID)
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+	if webhook.OwnerID() != account.ID() {
+		return entities.Webhook{}, fmt.Errorf("%w: %s", entities.ErrWebhookNotFound, webhookID)
+	}
+	return webhook, nil
+}
+
+// checkWebhookTarget refuses webhooks that could not be posted to, or whose payloads
+// would be signed with a secret too short to be trusted
+func checkWebhookTarget(target WebhookTarget) error {
+	u, err := url.Parse(target.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: URL must be an absolute http or https URL", entities.ErrInvalidWebhook)
+	}
+	if len(target.Secret) < minWebhookSecretLength {
+		return fmt.Errorf("%w: secret must be at least %d characters", entities.ErrInvalidWebhook, minWebhookSecretLength)
+	}
+	return nil
+}
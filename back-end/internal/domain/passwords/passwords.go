@@ -0,0 +1,109 @@
+// Passwords package hashes and checks account passwords. Hashes are salted and
+// deliberately slow to compute, so that a stolen hash is expensive to crack.
+package passwords
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+const (
+	saltLength = 16
+	keyLength  = 32
+	scheme     = "pbkdf2-sha256"
+
+	MinLength = 8
+	MaxLength = 128
+)
+
+// Hasher hashes passwords with a given cost
+type Hasher struct {
+	// Iterations of PBKDF2-HMAC-SHA256. More makes hashes slower to crack, and to make.
+	Iterations int
+}
+
+// Default follows the OWASP recommendation for PBKDF2-HMAC-SHA256
+var Default = Hasher{Iterations: 600_000}
+
+// Hash returns an encoded, salted hash of password that records how it was made,
+// so that the cost can change without breaking existing hashes
+func (h Hasher) Hash(password string) (string, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	key, err := pbkdf2.Key(sha256.New, password, salt, h.Iterations, keyLength)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return strings.Join([]string{
+		scheme,
+		strconv.Itoa(h.Iterations),
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	}, "$"), nil
+}
+
+// VerifyNothing does the same work as verifying a hash made by h, but matches nothing.
+// Use it when there is no hash to check, so that the time taken does not reveal that
+// there isn't one.
+func (h Hasher) VerifyNothing(password string) {
+	_, _ = pbkdf2.Key(sha256.New, password, make([]byte, saltLength), h.Iterations, keyLength)
+}
+
+// Verify reports whether password matches a hash made by any Hasher
+func Verify(password, hash string) (bool, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 4 || parts[0] != scheme {
+		return false, errors.New("unrecognised password hash")
+	}
+	iter, err := strconv.Atoi(parts[1])
+	if err != nil || iter <= 0 {
+		return false, errors.New("invalid password hash iterations")
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false, fmt.Errorf("invalid password hash salt: %w", err)
+	}
+	expected, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false, fmt.Errorf("invalid password hash key: %w", err)
+	}
+	key, err := pbkdf2.Key(sha256.New, password, salt, iter, len(expected))
+	if err != nil {
+		return false, fmt.Errorf("failed to hash password: %w", err)
+	}
+	return subtle.ConstantTimeCompare(key, expected) == 1, nil
+}
+
+// CheckStrength returns an error wrapping entities.ErrWeakPassword if password
+// is too easy to guess for the named account
+func CheckStrength(name, password string) error {
+	length := utf8.RuneCountInString(password)
+	switch {
+	case length < MinLength:
+		return fmt.Errorf("%w: it must be at least %d characters long", entities.ErrWeakPassword, MinLength)
+	case length > MaxLength:
+		return fmt.Errorf("%w: it must be at most %d characters long", entities.ErrWeakPassword, MaxLength)
+	case !strings.ContainsFunc(password, unicode.IsLetter) || !strings.ContainsFunc(password, isNotLetter):
+		return fmt.Errorf("%w: it must contain both letters and other characters", entities.ErrWeakPassword)
+	case name != "" && strings.Contains(strings.ToLower(password), strings.ToLower(name)):
+		return fmt.Errorf("%w: it must not contain the account name", entities.ErrWeakPassword)
+	}
+	return nil
+}
+
+func isNotLetter(r rune) bool {
+	return !unicode.IsLetter(r)
+}
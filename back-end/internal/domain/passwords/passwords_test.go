@@ -0,0 +1,87 @@
+package passwords_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/internal/domain/passwords"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+func TestHash(t *testing.T) {
+	hash, err := passwords.Default.Hash("correct horse 1")
+	expectNoError(t, err)
+
+	t.Run("VerifiesMatchingPassword", func(t *testing.T) {
+		ok, err := passwords.Verify("correct horse 1", hash)
+		expectNoError(t, err)
+		if !ok {
+			t.Fatal("expected the password to match its hash")
+		}
+	})
+
+	t.Run("RejectsOtherPassword", func(t *testing.T) {
+		ok, err := passwords.Verify("correct horse 2", hash)
+		expectNoError(t, err)
+		if ok {
+			t.Fatal("expected a different password not to match")
+		}
+	})
+
+	t.Run("SaltsEachHash", func(t *testing.T) {
+		other, err := passwords.Default.Hash("correct horse 1")
+		expectNoError(t, err)
+		if other == hash {
+			t.Fatal("expected hashes of the same password to differ")
+		}
+	})
+
+	t.Run("VerifiesHashesOfAnyCost", func(t *testing.T) {
+		cheap, err := passwords.Hasher{Iterations: 1}.Hash("correct horse 1")
+		expectNoError(t, err)
+		ok, err := passwords.Verify("correct horse 1", cheap)
+		expectNoError(t, err)
+		if !ok {
+			t.Fatal("expected the password to match its hash")
+		}
+	})
+
+	t.Run("RejectsMalformedHash", func(t *testing.T) {
+		if _, err := passwords.Verify("correct horse 1", "plain"); err == nil {
+			t.Fatal("expected an error for a malformed hash")
+		}
+	})
+}
+
+func TestCheckStrength(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		password string
+		weak     bool
+	}{
+		{"Strong", "correct horse 1", false},
+		{"TooShort", "a1b2c3", true},
+		{"TooLong", strings.Repeat("a1", passwords.MaxLength), true},
+		{"OnlyLetters", "correcthorse", true},
+		{"OnlyDigits", "1234567890", true},
+		{"ContainsAccountName", "sue-is-great", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := passwords.CheckStrength("Sue", tc.password)
+			if tc.weak && !errors.Is(err, entities.ErrWeakPassword) {
+				t.Fatalf("expected error '%v' but got %v", entities.ErrWeakPassword, err)
+			}
+			if !tc.weak && err != nil {
+				t.Fatalf("expected no error but got %v", err)
+			}
+		})
+	}
+}
+
+func expectNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/testhelpers"
+)
+
+func TestFixtures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures.yaml")
+	fixture := `accounts:
+  - name: Sue
+    password: correct-horse-1
+    activated: true
+    plan: pro
+    projects:
+      - Roadmap
+      - Launch plan
+`
+	if err := os.WriteFile(path, []byte(fixture), 0o600); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	baseURL := testhelpers.NewInProcessServerWithFixtures(t, path)
+
+	for name, namespace := range map[string]string{"SeedsTheServer": "", "SeedsEachNamespace": "fixtures"} {
+		t.Run(name, func(t *testing.T) {
+			var account struct {
+				Name      string `json:"name"`
+				Activated bool   `json:"activated"`
+				Plan      string `json:"plan"`
+			}
+			fetch(t, "GET", baseURL+"/accounts/Sue", namespace, "", "", &account)
+			if account.Name != "Sue" || !account.Activated || account.Plan != "pro" {
+				t.Fatalf("expected Sue's account, activated and on the pro plan, but got %+v", account)
+			}
+
+			var session struct {
+				Token string `json:"token"`
+			}
+			fetch(t, "POST", baseURL+"/accounts/Sue/authenticate", namespace, "", `{"password": "correct-horse-1"}`, &session)
+			var projects []struct {
+				Name string `json:"name"`
+			}
+			fetch(t, "GET", baseURL+"/accounts/Sue/projects", namespace, session.Token, "", &projects)
+			var names []string
+			for _, project := range projects {
+				names = append(names, project.Name)
+			}
+			if got := strings.Join(names, ", "); got != "Roadmap, Launch plan" {
+				t.Fatalf("expected Sue's projects to be Roadmap, Launch plan but got %s", got)
+			}
+		})
+	}
+}
+
+// fetch sends a request that should succeed, in the namespace and signed in with token
+// if they are given, and decodes the response into v
+func fetch(t *testing.T, method string, url string, namespace string, token string, body string, v any) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if namespace != "" {
+		req.Header.Set("X-Test-Namespace", namespace)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected %s %s to succeed but got status %d", method, url, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+}
@@ -2,34 +2,166 @@ package server
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/sirockin/cucumber-screenplay-go/back-end/internal/domain/application"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/clock/manual"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events/eventlog"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events/feed"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/export"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/notifier/outbox"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/webhooks"
 )
 
 type Server struct {
 	domain *application.Service
-	mux    *http.ServeMux
+	outbox *outbox.Outbox
+	clock  *manual.Clock
+	events *eventlog.Log
+	feed   *feed.Feed
+	// deliveries logs how posting events to webhooks went
+	deliveries *webhooks.Dispatcher
+	namespaces *namespaces
+	// clearing is set if tests may clear all data
+	clearing bool
+	mux      *http.ServeMux
 }
 
-func NewServer(domainInstance *application.Service) *Server {
+// Option configures optional features of a Server
+type Option func(*Server)
+
+// WithTestClock lets tests advance c, which the domain should be telling the time
+// with, through a test endpoint. Tests can also take a snapshot of the domain's data
+// and restore it, turning c back to when the snapshot was taken. Without it the
+// endpoints do not exist.
+func WithTestClock(c *manual.Clock) Option {
+	return func(s *Server) {
+		s.clock = c
+	}
+}
+
+// WithClearing lets tests clear all of the domain's data through a test endpoint.
+// Without it the endpoint does not exist, so that no one can wipe the data.
+func WithClearing() Option {
+	return func(s *Server) {
+		s.clearing = true
+	}
+}
+
+// WithOutbox lets tests read the messages in o, which the domain should be sending to,
+// through a test endpoint. Without it the endpoint does not exist, so that no one can
+// read the activation links sent to other accounts.
+func WithOutbox(o *outbox.Outbox) Option {
+	return func(s *Server) {
+		s.outbox = o
+	}
+}
+
+// WithEventLog lets tests read the events in log, which the domain should be publishing
+// to, through a test endpoint. Without it the endpoint does not exist.
+func WithEventLog(log *eventlog.Log) Option {
+	return func(s *Server) {
+		s.events = log
+	}
+}
+
+// WithEventFeed lets account holders follow their account's events in f, which the
+// domain should be publishing to, as a stream. Without it the stream does not exist.
+func WithEventFeed(f *feed.Feed) Option {
+	return func(s *Server) {
+		s.feed = f
+	}
+}
+
+// WithWebhookDeliveries lets account holders read the log of deliveries to their
+// webhooks kept by d, which should be subscribed to the domain's events. Without it
+// the log does not exist.
+func WithWebhookDeliveries(d *webhooks.Dispatcher) Option {
+	return func(s *Server) {
+		s.deliveries = d
+	}
+}
+
+// WithNamespaces gives each namespace that requests name, in the X-Test-Namespace header
+// or the test-namespace cookie, a data set of its own, so that tests can share the server
+// without seeing or clearing each other's data. open makes the server for a namespace,
+// with any data kept for it, the first time the namespace is named, along with anything
+// to close when the server is no longer needed. Clearing all data in a namespace closes
+// it and then calls remove, if set, to delete its data. A namespace left unused for an
+// hour is closed, to be opened again if it is named again. Requests that name no
+// namespace are served with this server's own data. Without it, requests that name a
+// namespace are refused.
+func WithNamespaces(open func(namespace string) (*Server, io.Closer, error), remove func(namespace string) error) Option {
+	return func(s *Server) {
+		s.namespaces = &namespaces{open: open, remove: remove, servers: make(map[string]*namespace), removing: make(map[string]chan struct{})}
+	}
+}
+
+// NewServer creates a server for the domain
+func NewServer(domainInstance *application.Service, options ...Option) *Server {
 	s := &Server{
 		domain: domainInstance,
 		mux:    http.NewServeMux(),
 	}
+	for _, option := range options {
+		option(s)
+	}
 	s.setupRoutes()
 	return s
 }
 
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if name, ok := requestNamespace(r); ok {
+		s.serveNamespace(w, r, name)
+		return
+	}
 	s.mux.ServeHTTP(w, r)
 }
 
+// Close ends the server's event streams and stops its webhook deliveries, along with
+// those of its namespaces. Requests in a namespace are ended and waited for before what
+// the namespace holds is released; the server's own requests are not waited for.
+func (s *Server) Close() {
+	if s.feed != nil {
+		s.feed.Clear()
+	}
+	if s.deliveries != nil {
+		s.deliveries.Close()
+	}
+	if s.namespaces != nil {
+		s.namespaces.close()
+	}
+}
+
 func (s *Server) setupRoutes() {
 	s.mux.HandleFunc("/accounts", s.handleAccounts)
 	s.mux.HandleFunc("/accounts/", s.handleAccountsWithName)
-	s.mux.HandleFunc("/clear", s.handleClear)
+	s.mux.HandleFunc("/sessions/current/sign-out", s.handleSignOut)
+	s.mux.HandleFunc("/orgs", s.handleOrganisations)
+	s.mux.HandleFunc("/orgs/", s.handleOrganisationsWithName)
+	if s.clock != nil {
+		s.mux.HandleFunc("/clock/advance", s.handleAdvanceClock)
+		s.mux.HandleFunc("/admin/snapshot", s.handleSnapshot)
+	}
+	if s.clearing {
+		s.mux.HandleFunc("/clear", s.handleClear)
+	}
+	if s.outbox != nil {
+		s.mux.HandleFunc("/outbox/", s.handleOutbox)
+	}
+	if s.events != nil {
+		s.mux.HandleFunc("/events/", s.handleEvents)
+	}
 }
 
 func (s *Server) handleAccounts(w http.ResponseWriter, r *http.Request) {
@@ -58,6 +190,8 @@ func (s *Server) handleAccountsWithName(w http.ResponseWriter, r *http.Request)
 		switch r.Method {
 		case "GET":
 			s.getAccount(w, r, accountName)
+		case "DELETE":
+			s.closeAccount(w, r, accountName)
 		default:
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
@@ -82,6 +216,14 @@ func (s *Server) handleAccountsWithName(w http.ResponseWriter, r *http.Request)
 			} else {
 				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			}
+		case "events":
+			if s.feed == nil {
+				http.Error(w, "Not found", http.StatusNotFound)
+			} else if r.Method == "GET" {
+				s.streamEvents(w, r, accountName)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
 		case "projects":
 			switch r.Method {
 			case "GET":
@@ -91,8 +233,89 @@ func (s *Server) handleAccountsWithName(w http.ResponseWriter, r *http.Request)
 			default:
 				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			}
+		case "plan":
+			if r.Method == "PUT" {
+				s.changePlan(w, r, accountName)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		case "export":
+			if r.Method == "GET" {
+				s.exportAccount(w, r, accountName)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		case "invitations":
+			if r.Method == "GET" {
+				s.getInvitations(w, r, accountName)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		case "webhooks":
+			switch r.Method {
+			case "GET":
+				s.getWebhooks(w, r, accountName)
+			case "POST":
+				s.registerWebhook(w, r, accountName)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		default:
+			http.Error(w, "Not found", http.StatusNotFound)
+		}
+	} else if len(parts) == 3 && parts[1] == "projects" {
+		// /accounts/{name}/projects/{id}
+		projectID := parts[2]
+		switch r.Method {
+		case "GET":
+			s.getProject(w, r, accountName, projectID)
+		case "PATCH":
+			s.updateProject(w, r, accountName, projectID)
+		case "DELETE":
+			s.deleteProject(w, r, accountName, projectID)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	} else if len(parts) == 4 && parts[1] == "projects" && (parts[3] == "archive" || parts[3] == "restore") {
+		// /accounts/{name}/projects/{id}/archive and /accounts/{name}/projects/{id}/restore
+		if r.Method == "POST" {
+			s.moveProject(w, r, accountName, parts[2], parts[3])
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	} else if len(parts) == 4 && parts[1] == "projects" && parts[3] == "invitations" {
+		// /accounts/{name}/projects/{id}/invitations
+		if r.Method == "POST" {
+			s.inviteToProject(w, r, accountName, parts[2])
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	} else if len(parts) == 4 && parts[1] == "invitations" && (parts[3] == "accept" || parts[3] == "decline") {
+		// /accounts/{name}/invitations/{projectId}/accept and /accounts/{name}/invitations/{projectId}/decline
+		if r.Method == "POST" {
+			s.answerInvitation(w, r, accountName, parts[2], parts[3])
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	} else if len(parts) == 3 && parts[1] == "webhooks" {
+		// /accounts/{name}/webhooks/{id}
+		webhookID := parts[2]
+		switch r.Method {
+		case "GET":
+			s.getWebhook(w, r, accountName, webhookID)
+		case "DELETE":
+			s.deleteWebhook(w, r, accountName, webhookID)
 		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	} else if len(parts) == 4 && parts[1] == "webhooks" && parts[3] == "deliveries" {
+		// /accounts/{name}/webhooks/{id}/deliveries
+		if s.deliveries == nil {
 			http.Error(w, "Not found", http.StatusNotFound)
+		} else if r.Method == "GET" {
+			s.getWebhookDeliveries(w, r, accountName, parts[2])
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
 	} else {
 		http.Error(w, "Not found", http.StatusNotFound)
@@ -108,9 +331,73 @@ func (s *Server) handleClear(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (s *Server) handleSignOut(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "POST":
+		s.signOut(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleAdvanceClock(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "POST":
+		s.advanceClock(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleSnapshot(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+		s.getSnapshot(w, r)
+	case "PUT":
+		s.restoreSnapshot(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleOutbox(w http.ResponseWriter, r *http.Request) {
+	// Extract account name from path
+	name := strings.TrimPrefix(r.URL.Path, "/outbox/")
+	if name == "" || strings.Contains(name, "/") {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		s.getOutbox(w, r, name)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	// Extract account name from path
+	name := strings.TrimPrefix(r.URL.Path, "/events/")
+	if name == "" || strings.Contains(name, "/") {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		s.getEvents(w, r, name)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
 func (s *Server) createAccount(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		Name string `json:"name"`
+		Name     string `json:"name"`
+		Password string `json:"password"`
+		// Webhook is registered along with the account, so that it is told when the account is activated
+		Webhook *webhookRequest `json:"webhook"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -123,8 +410,13 @@ func (s *Server) createAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := s.domain.CreateAccount(req.Name); err != nil {
-		s.writeError(w, err.Error(), http.StatusInternalServerError)
+	var targets []application.WebhookTarget
+	if req.Webhook != nil {
+		targets = append(targets, req.Webhook.toTarget())
+	}
+
+	if err := s.domain.CreateAccount(req.Name, req.Password, targets...); err != nil {
+		s.writeDomainError(w, err)
 		return
 	}
 
@@ -134,22 +426,26 @@ func (s *Server) createAccount(w http.ResponseWriter, r *http.Request) {
 func (s *Server) getAccount(w http.ResponseWriter, _ *http.Request, name string) {
 	account, err := s.domain.GetAccount(name)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			s.writeError(w, err.Error(), http.StatusNotFound)
-		} else {
-			s.writeError(w, err.Error(), http.StatusInternalServerError)
-		}
+		s.writeDomainError(w, err)
 		return
 	}
 
 	response := struct {
-		Name          string `json:"name"`
-		Activated     bool   `json:"activated"`
-		Authenticated bool   `json:"authenticated"`
+		ID        string        `json:"id"`
+		Name      string        `json:"name"`
+		Activated bool          `json:"activated"`
+		Plan      entities.Plan `json:"plan"`
+		// ClosedAt is only given for closed accounts
+		ClosedAt *time.Time `json:"closedAt,omitempty"`
 	}{
-		Name:          account.Name(),
-		Activated:     account.IsActivated(),
-		Authenticated: account.IsAuthenticated(),
+		ID:        account.ID(),
+		Name:      account.Name(),
+		Activated: account.IsActivated(),
+		Plan:      account.Plan(),
+	}
+	if account.IsClosed() {
+		closedAt := account.ClosedAt()
+		response.ClosedAt = &closedAt
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -159,46 +455,122 @@ func (s *Server) getAccount(w http.ResponseWriter, _ *http.Request, name string)
 	}
 }
 
+// changePlan moves an account to another plan, as its holder
+func (s *Server) changePlan(w http.ResponseWriter, r *http.Request, name string) {
+	var req struct {
+		Plan entities.Plan `json:"plan"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.domain.ChangePlan(bearerToken(r), name, req.Plan); err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// closeAccount closes an account for good, as its holder
+func (s *Server) closeAccount(w http.ResponseWriter, r *http.Request, name string) {
+	if err := s.domain.CloseAccount(bearerToken(r), name); err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// exportAccount sends the account holder all of their account's data, as an archive to
+// download rather than show
+func (s *Server) exportAccount(w http.ResponseWriter, r *http.Request, name string) {
+	archive, err := s.domain.ExportAccount(bearerToken(r), name)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": export.Filename(name)}))
+	if err := json.NewEncoder(w).Encode(archive); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
 func (s *Server) activateAccount(w http.ResponseWriter, r *http.Request, name string) {
-	if err := s.domain.Activate(name); err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			s.writeError(w, err.Error(), http.StatusNotFound)
-		} else {
-			s.writeError(w, err.Error(), http.StatusInternalServerError)
-		}
+	var req struct {
+		Token string `json:"token"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	token, err := s.domain.Activate(name, req.Token)
+	if err != nil {
+		s.writeDomainError(w, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	s.writeSessionToken(w, token)
 }
 
 func (s *Server) authenticateAccount(w http.ResponseWriter, r *http.Request, name string) {
-	if err := s.domain.Authenticate(name); err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			s.writeError(w, err.Error(), http.StatusNotFound)
-		} else if strings.Contains(err.Error(), "activate") {
-			s.writeError(w, err.Error(), http.StatusBadRequest)
-		} else {
-			s.writeError(w, err.Error(), http.StatusInternalServerError)
-		}
+	var req struct {
+		Password string `json:"password"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, "Invalid JSON", http.StatusBadRequest)
 		return
 	}
 
+	token, err := s.domain.Authenticate(name, req.Password)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	s.writeSessionToken(w, token)
+}
+
+// writeSessionToken gives the client the token for its new session
+func (s *Server) writeSessionToken(w http.ResponseWriter, token application.SessionToken) {
 	response := struct {
-		Authenticated bool `json:"authenticated"`
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expiresAt"`
 	}{
-		Authenticated: true,
+		Token:     token.Value,
+		ExpiresAt: token.ExpiresAt,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	// Session tokens are credentials, so must not be kept by caches
+	w.Header().Set("Cache-Control", "no-store")
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 		return
 	}
 }
 
+func (s *Server) signOut(w http.ResponseWriter, r *http.Request) {
+	if err := s.domain.SignOut(bearerToken(r)); err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getAuthenticationStatus reports whether the caller's own session, identified by
+// its bearer token, is signed in to the account
 func (s *Server) getAuthenticationStatus(w http.ResponseWriter, r *http.Request, name string) {
-	authenticated := s.domain.IsAuthenticated(name)
+	authenticated := s.domain.IsAuthenticated(name, bearerToken(r))
 
 	response := struct {
 		Authenticated bool `json:"authenticated"`
@@ -213,50 +585,412 @@ func (s *Server) getAuthenticationStatus(w http.ResponseWriter, r *http.Request,
 	}
 }
 
+// getProjects lists a page of an account's projects. The projects are a plain array, and
+// the next page, if there is one, is linked to in the Link header.
 func (s *Server) getProjects(w http.ResponseWriter, r *http.Request, name string) {
-	projects, err := s.domain.GetProjects(name)
+	query, err := projectQuery(r.URL.Query())
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			s.writeError(w, err.Error(), http.StatusNotFound)
-		} else {
-			s.writeError(w, err.Error(), http.StatusInternalServerError)
-		}
+		s.writeDomainError(w, err)
+		return
+	}
+	page, err := s.domain.ListProjects(bearerToken(r), name, query)
+	if err != nil {
+		s.writeDomainError(w, err)
 		return
 	}
 
+	response := make([]projectResponse, 0, len(page.Projects))
+	for _, project := range page.Projects {
+		response = append(response, newProjectResponse(project))
+	}
+
+	if page.NextCursor != "" {
+		next := r.URL.Query()
+		next.Set("cursor", page.NextCursor)
+		w.Header().Set("Link", fmt.Sprintf(`<%s?%s>; rel="next"`, r.URL.EscapedPath(), next.Encode()))
+	}
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(projects); err != nil {
+	if err := json.NewEncoder(w).Encode(response); err != nil {
 		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 		return
 	}
 }
 
 func (s *Server) createProject(w http.ResponseWriter, r *http.Request, name string) {
-	if err := s.domain.CreateProject(name); err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			s.writeError(w, err.Error(), http.StatusNotFound)
-		} else {
-			s.writeError(w, err.Error(), http.StatusInternalServerError)
-		}
+	var req struct {
+		Name string `json:"name"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, "Invalid JSON", http.StatusBadRequest)
 		return
 	}
 
-	w.WriteHeader(http.StatusCreated)
+	if req.Name == "" {
+		s.writeError(w, "Name is required", http.StatusBadRequest)
+		return
+	}
+
+	project, err := s.domain.CreateProject(bearerToken(r), name, req.Name)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/accounts/"+url.PathEscape(name)+"/projects/"+url.PathEscape(project.ID()))
+	s.writeProject(w, project, http.StatusCreated)
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request, name string, projectID string) {
+	project, err := s.domain.GetProject(bearerToken(r), name, projectID)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	s.writeProject(w, project, http.StatusOK)
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request, name string, projectID string) {
+	var req struct {
+		Name *string `json:"name"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if req.Name == nil {
+		// Nothing to change
+		s.getProject(w, r, name, projectID)
+		return
+	}
+
+	if *req.Name == "" {
+		s.writeError(w, "Name must not be empty", http.StatusBadRequest)
+		return
+	}
+
+	project, err := s.domain.RenameProject(bearerToken(r), name, projectID, *req.Name)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	s.writeProject(w, project, http.StatusOK)
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request, name string, projectID string) {
+	if err := s.domain.DeleteProject(bearerToken(r), name, projectID); err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// moveProject archives or restores a project, as action says
+func (s *Server) moveProject(w http.ResponseWriter, r *http.Request, name string, projectID string, action string) {
+	move := s.domain.ArchiveProject
+	if action == "restore" {
+		move = s.domain.RestoreProject
+	}
+	project, err := move(bearerToken(r), name, projectID)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	s.writeProject(w, project, http.StatusOK)
+}
+
+// projectResponse is the JSON representation of a project
+type projectResponse struct {
+	ID        string                `json:"id"`
+	Name      string                `json:"name"`
+	OwnerID   string                `json:"ownerId"`
+	CreatedAt time.Time             `json:"createdAt"`
+	State     entities.ProjectState `json:"state"`
+}
+
+func newProjectResponse(project entities.Project) projectResponse {
+	return projectResponse{
+		ID:        project.ID(),
+		Name:      project.Name(),
+		OwnerID:   project.OwnerID(),
+		CreatedAt: project.CreatedAt(),
+		State:     project.State(),
+	}
+}
+
+func (s *Server) writeProject(w http.ResponseWriter, project entities.Project, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(newProjectResponse(project)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
 }
 
 func (s *Server) clearAll(w http.ResponseWriter, r *http.Request) {
-	s.domain.ClearAll()
+	if err := s.domain.ClearAll(); err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+	s.clearRecords()
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// snapshotBody is a snapshot of the domain's data, along with the time it was taken
+type snapshotBody struct {
+	TakenAt time.Time       `json:"takenAt"`
+	State   json.RawMessage `json:"state"`
+}
+
+func (s *Server) getSnapshot(w http.ResponseWriter, _ *http.Request) {
+	takenAt := s.clock.Now()
+	state, err := s.domain.Snapshot()
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(snapshotBody{TakenAt: takenAt, State: state}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// restoreSnapshot replaces the domain's data with the snapshot and turns the clock back
+// to when it was taken. Messages, events and webhook deliveries are not part of the
+// snapshot, so they are cleared as they are when clearing all data.
+func (s *Server) restoreSnapshot(w http.ResponseWriter, r *http.Request) {
+	var req snapshotBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if req.TakenAt.IsZero() || len(req.State) == 0 {
+		s.writeError(w, "Snapshot must have takenAt and state", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.domain.Restore(req.State); err != nil {
+		if errors.Is(err, application.ErrInvalidSnapshot) {
+			s.writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.writeDomainError(w, err)
+		return
+	}
+	s.clock.Set(req.TakenAt)
+	s.clearRecords()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// clearRecords clears what the server keeps of what the domain has done: the messages
+// it has sent, the events it has published and the deliveries of them to webhooks
+func (s *Server) clearRecords() {
+	if s.outbox != nil {
+		s.outbox.Clear()
+	}
+	if s.events != nil {
+		s.events.Clear()
+	}
+	if s.feed != nil {
+		s.feed.Clear()
+	}
+	if s.deliveries != nil {
+		s.deliveries.Clear()
+	}
+}
+
+func (s *Server) advanceClock(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Duration string `json:"duration"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil {
+		s.writeError(w, "Duration must be a Go duration such as 192h", http.StatusBadRequest)
+		return
+	}
+
+	now, err := s.clock.Advance(duration)
+	if err != nil {
+		s.writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := struct {
+		Now time.Time `json:"now"`
+	}{
+		Now: now,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s *Server) getOutbox(w http.ResponseWriter, _ *http.Request, name string) {
+	type messageResponse struct {
+		To      string `json:"to"`
+		Subject string `json:"subject"`
+		Body    string `json:"body"`
+		Link    string `json:"link"`
+	}
+
+	messages := s.outbox.Messages(name)
+	response := make([]messageResponse, 0, len(messages))
+	for _, message := range messages {
+		response = append(response, messageResponse{
+			To:      message.To,
+			Subject: message.Subject,
+			Body:    message.Body,
+			Link:    message.Link,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s *Server) getEvents(w http.ResponseWriter, _ *http.Request, name string) {
+	published := s.events.Events(name)
+	response := make([]eventResponse, 0, len(published))
+	for _, event := range published {
+		response = append(response, newEventResponse(event))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// eventResponse is the JSON representation of an event
+type eventResponse struct {
+	Kind       string    `json:"kind"`
+	Account    string    `json:"account"`
+	ProjectID  string    `json:"projectId,omitempty"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+func newEventResponse(event events.Event) eventResponse {
+	return eventResponse{
+		Kind:       string(event.Kind),
+		Account:    event.Account,
+		ProjectID:  event.ProjectID,
+		OccurredAt: event.OccurredAt,
+	}
+}
+
+// bearerToken returns the session token the caller sent in its Authorization header,
+// or "" if it sent none
+func bearerToken(r *http.Request) string {
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// projectQuery reads the query parameters that choose which projects to list. Times are
+// in RFC 3339 format.
+func projectQuery(values url.Values) (entities.ProjectQuery, error) {
+	query := entities.ProjectQuery{
+		Cursor:     values.Get("cursor"),
+		Sort:       entities.ProjectSort(values.Get("sort")),
+		NamePrefix: values.Get("namePrefix"),
+	}
+	if archived := values.Get("includeArchived"); archived != "" {
+		include, err := strconv.ParseBool(archived)
+		if err != nil {
+			return entities.ProjectQuery{}, fmt.Errorf("%w: includeArchived must be true or false", entities.ErrInvalidQuery)
+		}
+		query.IncludeArchived = include
+	}
+	if limit := values.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 {
+			return entities.ProjectQuery{}, fmt.Errorf("%w: limit must be a whole number from 1", entities.ErrInvalidQuery)
+		}
+		query.Limit = n
+	}
+	for param, t := range map[string]*time.Time{"createdFrom": &query.CreatedFrom, "createdBefore": &query.CreatedBefore} {
+		if value := values.Get(param); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return entities.ProjectQuery{}, fmt.Errorf("%w: %s must be a time such as 2025-01-31T00:00:00Z", entities.ErrInvalidQuery, param)
+			}
+			*t = parsed
+		}
+	}
+	return query, nil
+}
+
+// writeDomainError writes an error returned by the domain, choosing the status code
+// from the kind of error and including its code so that clients can tell them apart
+func (s *Server) writeDomainError(w http.ResponseWriter, err error) {
+	s.writeErrorWithCode(w, err.Error(), entities.ErrorCode(err), domainErrorStatus(err))
+}
+
+func domainErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, entities.ErrAccountNotFound), errors.Is(err, entities.ErrProjectNotFound),
+		errors.Is(err, entities.ErrWebhookNotFound), errors.Is(err, entities.ErrInvitationNotFound),
+		errors.Is(err, entities.ErrOrganisationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, entities.ErrAccountNotActivated), errors.Is(err, entities.ErrInvalidActivation),
+		errors.Is(err, entities.ErrWeakPassword), errors.Is(err, entities.ErrActivationExpired),
+		errors.Is(err, entities.ErrInvalidWebhook), errors.Is(err, entities.ErrInvalidQuery),
+		errors.Is(err, entities.ErrInvalidRole), errors.Is(err, entities.ErrInvalidPlan):
+		return http.StatusBadRequest
+	case errors.Is(err, entities.ErrWrongCredentials), errors.Is(err, entities.ErrNotSignedIn),
+		errors.Is(err, entities.ErrSessionNotFound):
+		return http.StatusUnauthorized
+	case errors.Is(err, entities.ErrQuotaExceeded):
+		return http.StatusPaymentRequired
+	case errors.Is(err, entities.ErrAccessDenied):
+		return http.StatusForbidden
+	case errors.Is(err, entities.ErrAccountExists), errors.Is(err, entities.ErrProjectArchived),
+		errors.Is(err, entities.ErrProjectNotArchived), errors.Is(err, entities.ErrAlreadyMember),
+		errors.Is(err, entities.ErrOrganisationExists), errors.Is(err, entities.ErrLastOwner):
+		return http.StatusConflict
+	case errors.Is(err, entities.ErrAccountLocked):
+		return http.StatusLocked
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 func (s *Server) writeError(w http.ResponseWriter, message string, statusCode int) {
+	s.writeErrorWithCode(w, message, "", statusCode)
+}
+
+func (s *Server) writeErrorWithCode(w http.ResponseWriter, message string, code string, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 
 	errorResponse := struct {
 		Error string `json:"error"`
+		Code  string `json:"code,omitempty"`
 	}{
 		Error: message,
+		Code:  code,
 	}
 
 	if err := json.NewEncoder(w).Encode(errorResponse); err != nil {
@@ -0,0 +1,48 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/internal/domain/application"
+	server "github.com/sirockin/cucumber-screenplay-go/back-end/internal/http"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/notifier/outbox"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/testhelpers"
+)
+
+func TestOutbox(t *testing.T) {
+	t.Run("IsOnlyServedWithAnOutbox", func(t *testing.T) {
+		httpServer := httptest.NewServer(server.NewServer(application.New(application.WithNotifier(outbox.New()))))
+		t.Cleanup(httpServer.Close)
+
+		expectStatus(t, send(t, "POST", httpServer.URL+"/accounts", "", `{"name": "Sue", "password": "correct-horse-1"}`), http.StatusCreated)
+		expectStatus(t, send(t, "GET", httpServer.URL+"/outbox/Sue", "", ""), http.StatusNotFound)
+	})
+
+	t.Run("IsServedWithAnOutbox", func(t *testing.T) {
+		baseURL := testhelpers.NewInProcessServer(t)
+
+		expectStatus(t, send(t, "POST", baseURL+"/accounts", "outbox", `{"name": "Sue", "password": "correct-horse-1"}`), http.StatusCreated)
+		expectStatus(t, send(t, "GET", baseURL+"/outbox/Sue", "outbox", ""), http.StatusOK)
+	})
+}
+
+func TestClear(t *testing.T) {
+	t.Run("IsOnlyServedWithClearing", func(t *testing.T) {
+		httpServer := httptest.NewServer(server.NewServer(application.New()))
+		t.Cleanup(httpServer.Close)
+
+		expectStatus(t, send(t, "POST", httpServer.URL+"/accounts", "", `{"name": "Sue", "password": "correct-horse-1"}`), http.StatusCreated)
+		expectStatus(t, send(t, "DELETE", httpServer.URL+"/clear", "", ""), http.StatusNotFound)
+		expectStatus(t, send(t, "GET", httpServer.URL+"/accounts/Sue", "", ""), http.StatusOK)
+	})
+
+	t.Run("IsServedWithClearing", func(t *testing.T) {
+		baseURL := testhelpers.NewInProcessServer(t)
+
+		expectStatus(t, send(t, "POST", baseURL+"/accounts", "clear", `{"name": "Sue", "password": "correct-horse-1"}`), http.StatusCreated)
+		expectStatus(t, send(t, "DELETE", baseURL+"/clear", "clear", ""), http.StatusNoContent)
+		expectStatus(t, send(t, "GET", baseURL+"/accounts/Sue", "clear", ""), http.StatusNotFound)
+	})
+}
@@ -0,0 +1,241 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+)
+
+const (
+	// NamespaceHeader names the namespace a request is in
+	NamespaceHeader = "X-Test-Namespace"
+	// NamespaceCookie names the namespace a browser's requests are in, as browsers cannot
+	// add a header to every request they make, such as those for event streams
+	NamespaceCookie = "test-namespace"
+	// namespaceIdleTimeout is how long a namespace is kept open after its last request
+	// ends, so that those left behind by test runs do not hold on to memory
+	namespaceIdleTimeout = time.Hour
+)
+
+// validNamespace matches the names namespaces may have, which are safe to use in paths
+var validNamespace = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// namespaces holds the server for each namespace that is open. Namespaces are opened and
+// removed without holding the lock, so that a slow one does not hold up requests in the
+// others.
+type namespaces struct {
+	open   func(namespace string) (*Server, io.Closer, error)
+	remove func(namespace string) error
+
+	mu      sync.Mutex
+	servers map[string]*namespace
+	// removing holds a channel for each namespace whose data is being removed, which is
+	// closed once it has been, so that the namespace is not opened again before then
+	removing map[string]chan struct{}
+}
+
+// namespace is an open namespace, and how it is being used
+type namespace struct {
+	// opened is closed once the namespace has been opened, or has failed to open with err
+	opened chan struct{}
+	err    error
+	server *Server
+	closer io.Closer
+	// ctx is cancelled when the namespace is closed, to end its requests in progress,
+	// such as event streams
+	ctx    context.Context
+	cancel context.CancelFunc
+	// requests are the namespace's requests in progress, which are waited for before
+	// closing it, and active counts them to keep it open
+	requests sync.WaitGroup
+	active   int
+	lastUsed time.Time
+}
+
+// requestNamespace returns the namespace the request names, if any
+func requestNamespace(r *http.Request) (string, bool) {
+	if name := r.Header.Get(NamespaceHeader); name != "" {
+		return name, true
+	}
+	if cookie, err := r.Cookie(NamespaceCookie); err == nil && cookie.Value != "" {
+		return cookie.Value, true
+	}
+	return "", false
+}
+
+// serveNamespace serves a request in the named namespace. Clearing all data removes the
+// namespace, which starts afresh when it is next named.
+func (s *Server) serveNamespace(w http.ResponseWriter, r *http.Request, name string) {
+	if s.namespaces == nil {
+		s.writeError(w, "Namespaces are only available in test mode", http.StatusBadRequest)
+		return
+	}
+	if !validNamespace.MatchString(name) {
+		s.writeError(w, "Namespace must be 1 to 64 letters, digits, hyphens and underscores", http.StatusBadRequest)
+		return
+	}
+	if r.URL.Path == "/clear" && r.Method == "DELETE" {
+		if err := s.namespaces.drop(name); err != nil {
+			s.writeDomainError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	ns, err := s.namespaces.use(name)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+	defer s.namespaces.done(ns)
+	// Requests end when the namespace is closed, rather than keeping it from closing
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	defer context.AfterFunc(ns.ctx, cancel)()
+	// The namespace's server serves the request as its own, rather than looking for
+	// the namespace again
+	ns.server.mux.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// use returns the named namespace, opening it if it is not open, and closes namespaces
+// that have been idle for too long. Call done with it when the request ends.
+func (n *namespaces) use(name string) (*namespace, error) {
+	n.mu.Lock()
+	// Wait for the namespace's data to be removed before opening it again
+	for {
+		removed, ok := n.removing[name]
+		if !ok {
+			break
+		}
+		n.mu.Unlock()
+		<-removed
+		n.mu.Lock()
+	}
+	now := time.Now()
+	var idle []*namespace
+	for other, ns := range n.servers {
+		if other != name && ns.active == 0 && now.Sub(ns.lastUsed) > namespaceIdleTimeout {
+			delete(n.servers, other)
+			idle = append(idle, ns)
+		}
+	}
+	ns, ok := n.servers[name]
+	if !ok {
+		ns = &namespace{opened: make(chan struct{})}
+		n.servers[name] = ns
+	}
+	ns.active++
+	ns.requests.Add(1)
+	n.mu.Unlock()
+
+	for _, ns := range idle {
+		ns.close()
+	}
+	if !ok {
+		n.openNamespace(name, ns)
+	}
+	<-ns.opened
+	if ns.err != nil {
+		n.done(ns)
+		return nil, ns.err
+	}
+	return ns, nil
+}
+
+// openNamespace opens a namespace that use has just made, forgetting it again if it
+// cannot be opened, so that the next request tries again
+func (n *namespaces) openNamespace(name string, ns *namespace) {
+	defer close(ns.opened)
+	server, closer, err := n.open(name)
+	if err != nil {
+		ns.err = fmt.Errorf("failed to open namespace %s: %w", name, err)
+		n.mu.Lock()
+		if n.servers[name] == ns {
+			delete(n.servers, name)
+		}
+		n.mu.Unlock()
+		return
+	}
+	ns.server, ns.closer = server, closer
+	ns.ctx, ns.cancel = context.WithCancel(context.Background())
+}
+
+// done records that a request in the namespace has ended
+func (n *namespaces) done(ns *namespace) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	ns.active--
+	ns.lastUsed = time.Now()
+	ns.requests.Done()
+}
+
+// drop closes the namespace, if it is open, and removes its data. Requests naming the
+// namespace wait until it has been removed, and then open it afresh.
+func (n *namespaces) drop(name string) error {
+	n.mu.Lock()
+	for {
+		removed, ok := n.removing[name]
+		if !ok {
+			break
+		}
+		n.mu.Unlock()
+		<-removed
+		n.mu.Lock()
+	}
+	ns, ok := n.servers[name]
+	delete(n.servers, name)
+	removed := make(chan struct{})
+	n.removing[name] = removed
+	n.mu.Unlock()
+
+	defer func() {
+		n.mu.Lock()
+		delete(n.removing, name)
+		n.mu.Unlock()
+		close(removed)
+	}()
+	if ok {
+		ns.close()
+	}
+	if n.remove == nil {
+		return nil
+	}
+	if err := n.remove(name); err != nil {
+		return fmt.Errorf("failed to remove namespace %s: %w", name, err)
+	}
+	return nil
+}
+
+// close closes every namespace, keeping their data
+func (n *namespaces) close() {
+	n.mu.Lock()
+	servers := n.servers
+	n.servers = make(map[string]*namespace)
+	n.mu.Unlock()
+
+	for _, ns := range servers {
+		ns.close()
+	}
+}
+
+// close ends the namespace's requests, streams and background work, waits for its
+// requests to finish, and then releases what it holds. Nobody is waiting to hear if
+// releasing fails, and storage is expected to recover anything written before it did,
+// so the error is dropped.
+func (ns *namespace) close() {
+	<-ns.opened
+	if ns.err != nil {
+		return
+	}
+	ns.cancel()
+	ns.server.Close()
+	ns.requests.Wait()
+	if ns.closer != nil {
+		_ = ns.closer.Close()
+	}
+}
@@ -0,0 +1,124 @@
+package server_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/internal/domain/application"
+	server "github.com/sirockin/cucumber-screenplay-go/back-end/internal/http"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/testhelpers"
+)
+
+func TestNamespaces(t *testing.T) {
+	baseURL := testhelpers.NewInProcessServer(t)
+
+	t.Run("KeepsDataApart", func(t *testing.T) {
+		expectStatus(t, send(t, "POST", baseURL+"/accounts", "apart-a", `{"name": "Sue", "password": "correct-horse-1"}`), http.StatusCreated)
+
+		expectStatus(t, send(t, "GET", baseURL+"/accounts/Sue", "apart-a", ""), http.StatusOK)
+		expectStatus(t, send(t, "GET", baseURL+"/accounts/Sue", "apart-b", ""), http.StatusNotFound)
+		expectStatus(t, send(t, "GET", baseURL+"/accounts/Sue", "", ""), http.StatusNotFound)
+	})
+
+	t.Run("ClearsOnlyTheNamespace", func(t *testing.T) {
+		expectStatus(t, send(t, "POST", baseURL+"/accounts", "clear-a", `{"name": "Sue", "password": "correct-horse-1"}`), http.StatusCreated)
+		expectStatus(t, send(t, "POST", baseURL+"/accounts", "clear-b", `{"name": "Sue", "password": "correct-horse-1"}`), http.StatusCreated)
+
+		expectStatus(t, send(t, "DELETE", baseURL+"/clear", "clear-a", ""), http.StatusNoContent)
+
+		expectStatus(t, send(t, "GET", baseURL+"/accounts/Sue", "clear-a", ""), http.StatusNotFound)
+		expectStatus(t, send(t, "GET", baseURL+"/accounts/Sue", "clear-b", ""), http.StatusOK)
+	})
+
+	t.Run("ReadsNamespaceFromCookie", func(t *testing.T) {
+		expectStatus(t, send(t, "POST", baseURL+"/accounts", "cookie-a", `{"name": "Sue", "password": "correct-horse-1"}`), http.StatusCreated)
+
+		req, err := http.NewRequest("GET", baseURL+"/accounts/Sue", nil)
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		req.AddCookie(&http.Cookie{Name: "test-namespace", Value: "cookie-a"})
+		expectStatus(t, do(t, req), http.StatusOK)
+	})
+
+	t.Run("RefusesInvalidNamespace", func(t *testing.T) {
+		expectStatus(t, send(t, "GET", baseURL+"/accounts/Sue", "not/valid", ""), http.StatusBadRequest)
+	})
+}
+
+func TestOpeningNamespaces(t *testing.T) {
+	t.Run("DoesNotHoldUpOtherNamespaces", func(t *testing.T) {
+		opening, release := make(chan struct{}), make(chan struct{})
+		httpServer := httptest.NewServer(server.NewServer(application.New(), server.WithNamespaces(
+			func(namespace string) (*server.Server, io.Closer, error) {
+				if namespace == "slow" {
+					close(opening)
+					<-release
+				}
+				return server.NewServer(application.New()), nil, nil
+			}, nil)))
+		t.Cleanup(httpServer.Close)
+
+		slow := make(chan *http.Response)
+		req := namespaceRequest(t, httpServer.URL+"/accounts/Sue", "slow")
+		go func() {
+			resp, err := http.DefaultClient.Do(req)
+			if err == nil {
+				resp.Body.Close()
+			}
+			slow <- resp
+		}()
+		<-opening
+
+		expectStatus(t, send(t, "GET", httpServer.URL+"/accounts/Sue", "fast", ""), http.StatusNotFound)
+		close(release)
+		if resp := <-slow; resp == nil || resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("expected the slow namespace to open once released but got %v", resp)
+		}
+	})
+}
+
+func namespaceRequest(t *testing.T, url string, namespace string) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	req.Header.Set("X-Test-Namespace", namespace)
+	return req
+}
+
+func send(t *testing.T, method string, url string, namespace string, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if namespace != "" {
+		req.Header.Set("X-Test-Namespace", namespace)
+	}
+	return do(t, req)
+}
+
+func do(t *testing.T, req *http.Request) *http.Response {
+	t.Helper()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+func expectStatus(t *testing.T, resp *http.Response, status int) {
+	t.Helper()
+	if resp.StatusCode != status {
+		t.Fatalf("expected %s %s to return %d but got %d", resp.Request.Method, resp.Request.URL, status, resp.StatusCode)
+	}
+}
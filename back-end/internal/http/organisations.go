@@ -0,0 +1,326 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+// organisationResponse is the JSON representation of an organisation
+type organisationResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// membershipResponse is the JSON representation of an organisation the caller belongs
+// to, or of one of an organisation's members. Only the side that is not already known
+// is filled in.
+type membershipResponse struct {
+	Organisation string           `json:"organisation,omitempty"`
+	Account      string           `json:"account,omitempty"`
+	Role         entities.OrgRole `json:"role"`
+}
+
+// memberRequest is the JSON representation of the role to give a member
+type memberRequest struct {
+	Role entities.OrgRole `json:"role"`
+}
+
+func (s *Server) handleOrganisations(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+		s.getOrganisations(w, r)
+	case "POST":
+		s.createOrganisation(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleOrganisationsWithName(w http.ResponseWriter, r *http.Request) {
+	// Extract organisation name from path
+	path := strings.TrimPrefix(r.URL.Path, "/orgs/")
+	parts := strings.Split(path, "/")
+	if len(parts) == 0 || parts[0] == "" {
+		http.Error(w, "Organisation name required", http.StatusBadRequest)
+		return
+	}
+
+	orgName := parts[0]
+
+	if len(parts) == 1 {
+		// /orgs/{org}
+		switch r.Method {
+		case "GET":
+			s.getOrganisation(w, r, orgName)
+		case "DELETE":
+			s.deleteOrganisation(w, r, orgName)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	} else if len(parts) == 2 && parts[1] == "members" {
+		// /orgs/{org}/members
+		if r.Method == "GET" {
+			s.getOrganisationMembers(w, r, orgName)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	} else if len(parts) == 3 && parts[1] == "members" {
+		// /orgs/{org}/members/{account}
+		member := parts[2]
+		switch r.Method {
+		case "PUT":
+			s.setOrganisationMember(w, r, orgName, member)
+		case "DELETE":
+			s.removeOrganisationMember(w, r, orgName, member)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	} else if len(parts) == 2 && parts[1] == "projects" {
+		// /orgs/{org}/projects
+		switch r.Method {
+		case "GET":
+			s.getOrganisationProjects(w, r, orgName)
+		case "POST":
+			s.createOrganisationProject(w, r, orgName)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	} else if len(parts) == 3 && parts[1] == "projects" {
+		// /orgs/{org}/projects/{id}
+		projectID := parts[2]
+		switch r.Method {
+		case "GET":
+			s.getOrganisationProject(w, r, orgName, projectID)
+		case "PATCH":
+			s.updateOrganisationProject(w, r, orgName, projectID)
+		case "DELETE":
+			s.deleteOrganisationProject(w, r, orgName, projectID)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	} else {
+		http.Error(w, "Not found", http.StatusNotFound)
+	}
+}
+
+// getOrganisations lists the organisations the caller belongs to, and their role in each
+func (s *Server) getOrganisations(w http.ResponseWriter, r *http.Request) {
+	memberships, err := s.domain.GetOrganisations(bearerToken(r))
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	response := make([]membershipResponse, 0, len(memberships))
+	for _, membership := range memberships {
+		response = append(response, membershipResponse{Organisation: membership.Organisation, Role: membership.Role})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s *Server) createOrganisation(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name string `json:"name"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if req.Name == "" {
+		s.writeError(w, "Name is required", http.StatusBadRequest)
+		return
+	}
+
+	organisation, err := s.domain.CreateOrganisation(bearerToken(r), req.Name)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/orgs/"+url.PathEscape(organisation.Name()))
+	s.writeOrganisation(w, organisation, http.StatusCreated)
+}
+
+func (s *Server) getOrganisation(w http.ResponseWriter, r *http.Request, orgName string) {
+	organisation, err := s.domain.GetOrganisation(bearerToken(r), orgName)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	s.writeOrganisation(w, organisation, http.StatusOK)
+}
+
+func (s *Server) deleteOrganisation(w http.ResponseWriter, r *http.Request, orgName string) {
+	if err := s.domain.DeleteOrganisation(bearerToken(r), orgName); err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getOrganisationMembers(w http.ResponseWriter, r *http.Request, orgName string) {
+	members, err := s.domain.GetOrganisationMembers(bearerToken(r), orgName)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	response := make([]membershipResponse, 0, len(members))
+	for _, member := range members {
+		response = append(response, membershipResponse{Account: member.Account, Role: member.Role})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// setOrganisationMember adds an account to an organisation, or changes the role of one
+// that already belongs to it
+func (s *Server) setOrganisationMember(w http.ResponseWriter, r *http.Request, orgName string, member string) {
+	var req memberRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.domain.SetOrganisationMember(bearerToken(r), orgName, member, req.Role); err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) removeOrganisationMember(w http.ResponseWriter, r *http.Request, orgName string, member string) {
+	if err := s.domain.RemoveOrganisationMember(bearerToken(r), orgName, member); err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getOrganisationProjects(w http.ResponseWriter, r *http.Request, orgName string) {
+	projects, err := s.domain.GetOrganisationProjects(bearerToken(r), orgName)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	response := make([]projectResponse, 0, len(projects))
+	for _, project := range projects {
+		response = append(response, newProjectResponse(project))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s *Server) createOrganisationProject(w http.ResponseWriter, r *http.Request, orgName string) {
+	var req struct {
+		Name string `json:"name"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if req.Name == "" {
+		s.writeError(w, "Name is required", http.StatusBadRequest)
+		return
+	}
+
+	project, err := s.domain.CreateOrganisationProject(bearerToken(r), orgName, req.Name)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/orgs/"+url.PathEscape(orgName)+"/projects/"+url.PathEscape(project.ID()))
+	s.writeProject(w, project, http.StatusCreated)
+}
+
+func (s *Server) getOrganisationProject(w http.ResponseWriter, r *http.Request, orgName string, projectID string) {
+	project, err := s.domain.GetOrganisationProject(bearerToken(r), orgName, projectID)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	s.writeProject(w, project, http.StatusOK)
+}
+
+func (s *Server) updateOrganisationProject(w http.ResponseWriter, r *http.Request, orgName string, projectID string) {
+	var req struct {
+		Name *string `json:"name"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if req.Name == nil {
+		// Nothing to change
+		s.getOrganisationProject(w, r, orgName, projectID)
+		return
+	}
+
+	if *req.Name == "" {
+		s.writeError(w, "Name must not be empty", http.StatusBadRequest)
+		return
+	}
+
+	project, err := s.domain.RenameOrganisationProject(bearerToken(r), orgName, projectID, *req.Name)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	s.writeProject(w, project, http.StatusOK)
+}
+
+func (s *Server) deleteOrganisationProject(w http.ResponseWriter, r *http.Request, orgName string, projectID string) {
+	if err := s.domain.DeleteOrganisationProject(bearerToken(r), orgName, projectID); err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) writeOrganisation(w http.ResponseWriter, organisation entities.Organisation, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	response := organisationResponse{
+		ID:        organisation.ID(),
+		Name:      organisation.Name(),
+		CreatedAt: organisation.CreatedAt(),
+	}
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
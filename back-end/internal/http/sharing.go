@@ -0,0 +1,89 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+// invitationRequest is the JSON representation of an invitation to send
+type invitationRequest struct {
+	Account string        `json:"account"`
+	Role    entities.Role `json:"role"`
+}
+
+// invitationResponse is the JSON representation of an invitation waiting for an answer
+type invitationResponse struct {
+	ProjectID   string        `json:"projectId"`
+	ProjectName string        `json:"projectName"`
+	Owner       string        `json:"owner"`
+	Role        entities.Role `json:"role"`
+	InvitedAt   time.Time     `json:"invitedAt"`
+}
+
+func newInvitationResponse(invitation entities.Invitation) invitationResponse {
+	return invitationResponse(invitation)
+}
+
+func (s *Server) inviteToProject(w http.ResponseWriter, r *http.Request, name string, projectID string) {
+	var req invitationRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if req.Account == "" {
+		s.writeError(w, "Account is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.domain.InviteToProject(bearerToken(r), name, projectID, req.Account, req.Role); err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getInvitations(w http.ResponseWriter, r *http.Request, name string) {
+	invitations, err := s.domain.GetInvitations(bearerToken(r), name)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	response := make([]invitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		response = append(response, newInvitationResponse(invitation))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// answerInvitation accepts or declines an invitation, as action says. Accepting it
+// returns the project that is now shared.
+func (s *Server) answerInvitation(w http.ResponseWriter, r *http.Request, name string, projectID string, action string) {
+	if action == "decline" {
+		if err := s.domain.DeclineInvitation(bearerToken(r), name, projectID); err != nil {
+			s.writeDomainError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	project, err := s.domain.AcceptInvitation(bearerToken(r), name, projectID)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	s.writeProject(w, project, http.StatusOK)
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events/feed"
+)
+
+// heartbeatInterval is how often a quiet event stream sends a comment, so that proxies
+// and clients can tell that the connection is still open
+const heartbeatInterval = 15 * time.Second
+
+// streamEvents streams the account's events to its holder as Server-Sent Events. A
+// client resuming the stream gets the events after the one named by its Last-Event-ID
+// header first. A ready event then marks the point the stream has caught up to, and
+// later events are sent as they happen.
+func (s *Server) streamEvents(w http.ResponseWriter, r *http.Request, name string) {
+	if err := s.domain.Authorize(bearerToken(r), name); err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	// The stream stays open for longer than the server's write timeout allows
+	controller := http.NewResponseController(w)
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		http.Error(w, "Failed to open event stream", http.StatusInternalServerError)
+		return
+	}
+
+	var follower *feed.Follower
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		after, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			s.writeError(w, "Last-Event-ID must be the id of an event from this stream", http.StatusBadRequest)
+			return
+		}
+		follower = s.feed.FollowAfter(name, after)
+	} else {
+		follower = s.feed.Follow(name)
+	}
+	defer follower.Stop()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	// Proxies, including the front end's, must pass events on as they come rather than
+	// buffering or compressing the stream
+	w.Header().Set("Cache-Control", "no-store, no-transform")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	for _, entry := range follower.Missed {
+		if err := writeStreamEvent(w, entry); err != nil {
+			return
+		}
+	}
+	if _, err := fmt.Fprintf(w, "id: %d\nevent: ready\ndata: {}\n\n", follower.LastID); err != nil {
+		return
+	}
+	if err := controller.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case entry, ok := <-follower.Entries():
+			if !ok {
+				// Fallen too far behind or cleared; the client reconnects with the last
+				// id it got to catch up
+				return
+			}
+			if err := writeStreamEvent(w, entry); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		if err := controller.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeStreamEvent writes an entry from the feed as a Server-Sent Event named after the
+// kind of event
+func writeStreamEvent(w io.Writer, entry feed.Entry) error {
+	data, err := json.Marshal(newEventResponse(entry.Event))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", entry.ID, entry.Event.Kind, data)
+	return err
+}
@@ -0,0 +1,157 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/internal/domain/application"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/webhooks"
+)
+
+// webhookRequest is the JSON representation of a webhook to register
+type webhookRequest struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+}
+
+func (r webhookRequest) toTarget() application.WebhookTarget {
+	return application.WebhookTarget{URL: r.URL, Secret: r.Secret}
+}
+
+// webhookResponse is the JSON representation of a webhook. Its secret is left out, as
+// only the account holder and the receiver should know it.
+type webhookResponse struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func newWebhookResponse(webhook entities.Webhook) webhookResponse {
+	return webhookResponse{
+		ID:        webhook.ID(),
+		URL:       webhook.URL(),
+		CreatedAt: webhook.CreatedAt(),
+	}
+}
+
+// deliveryResponse is the JSON representation of a delivery to a webhook
+type deliveryResponse struct {
+	ID             string        `json:"id"`
+	Event          eventResponse `json:"event"`
+	Status         string        `json:"status"`
+	Attempts       int           `json:"attempts"`
+	ResponseStatus int           `json:"responseStatus,omitempty"`
+	Error          string        `json:"error,omitempty"`
+	LastAttemptAt  *time.Time    `json:"lastAttemptAt,omitempty"`
+	NextAttemptAt  *time.Time    `json:"nextAttemptAt,omitempty"`
+}
+
+func newDeliveryResponse(delivery webhooks.Delivery) deliveryResponse {
+	return deliveryResponse{
+		ID:             delivery.ID,
+		Event:          newEventResponse(delivery.Event),
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		Error:          delivery.Error,
+		LastAttemptAt:  timeOrNil(delivery.LastAttemptAt),
+		NextAttemptAt:  timeOrNil(delivery.NextAttemptAt),
+	}
+}
+
+// timeOrNil leaves out times that are not set, rather than showing them as the zero time
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func (s *Server) registerWebhook(w http.ResponseWriter, r *http.Request, name string) {
+	var req webhookRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	webhook, err := s.domain.RegisterWebhook(bearerToken(r), name, req.toTarget())
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/accounts/"+url.PathEscape(name)+"/webhooks/"+url.PathEscape(webhook.ID()))
+	s.writeWebhook(w, webhook, http.StatusCreated)
+}
+
+func (s *Server) getWebhooks(w http.ResponseWriter, r *http.Request, name string) {
+	registered, err := s.domain.GetWebhooks(bearerToken(r), name)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	response := make([]webhookResponse, 0, len(registered))
+	for _, webhook := range registered {
+		response = append(response, newWebhookResponse(webhook))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s *Server) getWebhook(w http.ResponseWriter, r *http.Request, name string, webhookID string) {
+	webhook, err := s.domain.GetWebhook(bearerToken(r), name, webhookID)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	s.writeWebhook(w, webhook, http.StatusOK)
+}
+
+func (s *Server) deleteWebhook(w http.ResponseWriter, r *http.Request, name string, webhookID string) {
+	if err := s.domain.DeleteWebhook(bearerToken(r), name, webhookID); err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getWebhookDeliveries lists the deliveries to one of the account's webhooks, oldest
+// first, so that its holder can see which of their events got through
+func (s *Server) getWebhookDeliveries(w http.ResponseWriter, r *http.Request, name string, webhookID string) {
+	webhook, err := s.domain.GetWebhook(bearerToken(r), name, webhookID)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	deliveries := s.deliveries.Deliveries(webhook.ID())
+	response := make([]deliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		response = append(response, newDeliveryResponse(delivery))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s *Server) writeWebhook(w http.ResponseWriter, webhook entities.Webhook, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(newWebhookResponse(webhook)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,19 @@
+// Clock package defines where the application gets the time from, so that tests
+// can control it and specify rules that depend on time passing
+package clock
+
+import "time"
+
+// Clock tells the current time
+type Clock interface {
+	Now() time.Time
+}
+
+// System is a clock that tells the real time
+var System Clock = system{}
+
+type system struct{}
+
+func (system) Now() time.Time {
+	return time.Now()
+}
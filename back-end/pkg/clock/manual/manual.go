@@ -0,0 +1,52 @@
+// Manual package provides a clock that only moves when told to, so that tests
+// and the test endpoint can make time pass without waiting
+package manual
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/clock"
+)
+
+// Clock tells a time that stands still until it is advanced
+type Clock struct {
+	mu  sync.RWMutex
+	now time.Time
+}
+
+// New creates a clock that starts at start
+func New(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+// verify that Clock implements clock.Clock
+var _ clock.Clock = (*Clock)(nil)
+
+func (c *Clock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d and returns the new time. Moving it
+// backwards is refused, as nothing expects time to run in reverse.
+func (c *Clock) Advance(d time.Duration) (time.Time, error) {
+	if d < 0 {
+		return time.Time{}, fmt.Errorf("cannot move the clock back by %v", -d)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	return c.now, nil
+}
+
+// Set puts the clock at t, even if that is earlier than it is now. It is for turning
+// the clock back along with data restored from a snapshot, so that what had not
+// expired when the snapshot was taken has not expired again.
+func (c *Clock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
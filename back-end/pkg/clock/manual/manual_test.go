@@ -0,0 +1,49 @@
+package manual_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/clock/manual"
+)
+
+func TestClock(t *testing.T) {
+	start := time.Date(2025, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("StandsStillUntilAdvanced", func(t *testing.T) {
+		c := manual.New(start)
+		if !c.Now().Equal(start) {
+			t.Fatalf("expected %v but got %v", start, c.Now())
+		}
+
+		now, err := c.Advance(8 * 24 * time.Hour)
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		expected := start.Add(8 * 24 * time.Hour)
+		if !now.Equal(expected) || !c.Now().Equal(expected) {
+			t.Fatalf("expected %v but got %v", expected, c.Now())
+		}
+	})
+
+	t.Run("RefusesToMoveBack", func(t *testing.T) {
+		c := manual.New(start)
+		if _, err := c.Advance(-time.Second); err == nil {
+			t.Fatalf("expected an error but there is no error")
+		}
+		if !c.Now().Equal(start) {
+			t.Fatalf("expected %v but got %v", start, c.Now())
+		}
+	})
+	t.Run("SetsBackForRestoring", func(t *testing.T) {
+		c := manual.New(start)
+		if _, err := c.Advance(time.Hour); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+
+		c.Set(start)
+		if !c.Now().Equal(start) {
+			t.Fatalf("expected %v but got %v", start, c.Now())
+		}
+	})
+}
@@ -1,22 +1,205 @@
 // Entities package is exported so that it can be reused in acceptance tests
 package entities
 
-type Project struct{}
+import (
+	"slices"
+	"time"
+)
+
+// ProjectState is where a project is in its lifecycle
+type ProjectState string
+
+const (
+	// ProjectActive is the state of a project being worked on, which every project starts in
+	ProjectActive ProjectState = "active"
+	// ProjectArchived is the state of a finished project, which is kept but cannot be
+	// changed until it is restored
+	ProjectArchived ProjectState = "archived"
+)
+
+// Role is what an account may do with a project
+type Role string
+
+const (
+	// RoleOwner is the role of the account that owns a project, which may do anything with it
+	RoleOwner Role = "owner"
+	// RoleEditor is the role of a member who may see and rename a project
+	RoleEditor Role = "editor"
+	// RoleViewer is the role of a member who may only see a project
+	RoleViewer Role = "viewer"
+)
+
+// Plan is what an account holder pays for, which sets the quotas the account has
+type Plan string
+
+const (
+	// PlanFree is the plan every account starts on
+	PlanFree Plan = "free"
+	// PlanPro is the paid plan, with higher quotas
+	PlanPro Plan = "pro"
+)
+
+// Member is an account that a project's owner has invited to share it. The member
+// has no access to the project until they accept the invitation.
+type Member struct {
+	AccountID string
+	Role      Role
+	Accepted  bool
+	InvitedAt time.Time
+}
+
+// Invitation is an invitation to share a project that an account has not yet accepted
+// or declined
+type Invitation struct {
+	ProjectID   string
+	ProjectName string
+	// Owner is the name of the account that owns the project and sent the invitation
+	Owner     string
+	Role      Role
+	InvitedAt time.Time
+}
+
+// Project is a piece of work owned by a single account or organisation. Projects
+// owned by an account can be shared with its members.
+type Project struct {
+	id        string
+	name      string
+	ownerID   string
+	createdAt time.Time
+	sequence  uint64
+	state     ProjectState
+	members   []Member
+}
+
+// NewProject creates an active project. The id is its stable identity, and ownerID
+// is the ID of the account or organisation that owns it.
+func NewProject(id, name, ownerID string, createdAt time.Time) *Project {
+	return &Project{
+		id:        id,
+		name:      name,
+		ownerID:   ownerID,
+		createdAt: createdAt,
+		state:     ProjectActive,
+	}
+}
+
+func (p *Project) ID() string {
+	return p.id
+}
+
+func (p *Project) Name() string {
+	return p.name
+}
+
+func (p *Project) OwnerID() string {
+	return p.ownerID
+}
+
+func (p *Project) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+// Sequence numbers the project in the order projects were created, so that projects
+// created at the same instant still have an order. It is zero for projects created
+// before they were numbered.
+func (p *Project) Sequence() uint64 {
+	return p.sequence
+}
+
+func (p *Project) SetSequence(sequence uint64) {
+	p.sequence = sequence
+}
+
+func (p *Project) SetName(name string) {
+	p.name = name
+}
+
+func (p *Project) State() ProjectState {
+	return p.state
+}
+
+// IsArchived reports whether the project is archived
+func (p *Project) IsArchived() bool {
+	return p.state == ProjectArchived
+}
+
+func (p *Project) SetState(state ProjectState) {
+	p.state = state
+}
+
+// Members returns the accounts invited to share the project, in the order they were
+// first invited, whether or not they have accepted
+func (p *Project) Members() []Member {
+	return slices.Clone(p.members)
+}
+
+// Member returns the member with the given account ID, if the account has been invited
+func (p *Project) Member(accountID string) (Member, bool) {
+	i := slices.IndexFunc(p.members, func(m Member) bool { return m.AccountID == accountID })
+	if i < 0 {
+		return Member{}, false
+	}
+	return p.members[i], true
+}
+
+// SetMember adds the member, or replaces the member with the same account ID
+func (p *Project) SetMember(member Member) {
+	members := slices.Clone(p.members)
+	i := slices.IndexFunc(members, func(m Member) bool { return m.AccountID == member.AccountID })
+	if i < 0 {
+		members = append(members, member)
+	} else {
+		members[i] = member
+	}
+	p.members = members
+}
+
+// RemoveMember removes the member with the given account ID, if there is one
+func (p *Project) RemoveMember(accountID string) {
+	p.members = slices.DeleteFunc(slices.Clone(p.members), func(m Member) bool { return m.AccountID == accountID })
+}
+
+// RoleOf returns the role of the account with the given ID, or "" if it has no access
+// to the project. Members have no role until they accept their invitation.
+func (p *Project) RoleOf(accountID string) Role {
+	if accountID == p.ownerID {
+		return RoleOwner
+	}
+	if member, ok := p.Member(accountID); ok && member.Accepted {
+		return member.Role
+	}
+	return ""
+}
 
 type Account struct {
-	name          string
-	activated     bool
-	authenticated bool
+	id              string
+	name            string
+	activated       bool
+	activationToken string
+	passwordHash    string
+	createdAt       time.Time
+	// failedSignIns counts the sign-ins that have failed in a row since failedSignInsSince
+	failedSignIns      int
+	failedSignInsSince time.Time
+	lockedUntil        time.Time
+	plan               Plan
+	closedAt           time.Time
 }
 
-func NewAccount(name string) *Account {
+// NewAccount creates an account. The id is its stable identity and never
+// changes, whatever happens to the rest of the account's state.
+func NewAccount(id, name string) *Account {
 	return &Account{
-		name:          name,
-		activated:     false,
-		authenticated: false,
+		id:        id,
+		name:      name,
+		activated: false,
 	}
 }
 
+func (a *Account) ID() string {
+	return a.id
+}
+
 func (a *Account) Name() string {
 	return a.name
 }
@@ -25,14 +208,276 @@ func (a *Account) IsActivated() bool {
 	return a.activated
 }
 
-func (a *Account) IsAuthenticated() bool {
-	return a.authenticated
-}
-
 func (a *Account) SetActivated(activated bool) {
 	a.activated = activated
 }
 
-func (a *Account) SetAuthenticated(authenticated bool) {
-	a.authenticated = authenticated
+// ActivationToken returns the token that must be presented to activate the
+// account, or "" once there is none outstanding
+func (a *Account) ActivationToken() string {
+	return a.activationToken
+}
+
+func (a *Account) SetActivationToken(token string) {
+	a.activationToken = token
+}
+
+// PasswordHash returns the salted hash of the account's password. The password
+// itself is never stored.
+func (a *Account) PasswordHash() string {
+	return a.passwordHash
+}
+
+func (a *Account) SetPasswordHash(hash string) {
+	a.passwordHash = hash
+}
+
+// CreatedAt returns when the account was created, which is when its activation
+// link was sent
+func (a *Account) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+func (a *Account) SetCreatedAt(createdAt time.Time) {
+	a.createdAt = createdAt
+}
+
+// FailedSignIns returns how many sign-ins have failed in a row, counting from
+// FailedSignInsSince
+func (a *Account) FailedSignIns() int {
+	return a.failedSignIns
+}
+
+// FailedSignInsSince returns when the first of the failed sign-ins counted by
+// FailedSignIns happened
+func (a *Account) FailedSignInsSince() time.Time {
+	return a.failedSignInsSince
+}
+
+func (a *Account) SetFailedSignIns(count int, since time.Time) {
+	a.failedSignIns = count
+	a.failedSignInsSince = since
+}
+
+// LockedUntil returns when the account can next be signed in to, or the zero
+// time if it has never been locked
+func (a *Account) LockedUntil() time.Time {
+	return a.lockedUntil
+}
+
+func (a *Account) SetLockedUntil(lockedUntil time.Time) {
+	a.lockedUntil = lockedUntil
+}
+
+// IsLocked reports whether signing in to the account is refused at time now
+func (a *Account) IsLocked(now time.Time) bool {
+	return now.Before(a.lockedUntil)
+}
+
+// Plan returns the plan the account is on. Accounts stored before there were plans
+// are on the free plan.
+func (a *Account) Plan() Plan {
+	if a.plan == "" {
+		return PlanFree
+	}
+	return a.plan
+}
+
+func (a *Account) SetPlan(plan Plan) {
+	a.plan = plan
+}
+
+// ClosedAt returns when the account holder closed the account, or the zero time if
+// it is open
+func (a *Account) ClosedAt() time.Time {
+	return a.closedAt
+}
+
+func (a *Account) SetClosedAt(closedAt time.Time) {
+	a.closedAt = closedAt
+}
+
+// IsClosed reports whether the account has been closed, after which it cannot be
+// signed in to again
+func (a *Account) IsClosed() bool {
+	return !a.closedAt.IsZero()
+}
+
+// Session lets one client act as an account until it expires or is signed out.
+// The client holds an opaque token, while the session is identified by a hash of
+// that token, so that stored sessions cannot be used to sign in.
+type Session struct {
+	id        string
+	accountID string
+	expiresAt time.Time
+}
+
+// NewSession creates a session for the account with the given ID
+func NewSession(id, accountID string, expiresAt time.Time) *Session {
+	return &Session{
+		id:        id,
+		accountID: accountID,
+		expiresAt: expiresAt,
+	}
+}
+
+func (s *Session) ID() string {
+	return s.id
+}
+
+func (s *Session) AccountID() string {
+	return s.accountID
+}
+
+func (s *Session) ExpiresAt() time.Time {
+	return s.expiresAt
+}
+
+// IsExpired reports whether the session has stopped being accepted at time now
+func (s *Session) IsExpired(now time.Time) bool {
+	return !now.Before(s.expiresAt)
+}
+
+// Webhook is a URL that an account's events are posted to. Each payload is signed
+// with a secret shared with whoever receives it, so that they can tell it came from us.
+type Webhook struct {
+	id        string
+	ownerID   string
+	url       string
+	secret    string
+	createdAt time.Time
+}
+
+// NewWebhook creates a webhook for the account with the given ID
+func NewWebhook(id, ownerID, url, secret string, createdAt time.Time) *Webhook {
+	return &Webhook{
+		id:        id,
+		ownerID:   ownerID,
+		url:       url,
+		secret:    secret,
+		createdAt: createdAt,
+	}
+}
+
+func (w *Webhook) ID() string {
+	return w.id
+}
+
+func (w *Webhook) OwnerID() string {
+	return w.ownerID
+}
+
+func (w *Webhook) URL() string {
+	return w.url
+}
+
+// Secret returns the key payloads are signed with. It is never shown again once the
+// webhook has been registered.
+func (w *Webhook) Secret() string {
+	return w.secret
+}
+
+func (w *Webhook) CreatedAt() time.Time {
+	return w.createdAt
+}
+
+// OrgRole is what a member of an organisation may do in it
+type OrgRole string
+
+const (
+	// OrgRoleOwner is the role of a member who may do anything in an organisation,
+	// including choosing its admins and deleting it
+	OrgRoleOwner OrgRole = "owner"
+	// OrgRoleAdmin is the role of a member who may also add and remove other members
+	// and delete the organisation's projects
+	OrgRoleAdmin OrgRole = "admin"
+	// OrgRoleMember is the role of a member who may see, create and rename the
+	// organisation's projects
+	OrgRoleMember OrgRole = "member"
+)
+
+// OrgMember is an account's membership of an organisation
+type OrgMember struct {
+	AccountID string
+	Role      OrgRole
+}
+
+// Membership is an account's place in an organisation, by name, as shown to its members
+type Membership struct {
+	Organisation string
+	Account      string
+	Role         OrgRole
+}
+
+// Organisation is a named group of accounts that own projects together. Every
+// organisation has at least one owner.
+type Organisation struct {
+	id        string
+	name      string
+	createdAt time.Time
+	members   []OrgMember
+}
+
+// NewOrganisation creates an organisation without members. The id is its stable
+// identity, and owns the organisation's projects.
+func NewOrganisation(id, name string, createdAt time.Time) *Organisation {
+	return &Organisation{
+		id:        id,
+		name:      name,
+		createdAt: createdAt,
+	}
+}
+
+func (o *Organisation) ID() string {
+	return o.id
+}
+
+func (o *Organisation) Name() string {
+	return o.name
+}
+
+func (o *Organisation) CreatedAt() time.Time {
+	return o.createdAt
+}
+
+// Members returns the organisation's members in the order they joined
+func (o *Organisation) Members() []OrgMember {
+	return slices.Clone(o.members)
+}
+
+// SetMember adds the member, or changes the role of the member with the same account ID
+func (o *Organisation) SetMember(member OrgMember) {
+	members := slices.Clone(o.members)
+	i := slices.IndexFunc(members, func(m OrgMember) bool { return m.AccountID == member.AccountID })
+	if i < 0 {
+		members = append(members, member)
+	} else {
+		members[i] = member
+	}
+	o.members = members
+}
+
+// RemoveMember removes the member with the given account ID, if there is one
+func (o *Organisation) RemoveMember(accountID string) {
+	o.members = slices.DeleteFunc(slices.Clone(o.members), func(m OrgMember) bool { return m.AccountID == accountID })
+}
+
+// RoleOf returns the role of the account with the given ID, or "" if it is not a member
+func (o *Organisation) RoleOf(accountID string) OrgRole {
+	i := slices.IndexFunc(o.members, func(m OrgMember) bool { return m.AccountID == accountID })
+	if i < 0 {
+		return ""
+	}
+	return o.members[i].Role
+}
+
+// Owners counts the members who are owners
+func (o *Organisation) Owners() int {
+	owners := 0
+	for _, member := range o.members {
+		if member.Role == OrgRoleOwner {
+			owners++
+		}
+	}
+	return owners
 }
@@ -0,0 +1,86 @@
+package entities
+
+import "errors"
+
+// Domain errors returned by the application. Test for them with errors.Is,
+// as they are usually wrapped with more detail such as the account name.
+var (
+	ErrAccountNotFound      = errors.New("account not found")
+	ErrAccountNotActivated  = errors.New("you need to activate your account")
+	ErrAccountExists        = errors.New("account already exists")
+	ErrProjectNotFound      = errors.New("project not found")
+	ErrInvalidActivation    = errors.New("activation link is not valid")
+	ErrActivationExpired    = errors.New("activation link has expired")
+	ErrWrongCredentials     = errors.New("wrong name or password")
+	ErrAccountLocked        = errors.New("account is locked after too many failed sign-ins")
+	ErrWeakPassword         = errors.New("password is too weak")
+	ErrSessionNotFound      = errors.New("session not found")
+	ErrNotSignedIn          = errors.New("you need to sign in")
+	ErrAccessDenied         = errors.New("you do not have access")
+	ErrWebhookNotFound      = errors.New("webhook not found")
+	ErrInvalidWebhook       = errors.New("webhook is not valid")
+	ErrInvalidQuery         = errors.New("query is not valid")
+	ErrProjectArchived      = errors.New("project is archived")
+	ErrProjectNotArchived   = errors.New("project is not archived")
+	ErrInvalidRole          = errors.New("role is not valid")
+	ErrAlreadyMember        = errors.New("account already shares the project")
+	ErrInvitationNotFound   = errors.New("invitation not found")
+	ErrOrganisationNotFound = errors.New("organisation not found")
+	ErrOrganisationExists   = errors.New("organisation already exists")
+	ErrLastOwner            = errors.New("organisation must keep an owner")
+	ErrInvalidPlan          = errors.New("plan is not valid")
+	ErrQuotaExceeded        = errors.New("plan quota exceeded")
+)
+
+// errorCodes gives each domain error a stable, machine-readable code so that
+// it can survive a trip across a protocol boundary such as HTTP
+var errorCodes = []struct {
+	code string
+	err  error
+}{
+	{"account_not_found", ErrAccountNotFound},
+	{"account_not_activated", ErrAccountNotActivated},
+	{"account_exists", ErrAccountExists},
+	{"project_not_found", ErrProjectNotFound},
+	{"invalid_activation", ErrInvalidActivation},
+	{"activation_expired", ErrActivationExpired},
+	{"wrong_credentials", ErrWrongCredentials},
+	{"account_locked", ErrAccountLocked},
+	{"weak_password", ErrWeakPassword},
+	{"session_not_found", ErrSessionNotFound},
+	{"not_signed_in", ErrNotSignedIn},
+	{"access_denied", ErrAccessDenied},
+	{"webhook_not_found", ErrWebhookNotFound},
+	{"invalid_webhook", ErrInvalidWebhook},
+	{"invalid_query", ErrInvalidQuery},
+	{"project_archived", ErrProjectArchived},
+	{"project_not_archived", ErrProjectNotArchived},
+	{"invalid_role", ErrInvalidRole},
+	{"already_member", ErrAlreadyMember},
+	{"invitation_not_found", ErrInvitationNotFound},
+	{"organisation_not_found", ErrOrganisationNotFound},
+	{"organisation_exists", ErrOrganisationExists},
+	{"last_owner", ErrLastOwner},
+	{"invalid_plan", ErrInvalidPlan},
+	{"quota_exceeded", ErrQuotaExceeded},
+}
+
+// ErrorCode returns the code of the domain error that err wraps, or "" if it wraps none
+func ErrorCode(err error) string {
+	for _, e := range errorCodes {
+		if errors.Is(err, e.err) {
+			return e.code
+		}
+	}
+	return ""
+}
+
+// ErrorFromCode returns the domain error with the given code, or nil if there is none
+func ErrorFromCode(code string) error {
+	for _, e := range errorCodes {
+		if e.code == code {
+			return e.err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package entities
+
+import "time"
+
+// ProjectSort is the order that projects are listed in
+type ProjectSort string
+
+const (
+	// SortByCreated lists the oldest projects first
+	SortByCreated ProjectSort = "created"
+	// SortByName lists projects alphabetically, ignoring case
+	SortByName ProjectSort = "name"
+)
+
+const (
+	// DefaultProjectLimit is how many projects a page holds when a query sets no limit
+	DefaultProjectLimit = 50
+	// MaxProjectLimit is the most projects a page can hold
+	MaxProjectLimit = 100
+)
+
+// ProjectQuery chooses which of an account's projects to list, in what order, and how
+// many at a time. The zero value lists the first page of all of them, oldest first.
+type ProjectQuery struct {
+	// Limit is the most projects a page holds, or zero for DefaultProjectLimit
+	Limit int
+	// Cursor carries on from the end of the page it was returned with. The rest of the
+	// query should be as it was for that page.
+	Cursor string
+	// Sort is the order to list projects in, or "" for SortByCreated. Projects that sort
+	// the same, such as those with the same name, are kept in the order they were created.
+	Sort ProjectSort
+	// NamePrefix lists only projects whose names start with it, ignoring case
+	NamePrefix string
+	// CreatedFrom lists only projects created at or after it, unless it is zero
+	CreatedFrom time.Time
+	// CreatedBefore lists only projects created before it, unless it is zero
+	CreatedBefore time.Time
+	// IncludeArchived lists archived projects along with active ones, which are the only
+	// ones listed otherwise
+	IncludeArchived bool
+}
+
+// ProjectPage is one page of the projects that a query lists
+type ProjectPage struct {
+	Projects []Project
+	// NextCursor carries on with the next page, or is "" if this is the last one
+	NextCursor string
+}
@@ -0,0 +1,79 @@
+package events
+
+import "sync"
+
+// Subscriber is called with each event published to a Bus that it subscribed to
+type Subscriber func(event Event)
+
+// Bus is an in-process publisher that passes each event to every subscriber, in the
+// order they subscribed. It is safe for concurrent use, but subscribers must not
+// subscribe to or close the bus they are called from.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers []Subscriber
+	queues      []chan Event
+	running     sync.WaitGroup
+	closed      bool
+}
+
+// NewBus creates a bus with no subscribers
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// verify that Bus implements Publisher
+var _ Publisher = (*Bus)(nil)
+
+// Subscribe calls s with each event before Publish returns, so a slow subscriber
+// slows down whatever published the event
+func (b *Bus) Subscribe(s Subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers, s)
+}
+
+// SubscribeBuffered calls s with each event on a goroutine of its own, keeping up to
+// size events that s has not got to yet. Publish only waits for s when that many are
+// waiting, so no event is lost.
+func (b *Bus) SubscribeBuffered(s Subscriber, size int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	queue := make(chan Event, size)
+	b.queues = append(b.queues, queue)
+	b.running.Add(1)
+	go func() {
+		defer b.running.Done()
+		for event := range queue {
+			s(event)
+		}
+	}()
+}
+
+// Publish passes the event to every subscriber. Events published after Close are dropped.
+func (b *Bus) Publish(event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return
+	}
+	for _, s := range b.subscribers {
+		s(event)
+	}
+	for _, queue := range b.queues {
+		queue <- event
+	}
+}
+
+// Close stops the bus, waiting for buffered subscribers to be called with the events
+// they have been sent
+func (b *Bus) Close() {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		for _, queue := range b.queues {
+			close(queue)
+		}
+	}
+	b.mu.Unlock()
+	b.running.Wait()
+}
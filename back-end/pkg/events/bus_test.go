@@ -0,0 +1,78 @@
+package events_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+)
+
+func TestBus(t *testing.T) {
+	t.Run("CallsSubscribersBeforePublishReturns", func(t *testing.T) {
+		bus := events.NewBus()
+		defer bus.Close()
+		var received []string
+		bus.Subscribe(func(e events.Event) { received = append(received, "first "+e.Account) })
+		bus.Subscribe(func(e events.Event) { received = append(received, "second "+e.Account) })
+
+		bus.Publish(events.Event{Kind: events.AccountCreated, Account: "Sue"})
+
+		if len(received) != 2 || received[0] != "first Sue" || received[1] != "second Sue" {
+			t.Fatalf("expected both subscribers to receive the event in order but got %v", received)
+		}
+	})
+
+	t.Run("PassesEventsToBufferedSubscribersInOrder", func(t *testing.T) {
+		bus := events.NewBus()
+		var received []string
+		bus.SubscribeBuffered(func(e events.Event) { received = append(received, e.Account) }, 1)
+
+		for _, name := range []string{"Sue", "Bob", "Tanya"} {
+			bus.Publish(events.Event{Kind: events.AccountCreated, Account: name})
+		}
+		bus.Close()
+
+		if len(received) != 3 || received[0] != "Sue" || received[1] != "Bob" || received[2] != "Tanya" {
+			t.Fatalf("expected every event in order but got %v", received)
+		}
+	})
+
+	t.Run("DoesNotWaitForBufferedSubscribers", func(t *testing.T) {
+		bus := events.NewBus()
+		var wg sync.WaitGroup
+		wg.Add(1)
+		release := make(chan struct{})
+		bus.SubscribeBuffered(func(events.Event) {
+			<-release
+			wg.Done()
+		}, 1)
+
+		published := make(chan struct{})
+		go func() {
+			bus.Publish(events.Event{Kind: events.AccountCreated, Account: "Sue"})
+			close(published)
+		}()
+		select {
+		case <-published:
+		case <-time.After(time.Second):
+			t.Fatal("expected Publish to return while the subscriber is busy")
+		}
+		close(release)
+		wg.Wait()
+		bus.Close()
+	})
+
+	t.Run("DropsEventsAfterClose", func(t *testing.T) {
+		bus := events.NewBus()
+		received := 0
+		bus.Subscribe(func(events.Event) { received++ })
+		bus.Close()
+
+		bus.Publish(events.Event{Kind: events.AccountCreated, Account: "Sue"})
+
+		if received != 0 {
+			t.Fatalf("expected no events after close but got %d", received)
+		}
+	})
+}
@@ -0,0 +1,47 @@
+// Eventlog package keeps the events published in memory, so that they can be read
+// back by tests and the test endpoint
+package eventlog
+
+import (
+	"sync"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+)
+
+// Log stores every event it records, in the order they were recorded
+type Log struct {
+	mu     sync.RWMutex
+	events []events.Event
+}
+
+// New creates an empty log
+func New() *Log {
+	return &Log{}
+}
+
+// Record adds the event to the log. It can be subscribed to an events.Bus.
+func (l *Log) Record(event events.Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+}
+
+// Events returns the events that happened to the named account, oldest first
+func (l *Log) Events(account string) []events.Event {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	result := []events.Event{}
+	for _, event := range l.events {
+		if event.Account == account {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// Clear removes all events
+func (l *Log) Clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = nil
+}
@@ -0,0 +1,32 @@
+package eventlog_test
+
+import (
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events/eventlog"
+)
+
+func TestLog(t *testing.T) {
+	t.Run("ListsOnlyAccountsEventsInOrder", func(t *testing.T) {
+		l := eventlog.New()
+		l.Record(events.Event{Kind: events.AccountCreated, Account: "Sue"})
+		l.Record(events.Event{Kind: events.AccountCreated, Account: "Bob"})
+		l.Record(events.Event{Kind: events.AccountActivated, Account: "Sue"})
+
+		got := l.Events("Sue")
+		if len(got) != 2 || got[0].Kind != events.AccountCreated || got[1].Kind != events.AccountActivated {
+			t.Fatalf("expected Sue's two events in order but got %+v", got)
+		}
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		l := eventlog.New()
+		l.Record(events.Event{Kind: events.AccountCreated, Account: "Sue"})
+		l.Clear()
+
+		if got := l.Events("Sue"); len(got) != 0 {
+			t.Fatalf("expected no events but got %+v", got)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+// Events package defines the things that happen in the application that others may
+// want to react to, and an in-process bus that passes them on. It is exported so that
+// acceptance tests can check which events were published.
+package events
+
+import "time"
+
+// Kind is the kind of thing that happened
+type Kind string
+
+const (
+	AccountCreated   Kind = "AccountCreated"
+	AccountActivated Kind = "AccountActivated"
+	// Authenticated is published when an account holder signs in with their password
+	Authenticated  Kind = "Authenticated"
+	ProjectCreated Kind = "ProjectCreated"
+	// AccountClosed is published when an account holder closes their account
+	AccountClosed Kind = "AccountClosed"
+)
+
+// Event is something that happened in the application
+type Event struct {
+	Kind Kind
+	// Account is the name of the account the event happened to
+	Account string
+	// ProjectID identifies the project, for events that happened to one
+	ProjectID  string
+	OccurredAt time.Time
+}
+
+// Publisher passes events on to whoever is interested in them
+type Publisher interface {
+	Publish(event Event)
+}
+
+// Discard is a publisher that drops every event
+var Discard Publisher = discard{}
+
+type discard struct{}
+
+func (discard) Publish(Event) {}
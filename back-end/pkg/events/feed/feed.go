@@ -0,0 +1,144 @@
+// Feed package keeps the events published recently, numbered in the order they
+// happened, so that listeners can follow an account's events as they happen and
+// catch up on the ones they missed
+package feed
+
+import (
+	"sync"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+)
+
+// followerBuffer is how many entries a follower can fall behind by before it is dropped
+const followerBuffer = 64
+
+// Entry is an event with its place in the feed
+type Entry struct {
+	ID    uint64
+	Event events.Event
+}
+
+// Feed keeps the most recent events, up to its capacity. It is safe for concurrent use.
+type Feed struct {
+	mu        sync.Mutex
+	capacity  int
+	recent    []Entry
+	lastID    uint64
+	followers map[*Follower]struct{}
+}
+
+// New creates an empty feed that keeps up to capacity events
+func New(capacity int) *Feed {
+	return &Feed{
+		capacity:  capacity,
+		followers: make(map[*Follower]struct{}),
+	}
+}
+
+// Follower receives an account's entries as they are added to a feed
+type Follower struct {
+	feed    *Feed
+	account string
+	entries chan Entry
+	// Missed holds the entries about the account that were already in the feed
+	// after the one asked to follow from, oldest first
+	Missed []Entry
+	// LastID is the ID of the latest entry in the feed, about any account, when
+	// following started. Following again from it misses nothing.
+	LastID uint64
+}
+
+// Record adds the event to the feed and passes it to whoever follows its account. It
+// can be subscribed to an events.Bus.
+func (f *Feed) Record(event events.Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lastID++
+	entry := Entry{ID: f.lastID, Event: event}
+	f.recent = append(f.recent, entry)
+	if len(f.recent) > f.capacity {
+		f.recent = f.recent[len(f.recent)-f.capacity:]
+	}
+	for follower := range f.followers {
+		if follower.account != event.Account {
+			continue
+		}
+		select {
+		case follower.entries <- entry:
+		default:
+			// The follower has fallen too far behind; it can follow again from the
+			// last entry it got
+			f.drop(follower)
+		}
+	}
+}
+
+// Clear forgets the events kept so far and stops everyone following. IDs carry on from
+// where they were, so that they are never reused.
+func (f *Feed) Clear() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.recent = nil
+	for follower := range f.followers {
+		f.drop(follower)
+	}
+}
+
+// Follow starts following the account's entries from now on
+func (f *Feed) Follow(account string) *Follower {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.follow(account, f.lastID)
+}
+
+// FollowAfter starts following the account's entries after the one with ID after,
+// catching up on those already in the feed. An ID later than any in the feed is taken
+// to be from before the feed started, so all the kept entries are caught up on. Entries
+// that are no longer kept are skipped.
+func (f *Feed) FollowAfter(account string, after uint64) *Follower {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if after > f.lastID {
+		after = 0
+	}
+	return f.follow(account, after)
+}
+
+// follow adds a follower. The caller must hold the lock.
+func (f *Feed) follow(account string, after uint64) *Follower {
+	follower := &Follower{
+		feed:    f,
+		account: account,
+		entries: make(chan Entry, followerBuffer),
+		LastID:  f.lastID,
+	}
+	for _, entry := range f.recent {
+		if entry.ID > after && entry.Event.Account == account {
+			follower.Missed = append(follower.Missed, entry)
+		}
+	}
+	f.followers[follower] = struct{}{}
+	return follower
+}
+
+// Entries returns the entries added after following started. It is closed when the
+// follower stops or falls too far behind.
+func (r *Follower) Entries() <-chan Entry {
+	return r.entries
+}
+
+// Stop stops following. It is safe to call more than once.
+func (r *Follower) Stop() {
+	r.feed.mu.Lock()
+	defer r.feed.mu.Unlock()
+	r.feed.drop(r)
+}
+
+// drop removes the follower. The caller must hold the lock.
+func (f *Feed) drop(follower *Follower) {
+	if _, ok := f.followers[follower]; !ok {
+		return
+	}
+	delete(f.followers, follower)
+	close(follower.entries)
+}
@@ -0,0 +1,118 @@
+package feed_test
+
+import (
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events/feed"
+)
+
+func TestFeed(t *testing.T) {
+	t.Run("PassesOnOnlyTheAccountsEvents", func(t *testing.T) {
+		f := feed.New(10)
+		f.Record(events.Event{Kind: events.AccountActivated, Account: "Sue"})
+		follower := f.Follow("Sue")
+		defer follower.Stop()
+
+		f.Record(events.Event{Kind: events.Authenticated, Account: "Bob"})
+		f.Record(events.Event{Kind: events.ProjectCreated, Account: "Sue"})
+
+		entry := <-follower.Entries()
+		if len(follower.Missed) != 0 {
+			t.Fatalf("expected nothing to catch up on but got %+v", follower.Missed)
+		}
+		if entry.ID != 3 || entry.Event.Kind != events.ProjectCreated {
+			t.Fatalf("expected Sue's project to be entry 3 but got %+v", entry)
+		}
+	})
+
+	t.Run("CatchesUpFromAnEarlierEntry", func(t *testing.T) {
+		f := feed.New(10)
+		f.Record(events.Event{Kind: events.AccountActivated, Account: "Sue"})
+		f.Record(events.Event{Kind: events.Authenticated, Account: "Bob"})
+		f.Record(events.Event{Kind: events.ProjectCreated, Account: "Sue"})
+
+		follower := f.FollowAfter("Sue", 1)
+		defer follower.Stop()
+
+		if len(follower.Missed) != 1 || follower.Missed[0].ID != 3 {
+			t.Fatalf("expected to catch up on entry 3 only but got %+v", follower.Missed)
+		}
+		if follower.LastID != 3 {
+			t.Fatalf("expected to start following after entry 3 but got %d", follower.LastID)
+		}
+	})
+
+	t.Run("KeepsOnlyTheMostRecentEntries", func(t *testing.T) {
+		f := feed.New(2)
+		for i := 0; i < 3; i++ {
+			f.Record(events.Event{Kind: events.ProjectCreated, Account: "Sue"})
+		}
+
+		follower := f.FollowAfter("Sue", 0)
+		defer follower.Stop()
+
+		if len(follower.Missed) != 2 || follower.Missed[0].ID != 2 {
+			t.Fatalf("expected entries 2 and 3 but got %+v", follower.Missed)
+		}
+	})
+
+	t.Run("StartsAgainFromIDsItHasNotReached", func(t *testing.T) {
+		f := feed.New(10)
+		f.Record(events.Event{Kind: events.ProjectCreated, Account: "Sue"})
+
+		follower := f.FollowAfter("Sue", 42)
+		defer follower.Stop()
+
+		if len(follower.Missed) != 1 {
+			t.Fatalf("expected to catch up on every kept entry but got %+v", follower.Missed)
+		}
+	})
+
+	t.Run("DropsFollowersThatFallBehind", func(t *testing.T) {
+		f := feed.New(1000)
+		follower := f.Follow("Sue")
+		defer follower.Stop()
+
+		for i := 0; i < 100; i++ {
+			f.Record(events.Event{Kind: events.ProjectCreated, Account: "Sue"})
+		}
+
+		received := 0
+		for range follower.Entries() {
+			received++
+		}
+		if received == 0 || received >= 100 {
+			t.Fatalf("expected the follower to be dropped part way through but it got %d entries", received)
+		}
+	})
+
+	t.Run("ClearStopsFollowersAndKeepsCounting", func(t *testing.T) {
+		f := feed.New(10)
+		f.Record(events.Event{Kind: events.ProjectCreated, Account: "Sue"})
+		follower := f.Follow("Sue")
+
+		f.Clear()
+		f.Record(events.Event{Kind: events.ProjectCreated, Account: "Sue"})
+
+		if _, ok := <-follower.Entries(); ok {
+			t.Fatal("expected entries to be closed")
+		}
+		again := f.FollowAfter("Sue", 0)
+		defer again.Stop()
+		if len(again.Missed) != 1 || again.Missed[0].ID != 2 {
+			t.Fatalf("expected only entry 2 to be kept but got %+v", again.Missed)
+		}
+	})
+
+	t.Run("StopClosesEntries", func(t *testing.T) {
+		f := feed.New(10)
+		follower := f.Follow("Sue")
+		follower.Stop()
+		follower.Stop()
+
+		if _, ok := <-follower.Entries(); ok {
+			t.Fatal("expected entries to be closed")
+		}
+	})
+}
@@ -0,0 +1,129 @@
+// Export package defines the archive that account holders download to take a copy of
+// all the data kept about their account. Archives are JSON:
+//
+//	{
+//	  "version": 1,
+//	  "exportedAt": "2025-01-02T03:04:05Z",
+//	  "account": {"id": "...", "name": "sue", "activated": true, "plan": "free", ...},
+//	  "projects": [{"id": "...", "name": "Roadmap", "state": "active", "members": [...], ...}],
+//	  "sharedProjects": [...],
+//	  "invitations": [...],
+//	  "organisations": [{"organisation": "Acme", "role": "owner"}],
+//	  "webhooks": [{"id": "...", "url": "https://example.com/hook", ...}],
+//	  "sessions": [{"expiresAt": "2025-01-03T03:04:05Z"}]
+//	}
+//
+// It is exported so that clients and acceptance tests can read archives.
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+// Version is the version of the archive format. It changes whenever archives could no
+// longer be read as before, so that readers can tell which format they have.
+const Version = 1
+
+// Archive is everything kept about an account. Accounts are referred to by name rather
+// than ID, and secrets such as password hashes, session tokens and webhook secrets are
+// left out.
+type Archive struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exportedAt"`
+	Account    Account   `json:"account"`
+	// Projects are those the account owns, archived ones included, in the order they were created
+	Projects []Project `json:"projects"`
+	// SharedProjects are other accounts' projects that the account has accepted to share
+	SharedProjects []SharedProject `json:"sharedProjects"`
+	// Invitations are those to share projects that the account has not yet answered
+	Invitations   []Invitation `json:"invitations"`
+	Organisations []Membership `json:"organisations"`
+	Webhooks      []Webhook    `json:"webhooks"`
+	// Sessions are those the account is signed in with, including the one that exported it
+	Sessions []Session `json:"sessions"`
+}
+
+// Account is what is kept about the account itself
+type Account struct {
+	ID        string        `json:"id"`
+	Name      string        `json:"name"`
+	Activated bool          `json:"activated"`
+	Plan      entities.Plan `json:"plan"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+// Project is one of the account's own projects, with the accounts it has invited to share it
+type Project struct {
+	ID        string                `json:"id"`
+	Name      string                `json:"name"`
+	State     entities.ProjectState `json:"state"`
+	CreatedAt time.Time             `json:"createdAt"`
+	Members   []Member              `json:"members"`
+}
+
+// Member is an account invited to share one of the account's projects
+type Member struct {
+	Account   string        `json:"account"`
+	Role      entities.Role `json:"role"`
+	Accepted  bool          `json:"accepted"`
+	InvitedAt time.Time     `json:"invitedAt"`
+}
+
+// SharedProject is a project that another account shares with the account
+type SharedProject struct {
+	ID        string                `json:"id"`
+	Name      string                `json:"name"`
+	Owner     string                `json:"owner"`
+	Role      entities.Role         `json:"role"`
+	State     entities.ProjectState `json:"state"`
+	CreatedAt time.Time             `json:"createdAt"`
+}
+
+// Invitation is an invitation to share a project that the account has not yet answered
+type Invitation struct {
+	ProjectID   string        `json:"projectId"`
+	ProjectName string        `json:"projectName"`
+	Owner       string        `json:"owner"`
+	Role        entities.Role `json:"role"`
+	InvitedAt   time.Time     `json:"invitedAt"`
+}
+
+// Membership is the account's place in an organisation
+type Membership struct {
+	Organisation string           `json:"organisation"`
+	Role         entities.OrgRole `json:"role"`
+}
+
+// Webhook is a webhook registered for the account, without its secret
+type Webhook struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Session is a session signed in to the account, without its token
+type Session struct {
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Filename is the name an account's archive is downloaded as
+func Filename(name string) string {
+	return name + "-export.json"
+}
+
+// Read reads an archive, refusing those written in another version of the format
+func Read(r io.Reader) (Archive, error) {
+	var archive Archive
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return Archive{}, fmt.Errorf("failed to read archive: %w", err)
+	}
+	if archive.Version != Version {
+		return Archive{}, fmt.Errorf("archive is version %d, but only version %d can be read", archive.Version, Version)
+	}
+	return archive, nil
+}
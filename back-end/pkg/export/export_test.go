@@ -0,0 +1,44 @@
+package export_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/export"
+)
+
+func TestRead(t *testing.T) {
+	t.Run("ReadsWhatWasWritten", func(t *testing.T) {
+		written := export.Archive{
+			Version:    export.Version,
+			ExportedAt: time.Date(2025, time.January, 2, 3, 4, 5, 0, time.UTC),
+			Account:    export.Account{ID: "sue-id", Name: "Sue", Activated: true, Plan: entities.PlanPro},
+			Projects:   []export.Project{{ID: "roadmap-id", Name: "Roadmap", State: entities.ProjectActive, Members: []export.Member{{Account: "Bob", Role: entities.RoleViewer}}}},
+		}
+		data, err := json.Marshal(written)
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+
+		read, err := export.Read(strings.NewReader(string(data)))
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		if read.Account != written.Account || !read.ExportedAt.Equal(written.ExportedAt) ||
+			len(read.Projects) != 1 || read.Projects[0].Members[0].Account != "Bob" {
+			t.Errorf("expected %+v but got %+v", written, read)
+		}
+	})
+
+	t.Run("RefusesOtherVersions", func(t *testing.T) {
+		if _, err := export.Read(strings.NewReader(`{"version": 2}`)); err == nil {
+			t.Errorf("expected a newer archive to be refused")
+		}
+		if _, err := export.Read(strings.NewReader(`not json`)); err == nil {
+			t.Errorf("expected something other than an archive to be refused")
+		}
+	})
+}
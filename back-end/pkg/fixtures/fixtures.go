@@ -0,0 +1,288 @@
+// Fixtures package reads the accounts and projects that a server is seeded with when it
+// starts, so that it can be demonstrated or explored without signing up by hand. Fixtures
+// are written in YAML, or in JSON, which YAML includes:
+//
+//	accounts:
+//	  - name: sue
+//	    password: correct-horse-1
+//	    activated: true
+//	    plan: pro
+//	    projects:
+//	      - Roadmap
+//	  - name: bob
+//	    password: battery-staple-2
+//
+// Problems are reported with the line they are on, and all of them are reported at once.
+package fixtures
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/internal/domain/passwords"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"gopkg.in/yaml.v3"
+)
+
+// Fixtures are what a server is seeded with
+type Fixtures struct {
+	Accounts []Account
+}
+
+// Account is an account to seed, with the password to sign in to it with. An account
+// that is not activated is sent an activation link, as if it had just been signed up.
+// Only activated accounts can have projects.
+type Account struct {
+	Name      string
+	Password  string
+	Activated bool
+	// Plan is the plan the account is on, the free plan unless the fixture says otherwise.
+	// Accounts are seeded with all of their projects, whatever the plan's quota.
+	Plan entities.Plan
+	// Projects holds the names of the account's projects, in the order they are created
+	Projects []string
+}
+
+// Error is a problem with fixtures, at a line of the file they were read from
+type Error struct {
+	// File is the file the fixtures were read from, if they were read from a file
+	File    string
+	Line    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.File == "" {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Message)
+}
+
+// Load reads and checks the fixtures in the named file. Each problem found is returned
+// as an *Error, joined with errors.Join.
+func Load(path string) (Fixtures, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Fixtures{}, err
+	}
+	fixtures, err := Parse(data)
+	if err != nil {
+		return Fixtures{}, inFile(err, path)
+	}
+	return fixtures, nil
+}
+
+// Parse reads and checks fixtures. Each problem found is returned as an *Error, joined
+// with errors.Join.
+func Parse(data []byte) (Fixtures, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return Fixtures{}, syntaxError(err)
+	}
+	p := &parser{names: make(map[string]int)}
+	var fixtures Fixtures
+	// An empty file has no document, and seeds nothing
+	if len(document.Content) > 0 {
+		fixtures = p.fixtures(document.Content[0])
+	}
+	if len(p.errs) > 0 {
+		slices.SortStableFunc(p.errs, func(a, b *Error) int { return a.Line - b.Line })
+		errs := make([]error, len(p.errs))
+		for i, err := range p.errs {
+			errs[i] = err
+		}
+		return Fixtures{}, errors.Join(errs...)
+	}
+	return fixtures, nil
+}
+
+// parser collects the problems found while reading fixtures, so that all of them can be
+// reported together
+type parser struct {
+	errs []*Error
+	// names holds the line each account's name was given on
+	names map[string]int
+}
+
+func (p *parser) fail(node *yaml.Node, format string, args ...any) {
+	p.errs = append(p.errs, &Error{Line: node.Line, Message: fmt.Sprintf(format, args...)})
+}
+
+func (p *parser) fixtures(node *yaml.Node) Fixtures {
+	var fixtures Fixtures
+	p.fields(node, "fixtures", func(key string, value *yaml.Node) {
+		switch key {
+		case "accounts":
+			if !p.isKind(value, yaml.SequenceNode, "accounts must be a list") {
+				return
+			}
+			for _, item := range value.Content {
+				if account, ok := p.account(item); ok {
+					fixtures.Accounts = append(fixtures.Accounts, account)
+				}
+			}
+		default:
+			p.fail(value, "unknown field %q; fixtures have only accounts", key)
+		}
+	})
+	return fixtures
+}
+
+func (p *parser) account(node *yaml.Node) (Account, bool) {
+	var account Account
+	before := len(p.errs)
+	var name, password, projects *yaml.Node
+	var hasName, hasPassword bool
+	p.fields(node, "an account", func(key string, value *yaml.Node) {
+		switch key {
+		case "name":
+			name = value
+			hasName = p.string(value, "name", &account.Name) && p.checkName(value, account.Name)
+		case "password":
+			password = value
+			hasPassword = p.string(value, "password", &account.Password)
+		case "activated":
+			if value.Kind != yaml.ScalarNode || value.Tag != "!!bool" || value.Decode(&account.Activated) != nil {
+				p.fail(value, "activated must be true or false")
+			}
+		case "plan":
+			var plan string
+			if p.string(value, "plan", &plan) {
+				account.Plan = entities.Plan(plan)
+				if account.Plan != entities.PlanFree && account.Plan != entities.PlanPro {
+					p.fail(value, "plan must be free or pro")
+				}
+			}
+		case "projects":
+			projects = value
+			account.Projects = p.projects(value)
+		default:
+			p.fail(value, "unknown field %q; accounts have name, password, activated, plan and projects", key)
+		}
+	})
+	if node.Kind != yaml.MappingNode {
+		return Account{}, false
+	}
+	if name == nil {
+		p.fail(node, "account has no name")
+	}
+	if password == nil {
+		p.fail(node, "account has no password")
+	}
+	if hasName && hasPassword {
+		if err := passwords.CheckStrength(account.Name, account.Password); err != nil {
+			p.fail(password, "%v", err)
+		}
+	}
+	if len(account.Projects) > 0 && !account.Activated {
+		p.fail(projects, "only activated accounts can have projects")
+	}
+	return account, len(p.errs) == before
+}
+
+// checkName refuses an empty name, or one given to an account earlier in the file
+func (p *parser) checkName(node *yaml.Node, name string) bool {
+	if name == "" {
+		p.fail(node, "name must not be empty")
+		return false
+	}
+	if line, ok := p.names[name]; ok {
+		p.fail(node, "name %q is already taken by the account at line %d", name, line)
+		return false
+	}
+	p.names[name] = node.Line
+	return true
+}
+
+func (p *parser) projects(node *yaml.Node) []string {
+	if !p.isKind(node, yaml.SequenceNode, "projects must be a list of project names") {
+		return nil
+	}
+	projects := make([]string, 0, len(node.Content))
+	for _, item := range node.Content {
+		var name string
+		if !p.string(item, "project name", &name) {
+			continue
+		}
+		if name == "" {
+			p.fail(item, "project name must not be empty")
+			continue
+		}
+		projects = append(projects, name)
+	}
+	return projects
+}
+
+// fields calls found with each field of a mapping, refusing fields given twice
+func (p *parser) fields(node *yaml.Node, what string, found func(key string, value *yaml.Node)) {
+	if !p.isKind(node, yaml.MappingNode, what+" must be a mapping of fields") {
+		return
+	}
+	seen := make(map[string]int)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if line, ok := seen[key.Value]; ok {
+			p.fail(key, "field %q is already given at line %d", key.Value, line)
+			continue
+		}
+		seen[key.Value] = key.Line
+		found(key.Value, value)
+	}
+}
+
+// string reads a string scalar into s. Numbers and the like must be quoted, so that
+// they are not mistaken for strings by accident.
+func (p *parser) string(node *yaml.Node, what string, s *string) bool {
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!str" {
+		p.fail(node, "%s must be a string; put it in quotes if it looks like something else", what)
+		return false
+	}
+	*s = node.Value
+	return true
+}
+
+func (p *parser) isKind(node *yaml.Node, kind yaml.Kind, message string) bool {
+	if node.Kind != kind {
+		p.fail(node, "%s", message)
+		return false
+	}
+	return true
+}
+
+// syntaxLine finds the line in the errors the YAML parser returns for malformed input
+var syntaxLine = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// syntaxError returns err from the YAML parser as an *Error if it says which line
+// the problem is on
+func syntaxError(err error) error {
+	match := syntaxLine.FindStringSubmatch(err.Error())
+	if match == nil {
+		return err
+	}
+	line, convErr := strconv.Atoi(match[1])
+	if convErr != nil {
+		return err
+	}
+	return &Error{Line: line, Message: match[2]}
+}
+
+// inFile records the file that each *Error in err was found in
+func inFile(err error, path string) error {
+	var errs []error
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	} else {
+		errs = []error{err}
+	}
+	for _, e := range errs {
+		var fixtureErr *Error
+		if errors.As(e, &fixtureErr) {
+			fixtureErr.File = path
+		}
+	}
+	return err
+}
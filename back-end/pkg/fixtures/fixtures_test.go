@@ -0,0 +1,200 @@
+package fixtures_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/internal/domain/application"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/internal/domain/passwords"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/fixtures"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/notifier/outbox"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("ReadsYAML", func(t *testing.T) {
+		f, err := fixtures.Parse([]byte(`
+accounts:
+  - name: Sue
+    password: correct-horse-1
+    activated: true
+    plan: pro
+    projects: [Roadmap, Plan]
+  - name: Bob
+    password: battery-staple-2
+`))
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		expectAccounts(t, f, "Sue: correct-horse-1 activated pro [Roadmap Plan]", "Bob: battery-staple-2 []")
+	})
+
+	t.Run("ReadsJSON", func(t *testing.T) {
+		f, err := fixtures.Parse([]byte(`{
+  "accounts": [
+    {"name": "Sue", "password": "correct-horse-1", "activated": true, "projects": ["Roadmap"]}
+  ]
+}`))
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		expectAccounts(t, f, "Sue: correct-horse-1 activated [Roadmap]")
+	})
+
+	t.Run("ReadsNothingFromEmptyFile", func(t *testing.T) {
+		f, err := fixtures.Parse(nil)
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		expectAccounts(t, f)
+	})
+
+	t.Run("ReportsEveryProblemWithItsLine", func(t *testing.T) {
+		_, err := fixtures.Parse([]byte(`accounts:
+  - name: Sue
+    password: correct-horse-1
+    activated: yes please
+  - name: Sue
+    password: battery-staple-2
+  - password: 12345678
+    projects:
+      - Roadmap
+      - ""
+  - name: Tanya
+    password: tanya-is-great-1
+    projects: [Roadmap]
+    colour: blue
+    plan: gold
+`))
+		expectErrors(t, err,
+			"line 4: activated must be true or false",
+			`line 5: name "Sue" is already taken by the account at line 2`,
+			"line 7: password must be a string; put it in quotes if it looks like something else",
+			"line 7: account has no name",
+			"line 9: only activated accounts can have projects",
+			"line 10: project name must not be empty",
+			"line 12: password is too weak: it must not contain the account name",
+			"line 13: only activated accounts can have projects",
+			`line 14: unknown field "colour"; accounts have name, password, activated, plan and projects`,
+			"line 15: plan must be free or pro",
+		)
+	})
+
+	t.Run("ReportsSyntaxErrorsWithTheirLine", func(t *testing.T) {
+		_, err := fixtures.Parse([]byte("accounts:\n  - name: Sue\n  password: [correct-horse-1\n"))
+		var fixtureErr *fixtures.Error
+		if !errors.As(err, &fixtureErr) || fixtureErr.Line == 0 {
+			t.Fatalf("expected an error with a line but got %v", err)
+		}
+	})
+
+	t.Run("ReportsFieldsGivenTwice", func(t *testing.T) {
+		_, err := fixtures.Parse([]byte("accounts:\n  - name: Sue\n    name: Bob\n    password: correct-horse-1\n"))
+		expectErrors(t, err, `line 3: field "name" is already given at line 2`)
+	})
+
+	t.Run("ReportsWrongShapes", func(t *testing.T) {
+		_, err := fixtures.Parse([]byte("accounts:\n  name: Sue\n"))
+		expectErrors(t, err, "line 2: accounts must be a list")
+
+		_, err = fixtures.Parse([]byte("- Sue\n"))
+		expectErrors(t, err, "line 1: fixtures must be a mapping of fields")
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("ReadsDemoFixtures", func(t *testing.T) {
+		f, err := fixtures.Load("../../cmd/server/demo-fixtures.yaml")
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		if len(f.Accounts) == 0 {
+			t.Fatal("expected demo fixtures to hold accounts")
+		}
+	})
+
+	t.Run("ReportsFileWithLine", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "fixtures.yaml")
+		if err := os.WriteFile(path, []byte("accounts:\n  - name: Sue\n"), 0o600); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		_, err := fixtures.Load(path)
+		expectErrors(t, err, path+":2: account has no password")
+	})
+}
+
+func TestSeed(t *testing.T) {
+	f, err := fixtures.Parse([]byte(`accounts:
+  - name: Sue
+    password: correct-horse-1
+    activated: true
+    projects: [Roadmap, Plan]
+  - name: Tanya
+    password: battery-staple-2
+`))
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	messages := outbox.New()
+	service := application.New(application.WithNotifier(messages), application.WithPasswordHasher(passwords.Hasher{Iterations: 1}))
+	if err := service.Seed(f); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+
+	session, err := service.Authenticate("Sue", "correct-horse-1")
+	if err != nil {
+		t.Fatalf("expected Sue to sign in but got %v", err)
+	}
+	projects, err := service.GetProjects(session.Value, "Sue")
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	if len(projects) != 2 || projects[0].Name() != "Roadmap" || projects[1].Name() != "Plan" {
+		t.Fatalf("expected Sue's projects in order but got %+v", projects)
+	}
+
+	if _, err := service.Authenticate("Tanya", "battery-staple-2"); !errors.Is(err, entities.ErrAccountNotActivated) {
+		t.Fatalf("expected Tanya to need to activate but got %v", err)
+	}
+	if sent := messages.Messages("Tanya"); len(sent) != 1 || sent[0].Link == "" {
+		t.Fatalf("expected Tanya to be sent an activation link but got %+v", sent)
+	}
+	if sent := messages.Messages("Sue"); len(sent) != 0 {
+		t.Fatalf("expected no messages for Sue but got %+v", sent)
+	}
+
+	if err := service.Seed(f); !errors.Is(err, entities.ErrAccountExists) {
+		t.Fatalf("expected seeding taken names to fail but got %v", err)
+	}
+}
+
+func expectAccounts(t *testing.T, f fixtures.Fixtures, expected ...string) {
+	t.Helper()
+	var got []string
+	for _, account := range f.Accounts {
+		description := account.Name + ": " + account.Password
+		if account.Activated {
+			description += " activated"
+		}
+		if account.Plan != "" {
+			description += " " + string(account.Plan)
+		}
+		got = append(got, description+" ["+strings.Join(account.Projects, " ")+"]")
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("expected accounts\n%s\nbut got\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func expectErrors(t *testing.T, err error, expected ...string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("expected errors\n%s\nbut got none", strings.Join(expected, "\n"))
+	}
+	if err.Error() != strings.Join(expected, "\n") {
+		t.Fatalf("expected errors\n%s\nbut got\n%v", strings.Join(expected, "\n"), err)
+	}
+}
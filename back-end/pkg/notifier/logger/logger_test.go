@@ -0,0 +1,26 @@
+package logger_test
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/notifier"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/notifier/logger"
+)
+
+func TestLogger(t *testing.T) {
+	t.Run("LogsRecipientSubjectAndLink", func(t *testing.T) {
+		var written bytes.Buffer
+		l := logger.New(log.New(&written, "", 0))
+		message := notifier.Message{To: "Sue", Subject: "Activate your account", Link: notifier.ActivationLink("Sue", "abc")}
+		if err := l.Send(message); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+
+		if got := strings.TrimSpace(written.String()); got != "Message to Sue: Activate your account /activate/Sue?token=abc" {
+			t.Fatalf("expected the message to be logged but got %q", got)
+		}
+	})
+}
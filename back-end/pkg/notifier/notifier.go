@@ -0,0 +1,52 @@
+// Notifier package defines how the application sends messages, such as activation
+// links, to account holders. It is exported so that acceptance tests can read them.
+package notifier
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Message is a message sent to an account holder
+type Message struct {
+	// To is the name of the account the message is for
+	To      string
+	Subject string
+	Body    string
+	// Link is the front-end path the account holder is asked to follow
+	Link string
+}
+
+// Notifier sends messages to account holders
+type Notifier interface {
+	Send(message Message) error
+}
+
+// Discard is a notifier that drops every message
+var Discard Notifier = discard{}
+
+type discard struct{}
+
+func (discard) Send(Message) error {
+	return nil
+}
+
+const activationPath = "/activate/"
+
+// ActivationLink returns the link an account holder follows to activate their account
+func ActivationLink(name, token string) string {
+	return activationPath + url.PathEscape(name) + "?token=" + url.QueryEscape(token)
+}
+
+// ParseActivationLink returns the account name and token from an activation link
+func ParseActivationLink(link string) (name, token string, err error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid activation link %q: %w", link, err)
+	}
+	if !strings.HasPrefix(u.Path, activationPath) {
+		return "", "", fmt.Errorf("invalid activation link %q", link)
+	}
+	return strings.TrimPrefix(u.Path, activationPath), u.Query().Get("token"), nil
+}
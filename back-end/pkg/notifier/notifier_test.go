@@ -0,0 +1,23 @@
+package notifier_test
+
+import (
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/notifier"
+)
+
+func TestActivationLink(t *testing.T) {
+	link := notifier.ActivationLink("Sue Smith", "a/b+c")
+
+	name, token, err := notifier.ParseActivationLink(link)
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	if name != "Sue Smith" || token != "a/b+c" {
+		t.Fatalf("expected Sue Smith and a/b+c but got %q and %q", name, token)
+	}
+
+	if _, _, err := notifier.ParseActivationLink("/account/Sue"); err == nil {
+		t.Fatal("expected an error for a link that is not an activation link")
+	}
+}
@@ -0,0 +1,50 @@
+// Outbox package provides a notifier that keeps messages in memory instead of
+// delivering them, so that they can be read back by tests and the test endpoint
+package outbox
+
+import (
+	"sync"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/notifier"
+)
+
+// Outbox stores every message sent, in the order they were sent
+type Outbox struct {
+	mu       sync.RWMutex
+	messages []notifier.Message
+}
+
+// New creates an empty outbox
+func New() *Outbox {
+	return &Outbox{}
+}
+
+// verify that Outbox implements notifier.Notifier
+var _ notifier.Notifier = (*Outbox)(nil)
+
+func (o *Outbox) Send(message notifier.Message) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.messages = append(o.messages, message)
+	return nil
+}
+
+// Messages returns the messages sent to the named account, oldest first
+func (o *Outbox) Messages(to string) []notifier.Message {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	messages := []notifier.Message{}
+	for _, message := range o.messages {
+		if message.To == to {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
+
+// Clear removes all messages
+func (o *Outbox) Clear() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.messages = nil
+}
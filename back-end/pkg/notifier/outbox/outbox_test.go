@@ -0,0 +1,38 @@
+package outbox_test
+
+import (
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/notifier"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/notifier/outbox"
+)
+
+func TestOutbox(t *testing.T) {
+	t.Run("ListsOnlyRecipientsMessagesInOrder", func(t *testing.T) {
+		o := outbox.New()
+		for _, message := range []notifier.Message{
+			{To: "Sue", Subject: "first"},
+			{To: "Bob", Subject: "other"},
+			{To: "Sue", Subject: "second"},
+		} {
+			if err := o.Send(message); err != nil {
+				t.Fatalf("expected no error but got %v", err)
+			}
+		}
+
+		messages := o.Messages("Sue")
+		if len(messages) != 2 || messages[0].Subject != "first" || messages[1].Subject != "second" {
+			t.Fatalf("expected Sue's two messages in order but got %+v", messages)
+		}
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		o := outbox.New()
+		_ = o.Send(notifier.Message{To: "Sue"})
+		o.Clear()
+
+		if messages := o.Messages("Sue"); len(messages) != 0 {
+			t.Fatalf("expected no messages but got %+v", messages)
+		}
+	})
+}
@@ -0,0 +1,779 @@
+// File package provides repositories that persist to a directory on the local
+// filesystem, so that data survives the process exiting or crashing.
+//
+// Every change is appended to a log as a length-prefixed, checksummed record and
+// synced before it is applied. The log is periodically compacted into a snapshot.
+// On start-up the snapshot is loaded and the log replayed on top of it. A torn
+// final record, left by a crash part way through a write, is discarded.
+package file
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/repository"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/repository/records"
+)
+
+const (
+	logFileName      = "data.log"
+	snapshotFileName = "snapshot.json"
+
+	// recordHeaderSize is the size of the length and checksum that precede each record
+	recordHeaderSize = 8
+
+	// defaultSnapshotEvery is how many records are appended to the log before it is compacted
+	defaultSnapshotEvery = 1000
+)
+
+// ErrCorrupt is returned by Open when the stored data is damaged somewhere other
+// than the final log record, so it cannot be explained by a crash during a write
+var ErrCorrupt = errors.New("data directory is corrupt")
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Store holds accounts, projects, sessions, webhooks and organisations in memory and persists every change to its directory.
+// It is safe for concurrent use.
+type Store struct {
+	mu       sync.RWMutex
+	dir      string
+	log      *os.File
+	logSize  int64
+	seq      uint64
+	accounts map[string]entities.Account
+	projects map[string]entities.Project
+	// byOwner holds the IDs of each owner's projects in the order they were added
+	byOwner map[string][]string
+	// projectSequence is the last number given to a project. Numbers given to projects
+	// are kept in the records that add them, and the last one in snapshots, but numbers
+	// given out for projects never added may be given out again after reopening.
+	projectSequence uint64
+	sessions        map[string]entities.Session
+	webhooks        map[string]entities.Webhook
+	// webhooksByOwner holds the IDs of each owner's webhooks in the order they were added
+	webhooksByOwner map[string][]string
+	organisations   map[string]entities.Organisation
+
+	// sinceSnapshot counts the records appended since the last snapshot
+	sinceSnapshot int
+	snapshotEvery int
+	// err is set if the log could not be restored after a failed write; the store then refuses all writes
+	err error
+}
+
+// Open loads the data stored in dir, creating the directory if it does not exist
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+	s := &Store{
+		dir:             dir,
+		accounts:        make(map[string]entities.Account),
+		projects:        make(map[string]entities.Project),
+		byOwner:         make(map[string][]string),
+		sessions:        make(map[string]entities.Session),
+		webhooks:        make(map[string]entities.Webhook),
+		webhooksByOwner: make(map[string][]string),
+		organisations:   make(map[string]entities.Organisation),
+		snapshotEvery:   defaultSnapshotEvery,
+	}
+	if err := s.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	log, err := os.OpenFile(filepath.Join(dir, logFileName), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log: %w", err)
+	}
+	s.log = log
+	if err := s.replayLog(); err != nil {
+		log.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Accounts returns the store's account repository
+func (s *Store) Accounts() *AccountRepository {
+	return &AccountRepository{store: s}
+}
+
+// Projects returns the store's project repository
+func (s *Store) Projects() *ProjectRepository {
+	return &ProjectRepository{store: s}
+}
+
+// Sessions returns the store's session repository
+func (s *Store) Sessions() *SessionRepository {
+	return &SessionRepository{store: s}
+}
+
+// Webhooks returns the store's webhook repository
+func (s *Store) Webhooks() *WebhookRepository {
+	return &WebhookRepository{store: s}
+}
+
+// Organisations returns the store's organisation repository
+func (s *Store) Organisations() *OrganisationRepository {
+	return &OrganisationRepository{store: s}
+}
+
+// Repositories returns all of the store's repositories
+func (s *Store) Repositories() repository.Repositories {
+	return repository.Repositories{
+		Accounts:      s.Accounts(),
+		Projects:      s.Projects(),
+		Sessions:      s.Sessions(),
+		Webhooks:      s.Webhooks(),
+		Organisations: s.Organisations(),
+	}
+}
+
+// Snapshot compacts the log into a snapshot of the current data
+func (s *Store) Snapshot() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	return s.snapshot()
+}
+
+// Close takes a final snapshot and releases the log
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var err error
+	if s.err == nil && s.sinceSnapshot > 0 {
+		err = s.snapshot()
+	}
+	return errors.Join(err, s.log.Close())
+}
+
+// AccountRepository stores accounts in the store
+type AccountRepository struct {
+	store *Store
+}
+
+// verify that AccountRepository implements repository.AccountRepository
+var _ repository.AccountRepository = (*AccountRepository)(nil)
+
+func (r *AccountRepository) Add(account entities.Account) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.accounts[account.Name()]; exists {
+		return fmt.Errorf("%w: %s", entities.ErrAccountExists, account.Name())
+	}
+	return s.write(record{Op: opAddAccount, Account: records.FromAccount(account)})
+}
+
+func (r *AccountRepository) Get(name string) (entities.Account, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	account, exists := s.accounts[name]
+	if !exists {
+		return entities.Account{}, fmt.Errorf("%w: %s", entities.ErrAccountNotFound, name)
+	}
+	return account, nil
+}
+
+func (r *AccountRepository) Update(account entities.Account) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.accounts[account.Name()]; !exists {
+		return fmt.Errorf("%w: %s", entities.ErrAccountNotFound, account.Name())
+	}
+	return s.write(record{Op: opUpdateAccount, Account: records.FromAccount(account)})
+}
+
+func (r *AccountRepository) List() ([]entities.Account, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	accounts := make([]entities.Account, 0, len(s.accounts))
+	for _, account := range s.accounts {
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+func (r *AccountRepository) Clear() error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(record{Op: opClearAccounts})
+}
+
+// ProjectRepository stores projects in the store
+type ProjectRepository struct {
+	store *Store
+}
+
+// verify that ProjectRepository implements repository.ProjectRepository
+var _ repository.ProjectRepository = (*ProjectRepository)(nil)
+
+func (r *ProjectRepository) Add(project entities.Project) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(record{Op: opAddProject, Project: records.FromProject(project)})
+}
+
+func (r *ProjectRepository) Get(id string) (entities.Project, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	project, exists := s.projects[id]
+	if !exists {
+		return entities.Project{}, fmt.Errorf("%w: %s", entities.ErrProjectNotFound, id)
+	}
+	return project, nil
+}
+
+func (r *ProjectRepository) Update(project entities.Project) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.projects[project.ID()]; !exists {
+		return fmt.Errorf("%w: %s", entities.ErrProjectNotFound, project.ID())
+	}
+	return s.write(record{Op: opUpdateProject, Project: records.FromProject(project)})
+}
+
+func (r *ProjectRepository) Delete(id string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.projects[id]; !exists {
+		return fmt.Errorf("%w: %s", entities.ErrProjectNotFound, id)
+	}
+	return s.write(record{Op: opDeleteProject, ProjectID: id})
+}
+
+func (r *ProjectRepository) ListByOwner(ownerID string) ([]entities.Project, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	// Build a new slice so callers never share storage with the store
+	projects := make([]entities.Project, 0, len(s.byOwner[ownerID]))
+	for _, id := range s.byOwner[ownerID] {
+		projects = append(projects, s.projects[id])
+	}
+	return projects, nil
+}
+
+func (r *ProjectRepository) List() ([]entities.Project, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	projects := make([]entities.Project, 0, len(s.projects))
+	for _, ids := range s.byOwner {
+		for _, id := range ids {
+			projects = append(projects, s.projects[id])
+		}
+	}
+	return projects, nil
+}
+
+func (r *ProjectRepository) Clear() error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(record{Op: opClearProjects})
+}
+
+func (r *ProjectRepository) NextSequence() (uint64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.projectSequence++
+	return s.projectSequence, nil
+}
+
+// SessionRepository stores sessions in the store
+type SessionRepository struct {
+	store *Store
+}
+
+// verify that SessionRepository implements repository.SessionRepository
+var _ repository.SessionRepository = (*SessionRepository)(nil)
+
+func (r *SessionRepository) Add(session entities.Session) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(record{Op: opAddSession, Session: records.FromSession(session)})
+}
+
+func (r *SessionRepository) Get(id string) (entities.Session, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	session, exists := s.sessions[id]
+	if !exists {
+		return entities.Session{}, entities.ErrSessionNotFound
+	}
+	return session, nil
+}
+
+func (r *SessionRepository) Delete(id string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.sessions[id]; !exists {
+		return entities.ErrSessionNotFound
+	}
+	return s.write(record{Op: opDeleteSession, SessionID: id})
+}
+
+func (r *SessionRepository) List() ([]entities.Session, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sessions := make([]entities.Session, 0, len(s.sessions))
+	for _, session := range s.sessions {
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+func (r *SessionRepository) Clear() error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(record{Op: opClearSessions})
+}
+
+// WebhookRepository stores webhooks in the store
+type WebhookRepository struct {
+	store *Store
+}
+
+// verify that WebhookRepository implements repository.WebhookRepository
+var _ repository.WebhookRepository = (*WebhookRepository)(nil)
+
+func (r *WebhookRepository) Add(webhook entities.Webhook) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(record{Op: opAddWebhook, Webhook: records.FromWebhook(webhook)})
+}
+
+func (r *WebhookRepository) Get(id string) (entities.Webhook, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	webhook, exists := s.webhooks[id]
+	if !exists {
+		return entities.Webhook{}, fmt.Errorf("%w: %s", entities.ErrWebhookNotFound, id)
+	}
+	return webhook, nil
+}
+
+func (r *WebhookRepository) Delete(id string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.webhooks[id]; !exists {
+		return fmt.Errorf("%w: %s", entities.ErrWebhookNotFound, id)
+	}
+	return s.write(record{Op: opDeleteWebhook, WebhookID: id})
+}
+
+func (r *WebhookRepository) ListByOwner(ownerID string) ([]entities.Webhook, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	// Build a new slice so callers never share storage with the store
+	webhooks := make([]entities.Webhook, 0, len(s.webhooksByOwner[ownerID]))
+	for _, id := range s.webhooksByOwner[ownerID] {
+		webhooks = append(webhooks, s.webhooks[id])
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) List() ([]entities.Webhook, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	webhooks := make([]entities.Webhook, 0, len(s.webhooks))
+	for _, ids := range s.webhooksByOwner {
+		for _, id := range ids {
+			webhooks = append(webhooks, s.webhooks[id])
+		}
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) Clear() error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(record{Op: opClearWebhooks})
+}
+
+// OrganisationRepository stores organisations in the store
+type OrganisationRepository struct {
+	store *Store
+}
+
+// verify that OrganisationRepository implements repository.OrganisationRepository
+var _ repository.OrganisationRepository = (*OrganisationRepository)(nil)
+
+func (r *OrganisationRepository) Add(organisation entities.Organisation) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.organisations[organisation.Name()]; exists {
+		return fmt.Errorf("%w: %s", entities.ErrOrganisationExists, organisation.Name())
+	}
+	return s.write(record{Op: opAddOrganisation, Organisation: records.FromOrganisation(organisation)})
+}
+
+func (r *OrganisationRepository) Get(name string) (entities.Organisation, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	organisation, exists := s.organisations[name]
+	if !exists {
+		return entities.Organisation{}, fmt.Errorf("%w: %s", entities.ErrOrganisationNotFound, name)
+	}
+	return organisation, nil
+}
+
+func (r *OrganisationRepository) Update(organisation entities.Organisation) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.organisations[organisation.Name()]; !exists {
+		return fmt.Errorf("%w: %s", entities.ErrOrganisationNotFound, organisation.Name())
+	}
+	return s.write(record{Op: opUpdateOrganisation, Organisation: records.FromOrganisation(organisation)})
+}
+
+func (r *OrganisationRepository) Delete(name string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.organisations[name]; !exists {
+		return fmt.Errorf("%w: %s", entities.ErrOrganisationNotFound, name)
+	}
+	return s.write(record{Op: opDeleteOrganisation, OrganisationName: name})
+}
+
+func (r *OrganisationRepository) List() ([]entities.Organisation, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	organisations := make([]entities.Organisation, 0, len(s.organisations))
+	for _, organisation := range s.organisations {
+		organisations = append(organisations, organisation)
+	}
+	return organisations, nil
+}
+
+func (r *OrganisationRepository) Clear() error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(record{Op: opClearOrganisations})
+}
+
+// write appends a change to the log and then applies it, compacting the log
+// when it has grown long enough. The caller must hold the write lock.
+func (s *Store) write(rec record) error {
+	if s.err != nil {
+		return s.err
+	}
+	rec.Seq = s.seq + 1
+	if err := s.append(rec); err != nil {
+		return err
+	}
+	s.seq = rec.Seq
+	s.apply(rec)
+	s.sinceSnapshot++
+	if s.sinceSnapshot >= s.snapshotEvery {
+		// The change is already durable, so a failed compaction is not reported
+		// to the caller; it is retried after the next write
+		_ = s.snapshot()
+	}
+	return nil
+}
+
+// append writes a record to the end of the log and syncs it to disk
+func (s *Store) append(rec record) error {
+	payload, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to encode record: %w", err)
+	}
+	buf := make([]byte, recordHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(buf[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(buf[4:8], crc32.Checksum(payload, crcTable))
+	copy(buf[recordHeaderSize:], payload)
+
+	_, err = s.log.WriteAt(buf, s.logSize)
+	if err == nil {
+		err = s.log.Sync()
+	}
+	if err != nil {
+		// Remove any partial record so that later records are not written after it
+		if truncErr := s.log.Truncate(s.logSize); truncErr != nil {
+			s.err = fmt.Errorf("store is unusable after a failed write: %w", truncErr)
+		}
+		return fmt.Errorf("failed to write log: %w", err)
+	}
+	s.logSize += int64(len(buf))
+	return nil
+}
+
+// apply makes a change to the data held in memory
+func (s *Store) apply(rec record) {
+	switch rec.Op {
+	case opAddAccount, opUpdateAccount:
+		account := rec.Account.ToAccount()
+		s.accounts[account.Name()] = account
+	case opClearAccounts:
+		s.accounts = make(map[string]entities.Account)
+	case opAddProject:
+		s.addProject(rec.Project.ToProject())
+	case opUpdateProject:
+		project := rec.Project.ToProject()
+		s.projects[project.ID()] = project
+	case opDeleteProject:
+		if project, exists := s.projects[rec.ProjectID]; exists {
+			delete(s.projects, rec.ProjectID)
+			s.byOwner[project.OwnerID()] = slices.DeleteFunc(s.byOwner[project.OwnerID()], func(id string) bool {
+				return id == rec.ProjectID
+			})
+		}
+	case opClearProjects:
+		s.projects = make(map[string]entities.Project)
+		s.byOwner = make(map[string][]string)
+	case opAddSession:
+		session := rec.Session.ToSession()
+		s.sessions[session.ID()] = session
+	case opDeleteSession:
+		delete(s.sessions, rec.SessionID)
+	case opClearSessions:
+		s.sessions = make(map[string]entities.Session)
+	case opAddWebhook:
+		s.addWebhook(rec.Webhook.ToWebhook())
+	case opDeleteWebhook:
+		if webhook, exists := s.webhooks[rec.WebhookID]; exists {
+			delete(s.webhooks, rec.WebhookID)
+			s.webhooksByOwner[webhook.OwnerID()] = slices.DeleteFunc(s.webhooksByOwner[webhook.OwnerID()], func(id string) bool {
+				return id == rec.WebhookID
+			})
+		}
+	case opClearWebhooks:
+		s.webhooks = make(map[string]entities.Webhook)
+		s.webhooksByOwner = make(map[string][]string)
+	case opAddOrganisation, opUpdateOrganisation:
+		organisation := rec.Organisation.ToOrganisation()
+		s.organisations[organisation.Name()] = organisation
+	case opDeleteOrganisation:
+		delete(s.organisations, rec.OrganisationName)
+	case opClearOrganisations:
+		s.organisations = make(map[string]entities.Organisation)
+	}
+}
+
+func (s *Store) addProject(project entities.Project) {
+	s.projects[project.ID()] = project
+	s.byOwner[project.OwnerID()] = append(s.byOwner[project.OwnerID()], project.ID())
+	s.projectSequence = max(s.projectSequence, project.Sequence())
+}
+
+func (s *Store) addWebhook(webhook entities.Webhook) {
+	s.webhooks[webhook.ID()] = webhook
+	s.webhooksByOwner[webhook.OwnerID()] = append(s.webhooksByOwner[webhook.OwnerID()], webhook.ID())
+}
+
+// replayLog applies the records in the log that are newer than the snapshot.
+// A torn final record is truncated away; damage anywhere else is reported as ErrCorrupt.
+func (s *Store) replayLog() error {
+	info, err := s.log.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to read log: %w", err)
+	}
+	size := info.Size()
+	reader := bufio.NewReader(io.NewSectionReader(s.log, 0, size))
+
+	var offset int64
+	for offset < size {
+		rec, n, err := readRecord(reader, size-offset)
+		if errors.Is(err, errTornRecord) {
+			if err := s.log.Truncate(offset); err != nil {
+				return fmt.Errorf("failed to discard torn record: %w", err)
+			}
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: record at offset %d: %v", ErrCorrupt, offset, err)
+		}
+		if rec.Seq > s.seq {
+			s.apply(rec)
+			s.seq = rec.Seq
+			s.sinceSnapshot++
+		}
+		offset += n
+	}
+	s.logSize = offset
+	return nil
+}
+
+// errTornRecord reports a final record that was only partly written
+var errTornRecord = errors.New("torn record")
+
+// readRecord reads the next record from the log, given how many bytes remain in it
+func readRecord(r io.Reader, remaining int64) (record, int64, error) {
+	var header [recordHeaderSize]byte
+	if remaining < recordHeaderSize {
+		return record{}, 0, errTornRecord
+	}
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return record{}, 0, err
+	}
+	length := int64(binary.LittleEndian.Uint32(header[0:4]))
+	checksum := binary.LittleEndian.Uint32(header[4:8])
+	n := recordHeaderSize + length
+	if n > remaining {
+		return record{}, 0, errTornRecord
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return record{}, 0, err
+	}
+	if crc32.Checksum(payload, crcTable) != checksum {
+		if n == remaining {
+			// The final record may have been extended but not filled in before a crash
+			return record{}, 0, errTornRecord
+		}
+		return record{}, 0, errors.New("checksum mismatch")
+	}
+	var rec record
+	if err := json.Unmarshal(payload, &rec); err != nil {
+		return record{}, 0, err
+	}
+	return rec, n, nil
+}
+
+// snapshot writes the data held in memory to the snapshot file and then empties
+// the log. The snapshot records the sequence number of the last change it includes,
+// so records left in the log by a crash before it is emptied are skipped on replay.
+// The caller must hold the write lock.
+func (s *Store) snapshot() error {
+	snap := snapshot{Seq: s.seq, ProjectSequence: s.projectSequence}
+	for _, account := range s.accounts {
+		snap.Accounts = append(snap.Accounts, *records.FromAccount(account))
+	}
+	for _, ids := range s.byOwner {
+		for _, id := range ids {
+			snap.Projects = append(snap.Projects, *records.FromProject(s.projects[id]))
+		}
+	}
+	for _, session := range s.sessions {
+		snap.Sessions = append(snap.Sessions, *records.FromSession(session))
+	}
+	for _, ids := range s.webhooksByOwner {
+		for _, id := range ids {
+			snap.Webhooks = append(snap.Webhooks, *records.FromWebhook(s.webhooks[id]))
+		}
+	}
+	for _, organisation := range s.organisations {
+		snap.Organisations = append(snap.Organisations, *records.FromOrganisation(organisation))
+	}
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := writeFileAtomically(filepath.Join(s.dir, snapshotFileName), data); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := s.log.Truncate(0); err != nil {
+		return fmt.Errorf("failed to empty log: %w", err)
+	}
+	if err := s.log.Sync(); err != nil {
+		return fmt.Errorf("failed to sync log: %w", err)
+	}
+	s.logSize = 0
+	s.sinceSnapshot = 0
+	return nil
+}
+
+// loadSnapshot loads the snapshot file, if there is one
+func (s *Store) loadSnapshot() error {
+	data, err := os.ReadFile(filepath.Join(s.dir, snapshotFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("%w: snapshot: %v", ErrCorrupt, err)
+	}
+	s.seq = snap.Seq
+	s.projectSequence = snap.ProjectSequence
+	for _, account := range snap.Accounts {
+		s.accounts[account.Name] = account.ToAccount()
+	}
+	// Projects are stored in the order they were added to each owner
+	for _, project := range snap.Projects {
+		s.addProject(project.ToProject())
+	}
+	for _, session := range snap.Sessions {
+		s.sessions[session.ID] = session.ToSession()
+	}
+	// Webhooks are stored in the order they were added to each owner
+	for _, webhook := range snap.Webhooks {
+		s.addWebhook(webhook.ToWebhook())
+	}
+	for _, organisation := range snap.Organisations {
+		s.organisations[organisation.Name] = organisation.ToOrganisation()
+	}
+	return nil
+}
+
+// writeFileAtomically replaces a file so that readers see either the old or the new contents in full
+func writeFileAtomically(path string, data []byte) error {
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	// Sync the directory so that the rename itself survives a crash
+	dir, err := os.Open(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
@@ -0,0 +1,305 @@
+package file_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/repository"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/repository/file"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/testhelpers"
+)
+
+func TestRepositoryConformance(t *testing.T) {
+	testhelpers.RunRepositoryConformanceTests(t, func(t *testing.T) repository.Repositories {
+		return openStore(t, t.TempDir()).Repositories()
+	})
+}
+
+func TestReopen(t *testing.T) {
+	t.Run("KeepsChangesFromLog", func(t *testing.T) {
+		dir := t.TempDir()
+		store := openStore(t, dir)
+		addSueWithProject(t, store)
+
+		// Simulate a crash by reopening without closing, so that nothing is snapshotted
+		expectSueWithProject(t, openStore(t, dir))
+	})
+
+	t.Run("KeepsChangesFromSnapshotAndLog", func(t *testing.T) {
+		dir := t.TempDir()
+		store := openStore(t, dir)
+		addSueWithProject(t, store)
+		expectNoError(t, store.Snapshot())
+		expectNoError(t, store.Projects().Add(newProject("second-id")))
+
+		reopened := openStore(t, dir)
+		projects, err := reopened.Projects().ListByOwner("sue-id")
+		expectNoError(t, err)
+		if len(projects) != 2 {
+			t.Fatalf("expected 2 projects but got %d", len(projects))
+		}
+	})
+
+	t.Run("KeepsChangesAfterClose", func(t *testing.T) {
+		dir := t.TempDir()
+		store, err := file.Open(dir)
+		expectNoError(t, err)
+		addSueWithProject(t, store)
+		expectNoError(t, store.Close())
+
+		expectSueWithProject(t, openStore(t, dir))
+	})
+
+	t.Run("KeepsClear", func(t *testing.T) {
+		dir := t.TempDir()
+		store := openStore(t, dir)
+		addSueWithProject(t, store)
+		expectNoError(t, store.Projects().Clear())
+		expectNoError(t, store.Accounts().Clear())
+
+		_, err := openStore(t, dir).Accounts().Get("Sue")
+		if !errors.Is(err, entities.ErrAccountNotFound) {
+			t.Fatalf("expected error '%v' but got %v", entities.ErrAccountNotFound, err)
+		}
+	})
+
+	t.Run("KeepsRenamesAndDeletes", func(t *testing.T) {
+		dir := t.TempDir()
+		store := openStore(t, dir)
+		addSueWithProject(t, store)
+		renamed := newProject("renamed-id")
+		expectNoError(t, store.Projects().Add(renamed))
+		renamed.SetName("Plan")
+		expectNoError(t, store.Projects().Update(renamed))
+		expectNoError(t, store.Projects().Delete("renamed-id"))
+		kept := newProject("kept-id")
+		expectNoError(t, store.Projects().Add(kept))
+		kept.SetName("Plan")
+		expectNoError(t, store.Projects().Update(kept))
+
+		got, err := openStore(t, dir).Projects().Get("kept-id")
+		expectNoError(t, err)
+		if got.Name() != "Plan" {
+			t.Fatalf("expected project to be renamed to Plan but got %s", got.Name())
+		}
+		_, err = openStore(t, dir).Projects().Get("renamed-id")
+		if !errors.Is(err, entities.ErrProjectNotFound) {
+			t.Fatalf("expected error '%v' but got %v", entities.ErrProjectNotFound, err)
+		}
+	})
+
+	t.Run("KeepsSessionDeletes", func(t *testing.T) {
+		dir := t.TempDir()
+		store := openStore(t, dir)
+		addSueWithProject(t, store)
+		expectNoError(t, store.Sessions().Delete("session-id"))
+
+		_, err := openStore(t, dir).Sessions().Get("session-id")
+		if !errors.Is(err, entities.ErrSessionNotFound) {
+			t.Fatalf("expected error '%v' but got %v", entities.ErrSessionNotFound, err)
+		}
+	})
+
+	t.Run("KeepsWebhookDeletes", func(t *testing.T) {
+		dir := t.TempDir()
+		store := openStore(t, dir)
+		addSueWithProject(t, store)
+		expectNoError(t, store.Webhooks().Delete("webhook-id"))
+
+		_, err := openStore(t, dir).Webhooks().Get("webhook-id")
+		if !errors.Is(err, entities.ErrWebhookNotFound) {
+			t.Fatalf("expected error '%v' but got %v", entities.ErrWebhookNotFound, err)
+		}
+	})
+
+	t.Run("KeepsOrganisationDeletes", func(t *testing.T) {
+		dir := t.TempDir()
+		store := openStore(t, dir)
+		addSueWithProject(t, store)
+		expectNoError(t, store.Organisations().Delete("Acme"))
+
+		_, err := openStore(t, dir).Organisations().Get("Acme")
+		if !errors.Is(err, entities.ErrOrganisationNotFound) {
+			t.Fatalf("expected error '%v' but got %v", entities.ErrOrganisationNotFound, err)
+		}
+	})
+
+	t.Run("KeepsProjectSequence", func(t *testing.T) {
+		for name, compact := range map[string]bool{"FromLog": false, "FromSnapshot": true} {
+			t.Run(name, func(t *testing.T) {
+				dir := t.TempDir()
+				store := openStore(t, dir)
+				sequence, err := store.Projects().NextSequence()
+				expectNoError(t, err)
+				project := newProject("numbered-id")
+				project.SetSequence(sequence)
+				expectNoError(t, store.Projects().Add(project))
+				expectNoError(t, store.Projects().Delete("numbered-id"))
+				if compact {
+					expectNoError(t, store.Snapshot())
+				}
+
+				next, err := openStore(t, dir).Projects().NextSequence()
+				expectNoError(t, err)
+				if next <= sequence {
+					t.Fatalf("expected a number after %d but got %d", sequence, next)
+				}
+			})
+		}
+	})
+
+	t.Run("SkipsLogRecordsAlreadyInSnapshot", func(t *testing.T) {
+		dir := t.TempDir()
+		store := openStore(t, dir)
+		addSueWithProject(t, store)
+		logBeforeSnapshot := readFile(t, filepath.Join(dir, "data.log"))
+		expectNoError(t, store.Snapshot())
+
+		// Simulate a crash after the snapshot was written but before the log was emptied
+		writeFile(t, filepath.Join(dir, "data.log"), logBeforeSnapshot)
+
+		expectSueWithProject(t, openStore(t, dir))
+	})
+}
+
+func TestRecovery(t *testing.T) {
+	t.Run("DiscardsPartlyWrittenFinalRecord", func(t *testing.T) {
+		dir := t.TempDir()
+		store := openStore(t, dir)
+		addSueWithProject(t, store)
+		complete := readFile(t, filepath.Join(dir, "data.log"))
+		expectNoError(t, store.Projects().Add(newProject("second-id")))
+		withTorn := readFile(t, filepath.Join(dir, "data.log"))
+
+		// Cut the final record short, as a crash part way through the write would
+		writeFile(t, filepath.Join(dir, "data.log"), withTorn[:len(complete)+5])
+
+		reopened := openStore(t, dir)
+		expectSueWithProject(t, reopened)
+
+		// Later writes must be readable after the torn record has been discarded
+		expectNoError(t, reopened.Projects().Add(newProject("third-id")))
+		projects, err := openStore(t, dir).Projects().ListByOwner("sue-id")
+		expectNoError(t, err)
+		if len(projects) != 2 {
+			t.Fatalf("expected 2 projects but got %d", len(projects))
+		}
+	})
+
+	t.Run("DiscardsUnfilledFinalRecord", func(t *testing.T) {
+		dir := t.TempDir()
+		store := openStore(t, dir)
+		addSueWithProject(t, store)
+		complete := readFile(t, filepath.Join(dir, "data.log"))
+		expectNoError(t, store.Projects().Add(newProject("second-id")))
+		withTorn := readFile(t, filepath.Join(dir, "data.log"))
+
+		// The file was extended but the final record's contents never reached the disk
+		for i := len(complete) + 8; i < len(withTorn); i++ {
+			withTorn[i] = 0
+		}
+		writeFile(t, filepath.Join(dir, "data.log"), withTorn)
+
+		expectSueWithProject(t, openStore(t, dir))
+	})
+
+	t.Run("RejectsDamageBeforeFinalRecord", func(t *testing.T) {
+		dir := t.TempDir()
+		store := openStore(t, dir)
+		addSueWithProject(t, store)
+		data := readFile(t, filepath.Join(dir, "data.log"))
+
+		// Damage the first record's contents
+		data[10] ^= 0xff
+		writeFile(t, filepath.Join(dir, "data.log"), data)
+
+		_, err := file.Open(dir)
+		if !errors.Is(err, file.ErrCorrupt) {
+			t.Fatalf("expected error '%v' but got %v", file.ErrCorrupt, err)
+		}
+	})
+}
+
+func openStore(t *testing.T, dir string) *file.Store {
+	t.Helper()
+	store, err := file.Open(dir)
+	expectNoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func addSueWithProject(t *testing.T, store *file.Store) {
+	t.Helper()
+	account := entities.NewAccount("sue-id", "Sue")
+	expectNoError(t, store.Accounts().Add(*account))
+	account.SetActivated(true)
+	expectNoError(t, store.Accounts().Update(*account))
+	expectNoError(t, store.Projects().Add(newProject("roadmap-id")))
+	expectNoError(t, store.Sessions().Add(*entities.NewSession("session-id", "sue-id", expiresAt)))
+	expectNoError(t, store.Webhooks().Add(*entities.NewWebhook("webhook-id", "sue-id", "https://example.com/hooks", "secret", createdAt)))
+	organisation := entities.NewOrganisation("acme-id", "Acme", createdAt)
+	expectNoError(t, store.Organisations().Add(*organisation))
+	organisation.SetMember(entities.OrgMember{AccountID: "sue-id", Role: entities.OrgRoleOwner})
+	expectNoError(t, store.Organisations().Update(*organisation))
+}
+
+var (
+	createdAt = time.Date(2025, time.January, 2, 3, 4, 5, 0, time.UTC)
+	expiresAt = time.Date(2025, time.January, 3, 3, 4, 5, 0, time.UTC)
+)
+
+func newProject(id string) entities.Project {
+	return *entities.NewProject(id, "Roadmap", "sue-id", createdAt)
+}
+
+func expectSueWithProject(t *testing.T, store *file.Store) {
+	t.Helper()
+	account, err := store.Accounts().Get("Sue")
+	expectNoError(t, err)
+	if account.ID() != "sue-id" || !account.IsActivated() {
+		t.Fatalf("expected activated account sue-id but got %+v", account)
+	}
+	projects, err := store.Projects().ListByOwner("sue-id")
+	expectNoError(t, err)
+	if len(projects) != 1 || projects[0].Name() != "Roadmap" || !projects[0].CreatedAt().Equal(createdAt) {
+		t.Fatalf("expected project Roadmap but got %+v", projects)
+	}
+	session, err := store.Sessions().Get("session-id")
+	expectNoError(t, err)
+	if session.AccountID() != "sue-id" || !session.ExpiresAt().Equal(expiresAt) {
+		t.Fatalf("expected session for sue-id but got %+v", session)
+	}
+	webhooks, err := store.Webhooks().ListByOwner("sue-id")
+	expectNoError(t, err)
+	if len(webhooks) != 1 || webhooks[0].URL() != "https://example.com/hooks" || webhooks[0].Secret() != "secret" {
+		t.Fatalf("expected webhook to https://example.com/hooks but got %+v", webhooks)
+	}
+	organisation, err := store.Organisations().Get("Acme")
+	expectNoError(t, err)
+	if organisation.ID() != "acme-id" || organisation.RoleOf("sue-id") != entities.OrgRoleOwner {
+		t.Fatalf("expected organisation Acme owned by sue-id but got %+v", organisation)
+	}
+}
+
+func readFile(t *testing.T, path string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	expectNoError(t, err)
+	return data
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	expectNoError(t, os.WriteFile(path, data, 0o644))
+}
+
+func expectNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package file
+
+import "github.com/sirockin/cucumber-screenplay-go/back-end/pkg/repository/records"
+
+// Operations recorded in the log
+const (
+	opAddAccount         = "add_account"
+	opUpdateAccount      = "update_account"
+	opClearAccounts      = "clear_accounts"
+	opAddProject         = "add_project"
+	opUpdateProject      = "update_project"
+	opDeleteProject      = "delete_project"
+	opClearProjects      = "clear_projects"
+	opAddSession         = "add_session"
+	opDeleteSession      = "delete_session"
+	opClearSessions      = "clear_sessions"
+	opAddWebhook         = "add_webhook"
+	opDeleteWebhook      = "delete_webhook"
+	opClearWebhooks      = "clear_webhooks"
+	opAddOrganisation    = "add_organisation"
+	opUpdateOrganisation = "update_organisation"
+	opDeleteOrganisation = "delete_organisation"
+	opClearOrganisations = "clear_organisations"
+)
+
+// record is a single change appended to the log
+type record struct {
+	Seq          uint64                `json:"seq"`
+	Op           string                `json:"op"`
+	Account      *records.Account      `json:"account,omitempty"`
+	Project      *records.Project      `json:"project,omitempty"`
+	Session      *records.Session      `json:"session,omitempty"`
+	Webhook      *records.Webhook      `json:"webhook,omitempty"`
+	Organisation *records.Organisation `json:"organisation,omitempty"`
+	// ProjectID identifies the project to delete
+	ProjectID string `json:"projectId,omitempty"`
+	// SessionID identifies the session to delete
+	SessionID string `json:"sessionId,omitempty"`
+	// WebhookID identifies the webhook to delete
+	WebhookID string `json:"webhookId,omitempty"`
+	// OrganisationName identifies the organisation to delete
+	OrganisationName string `json:"organisationName,omitempty"`
+}
+
+// snapshot is the full data set as of the change with sequence number Seq
+type snapshot struct {
+	Seq      uint64            `json:"seq"`
+	Accounts []records.Account `json:"accounts"`
+	Projects []records.Project `json:"projects"`
+	Sessions []records.Session `json:"sessions"`
+	Webhooks []records.Webhook `json:"webhooks"`
+	// ProjectSequence is the last number given to a project, and is missing from
+	// snapshots taken before projects were numbered
+	ProjectSequence uint64 `json:"projectSequence,omitempty"`
+	// Organisations is missing from snapshots taken before there were organisations
+	Organisations []records.Organisation `json:"organisations,omitempty"`
+}
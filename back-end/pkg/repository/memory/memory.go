@@ -0,0 +1,403 @@
+// Memory package provides in-memory repositories. They are the default storage
+// for the application and lose everything when the process exits.
+package memory
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/repository"
+)
+
+// NewRepositories creates an empty set of repositories
+func NewRepositories() repository.Repositories {
+	return repository.Repositories{
+		Accounts:      NewAccountRepository(),
+		Projects:      NewProjectRepository(),
+		Sessions:      NewSessionRepository(),
+		Webhooks:      NewWebhookRepository(),
+		Organisations: NewOrganisationRepository(),
+	}
+}
+
+// AccountRepository stores accounts in a map
+type AccountRepository struct {
+	mu       sync.RWMutex
+	accounts map[string]entities.Account
+}
+
+// NewAccountRepository creates an empty account repository
+func NewAccountRepository() *AccountRepository {
+	return &AccountRepository{
+		accounts: make(map[string]entities.Account),
+	}
+}
+
+// verify that AccountRepository implements repository.AccountRepository
+var _ repository.AccountRepository = (*AccountRepository)(nil)
+
+func (r *AccountRepository) Add(account entities.Account) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.accounts[account.Name()]; exists {
+		return fmt.Errorf("%w: %s", entities.ErrAccountExists, account.Name())
+	}
+	r.accounts[account.Name()] = account
+	return nil
+}
+
+func (r *AccountRepository) Get(name string) (entities.Account, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	account, exists := r.accounts[name]
+	if !exists {
+		return entities.Account{}, fmt.Errorf("%w: %s", entities.ErrAccountNotFound, name)
+	}
+	return account, nil
+}
+
+func (r *AccountRepository) Update(account entities.Account) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.accounts[account.Name()]; !exists {
+		return fmt.Errorf("%w: %s", entities.ErrAccountNotFound, account.Name())
+	}
+	r.accounts[account.Name()] = account
+	return nil
+}
+
+func (r *AccountRepository) List() ([]entities.Account, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	accounts := make([]entities.Account, 0, len(r.accounts))
+	for _, account := range r.accounts {
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+func (r *AccountRepository) Clear() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.accounts = make(map[string]entities.Account)
+	return nil
+}
+
+// ProjectRepository stores projects in a map keyed by ID, keeping the order
+// in which each owner's projects were added
+type ProjectRepository struct {
+	mu       sync.RWMutex
+	projects map[string]entities.Project
+	byOwner  map[string][]string
+	// sequence is the last number given to a project
+	sequence uint64
+}
+
+// NewProjectRepository creates an empty project repository
+func NewProjectRepository() *ProjectRepository {
+	return &ProjectRepository{
+		projects: make(map[string]entities.Project),
+		byOwner:  make(map[string][]string),
+	}
+}
+
+// verify that ProjectRepository implements repository.ProjectRepository
+var _ repository.ProjectRepository = (*ProjectRepository)(nil)
+
+func (r *ProjectRepository) Add(project entities.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.projects[project.ID()] = project
+	r.byOwner[project.OwnerID()] = append(r.byOwner[project.OwnerID()], project.ID())
+	r.sequence = max(r.sequence, project.Sequence())
+	return nil
+}
+
+func (r *ProjectRepository) Get(id string) (entities.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	project, exists := r.projects[id]
+	if !exists {
+		return entities.Project{}, fmt.Errorf("%w: %s", entities.ErrProjectNotFound, id)
+	}
+	return project, nil
+}
+
+func (r *ProjectRepository) Update(project entities.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.projects[project.ID()]; !exists {
+		return fmt.Errorf("%w: %s", entities.ErrProjectNotFound, project.ID())
+	}
+	r.projects[project.ID()] = project
+	return nil
+}
+
+func (r *ProjectRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	project, exists := r.projects[id]
+	if !exists {
+		return fmt.Errorf("%w: %s", entities.ErrProjectNotFound, id)
+	}
+	delete(r.projects, id)
+	r.byOwner[project.OwnerID()] = slices.DeleteFunc(r.byOwner[project.OwnerID()], func(ownedID string) bool {
+		return ownedID == id
+	})
+	return nil
+}
+
+func (r *ProjectRepository) ListByOwner(ownerID string) ([]entities.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	// Build a new slice so callers never share storage with the repository
+	projects := make([]entities.Project, 0, len(r.byOwner[ownerID]))
+	for _, id := range r.byOwner[ownerID] {
+		projects = append(projects, r.projects[id])
+	}
+	return projects, nil
+}
+
+func (r *ProjectRepository) List() ([]entities.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	projects := make([]entities.Project, 0, len(r.projects))
+	for _, ids := range r.byOwner {
+		for _, id := range ids {
+			projects = append(projects, r.projects[id])
+		}
+	}
+	return projects, nil
+}
+
+func (r *ProjectRepository) Clear() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.projects = make(map[string]entities.Project)
+	r.byOwner = make(map[string][]string)
+	return nil
+}
+
+func (r *ProjectRepository) NextSequence() (uint64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sequence++
+	return r.sequence, nil
+}
+
+// SessionRepository stores sessions in a map keyed by ID
+type SessionRepository struct {
+	mu       sync.RWMutex
+	sessions map[string]entities.Session
+}
+
+// NewSessionRepository creates an empty session repository
+func NewSessionRepository() *SessionRepository {
+	return &SessionRepository{
+		sessions: make(map[string]entities.Session),
+	}
+}
+
+// verify that SessionRepository implements repository.SessionRepository
+var _ repository.SessionRepository = (*SessionRepository)(nil)
+
+func (r *SessionRepository) Add(session entities.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[session.ID()] = session
+	return nil
+}
+
+func (r *SessionRepository) Get(id string) (entities.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	session, exists := r.sessions[id]
+	if !exists {
+		return entities.Session{}, entities.ErrSessionNotFound
+	}
+	return session, nil
+}
+
+func (r *SessionRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.sessions[id]; !exists {
+		return entities.ErrSessionNotFound
+	}
+	delete(r.sessions, id)
+	return nil
+}
+
+func (r *SessionRepository) List() ([]entities.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sessions := make([]entities.Session, 0, len(r.sessions))
+	for _, session := range r.sessions {
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+func (r *SessionRepository) Clear() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions = make(map[string]entities.Session)
+	return nil
+}
+
+// WebhookRepository stores webhooks in a map keyed by ID, keeping the order
+// in which each owner's webhooks were added
+type WebhookRepository struct {
+	mu       sync.RWMutex
+	webhooks map[string]entities.Webhook
+	byOwner  map[string][]string
+}
+
+// NewWebhookRepository creates an empty webhook repository
+func NewWebhookRepository() *WebhookRepository {
+	return &WebhookRepository{
+		webhooks: make(map[string]entities.Webhook),
+		byOwner:  make(map[string][]string),
+	}
+}
+
+// verify that WebhookRepository implements repository.WebhookRepository
+var _ repository.WebhookRepository = (*WebhookRepository)(nil)
+
+func (r *WebhookRepository) Add(webhook entities.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.webhooks[webhook.ID()] = webhook
+	r.byOwner[webhook.OwnerID()] = append(r.byOwner[webhook.OwnerID()], webhook.ID())
+	return nil
+}
+
+func (r *WebhookRepository) Get(id string) (entities.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	webhook, exists := r.webhooks[id]
+	if !exists {
+		return entities.Webhook{}, fmt.Errorf("%w: %s", entities.ErrWebhookNotFound, id)
+	}
+	return webhook, nil
+}
+
+func (r *WebhookRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	webhook, exists := r.webhooks[id]
+	if !exists {
+		return fmt.Errorf("%w: %s", entities.ErrWebhookNotFound, id)
+	}
+	delete(r.webhooks, id)
+	r.byOwner[webhook.OwnerID()] = slices.DeleteFunc(r.byOwner[webhook.OwnerID()], func(ownedID string) bool {
+		return ownedID == id
+	})
+	return nil
+}
+
+func (r *WebhookRepository) ListByOwner(ownerID string) ([]entities.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	// Build a new slice so callers never share storage with the repository
+	webhooks := make([]entities.Webhook, 0, len(r.byOwner[ownerID]))
+	for _, id := range r.byOwner[ownerID] {
+		webhooks = append(webhooks, r.webhooks[id])
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) List() ([]entities.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	webhooks := make([]entities.Webhook, 0, len(r.webhooks))
+	for _, ids := range r.byOwner {
+		for _, id := range ids {
+			webhooks = append(webhooks, r.webhooks[id])
+		}
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) Clear() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.webhooks = make(map[string]entities.Webhook)
+	r.byOwner = make(map[string][]string)
+	return nil
+}
+
+// OrganisationRepository stores organisations in a map
+type OrganisationRepository struct {
+	mu            sync.RWMutex
+	organisations map[string]entities.Organisation
+}
+
+// NewOrganisationRepository creates an empty organisation repository
+func NewOrganisationRepository() *OrganisationRepository {
+	return &OrganisationRepository{
+		organisations: make(map[string]entities.Organisation),
+	}
+}
+
+// verify that OrganisationRepository implements repository.OrganisationRepository
+var _ repository.OrganisationRepository = (*OrganisationRepository)(nil)
+
+func (r *OrganisationRepository) Add(organisation entities.Organisation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.organisations[organisation.Name()]; exists {
+		return fmt.Errorf("%w: %s", entities.ErrOrganisationExists, organisation.Name())
+	}
+	r.organisations[organisation.Name()] = organisation
+	return nil
+}
+
+func (r *OrganisationRepository) Get(name string) (entities.Organisation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	organisation, exists := r.organisations[name]
+	if !exists {
+		return entities.Organisation{}, fmt.Errorf("%w: %s", entities.ErrOrganisationNotFound, name)
+	}
+	return organisation, nil
+}
+
+func (r *OrganisationRepository) Update(organisation entities.Organisation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.organisations[organisation.Name()]; !exists {
+		return fmt.Errorf("%w: %s", entities.ErrOrganisationNotFound, organisation.Name())
+	}
+	r.organisations[organisation.Name()] = organisation
+	return nil
+}
+
+func (r *OrganisationRepository) Delete(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.organisations[name]; !exists {
+		return fmt.Errorf("%w: %s", entities.ErrOrganisationNotFound, name)
+	}
+	delete(r.organisations, name)
+	return nil
+}
+
+func (r *OrganisationRepository) List() ([]entities.Organisation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	organisations := make([]entities.Organisation, 0, len(r.organisations))
+	for _, organisation := range r.organisations {
+		organisations = append(organisations, organisation)
+	}
+	return organisations, nil
+}
+
+func (r *OrganisationRepository) Clear() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.organisations = make(map[string]entities.Organisation)
+	return nil
+}
@@ -0,0 +1,15 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/repository"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/repository/memory"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/testhelpers"
+)
+
+func TestRepositoryConformance(t *testing.T) {
+	testhelpers.RunRepositoryConformanceTests(t, func(t *testing.T) repository.Repositories {
+		return memory.NewRepositories()
+	})
+}
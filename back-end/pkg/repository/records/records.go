@@ -0,0 +1,196 @@
+// Records package defines the stored form of each entity, which is what is serialized
+// wherever the data is kept or copied, such as in the file repositories and in the
+// application's snapshots. Fields added since the first version are read from data
+// written before them as their zero values, so that older data can still be read.
+package records
+
+import (
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+)
+
+// Account is the stored form of an account
+type Account struct {
+	ID                 string    `json:"id"`
+	Name               string    `json:"name"`
+	Activated          bool      `json:"activated"`
+	ActivationToken    string    `json:"activationToken,omitempty"`
+	PasswordHash       string    `json:"passwordHash,omitempty"`
+	CreatedAt          time.Time `json:"createdAt"`
+	FailedSignIns      int       `json:"failedSignIns,omitempty"`
+	FailedSignInsSince time.Time `json:"failedSignInsSince"`
+	LockedUntil        time.Time `json:"lockedUntil"`
+	// Plan is missing from records stored before there were plans
+	Plan     entities.Plan `json:"plan,omitempty"`
+	ClosedAt time.Time     `json:"closedAt"`
+}
+
+// FromAccount returns the stored form of an account
+func FromAccount(account entities.Account) *Account {
+	return &Account{
+		ID:                 account.ID(),
+		Name:               account.Name(),
+		Activated:          account.IsActivated(),
+		ActivationToken:    account.ActivationToken(),
+		PasswordHash:       account.PasswordHash(),
+		CreatedAt:          account.CreatedAt(),
+		FailedSignIns:      account.FailedSignIns(),
+		FailedSignInsSince: account.FailedSignInsSince(),
+		LockedUntil:        account.LockedUntil(),
+		Plan:               account.Plan(),
+		ClosedAt:           account.ClosedAt(),
+	}
+}
+
+// ToAccount returns the account that r is the stored form of
+func (r *Account) ToAccount() entities.Account {
+	account := entities.NewAccount(r.ID, r.Name)
+	account.SetActivated(r.Activated)
+	account.SetActivationToken(r.ActivationToken)
+	account.SetPasswordHash(r.PasswordHash)
+	account.SetCreatedAt(r.CreatedAt)
+	account.SetFailedSignIns(r.FailedSignIns, r.FailedSignInsSince)
+	account.SetLockedUntil(r.LockedUntil)
+	account.SetPlan(r.Plan)
+	account.SetClosedAt(r.ClosedAt)
+	return *account
+}
+
+// Project is the stored form of a project
+type Project struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	OwnerID   string    `json:"ownerId"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Sequence is zero for projects stored before they were numbered
+	Sequence uint64 `json:"sequence,omitempty"`
+	// State is empty for projects stored before they had a lifecycle, which are active
+	State   entities.ProjectState `json:"state,omitempty"`
+	Members []Member              `json:"members,omitempty"`
+}
+
+// Member is the stored form of a project's member
+type Member struct {
+	AccountID string        `json:"accountId"`
+	Role      entities.Role `json:"role"`
+	Accepted  bool          `json:"accepted"`
+	InvitedAt time.Time     `json:"invitedAt"`
+}
+
+// FromProject returns the stored form of a project
+func FromProject(project entities.Project) *Project {
+	return &Project{
+		ID:        project.ID(),
+		Name:      project.Name(),
+		OwnerID:   project.OwnerID(),
+		CreatedAt: project.CreatedAt(),
+		Sequence:  project.Sequence(),
+		State:     project.State(),
+		Members:   fromMembers(project.Members()),
+	}
+}
+
+func fromMembers(members []entities.Member) []Member {
+	var records []Member
+	for _, member := range members {
+		records = append(records, Member(member))
+	}
+	return records
+}
+
+// ToProject returns the project that r is the stored form of
+func (r *Project) ToProject() entities.Project {
+	project := entities.NewProject(r.ID, r.Name, r.OwnerID, r.CreatedAt)
+	project.SetSequence(r.Sequence)
+	if r.State != "" {
+		project.SetState(r.State)
+	}
+	for _, member := range r.Members {
+		project.SetMember(entities.Member(member))
+	}
+	return *project
+}
+
+// Session is the stored form of a session. Only the ID derived from the session's
+// token is kept, so the token itself is never serialized.
+type Session struct {
+	ID        string    `json:"id"`
+	AccountID string    `json:"accountId"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// FromSession returns the stored form of a session
+func FromSession(session entities.Session) *Session {
+	return &Session{
+		ID:        session.ID(),
+		AccountID: session.AccountID(),
+		ExpiresAt: session.ExpiresAt(),
+	}
+}
+
+// ToSession returns the session that r is the stored form of
+func (r *Session) ToSession() entities.Session {
+	return *entities.NewSession(r.ID, r.AccountID, r.ExpiresAt)
+}
+
+// Webhook is the stored form of a webhook
+type Webhook struct {
+	ID        string    `json:"id"`
+	OwnerID   string    `json:"ownerId"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// FromWebhook returns the stored form of a webhook
+func FromWebhook(webhook entities.Webhook) *Webhook {
+	return &Webhook{
+		ID:        webhook.ID(),
+		OwnerID:   webhook.OwnerID(),
+		URL:       webhook.URL(),
+		Secret:    webhook.Secret(),
+		CreatedAt: webhook.CreatedAt(),
+	}
+}
+
+// ToWebhook returns the webhook that r is the stored form of
+func (r *Webhook) ToWebhook() entities.Webhook {
+	return *entities.NewWebhook(r.ID, r.OwnerID, r.URL, r.Secret, r.CreatedAt)
+}
+
+// Organisation is the stored form of an organisation
+type Organisation struct {
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	CreatedAt time.Time   `json:"createdAt"`
+	Members   []OrgMember `json:"members"`
+}
+
+// OrgMember is the stored form of an organisation's member
+type OrgMember struct {
+	AccountID string           `json:"accountId"`
+	Role      entities.OrgRole `json:"role"`
+}
+
+// FromOrganisation returns the stored form of an organisation
+func FromOrganisation(organisation entities.Organisation) *Organisation {
+	record := &Organisation{
+		ID:        organisation.ID(),
+		Name:      organisation.Name(),
+		CreatedAt: organisation.CreatedAt(),
+	}
+	for _, member := range organisation.Members() {
+		record.Members = append(record.Members, OrgMember(member))
+	}
+	return record
+}
+
+// ToOrganisation returns the organisation that r is the stored form of
+func (r *Organisation) ToOrganisation() entities.Organisation {
+	organisation := entities.NewOrganisation(r.ID, r.Name, r.CreatedAt)
+	for _, member := range r.Members {
+		organisation.SetMember(entities.OrgMember(member))
+	}
+	return *organisation
+}
@@ -0,0 +1,104 @@
+// Repository package defines the storage ports the application depends on.
+// It is exported so that other storage implementations can be plugged in and
+// checked with the conformance tests in testhelpers.
+package repository
+
+import "github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+
+// AccountRepository stores accounts by name.
+// Implementations must be safe for concurrent use.
+type AccountRepository interface {
+	// Add stores a new account, returning entities.ErrAccountExists if its name is taken
+	Add(account entities.Account) error
+	// Get returns the account with the given name, or entities.ErrAccountNotFound
+	Get(name string) (entities.Account, error)
+	// Update replaces the stored account with the same name, or returns entities.ErrAccountNotFound
+	Update(account entities.Account) error
+	// List returns all accounts, in no particular order
+	List() ([]entities.Account, error)
+	// Clear removes all accounts
+	Clear() error
+}
+
+// ProjectRepository stores projects by ID, and lists them by the ID of the account or
+// organisation that owns them.
+// Implementations must be safe for concurrent use.
+type ProjectRepository interface {
+	// Add stores a new project
+	Add(project entities.Project) error
+	// Get returns the project with the given ID, or entities.ErrProjectNotFound
+	Get(id string) (entities.Project, error)
+	// Update replaces the stored project with the same ID, or returns entities.ErrProjectNotFound
+	Update(project entities.Project) error
+	// Delete removes the project with the given ID, or returns entities.ErrProjectNotFound
+	Delete(id string) error
+	// ListByOwner returns the owner's projects in the order they were added
+	ListByOwner(ownerID string) ([]entities.Project, error)
+	// List returns all projects, with each owner's projects in the order they were added
+	List() ([]entities.Project, error)
+	// Clear removes all projects
+	Clear() error
+	// NextSequence returns the next number to give a project as it is created. The
+	// numbers only ever go up, and are kept with the projects, so no number is given to
+	// two projects, even after projects are deleted or cleared, or the repository is
+	// reopened. Adding a project with a higher number moves the sequence on past it.
+	NextSequence() (uint64, error)
+}
+
+// SessionRepository stores sessions by ID.
+// Implementations must be safe for concurrent use.
+type SessionRepository interface {
+	// Add stores a new session
+	Add(session entities.Session) error
+	// Get returns the session with the given ID, or entities.ErrSessionNotFound
+	Get(id string) (entities.Session, error)
+	// Delete removes the session with the given ID, or returns entities.ErrSessionNotFound
+	Delete(id string) error
+	// List returns all sessions, in no particular order
+	List() ([]entities.Session, error)
+	// Clear removes all sessions
+	Clear() error
+}
+
+// WebhookRepository stores webhooks by ID, and lists them by the ID of the account that registered them.
+// Implementations must be safe for concurrent use.
+type WebhookRepository interface {
+	// Add stores a new webhook
+	Add(webhook entities.Webhook) error
+	// Get returns the webhook with the given ID, or entities.ErrWebhookNotFound
+	Get(id string) (entities.Webhook, error)
+	// Delete removes the webhook with the given ID, or returns entities.ErrWebhookNotFound
+	Delete(id string) error
+	// ListByOwner returns the owner's webhooks in the order they were added
+	ListByOwner(ownerID string) ([]entities.Webhook, error)
+	// List returns all webhooks, with each owner's webhooks in the order they were added
+	List() ([]entities.Webhook, error)
+	// Clear removes all webhooks
+	Clear() error
+}
+
+// OrganisationRepository stores organisations by name.
+// Implementations must be safe for concurrent use.
+type OrganisationRepository interface {
+	// Add stores a new organisation, returning entities.ErrOrganisationExists if its name is taken
+	Add(organisation entities.Organisation) error
+	// Get returns the organisation with the given name, or entities.ErrOrganisationNotFound
+	Get(name string) (entities.Organisation, error)
+	// Update replaces the stored organisation with the same name, or returns entities.ErrOrganisationNotFound
+	Update(organisation entities.Organisation) error
+	// Delete removes the organisation with the given name, or returns entities.ErrOrganisationNotFound
+	Delete(name string) error
+	// List returns all organisations, in no particular order
+	List() ([]entities.Organisation, error)
+	// Clear removes all organisations
+	Clear() error
+}
+
+// Repositories is the full set of repositories that the application stores its data in
+type Repositories struct {
+	Accounts      AccountRepository
+	Projects      ProjectRepository
+	Sessions      SessionRepository
+	Webhooks      WebhookRepository
+	Organisations OrganisationRepository
+}
@@ -1,55 +1,305 @@
 package testhelpers
 
 import (
+	"sync"
+	"time"
+
 	"github.com/sirockin/cucumber-screenplay-go/back-end/internal/domain/application"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/internal/domain/passwords"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/clock/manual"
 	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events/eventlog"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events/feed"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/export"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/notifier"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/notifier/outbox"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/repository"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/repository/memory"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/webhooks"
 )
 
+// testHasher hashes passwords cheaply. Full-strength hashing makes runs with the race
+// detector very slow, and hashes record their cost, so checking them works the same.
+var testHasher = passwords.Hasher{Iterations: 1_000}
+
+// harness is what tests use to see into a service and steer it: the outbox it sends
+// messages to, a log of the events it publishes, a feed of them for clients to follow,
+// the dispatcher that posts them to webhooks and a clock that the test controls
+type harness struct {
+	outbox     *outbox.Outbox
+	bus        *events.Bus
+	events     *eventlog.Log
+	feed       *feed.Feed
+	deliveries *webhooks.Dispatcher
+	clock      *manual.Clock
+}
+
+// newService creates a service that stores its data in repositories, along with the
+// harness for it
+func newService(repositories repository.Repositories) (*application.Service, harness) {
+	h := harness{
+		outbox: outbox.New(),
+		bus:    events.NewBus(),
+		events: eventlog.New(),
+		feed:   feed.New(1000),
+		clock:  manual.New(time.Now()),
+	}
+	h.bus.Subscribe(h.events.Record)
+	h.bus.Subscribe(h.feed.Record)
+	appService := application.NewWithRepositories(repositories,
+		application.WithNotifier(h.outbox),
+		application.WithPublisher(h.bus),
+		application.WithPasswordHasher(testHasher),
+		application.WithClock(h.clock),
+	)
+	h.deliveries = webhooks.New(appService.WebhooksFor, webhooks.WithClock(h.clock), webhooks.WithPrivateDestinations())
+	h.bus.Subscribe(h.deliveries.Record)
+	return appService, h
+}
+
 // New creates a new acceptance test driver that wraps the actual domain
 func NewDomainTestDriver() *DomainTestDriver {
+	return NewDomainTestDriverWithRepositories(memory.NewRepositories())
+}
+
+// NewDomainTestDriverWithRepositories creates a new acceptance test driver that wraps
+// the actual domain, storing its data in the given repositories
+func NewDomainTestDriverWithRepositories(repositories repository.Repositories) *DomainTestDriver {
+	return newDomainTestDriver(newService(repositories))
+}
+
+func newDomainTestDriver(appService *application.Service, h harness) *DomainTestDriver {
 	return &DomainTestDriver{
-		appService: application.New(),
+		appService: appService,
+		harness:    h,
+		sessions:   make(map[string]string),
+		devices:    make(map[string]*DomainTestDriver),
+		watches:    make(map[string]*watch),
 	}
 }
 
 // DomainTestDriver is a test driver that delegates to the actual domain
-// It implements the AcceptanceTestDriver interface implicitly
+// It implements the AcceptanceTestDriver interface implicitly, apart from OnDevice,
+// whose result must be wrapped to be returned as one.
+// Each driver acts as a single client, keeping its own session for each account.
 type DomainTestDriver struct {
 	appService *application.Service
+	harness    harness
+
+	mu sync.Mutex
+	// sessions holds the client's session token for each account it is signed in to
+	sessions map[string]string
+	devices  map[string]*DomainTestDriver
+	// watches holds what the client has been notified of about each account it watches
+	watches map[string]*watch
+}
+
+// OnDevice returns a driver for another client of the same domain, such as a second
+// device that someone signs in on. The same driver is returned for the same device.
+func (t *DomainTestDriver) OnDevice(device string) *DomainTestDriver {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, exists := t.devices[device]; !exists {
+		t.devices[device] = newDomainTestDriver(t.appService, t.harness)
+	}
+	return t.devices[device]
 }
 
 func (t *DomainTestDriver) ClearAll() {
-	t.appService.ClearAll()
+	_ = t.appService.ClearAll()
+	t.harness.outbox.Clear()
+	t.harness.events.Clear()
+	t.harness.feed.Clear()
+	t.harness.deliveries.Clear()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sessions = make(map[string]string)
+	t.devices = make(map[string]*DomainTestDriver)
+	t.watches = make(map[string]*watch)
+}
+
+func (t *DomainTestDriver) session(name string) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.sessions[name]
+}
+
+func (t *DomainTestDriver) setSession(name string, token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if token == "" {
+		delete(t.sessions, name)
+		return
+	}
+	t.sessions[name] = token
 }
 
-func (t *DomainTestDriver) CreateAccount(name string) error {
-	return t.appService.CreateAccount(name)
+// AdvanceClock makes time pass for the domain
+func (t *DomainTestDriver) AdvanceClock(d time.Duration) error {
+	_, err := t.harness.clock.Advance(d)
+	return err
+}
+
+func (t *DomainTestDriver) CreateAccount(name string, password string) error {
+	return t.appService.CreateAccount(name, password)
+}
+
+// CreateAccountWithWebhook creates an account along with a webhook, so that the webhook
+// is told when the account is activated
+func (t *DomainTestDriver) CreateAccountWithWebhook(name string, password string, url string, secret string) error {
+	return t.appService.CreateAccount(name, password, application.WebhookTarget{URL: url, Secret: secret})
 }
 
 func (t *DomainTestDriver) GetAccount(name string) (entities.Account, error) {
 	return t.appService.GetAccount(name)
 }
 
-func (t *DomainTestDriver) Activate(name string) error {
-	return t.appService.Activate(name)
+func (t *DomainTestDriver) GetMessages(name string) ([]notifier.Message, error) {
+	return t.harness.outbox.Messages(name), nil
+}
+
+// GetEvents returns the events the domain has published about the named account
+func (t *DomainTestDriver) GetEvents(name string) ([]events.Event, error) {
+	return t.harness.events.Events(name), nil
+}
+
+func (t *DomainTestDriver) FollowActivationLink(link string) error {
+	name, token, err := notifier.ParseActivationLink(link)
+	if err != nil {
+		return err
+	}
+	session, err := t.appService.Activate(name, token)
+	if err != nil {
+		return err
+	}
+	t.setSession(name, session.Value)
+	return nil
 }
 
 func (t *DomainTestDriver) IsActivated(name string) bool {
 	return t.appService.IsActivated(name)
 }
 
-func (t *DomainTestDriver) Authenticate(name string) error {
-	return t.appService.Authenticate(name)
+func (t *DomainTestDriver) Authenticate(name string, password string) error {
+	session, err := t.appService.Authenticate(name, password)
+	if err != nil {
+		return err
+	}
+	t.setSession(name, session.Value)
+	return nil
 }
 
 func (t *DomainTestDriver) IsAuthenticated(name string) bool {
-	return t.appService.IsAuthenticated(name)
+	return t.appService.IsAuthenticated(name, t.session(name))
+}
+
+func (t *DomainTestDriver) SignOut(name string) error {
+	if err := t.appService.SignOut(t.session(name)); err != nil {
+		return err
+	}
+	t.setSession(name, "")
+	return nil
+}
+
+func (t *DomainTestDriver) ChangePlan(name string, plan entities.Plan) error {
+	return t.appService.ChangePlan(t.session(name), name, plan)
+}
+
+func (t *DomainTestDriver) CloseAccount(name string) error {
+	return t.appService.CloseAccount(t.session(name), name)
+}
+
+func (t *DomainTestDriver) ExportAccount(name string) (export.Archive, error) {
+	return t.appService.ExportAccount(t.session(name), name)
 }
 
 func (t *DomainTestDriver) GetProjects(name string) ([]entities.Project, error) {
-	return t.appService.GetProjects(name)
+	return t.appService.GetProjects(t.session(name), name)
+}
+
+func (t *DomainTestDriver) GetProjectsOf(name string, owner string) ([]entities.Project, error) {
+	return t.appService.GetProjects(t.session(name), owner)
+}
+
+func (t *DomainTestDriver) ListProjects(name string, query entities.ProjectQuery) (entities.ProjectPage, error) {
+	return t.appService.ListProjects(t.session(name), name, query)
+}
+
+func (t *DomainTestDriver) CreateProject(name string, projectName string) (entities.Project, error) {
+	return t.appService.CreateProject(t.session(name), name, projectName)
+}
+
+func (t *DomainTestDriver) GetProject(name string, projectID string) (entities.Project, error) {
+	return t.appService.GetProject(t.session(name), name, projectID)
+}
+
+func (t *DomainTestDriver) RenameProject(name string, projectID string, projectName string) (entities.Project, error) {
+	return t.appService.RenameProject(t.session(name), name, projectID, projectName)
+}
+
+func (t *DomainTestDriver) DeleteProject(name string, projectID string) error {
+	return t.appService.DeleteProject(t.session(name), name, projectID)
+}
+
+func (t *DomainTestDriver) ArchiveProject(name string, projectID string) (entities.Project, error) {
+	return t.appService.ArchiveProject(t.session(name), name, projectID)
+}
+
+func (t *DomainTestDriver) RestoreProject(name string, projectID string) (entities.Project, error) {
+	return t.appService.RestoreProject(t.session(name), name, projectID)
+}
+
+func (t *DomainTestDriver) InviteToProject(name string, projectID string, invitee string, role entities.Role) error {
+	return t.appService.InviteToProject(t.session(name), name, projectID, invitee, role)
+}
+
+func (t *DomainTestDriver) GetInvitations(name string) ([]entities.Invitation, error) {
+	return t.appService.GetInvitations(t.session(name), name)
+}
+
+func (t *DomainTestDriver) AcceptInvitation(name string, projectID string) error {
+	_, err := t.appService.AcceptInvitation(t.session(name), name, projectID)
+	return err
+}
+
+func (t *DomainTestDriver) DeclineInvitation(name string, projectID string) error {
+	return t.appService.DeclineInvitation(t.session(name), name, projectID)
+}
+
+func (t *DomainTestDriver) CreateOrganisation(name string, orgName string) error {
+	_, err := t.appService.CreateOrganisation(t.session(name), orgName)
+	return err
+}
+
+func (t *DomainTestDriver) GetOrganisations(name string) ([]entities.Membership, error) {
+	return t.appService.GetOrganisations(t.session(name))
+}
+
+func (t *DomainTestDriver) GetOrganisationMembers(name string, orgName string) ([]entities.Membership, error) {
+	return t.appService.GetOrganisationMembers(t.session(name), orgName)
+}
+
+func (t *DomainTestDriver) SetOrganisationMember(name string, orgName string, member string, role entities.OrgRole) error {
+	return t.appService.SetOrganisationMember(t.session(name), orgName, member, role)
+}
+
+func (t *DomainTestDriver) RemoveOrganisationMember(name string, orgName string, member string) error {
+	return t.appService.RemoveOrganisationMember(t.session(name), orgName, member)
+}
+
+func (t *DomainTestDriver) DeleteOrganisation(name string, orgName string) error {
+	return t.appService.DeleteOrganisation(t.session(name), orgName)
+}
+
+func (t *DomainTestDriver) CreateOrganisationProject(name string, orgName string, projectName string) (entities.Project, error) {
+	return t.appService.CreateOrganisationProject(t.session(name), orgName, projectName)
+}
+
+func (t *DomainTestDriver) GetOrganisationProjects(name string, orgName string) ([]entities.Project, error) {
+	return t.appService.GetOrganisationProjects(t.session(name), orgName)
 }
 
-func (t *DomainTestDriver) CreateProject(name string) error {
-	return t.appService.CreateProject(name)
+func (t *DomainTestDriver) DeleteOrganisationProject(name string, orgName string, projectID string) error {
+	return t.appService.DeleteOrganisationProject(t.session(name), orgName, projectID)
 }
@@ -0,0 +1,171 @@
+package testhelpers
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events/feed"
+)
+
+// NotificationTimeout is how long drivers wait for a notification before giving up
+const NotificationTimeout = 5 * time.Second
+
+// Notifications collects the events a client is notified of about an account as they
+// arrive, remembering the id of the latest so that the client can resume from it. The
+// zero value is empty and ready to use.
+type Notifications struct {
+	mu       sync.Mutex
+	received []events.Event
+	lastID   uint64
+	followed bool
+	changed  chan struct{}
+}
+
+// Add records a notification along with its id in the stream
+func (n *Notifications) Add(id uint64, event events.Event) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.received = append(n.received, event)
+	n.reach(id)
+	if n.changed != nil {
+		close(n.changed)
+		n.changed = nil
+	}
+}
+
+// Reach records that the stream has got as far as id without a notification, as when
+// it catches up
+func (n *Notifications) Reach(id uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.reach(id)
+}
+
+func (n *Notifications) reach(id uint64) {
+	if id > n.lastID {
+		n.lastID = id
+	}
+	n.followed = true
+}
+
+// LastID returns the id to resume the stream from, and false if the stream has not
+// been followed before
+func (n *Notifications) LastID() (uint64, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.lastID, n.followed
+}
+
+// Events returns the notifications received so far, oldest first
+func (n *Notifications) Events() []events.Event {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]events.Event(nil), n.received...)
+}
+
+// Await waits up to NotificationTimeout for a notification of the given kind
+func (n *Notifications) Await(kind events.Kind) error {
+	deadline := time.After(NotificationTimeout)
+	for {
+		n.mu.Lock()
+		for _, event := range n.received {
+			if event.Kind == kind {
+				n.mu.Unlock()
+				return nil
+			}
+		}
+		if n.changed == nil {
+			n.changed = make(chan struct{})
+		}
+		changed := n.changed
+		n.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-deadline:
+			return fmt.Errorf("no %s notification within %v", kind, NotificationTimeout)
+		}
+	}
+}
+
+// watch is a domain test driver's view of an account's events, followed straight from
+// the feed
+type watch struct {
+	notifications Notifications
+
+	mu       sync.Mutex
+	follower *feed.Follower
+	done     chan struct{}
+}
+
+// WatchAccount starts following the named account's events as its holder, picking up
+// after the last one this client was notified of if it has watched the account before
+func (t *DomainTestDriver) WatchAccount(name string) error {
+	if err := t.appService.Authorize(t.session(name), name); err != nil {
+		return err
+	}
+	w := t.watch(name)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.follower != nil {
+		return nil
+	}
+
+	var follower *feed.Follower
+	if lastID, ok := w.notifications.LastID(); ok {
+		follower = t.harness.feed.FollowAfter(name, lastID)
+	} else {
+		follower = t.harness.feed.Follow(name)
+	}
+	for _, entry := range follower.Missed {
+		w.notifications.Add(entry.ID, entry.Event)
+	}
+	w.notifications.Reach(follower.LastID)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for entry := range follower.Entries() {
+			w.notifications.Add(entry.ID, entry.Event)
+		}
+	}()
+	w.follower, w.done = follower, done
+	return nil
+}
+
+// StopWatchingAccount stops following the named account's events
+func (t *DomainTestDriver) StopWatchingAccount(name string) error {
+	w := t.watch(name)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.follower == nil {
+		return nil
+	}
+	w.follower.Stop()
+	<-w.done
+	w.follower, w.done = nil, nil
+	return nil
+}
+
+// AwaitNotification waits for the client to be notified of an event of the given kind
+// about the named account, which it must be watching
+func (t *DomainTestDriver) AwaitNotification(name string, kind events.Kind) error {
+	return t.watch(name).notifications.Await(kind)
+}
+
+// GetNotifications returns the events the client has been notified of about the
+// named account
+func (t *DomainTestDriver) GetNotifications(name string) ([]events.Event, error) {
+	return t.watch(name).notifications.Events(), nil
+}
+
+func (t *DomainTestDriver) watch(name string) *watch {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.watches[name] == nil {
+		t.watches[name] = &watch{}
+	}
+	return t.watches[name]
+}
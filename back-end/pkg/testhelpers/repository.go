@@ -0,0 +1,651 @@
+package testhelpers
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/repository"
+)
+
+// RepositoryFactory creates a fresh, empty set of repositories for a single test
+type RepositoryFactory func(t *testing.T) repository.Repositories
+
+// RunRepositoryConformanceTests checks that a storage implementation behaves the way
+// the application expects. Every implementation of the repository interfaces should pass.
+func RunRepositoryConformanceTests(t *testing.T, newRepositories RepositoryFactory) {
+	t.Run("Accounts", func(t *testing.T) {
+		t.Run("GetMissingAccount", func(t *testing.T) {
+			accounts := newRepositories(t).Accounts
+			_, err := accounts.Get("Sue")
+			expectError(t, err, entities.ErrAccountNotFound)
+		})
+
+		t.Run("AddAndGetAccount", func(t *testing.T) {
+			accounts := newRepositories(t).Accounts
+			account := entities.NewAccount("sue-id", "Sue")
+			account.SetActivated(true)
+			account.SetActivationToken("token")
+			account.SetPasswordHash("hash")
+			account.SetCreatedAt(time.Date(2025, time.January, 2, 3, 4, 5, 0, time.UTC))
+			account.SetFailedSignIns(2, time.Date(2025, time.January, 3, 3, 4, 5, 0, time.UTC))
+			account.SetLockedUntil(time.Date(2025, time.January, 4, 3, 4, 5, 0, time.UTC))
+			account.SetPlan(entities.PlanPro)
+			account.SetClosedAt(time.Date(2025, time.January, 5, 3, 4, 5, 0, time.UTC))
+			expectNoError(t, accounts.Add(*account))
+
+			got, err := accounts.Get("Sue")
+			expectNoError(t, err)
+			expectAccount(t, got, *account)
+		})
+
+		t.Run("AddAccountWithTakenName", func(t *testing.T) {
+			accounts := newRepositories(t).Accounts
+			expectNoError(t, accounts.Add(*entities.NewAccount("sue-id", "Sue")))
+
+			err := accounts.Add(*entities.NewAccount("other-id", "Sue"))
+			expectError(t, err, entities.ErrAccountExists)
+
+			got, err := accounts.Get("Sue")
+			expectNoError(t, err)
+			expectAccount(t, got, *entities.NewAccount("sue-id", "Sue"))
+		})
+
+		t.Run("UpdateAccount", func(t *testing.T) {
+			accounts := newRepositories(t).Accounts
+			account := entities.NewAccount("sue-id", "Sue")
+			account.SetActivationToken("token")
+			expectNoError(t, accounts.Add(*account))
+
+			account.SetActivated(true)
+			account.SetActivationToken("")
+			expectNoError(t, accounts.Update(*account))
+
+			got, err := accounts.Get("Sue")
+			expectNoError(t, err)
+			expectAccount(t, got, *account)
+		})
+
+		t.Run("UpdateMissingAccount", func(t *testing.T) {
+			accounts := newRepositories(t).Accounts
+			err := accounts.Update(*entities.NewAccount("sue-id", "Sue"))
+			expectError(t, err, entities.ErrAccountNotFound)
+		})
+
+		t.Run("ClearAccounts", func(t *testing.T) {
+			accounts := newRepositories(t).Accounts
+			expectNoError(t, accounts.Add(*entities.NewAccount("sue-id", "Sue")))
+			expectNoError(t, accounts.Clear())
+
+			_, err := accounts.Get("Sue")
+			expectError(t, err, entities.ErrAccountNotFound)
+			expectNoError(t, accounts.Add(*entities.NewAccount("sue-id", "Sue")))
+		})
+
+		t.Run("ListAccounts", func(t *testing.T) {
+			accounts := newRepositories(t).Accounts
+			expectNoError(t, accounts.Add(*entities.NewAccount("sue-id", "Sue")))
+			expectNoError(t, accounts.Add(*entities.NewAccount("bob-id", "Bob")))
+
+			got, err := accounts.List()
+			expectNoError(t, err)
+			names := make([]string, 0, len(got))
+			for _, account := range got {
+				names = append(names, account.Name())
+			}
+			slices.Sort(names)
+			if !slices.Equal(names, []string{"Bob", "Sue"}) {
+				t.Fatalf("expected accounts [Bob Sue] but got %v", names)
+			}
+		})
+
+		t.Run("AddAccountsConcurrently", func(t *testing.T) {
+			accounts := newRepositories(t).Accounts
+			runConcurrently(t, 20, func(i int) error {
+				return accounts.Add(*entities.NewAccount(fmt.Sprintf("id-%d", i), fmt.Sprintf("account-%d", i)))
+			})
+			for i := 0; i < 20; i++ {
+				_, err := accounts.Get(fmt.Sprintf("account-%d", i))
+				expectNoError(t, err)
+			}
+		})
+	})
+
+	t.Run("Projects", func(t *testing.T) {
+		t.Run("ListWithoutProjects", func(t *testing.T) {
+			projects := newRepositories(t).Projects
+			got, err := projects.ListByOwner("sue-id")
+			expectNoError(t, err)
+			expectProjectNames(t, got)
+		})
+
+		t.Run("AddAndGetProject", func(t *testing.T) {
+			projects := newRepositories(t).Projects
+			project := newProject("roadmap-id", "Roadmap", "sue-id")
+			project.SetSequence(7)
+			expectNoError(t, projects.Add(project))
+
+			got, err := projects.Get("roadmap-id")
+			expectNoError(t, err)
+			expectProject(t, got, project)
+		})
+
+		t.Run("GetMissingProject", func(t *testing.T) {
+			projects := newRepositories(t).Projects
+			_, err := projects.Get("roadmap-id")
+			expectError(t, err, entities.ErrProjectNotFound)
+		})
+
+		t.Run("AddAndListProjectsInOrder", func(t *testing.T) {
+			projects := newRepositories(t).Projects
+			expectNoError(t, projects.Add(newProject("roadmap-id", "Roadmap", "sue-id")))
+			expectNoError(t, projects.Add(newProject("budget-id", "Budget", "sue-id")))
+			expectNoError(t, projects.Add(newProject("archive-id", "Archive", "sue-id")))
+
+			got, err := projects.ListByOwner("sue-id")
+			expectNoError(t, err)
+			expectProjectNames(t, got, "Roadmap", "Budget", "Archive")
+		})
+
+		t.Run("ListOnlyOwnersProjects", func(t *testing.T) {
+			projects := newRepositories(t).Projects
+			expectNoError(t, projects.Add(newProject("roadmap-id", "Roadmap", "sue-id")))
+
+			got, err := projects.ListByOwner("bob-id")
+			expectNoError(t, err)
+			expectProjectNames(t, got)
+		})
+
+		t.Run("ListAllProjectsInOrder", func(t *testing.T) {
+			projects := newRepositories(t).Projects
+			expectNoError(t, projects.Add(newProject("roadmap-id", "Roadmap", "sue-id")))
+			expectNoError(t, projects.Add(newProject("launch-id", "Launch", "bob-id")))
+			expectNoError(t, projects.Add(newProject("budget-id", "Budget", "sue-id")))
+
+			got, err := projects.List()
+			expectNoError(t, err)
+			expectProjectNames(t, ownedBy(got, "sue-id", projectOwner), "Roadmap", "Budget")
+			expectProjectNames(t, ownedBy(got, "bob-id", projectOwner), "Launch")
+		})
+
+		t.Run("UpdateProject", func(t *testing.T) {
+			projects := newRepositories(t).Projects
+			project := newProject("roadmap-id", "Roadmap", "sue-id")
+			expectNoError(t, projects.Add(project))
+
+			project.SetName("Plan")
+			project.SetState(entities.ProjectArchived)
+			project.SetMember(entities.Member{AccountID: "bob-id", Role: entities.RoleEditor, Accepted: true, InvitedAt: time.Unix(1700000000, 0).UTC()})
+			expectNoError(t, projects.Update(project))
+
+			got, err := projects.Get("roadmap-id")
+			expectNoError(t, err)
+			expectProject(t, got, project)
+			listed, err := projects.ListByOwner("sue-id")
+			expectNoError(t, err)
+			expectProjectNames(t, listed, "Plan")
+		})
+
+		t.Run("UpdateMissingProject", func(t *testing.T) {
+			projects := newRepositories(t).Projects
+			err := projects.Update(newProject("roadmap-id", "Roadmap", "sue-id"))
+			expectError(t, err, entities.ErrProjectNotFound)
+		})
+
+		t.Run("DeleteProject", func(t *testing.T) {
+			projects := newRepositories(t).Projects
+			expectNoError(t, projects.Add(newProject("roadmap-id", "Roadmap", "sue-id")))
+			expectNoError(t, projects.Add(newProject("budget-id", "Budget", "sue-id")))
+
+			expectNoError(t, projects.Delete("roadmap-id"))
+
+			_, err := projects.Get("roadmap-id")
+			expectError(t, err, entities.ErrProjectNotFound)
+			got, err := projects.ListByOwner("sue-id")
+			expectNoError(t, err)
+			expectProjectNames(t, got, "Budget")
+		})
+
+		t.Run("DeleteMissingProject", func(t *testing.T) {
+			projects := newRepositories(t).Projects
+			err := projects.Delete("roadmap-id")
+			expectError(t, err, entities.ErrProjectNotFound)
+		})
+
+		t.Run("ClearProjects", func(t *testing.T) {
+			projects := newRepositories(t).Projects
+			expectNoError(t, projects.Add(newProject("roadmap-id", "Roadmap", "sue-id")))
+			expectNoError(t, projects.Clear())
+
+			got, err := projects.ListByOwner("sue-id")
+			expectNoError(t, err)
+			expectProjectNames(t, got)
+			_, err = projects.Get("roadmap-id")
+			expectError(t, err, entities.ErrProjectNotFound)
+		})
+
+		t.Run("NumberProjectsWithoutReusingNumbers", func(t *testing.T) {
+			projects := newRepositories(t).Projects
+			first, err := projects.NextSequence()
+			expectNoError(t, err)
+			project := newProject("roadmap-id", "Roadmap", "sue-id")
+			project.SetSequence(first)
+			expectNoError(t, projects.Add(project))
+			expectNoError(t, projects.Delete("roadmap-id"))
+			expectNoError(t, projects.Clear())
+
+			second, err := projects.NextSequence()
+			expectNoError(t, err)
+			if second <= first {
+				t.Fatalf("expected a number after %d but got %d", first, second)
+			}
+			restored := newProject("budget-id", "Budget", "sue-id")
+			restored.SetSequence(second + 10)
+			expectNoError(t, projects.Add(restored))
+			third, err := projects.NextSequence()
+			expectNoError(t, err)
+			if third <= second+10 {
+				t.Fatalf("expected a number after %d but got %d", second+10, third)
+			}
+		})
+
+		t.Run("AddProjectsConcurrently", func(t *testing.T) {
+			projects := newRepositories(t).Projects
+			runConcurrently(t, 20, func(i int) error {
+				return projects.Add(newProject(fmt.Sprintf("id-%d", i), fmt.Sprintf("project-%d", i), "sue-id"))
+			})
+
+			got, err := projects.ListByOwner("sue-id")
+			expectNoError(t, err)
+			if len(got) != 20 {
+				t.Fatalf("expected %v projects to equal 20", len(got))
+			}
+		})
+	})
+
+	t.Run("Sessions", func(t *testing.T) {
+		t.Run("GetMissingSession", func(t *testing.T) {
+			sessions := newRepositories(t).Sessions
+			_, err := sessions.Get("session-id")
+			expectError(t, err, entities.ErrSessionNotFound)
+		})
+
+		t.Run("AddAndGetSession", func(t *testing.T) {
+			sessions := newRepositories(t).Sessions
+			session := newSession("session-id", "sue-id")
+			expectNoError(t, sessions.Add(session))
+
+			got, err := sessions.Get("session-id")
+			expectNoError(t, err)
+			expectSession(t, got, session)
+		})
+
+		t.Run("AddSessionsForSameAccount", func(t *testing.T) {
+			sessions := newRepositories(t).Sessions
+			expectNoError(t, sessions.Add(newSession("phone-id", "sue-id")))
+			expectNoError(t, sessions.Add(newSession("laptop-id", "sue-id")))
+
+			_, err := sessions.Get("phone-id")
+			expectNoError(t, err)
+			_, err = sessions.Get("laptop-id")
+			expectNoError(t, err)
+		})
+
+		t.Run("DeleteSession", func(t *testing.T) {
+			sessions := newRepositories(t).Sessions
+			expectNoError(t, sessions.Add(newSession("phone-id", "sue-id")))
+			expectNoError(t, sessions.Add(newSession("laptop-id", "sue-id")))
+
+			expectNoError(t, sessions.Delete("phone-id"))
+
+			_, err := sessions.Get("phone-id")
+			expectError(t, err, entities.ErrSessionNotFound)
+			_, err = sessions.Get("laptop-id")
+			expectNoError(t, err)
+		})
+
+		t.Run("DeleteMissingSession", func(t *testing.T) {
+			sessions := newRepositories(t).Sessions
+			err := sessions.Delete("session-id")
+			expectError(t, err, entities.ErrSessionNotFound)
+		})
+
+		t.Run("ListSessions", func(t *testing.T) {
+			sessions := newRepositories(t).Sessions
+			first := newSession("first-id", "sue-id")
+			expectNoError(t, sessions.Add(first))
+
+			got, err := sessions.List()
+			expectNoError(t, err)
+			if len(got) != 1 {
+				t.Fatalf("expected 1 session but got %d", len(got))
+			}
+			expectSession(t, got[0], first)
+		})
+
+		t.Run("ClearSessions", func(t *testing.T) {
+			sessions := newRepositories(t).Sessions
+			expectNoError(t, sessions.Add(newSession("session-id", "sue-id")))
+			expectNoError(t, sessions.Clear())
+
+			_, err := sessions.Get("session-id")
+			expectError(t, err, entities.ErrSessionNotFound)
+		})
+	})
+
+	t.Run("Webhooks", func(t *testing.T) {
+		t.Run("ListWithoutWebhooks", func(t *testing.T) {
+			webhooks := newRepositories(t).Webhooks
+			got, err := webhooks.ListByOwner("sue-id")
+			expectNoError(t, err)
+			expectWebhookURLs(t, got)
+		})
+
+		t.Run("AddAndGetWebhook", func(t *testing.T) {
+			webhooks := newRepositories(t).Webhooks
+			webhook := newWebhook("webhook-id", "https://example.com/hooks", "sue-id")
+			expectNoError(t, webhooks.Add(webhook))
+
+			got, err := webhooks.Get("webhook-id")
+			expectNoError(t, err)
+			expectWebhook(t, got, webhook)
+		})
+
+		t.Run("GetMissingWebhook", func(t *testing.T) {
+			webhooks := newRepositories(t).Webhooks
+			_, err := webhooks.Get("webhook-id")
+			expectError(t, err, entities.ErrWebhookNotFound)
+		})
+
+		t.Run("AddAndListWebhooksInOrder", func(t *testing.T) {
+			webhooks := newRepositories(t).Webhooks
+			expectNoError(t, webhooks.Add(newWebhook("first-id", "https://example.com/first", "sue-id")))
+			expectNoError(t, webhooks.Add(newWebhook("second-id", "https://example.com/second", "sue-id")))
+			expectNoError(t, webhooks.Add(newWebhook("other-id", "https://example.com/other", "bob-id")))
+
+			got, err := webhooks.ListByOwner("sue-id")
+			expectNoError(t, err)
+			expectWebhookURLs(t, got, "https://example.com/first", "https://example.com/second")
+		})
+
+		t.Run("ListAllWebhooksInOrder", func(t *testing.T) {
+			webhooks := newRepositories(t).Webhooks
+			expectNoError(t, webhooks.Add(newWebhook("first-id", "https://example.com/first", "sue-id")))
+			expectNoError(t, webhooks.Add(newWebhook("other-id", "https://example.com/other", "bob-id")))
+			expectNoError(t, webhooks.Add(newWebhook("second-id", "https://example.com/second", "sue-id")))
+
+			got, err := webhooks.List()
+			expectNoError(t, err)
+			expectWebhookURLs(t, ownedBy(got, "sue-id", webhookOwner), "https://example.com/first", "https://example.com/second")
+			expectWebhookURLs(t, ownedBy(got, "bob-id", webhookOwner), "https://example.com/other")
+		})
+
+		t.Run("DeleteWebhook", func(t *testing.T) {
+			webhooks := newRepositories(t).Webhooks
+			expectNoError(t, webhooks.Add(newWebhook("first-id", "https://example.com/first", "sue-id")))
+			expectNoError(t, webhooks.Add(newWebhook("second-id", "https://example.com/second", "sue-id")))
+
+			expectNoError(t, webhooks.Delete("first-id"))
+
+			_, err := webhooks.Get("first-id")
+			expectError(t, err, entities.ErrWebhookNotFound)
+			got, err := webhooks.ListByOwner("sue-id")
+			expectNoError(t, err)
+			expectWebhookURLs(t, got, "https://example.com/second")
+		})
+
+		t.Run("DeleteMissingWebhook", func(t *testing.T) {
+			webhooks := newRepositories(t).Webhooks
+			err := webhooks.Delete("webhook-id")
+			expectError(t, err, entities.ErrWebhookNotFound)
+		})
+
+		t.Run("ClearWebhooks", func(t *testing.T) {
+			webhooks := newRepositories(t).Webhooks
+			expectNoError(t, webhooks.Add(newWebhook("webhook-id", "https://example.com/hooks", "sue-id")))
+			expectNoError(t, webhooks.Clear())
+
+			got, err := webhooks.ListByOwner("sue-id")
+			expectNoError(t, err)
+			expectWebhookURLs(t, got)
+			_, err = webhooks.Get("webhook-id")
+			expectError(t, err, entities.ErrWebhookNotFound)
+		})
+	})
+
+	t.Run("Organisations", func(t *testing.T) {
+		t.Run("GetMissingOrganisation", func(t *testing.T) {
+			organisations := newRepositories(t).Organisations
+			_, err := organisations.Get("Acme")
+			expectError(t, err, entities.ErrOrganisationNotFound)
+		})
+
+		t.Run("AddAndGetOrganisation", func(t *testing.T) {
+			organisations := newRepositories(t).Organisations
+			organisation := newOrganisation("acme-id", "Acme")
+			organisation.SetMember(entities.OrgMember{AccountID: "bob-id", Role: entities.OrgRoleMember})
+			expectNoError(t, organisations.Add(organisation))
+
+			got, err := organisations.Get("Acme")
+			expectNoError(t, err)
+			expectOrganisation(t, got, organisation)
+		})
+
+		t.Run("AddOrganisationWithTakenName", func(t *testing.T) {
+			organisations := newRepositories(t).Organisations
+			expectNoError(t, organisations.Add(newOrganisation("acme-id", "Acme")))
+
+			err := organisations.Add(newOrganisation("other-id", "Acme"))
+			expectError(t, err, entities.ErrOrganisationExists)
+
+			got, err := organisations.Get("Acme")
+			expectNoError(t, err)
+			expectOrganisation(t, got, newOrganisation("acme-id", "Acme"))
+		})
+
+		t.Run("UpdateOrganisation", func(t *testing.T) {
+			organisations := newRepositories(t).Organisations
+			organisation := newOrganisation("acme-id", "Acme")
+			organisation.SetMember(entities.OrgMember{AccountID: "bob-id", Role: entities.OrgRoleMember})
+			expectNoError(t, organisations.Add(organisation))
+
+			organisation.SetMember(entities.OrgMember{AccountID: "bob-id", Role: entities.OrgRoleAdmin})
+			organisation.RemoveMember("sue-id")
+			expectNoError(t, organisations.Update(organisation))
+
+			got, err := organisations.Get("Acme")
+			expectNoError(t, err)
+			expectOrganisation(t, got, organisation)
+		})
+
+		t.Run("UpdateMissingOrganisation", func(t *testing.T) {
+			organisations := newRepositories(t).Organisations
+			err := organisations.Update(newOrganisation("acme-id", "Acme"))
+			expectError(t, err, entities.ErrOrganisationNotFound)
+		})
+
+		t.Run("DeleteOrganisation", func(t *testing.T) {
+			organisations := newRepositories(t).Organisations
+			expectNoError(t, organisations.Add(newOrganisation("acme-id", "Acme")))
+			expectNoError(t, organisations.Add(newOrganisation("globex-id", "Globex")))
+
+			expectNoError(t, organisations.Delete("Acme"))
+
+			_, err := organisations.Get("Acme")
+			expectError(t, err, entities.ErrOrganisationNotFound)
+			_, err = organisations.Get("Globex")
+			expectNoError(t, err)
+		})
+
+		t.Run("DeleteMissingOrganisation", func(t *testing.T) {
+			organisations := newRepositories(t).Organisations
+			err := organisations.Delete("Acme")
+			expectError(t, err, entities.ErrOrganisationNotFound)
+		})
+
+		t.Run("ListOrganisations", func(t *testing.T) {
+			organisations := newRepositories(t).Organisations
+			expectNoError(t, organisations.Add(newOrganisation("acme-id", "Acme")))
+			expectNoError(t, organisations.Add(newOrganisation("globex-id", "Globex")))
+
+			got, err := organisations.List()
+			expectNoError(t, err)
+			names := make([]string, 0, len(got))
+			for _, organisation := range got {
+				names = append(names, organisation.Name())
+			}
+			slices.Sort(names)
+			if !slices.Equal(names, []string{"Acme", "Globex"}) {
+				t.Fatalf("expected organisations [Acme Globex] but got %v", names)
+			}
+		})
+
+		t.Run("ClearOrganisations", func(t *testing.T) {
+			organisations := newRepositories(t).Organisations
+			expectNoError(t, organisations.Add(newOrganisation("acme-id", "Acme")))
+			expectNoError(t, organisations.Clear())
+
+			_, err := organisations.Get("Acme")
+			expectError(t, err, entities.ErrOrganisationNotFound)
+			expectNoError(t, organisations.Add(newOrganisation("acme-id", "Acme")))
+		})
+	})
+}
+
+func runConcurrently(t *testing.T, count int, fn func(i int) error) {
+	t.Helper()
+	var wg sync.WaitGroup
+	errs := make([]error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+	expectNoError(t, errors.Join(errs...))
+}
+
+// ownedBy keeps the items whose owner, as told by ownerOf, is ownerID
+func ownedBy[T any](items []T, ownerID string, ownerOf func(T) string) []T {
+	return slices.DeleteFunc(slices.Clone(items), func(item T) bool {
+		return ownerOf(item) != ownerID
+	})
+}
+
+func projectOwner(project entities.Project) string {
+	return project.OwnerID()
+}
+
+func webhookOwner(webhook entities.Webhook) string {
+	return webhook.OwnerID()
+}
+
+func expectNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+}
+
+func expectError(t *testing.T, err error, expected error) {
+	t.Helper()
+	if !errors.Is(err, expected) {
+		t.Fatalf("expected error '%v' but got %v", expected, err)
+	}
+}
+
+func expectAccount(t *testing.T, actual, expected entities.Account) {
+	t.Helper()
+	if actual.ID() != expected.ID() || actual.Name() != expected.Name() ||
+		actual.IsActivated() != expected.IsActivated() || actual.ActivationToken() != expected.ActivationToken() ||
+		actual.PasswordHash() != expected.PasswordHash() || !actual.CreatedAt().Equal(expected.CreatedAt()) ||
+		actual.FailedSignIns() != expected.FailedSignIns() ||
+		!actual.FailedSignInsSince().Equal(expected.FailedSignInsSince()) ||
+		!actual.LockedUntil().Equal(expected.LockedUntil()) || actual.Plan() != expected.Plan() ||
+		!actual.ClosedAt().Equal(expected.ClosedAt()) {
+		t.Fatalf("expected account %+v to equal %+v", actual, expected)
+	}
+}
+
+func newSession(id, accountID string) entities.Session {
+	return *entities.NewSession(id, accountID, time.Date(2025, time.January, 3, 3, 4, 5, 0, time.UTC))
+}
+
+func expectSession(t *testing.T, actual, expected entities.Session) {
+	t.Helper()
+	if actual.ID() != expected.ID() || actual.AccountID() != expected.AccountID() ||
+		!actual.ExpiresAt().Equal(expected.ExpiresAt()) {
+		t.Fatalf("expected session %+v to equal %+v", actual, expected)
+	}
+}
+
+func newProject(id, name, ownerID string) entities.Project {
+	return *entities.NewProject(id, name, ownerID, time.Date(2025, time.January, 2, 3, 4, 5, 0, time.UTC))
+}
+
+func expectProject(t *testing.T, actual, expected entities.Project) {
+	t.Helper()
+	if actual.ID() != expected.ID() || actual.Name() != expected.Name() ||
+		actual.OwnerID() != expected.OwnerID() || !actual.CreatedAt().Equal(expected.CreatedAt()) ||
+		actual.Sequence() != expected.Sequence() || actual.State() != expected.State() ||
+		!slices.EqualFunc(actual.Members(), expected.Members(), func(a, b entities.Member) bool {
+			return a.AccountID == b.AccountID && a.Role == b.Role && a.Accepted == b.Accepted && a.InvitedAt.Equal(b.InvitedAt)
+		}) {
+		t.Fatalf("expected project %+v to equal %+v", actual, expected)
+	}
+}
+
+func expectProjectNames(t *testing.T, projects []entities.Project, expected ...string) {
+	t.Helper()
+	names := make([]string, 0, len(projects))
+	for _, project := range projects {
+		names = append(names, project.Name())
+	}
+	if !slices.Equal(names, expected) {
+		t.Fatalf("expected projects %v to equal %v", names, expected)
+	}
+}
+
+func newWebhook(id, url, ownerID string) entities.Webhook {
+	return *entities.NewWebhook(id, ownerID, url, "secret-for-"+id, time.Date(2025, time.January, 2, 3, 4, 5, 0, time.UTC))
+}
+
+func expectWebhook(t *testing.T, actual, expected entities.Webhook) {
+	t.Helper()
+	if actual.ID() != expected.ID() || actual.OwnerID() != expected.OwnerID() || actual.URL() != expected.URL() ||
+		actual.Secret() != expected.Secret() || !actual.CreatedAt().Equal(expected.CreatedAt()) {
+		t.Fatalf("expected webhook %+v to equal %+v", actual, expected)
+	}
+}
+
+func expectWebhookURLs(t *testing.T, webhooks []entities.Webhook, expected ...string) {
+	t.Helper()
+	urls := make([]string, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		urls = append(urls, webhook.URL())
+	}
+	if !slices.Equal(urls, expected) {
+		t.Fatalf("expected webhooks %v to equal %v", urls, expected)
+	}
+}
+
+// newOrganisation creates an organisation owned by Sue
+func newOrganisation(id, name string) entities.Organisation {
+	organisation := entities.NewOrganisation(id, name, time.Date(2025, time.January, 2, 3, 4, 5, 0, time.UTC))
+	organisation.SetMember(entities.OrgMember{AccountID: "sue-id", Role: entities.OrgRoleOwner})
+	return *organisation
+}
+
+func expectOrganisation(t *testing.T, actual, expected entities.Organisation) {
+	t.Helper()
+	if actual.ID() != expected.ID() || actual.Name() != expected.Name() ||
+		!actual.CreatedAt().Equal(expected.CreatedAt()) || !slices.Equal(actual.Members(), expected.Members()) {
+		t.Fatalf("expected organisation %+v to equal %+v", actual, expected)
+	}
+}
@@ -2,6 +2,7 @@ package testhelpers
 
 import (
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"testing"
@@ -9,12 +10,49 @@ import (
 
 	"github.com/sirockin/cucumber-screenplay-go/back-end/internal/domain/application"
 	httpserver "github.com/sirockin/cucumber-screenplay-go/back-end/internal/http"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/fixtures"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/repository"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/repository/memory"
 )
 
-// Create an in-process server for testing
+// Create an in-process server for testing. Its clock is advanced, and the events it
+// publishes are read, through the test endpoints.
 func NewInProcessServer(t *testing.T) string {
-	// Create HTTP server using internal implementation directly
-	server := httpserver.NewServer(application.New())
+	return NewInProcessServerWithRepositories(t, memory.NewRepositories())
+}
+
+// Create an in-process server for testing that stores its data in the given repositories
+func NewInProcessServerWithRepositories(t *testing.T, repositories repository.Repositories) string {
+	appService, h := newService(repositories)
+	return startInProcessServer(t, appService, h, fixtures.Fixtures{})
+}
+
+// Create an in-process server for testing, seeded with the fixtures in the named file
+// as the server is with --seed. Each namespace is seeded with them too, when it is
+// first named, so that drivers find the seeded accounts in whichever namespace they use.
+func NewInProcessServerWithFixtures(t *testing.T, path string) string {
+	seed, err := fixtures.Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load fixtures:\n%v", err)
+	}
+	appService, h := newService(memory.NewRepositories())
+	if err := appService.Seed(seed); err != nil {
+		t.Fatalf("Failed to seed from %s: %v", path, err)
+	}
+	return startInProcessServer(t, appService, h, seed)
+}
+
+func startInProcessServer(t *testing.T, appService *application.Service, h harness, seed fixtures.Fixtures) string {
+	// Create HTTP server using internal implementation directly. Each namespace that
+	// requests name gets a service of its own, seeded as this one is, as it does with
+	// the server in test mode.
+	server := newHTTPServer(appService, h, httpserver.WithNamespaces(func(string) (*httpserver.Server, io.Closer, error) {
+		namespaceService, namespaceHarness := newService(memory.NewRepositories())
+		if err := namespaceService.Seed(seed); err != nil {
+			return nil, nil, err
+		}
+		return newHTTPServer(namespaceService, namespaceHarness), nil, nil
+	}, nil))
 
 	// Find an available port
 	listener, err := net.Listen("tcp", ":0")
@@ -43,7 +81,15 @@ func NewInProcessServer(t *testing.T) string {
 	serverURL := fmt.Sprintf("http://localhost:%d", port)
 	t.Cleanup(func() {
 		httpServer.Close()
+		server.Close()
 	})
 
 	return serverURL
 }
+
+// newHTTPServer creates a server for the service with all of the test endpoints
+func newHTTPServer(appService *application.Service, h harness, options ...httpserver.Option) *httpserver.Server {
+	options = append(options, httpserver.WithTestClock(h.clock), httpserver.WithOutbox(h.outbox), httpserver.WithClearing(), httpserver.WithEventLog(h.events),
+		httpserver.WithEventFeed(h.feed), httpserver.WithWebhookDeliveries(h.deliveries))
+	return httpserver.NewServer(appService, options...)
+}
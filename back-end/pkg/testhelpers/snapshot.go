@@ -0,0 +1,57 @@
+package testhelpers
+
+import (
+	"encoding/json"
+	"maps"
+	"time"
+)
+
+// Snapshot is the state of the system under test as seen by one of its clients, so
+// that preconditions which are slow to build, such as through a browser, can be built
+// once and restored before each scenario. It is encoded as the snapshot test endpoint
+// encodes it, with the client's sessions alongside.
+type Snapshot struct {
+	// TakenAt is the system's time when the snapshot was taken. Restoring the snapshot
+	// turns the clock back to it, so that sessions in the snapshot have not expired.
+	TakenAt time.Time `json:"takenAt"`
+	// State is the system's data, as serialized by the application service
+	State json.RawMessage `json:"state"`
+	// Sessions holds the client's session token for each account it was signed in to
+	Sessions map[string]string `json:"sessions"`
+}
+
+// Snapshot takes a snapshot of the domain's data and the client's sessions. Sessions on
+// other devices are not included.
+func (t *DomainTestDriver) Snapshot() (Snapshot, error) {
+	takenAt := t.harness.clock.Now()
+	state, err := t.appService.Snapshot()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return Snapshot{TakenAt: takenAt, State: state, Sessions: maps.Clone(t.sessions)}, nil
+}
+
+// Restore puts back the domain's data and the client's sessions as they were when the
+// snapshot was taken. Messages, events and webhook deliveries are cleared, and other
+// devices start afresh, as they do after clearing all data.
+func (t *DomainTestDriver) Restore(snapshot Snapshot) error {
+	if err := t.appService.Restore(snapshot.State); err != nil {
+		return err
+	}
+	t.harness.clock.Set(snapshot.TakenAt)
+	t.harness.outbox.Clear()
+	t.harness.events.Clear()
+	t.harness.feed.Clear()
+	t.harness.deliveries.Clear()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sessions = maps.Clone(snapshot.Sessions)
+	if t.sessions == nil {
+		t.sessions = make(map[string]string)
+	}
+	t.devices = make(map[string]*DomainTestDriver)
+	t.watches = make(map[string]*watch)
+	return nil
+}
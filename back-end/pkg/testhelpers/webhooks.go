@@ -0,0 +1,176 @@
+package testhelpers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/internal/domain/application"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/webhooks"
+)
+
+// WebhookReceiver is a local endpoint for tests to register as a webhook, so that they
+// can check what is delivered to it without any outside service. It checks each payload
+// against the signature sent with it, and can be taken down to see deliveries retried.
+// The system under test must be able to reach it on 127.0.0.1.
+type WebhookReceiver struct {
+	server *httptest.Server
+	secret string
+
+	mu       sync.Mutex
+	received []ReceivedWebhook
+	down     bool
+	changed  chan struct{}
+}
+
+// ReceivedWebhook is a payload that was posted to a WebhookReceiver
+type ReceivedWebhook struct {
+	Payload webhooks.Payload
+	// Signed is set if the payload was signed with the receiver's secret
+	Signed bool
+	// Accepted is set if the receiver was up, and so accepted the payload
+	Accepted bool
+}
+
+// NewWebhookReceiver starts a receiver with a secret of its own. Close it when done.
+func NewWebhookReceiver() *WebhookReceiver {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	r := &WebhookReceiver{secret: hex.EncodeToString(b)}
+	r.server = httptest.NewServer(http.HandlerFunc(r.receive))
+	return r
+}
+
+// URL returns the URL to register the receiver as a webhook at
+func (r *WebhookReceiver) URL() string {
+	return r.server.URL + "/webhook"
+}
+
+// Secret returns the secret to register the receiver with, which payloads must be signed with
+func (r *WebhookReceiver) Secret() string {
+	return r.secret
+}
+
+// SetDown makes the receiver refuse payloads with 503 Service Unavailable while down is set
+func (r *WebhookReceiver) SetDown(down bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.down = down
+}
+
+// Received returns every payload posted to the receiver, oldest first, including those it refused
+func (r *WebhookReceiver) Received() []ReceivedWebhook {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]ReceivedWebhook(nil), r.received...)
+}
+
+// Await waits up to NotificationTimeout for the receiver to accept a payload about an
+// event of the given kind, and returns it
+func (r *WebhookReceiver) Await(kind events.Kind) (ReceivedWebhook, error) {
+	deadline := time.After(NotificationTimeout)
+	for {
+		r.mu.Lock()
+		for _, received := range r.received {
+			if received.Accepted && received.Payload.Kind == kind {
+				r.mu.Unlock()
+				return received, nil
+			}
+		}
+		if r.changed == nil {
+			r.changed = make(chan struct{})
+		}
+		changed := r.changed
+		r.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-deadline:
+			return ReceivedWebhook{}, fmt.Errorf("no %s webhook within %v", kind, NotificationTimeout)
+		}
+	}
+}
+
+// Close shuts the receiver down
+func (r *WebhookReceiver) Close() {
+	r.server.Close()
+}
+
+func (r *WebhookReceiver) receive(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, "Failed to read payload", http.StatusBadRequest)
+		return
+	}
+	var payload webhooks.Payload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.received = append(r.received, ReceivedWebhook{
+		Payload:  payload,
+		Signed:   webhooks.Verify(r.secret, body, req.Header.Get(webhooks.SignatureHeader)),
+		Accepted: !r.down,
+	})
+	if r.changed != nil {
+		close(r.changed)
+		r.changed = nil
+	}
+	if r.down {
+		http.Error(w, "Down", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AwaitDeliveryAttempts waits up to NotificationTimeout for the latest of the deliveries
+// to a webhook to have been attempted the given number of times, and returns it. The
+// delivery log is updated once an attempt's response is in, so a receiver may have had
+// a payload a moment before its delivery shows up as attempted.
+func AwaitDeliveryAttempts(deliveries func() ([]webhooks.Delivery, error), attempts int) (webhooks.Delivery, error) {
+	deadline := time.Now().Add(NotificationTimeout)
+	for {
+		logged, err := deliveries()
+		if err != nil {
+			return webhooks.Delivery{}, err
+		}
+		if len(logged) > 0 && logged[len(logged)-1].Attempts >= attempts {
+			return logged[len(logged)-1], nil
+		}
+		if time.Now().After(deadline) {
+			return webhooks.Delivery{}, fmt.Errorf("no delivery attempted %d times within %v", attempts, NotificationTimeout)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// RegisterWebhook registers a webhook for the named account, acting as its holder
+func (t *DomainTestDriver) RegisterWebhook(name string, url string, secret string) (entities.Webhook, error) {
+	return t.appService.RegisterWebhook(t.session(name), name, application.WebhookTarget{URL: url, Secret: secret})
+}
+
+func (t *DomainTestDriver) GetWebhooks(name string) ([]entities.Webhook, error) {
+	return t.appService.GetWebhooks(t.session(name), name)
+}
+
+// AwaitWebhookDelivery waits for the latest delivery to one of the named account's
+// webhooks to have been attempted the given number of times, acting as its holder
+func (t *DomainTestDriver) AwaitWebhookDelivery(name string, webhookID string, attempts int) (webhooks.Delivery, error) {
+	return AwaitDeliveryAttempts(func() ([]webhooks.Delivery, error) {
+		if _, err := t.appService.GetWebhook(t.session(name), name, webhookID); err != nil {
+			return nil, err
+		}
+		return t.harness.deliveries.Deliveries(webhookID), nil
+	}, attempts)
+}
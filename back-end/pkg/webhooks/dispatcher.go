@@ -0,0 +1,416 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/clock"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+)
+
+const (
+	// pollInterval is how often deliveries are checked for being due to be retried. The
+	// clock may be one that tests move by hand, so waiting until one is due would not do.
+	pollInterval = 250 * time.Millisecond
+	// requestTimeout is how long a webhook has to respond before the attempt fails
+	requestTimeout = 10 * time.Second
+	// logCapacity is how many deliveries are kept in the log. Deliveries that are still
+	// being tried are always kept, so it can hold more for a while.
+	logCapacity = 1000
+)
+
+// deliveredKinds are the kinds of event that are posted to webhooks
+var deliveredKinds = map[events.Kind]bool{
+	events.AccountActivated: true,
+	events.ProjectCreated:   true,
+}
+
+// ErrPrivateDestination is the error attempts fail with when a webhook's host is at a
+// loopback, link-local or private address, which could otherwise be used to make the
+// server post to itself or to other services on the network it runs in
+var ErrPrivateDestination = errors.New("webhook destination is not a public address")
+
+// Status is how far a delivery has got
+type Status string
+
+const (
+	// StatusPending is a delivery that has not succeeded yet but will be tried again
+	StatusPending Status = "pending"
+	// StatusDelivered is a delivery that the webhook accepted
+	StatusDelivered Status = "delivered"
+	// StatusFailed is a delivery that was given up on after the last attempt failed
+	StatusFailed Status = "failed"
+)
+
+// Delivery is the record of posting an event to a webhook
+type Delivery struct {
+	ID        string
+	WebhookID string
+	Event     events.Event
+	Status    Status
+	// Attempts is how many times posting the event has been tried
+	Attempts int
+	// ResponseStatus is the HTTP status code the webhook responded to the latest attempt
+	// with, or 0 if it did not respond
+	ResponseStatus int
+	// Error says why the latest attempt failed, or is "" if it did not
+	Error         string
+	LastAttemptAt time.Time
+	// NextAttemptAt is when the event will next be posted, or the zero time if it will not be
+	NextAttemptAt time.Time
+}
+
+// Lookup returns the webhooks that the named account's events are posted to
+type Lookup func(account string) ([]entities.Webhook, error)
+
+// RetryPolicy decides how often, and how long after each other, the attempts to
+// deliver an event are made
+type RetryPolicy struct {
+	// MaxAttempts is how many attempts are made before the delivery is given up on
+	MaxAttempts int
+	// InitialBackoff is how long after the first failed attempt the next one is made.
+	// The wait doubles after each further failure, up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultRetryPolicy tries a delivery 6 times over about a quarter of an hour, waiting
+// 30 seconds after the first failure and twice as long after each one after that
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    6,
+	InitialBackoff: 30 * time.Second,
+	MaxBackoff:     30 * time.Minute,
+}
+
+// backoff returns how long to wait after the given number of failed attempts
+func (p RetryPolicy) backoff(failures int) time.Duration {
+	wait := p.InitialBackoff
+	for i := 1; i < failures && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, p.MaxBackoff)
+}
+
+// Dispatcher posts events to the webhooks of the accounts they happened to, in the
+// background, and logs how each delivery went. It is safe for concurrent use.
+type Dispatcher struct {
+	lookup Lookup
+	clock  clock.Clock
+	client *http.Client
+	retry  RetryPolicy
+	// allowPrivate lets payloads be posted to addresses that are not public
+	allowPrivate bool
+
+	mu         sync.Mutex
+	deliveries []*delivery
+
+	wake    chan struct{}
+	ctx     context.Context
+	stop    context.CancelFunc
+	running sync.WaitGroup
+}
+
+// delivery is a delivery along with what is needed to attempt it
+type delivery struct {
+	Delivery
+	url    string
+	secret string
+	body   []byte
+	// inFlight is set while an attempt is being made, so that it is not made twice
+	inFlight bool
+}
+
+// Option configures optional features of a Dispatcher
+type Option func(*Dispatcher)
+
+// WithClock tells the time with c instead of clock.System, so that tests can make
+// retries fall due without waiting
+func WithClock(c clock.Clock) Option {
+	return func(d *Dispatcher) {
+		d.clock = c
+	}
+}
+
+// WithClient posts payloads with c instead of a client that gives up after 10 seconds
+// and refuses to connect to addresses that are not public. Clients given this way are
+// trusted to refuse those themselves.
+func WithClient(c *http.Client) Option {
+	return func(d *Dispatcher) {
+		d.client = c
+	}
+}
+
+// WithPrivateDestinations lets payloads be posted to loopback, link-local and private
+// addresses, which are refused otherwise. It is meant for tests, whose webhooks run on
+// the same machine.
+func WithPrivateDestinations() Option {
+	return func(d *Dispatcher) {
+		d.allowPrivate = true
+	}
+}
+
+// WithRetryPolicy retries deliveries according to p instead of DefaultRetryPolicy
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(d *Dispatcher) {
+		d.retry = p
+	}
+}
+
+// New creates a dispatcher that finds the webhooks to post each account's events to
+// with lookup, and starts it delivering them
+func New(lookup Lookup, options ...Option) *Dispatcher {
+	d := &Dispatcher{
+		lookup: lookup,
+		clock:  clock.System,
+		retry:  DefaultRetryPolicy,
+		wake:   make(chan struct{}, 1),
+	}
+	for _, option := range options {
+		option(d)
+	}
+	if d.client == nil {
+		d.client = newClient(d.allowPrivate)
+	}
+	d.ctx, d.stop = context.WithCancel(context.Background())
+	d.running.Add(1)
+	go d.run()
+	return d
+}
+
+// Record queues the event to be posted to the webhooks of the account it happened to,
+// if it is of a kind that webhooks are told about. It can be subscribed to an events.Bus.
+func (d *Dispatcher) Record(event events.Event) {
+	if !deliveredKinds[event.Kind] {
+		return
+	}
+	webhooks, err := d.lookup(event.Account)
+	if err != nil {
+		// The account has gone, so there is nowhere to deliver to
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+
+	now := d.clock.Now().UTC()
+	d.mu.Lock()
+	for _, webhook := range webhooks {
+		id := newID()
+		// A payload is plain data, so encoding it cannot fail
+		body, _ := json.Marshal(Payload{
+			DeliveryID: id,
+			Kind:       event.Kind,
+			Account:    event.Account,
+			ProjectID:  event.ProjectID,
+			OccurredAt: event.OccurredAt,
+		})
+		d.deliveries = append(d.deliveries, &delivery{
+			Delivery: Delivery{
+				ID:            id,
+				WebhookID:     webhook.ID(),
+				Event:         event,
+				Status:        StatusPending,
+				NextAttemptAt: now,
+			},
+			url:    webhook.URL(),
+			secret: webhook.Secret(),
+			body:   body,
+		})
+	}
+	d.trim()
+	d.mu.Unlock()
+
+	// Make the first attempt straight away rather than at the next poll
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Deliveries returns the logged deliveries to the webhook with the given ID, oldest first
+func (d *Dispatcher) Deliveries(webhookID string) []Delivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	result := []Delivery{}
+	for _, dl := range d.deliveries {
+		if dl.WebhookID == webhookID {
+			result = append(result, dl.Delivery)
+		}
+	}
+	return result
+}
+
+// Clear forgets every delivery, including those still to be retried
+func (d *Dispatcher) Clear() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.deliveries = nil
+}
+
+// Close stops delivering, abandoning attempts under way, and waits for it to stop
+func (d *Dispatcher) Close() {
+	d.stop()
+	d.running.Wait()
+}
+
+// run makes the attempts that are due until the dispatcher is closed
+func (d *Dispatcher) run() {
+	defer d.running.Done()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-d.wake:
+		case <-ticker.C:
+		}
+		d.attemptDue()
+	}
+}
+
+// attemptDue starts an attempt at each pending delivery whose next attempt is due
+func (d *Dispatcher) attemptDue() {
+	now := d.clock.Now()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, dl := range d.deliveries {
+		if dl.Status != StatusPending || dl.inFlight || now.Before(dl.NextAttemptAt) {
+			continue
+		}
+		dl.inFlight = true
+		d.running.Add(1)
+		go func() {
+			defer d.running.Done()
+			d.attempt(dl)
+		}()
+	}
+}
+
+// attempt posts a delivery's payload to its webhook and records how it went, scheduling
+// another attempt if it failed and the retry policy allows one
+func (d *Dispatcher) attempt(dl *delivery) {
+	responseStatus, err := d.post(dl)
+	now := d.clock.Now().UTC()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	dl.inFlight = false
+	dl.Attempts++
+	dl.LastAttemptAt = now
+	dl.ResponseStatus = responseStatus
+	if err == nil {
+		dl.Status = StatusDelivered
+		dl.Error = ""
+		dl.NextAttemptAt = time.Time{}
+		return
+	}
+	dl.Error = err.Error()
+	if dl.Attempts >= d.retry.MaxAttempts {
+		dl.Status = StatusFailed
+		dl.NextAttemptAt = time.Time{}
+		return
+	}
+	dl.NextAttemptAt = now.Add(d.retry.backoff(dl.Attempts))
+}
+
+// newClient returns a client that gives up on a webhook after requestTimeout. Unless
+// allowPrivate is set, it refuses to connect to addresses that are not public. The
+// address is checked as each connection is made, after the host name is resolved, so
+// that neither a name that resolves to such an address nor a redirect can get round it.
+// Proxies from the environment are not used, as they would be connected to instead.
+func newClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: requestTimeout}
+	if !allowPrivate {
+		dialer.Control = refusePrivate
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: requestTimeout, Transport: transport}
+}
+
+// refusePrivate refuses connections to addresses that are not public, such as loopback,
+// link-local and private ones. It is called with the resolved address.
+func refusePrivate(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() || sharedAddressSpace.Contains(ip) {
+		return fmt.Errorf("%w: %s", ErrPrivateDestination, ip)
+	}
+	return nil
+}
+
+// sharedAddressSpace is used by carrier-grade NAT, and is no more public than private
+// addresses are
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// post sends the payload to the webhook, returning the status code it responded with
+// and an error unless it accepted the payload with a 2xx response
+func (d *Dispatcher) post(dl *delivery) (int, error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, dl.url, bytes.NewReader(dl.body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(dl.secret, dl.body))
+	req.Header.Set(DeliveryHeader, dl.ID)
+	req.Header.Set(EventHeader, string(dl.Event.Kind))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Read a little of the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// trim drops the oldest finished deliveries once the log holds more than it should.
+// The caller must hold the lock.
+func (d *Dispatcher) trim() {
+	excess := len(d.deliveries) - logCapacity
+	if excess <= 0 {
+		return
+	}
+	d.deliveries = slices.DeleteFunc(d.deliveries, func(dl *delivery) bool {
+		if excess > 0 && dl.Status != StatusPending {
+			excess--
+			return true
+		}
+		return false
+	})
+}
+
+// newID generates a random identifier for a delivery
+func newID() string {
+	b := make([]byte, 16)
+	// rand.Read never fails, crashing the program instead if randomness is unavailable
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,56 @@
+// Webhooks package posts events to the webhooks that account holders register, signing
+// each payload and retrying deliveries that fail, and keeps a log of the deliveries. It
+// is exported so that receivers, such as the acceptance tests, can check the signatures.
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+)
+
+// Headers sent with each payload
+const (
+	// SignatureHeader holds the signature of the payload, as returned by Sign
+	SignatureHeader = "X-Webhook-Signature"
+	// DeliveryHeader holds the ID of the delivery, which is the same for every attempt
+	DeliveryHeader = "X-Webhook-Delivery"
+	// EventHeader holds the kind of event the payload is about
+	EventHeader = "X-Webhook-Event"
+)
+
+// signaturePrefix names the algorithm a signature was made with
+const signaturePrefix = "sha256="
+
+// Payload is the JSON body posted to a webhook
+type Payload struct {
+	// DeliveryID is the same for every attempt to deliver an event to a webhook, so that
+	// receivers can ignore an event they have already been sent
+	DeliveryID string      `json:"deliveryId"`
+	Kind       events.Kind `json:"kind"`
+	// Account is the name of the account the event happened to
+	Account string `json:"account"`
+	// ProjectID identifies the project, for events that happened to one
+	ProjectID  string    `json:"projectId,omitempty"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+// Sign returns the signature of a payload's body, made with the webhook's secret. It is
+// the hex-encoded HMAC-SHA256 of the body, prefixed with "sha256=".
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the signature of body made with secret
+func Verify(secret string, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, body)))
+}
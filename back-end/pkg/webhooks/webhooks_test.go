@@ -0,0 +1,249 @@
+package webhooks_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/clock/manual"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/entities"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/events"
+	"github.com/sirockin/cucumber-screenplay-go/back-end/pkg/webhooks"
+)
+
+const secret = "a-secret-of-some-length"
+
+func TestSignatures(t *testing.T) {
+	body := []byte(`{"kind":"ProjectCreated"}`)
+	signature := webhooks.Sign(secret, body)
+
+	if !webhooks.Verify(secret, body, signature) {
+		t.Fatalf("expected signature %s to be verified", signature)
+	}
+	if webhooks.Verify("another-secret-entirely", body, signature) {
+		t.Fatalf("expected signature made with another secret not to be verified")
+	}
+	if webhooks.Verify(secret, []byte(`{"kind":"AccountActivated"}`), signature) {
+		t.Fatalf("expected signature of another body not to be verified")
+	}
+	if webhooks.Verify(secret, body, signature[len("sha256="):]) {
+		t.Fatalf("expected signature without its algorithm not to be verified")
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	t.Run("PostsSignedPayload", func(t *testing.T) {
+		r := newReceiver(t)
+		d := webhooks.New(r.lookup, webhooks.WithPrivateDestinations())
+		defer d.Close()
+
+		occurredAt := time.Date(2025, time.January, 2, 3, 4, 5, 0, time.UTC)
+		d.Record(events.Event{Kind: events.ProjectCreated, Account: "Sue", ProjectID: "roadmap-id", OccurredAt: occurredAt})
+
+		req := r.next(t)
+		if !webhooks.Verify(secret, req.body, req.header.Get(webhooks.SignatureHeader)) {
+			t.Fatalf("expected payload to be signed but got signature %q", req.header.Get(webhooks.SignatureHeader))
+		}
+		var payload webhooks.Payload
+		expectNoError(t, json.Unmarshal(req.body, &payload))
+		if payload.Kind != events.ProjectCreated || payload.Account != "Sue" || payload.ProjectID != "roadmap-id" ||
+			!payload.OccurredAt.Equal(occurredAt) {
+			t.Fatalf("expected payload about Sue's project but got %+v", payload)
+		}
+		if req.header.Get(webhooks.DeliveryHeader) != payload.DeliveryID || req.header.Get(webhooks.EventHeader) != "ProjectCreated" {
+			t.Fatalf("expected headers to match payload %+v but got %v", payload, req.header)
+		}
+
+		delivery := awaitDelivery(t, d, func(dl webhooks.Delivery) bool { return dl.Status == webhooks.StatusDelivered })
+		if delivery.ID != payload.DeliveryID || delivery.Attempts != 1 || delivery.ResponseStatus != http.StatusNoContent {
+			t.Fatalf("expected delivery %s to succeed first time but got %+v", payload.DeliveryID, delivery)
+		}
+	})
+
+	t.Run("IgnoresOtherKindsOfEvent", func(t *testing.T) {
+		r := newReceiver(t)
+		d := webhooks.New(r.lookup, webhooks.WithPrivateDestinations())
+		defer d.Close()
+
+		d.Record(events.Event{Kind: events.Authenticated, Account: "Sue"})
+
+		if deliveries := d.Deliveries("webhook-id"); len(deliveries) != 0 {
+			t.Fatalf("expected no deliveries but got %+v", deliveries)
+		}
+	})
+
+	t.Run("RetriesWithBackoff", func(t *testing.T) {
+		r := newReceiver(t)
+		r.fail(2)
+		clock := manual.New(time.Date(2025, time.January, 2, 3, 4, 5, 0, time.UTC))
+		d := webhooks.New(r.lookup, webhooks.WithPrivateDestinations(), webhooks.WithClock(clock))
+		defer d.Close()
+
+		d.Record(events.Event{Kind: events.AccountActivated, Account: "Sue"})
+		r.next(t)
+		first := awaitDelivery(t, d, func(dl webhooks.Delivery) bool { return dl.Attempts == 1 })
+		if first.Status != webhooks.StatusPending || first.ResponseStatus != http.StatusServiceUnavailable ||
+			!first.NextAttemptAt.Equal(clock.Now().Add(30*time.Second)) {
+			t.Fatalf("expected delivery to be retried in 30s but got %+v", first)
+		}
+
+		_, err := clock.Advance(30 * time.Second)
+		expectNoError(t, err)
+		r.next(t)
+		second := awaitDelivery(t, d, func(dl webhooks.Delivery) bool { return dl.Attempts == 2 })
+		if second.Status != webhooks.StatusPending || !second.NextAttemptAt.Equal(clock.Now().Add(time.Minute)) {
+			t.Fatalf("expected delivery to be retried in 1m but got %+v", second)
+		}
+
+		_, err = clock.Advance(time.Minute)
+		expectNoError(t, err)
+		r.next(t)
+		third := awaitDelivery(t, d, func(dl webhooks.Delivery) bool { return dl.Attempts == 3 })
+		if third.Status != webhooks.StatusDelivered || third.Error != "" || !third.NextAttemptAt.IsZero() {
+			t.Fatalf("expected delivery to succeed on the third attempt but got %+v", third)
+		}
+	})
+
+	t.Run("GivesUpAfterLastAttempt", func(t *testing.T) {
+		r := newReceiver(t)
+		r.fail(10)
+		clock := manual.New(time.Date(2025, time.January, 2, 3, 4, 5, 0, time.UTC))
+		d := webhooks.New(r.lookup, webhooks.WithPrivateDestinations(), webhooks.WithClock(clock), webhooks.WithRetryPolicy(webhooks.RetryPolicy{
+			MaxAttempts:    2,
+			InitialBackoff: time.Second,
+			MaxBackoff:     time.Second,
+		}))
+		defer d.Close()
+
+		d.Record(events.Event{Kind: events.ProjectCreated, Account: "Sue"})
+		r.next(t)
+		awaitDelivery(t, d, func(dl webhooks.Delivery) bool { return dl.Attempts == 1 })
+		_, err := clock.Advance(time.Second)
+		expectNoError(t, err)
+		r.next(t)
+
+		last := awaitDelivery(t, d, func(dl webhooks.Delivery) bool { return dl.Attempts == 2 })
+		if last.Status != webhooks.StatusFailed || last.Error != "webhook responded 503 Service Unavailable" ||
+			!last.NextAttemptAt.IsZero() {
+			t.Fatalf("expected delivery to be given up on but got %+v", last)
+		}
+	})
+
+	t.Run("RefusesPrivateDestinations", func(t *testing.T) {
+		r := newReceiver(t)
+		d := webhooks.New(r.lookup)
+		defer d.Close()
+
+		d.Record(events.Event{Kind: events.ProjectCreated, Account: "Sue"})
+
+		refused := awaitDelivery(t, d, func(dl webhooks.Delivery) bool { return dl.Attempts == 1 })
+		if refused.Status != webhooks.StatusPending || refused.ResponseStatus != 0 ||
+			!strings.Contains(refused.Error, webhooks.ErrPrivateDestination.Error()) {
+			t.Fatalf("expected delivery to the loopback address to be refused but got %+v", refused)
+		}
+		select {
+		case req := <-r.requests:
+			t.Fatalf("expected nothing to be posted but got %+v", req)
+		default:
+		}
+	})
+
+	t.Run("ClearForgetsDeliveries", func(t *testing.T) {
+		r := newReceiver(t)
+		d := webhooks.New(r.lookup, webhooks.WithPrivateDestinations())
+		defer d.Close()
+
+		d.Record(events.Event{Kind: events.ProjectCreated, Account: "Sue"})
+		d.Clear()
+
+		if deliveries := d.Deliveries("webhook-id"); len(deliveries) != 0 {
+			t.Fatalf("expected no deliveries but got %+v", deliveries)
+		}
+	})
+}
+
+// receiver is a webhook that Sue has registered, which refuses as many requests as it
+// is told to fail before accepting them
+type receiver struct {
+	server   *httptest.Server
+	requests chan request
+
+	mu       sync.Mutex
+	failures int
+}
+
+type request struct {
+	header http.Header
+	body   []byte
+}
+
+func newReceiver(t *testing.T) *receiver {
+	r := &receiver{requests: make(chan request, 10)}
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.requests <- request{header: req.Header, body: body}
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.failures > 0 {
+			r.failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func (r *receiver) fail(times int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures = times
+}
+
+func (r *receiver) lookup(account string) ([]entities.Webhook, error) {
+	if account != "Sue" {
+		return nil, entities.ErrAccountNotFound
+	}
+	return []entities.Webhook{*entities.NewWebhook("webhook-id", "sue-id", r.server.URL, secret, time.Now())}, nil
+}
+
+// next waits for the next request to the webhook
+func (r *receiver) next(t *testing.T) request {
+	t.Helper()
+	select {
+	case req := <-r.requests:
+		return req
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected a request to the webhook but got none")
+		return request{}
+	}
+}
+
+// awaitDelivery waits for the delivery to Sue's webhook to be logged as matching
+func awaitDelivery(t *testing.T, d *webhooks.Dispatcher, matches func(webhooks.Delivery) bool) webhooks.Delivery {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		deliveries := d.Deliveries("webhook-id")
+		if len(deliveries) == 1 && matches(deliveries[0]) {
+			return deliveries[0]
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected delivery to be logged but got %+v", deliveries)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func expectNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+}
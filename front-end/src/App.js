@@ -4,8 +4,19 @@ import SignUp from './components/SignUp';
 import Login from './components/Login';
 import Account from './components/Account';
 import Activate from './components/Activate';
+import Notifications from './components/Notifications';
 import Projects from './components/Projects';
+import Webhooks from './components/Webhooks';
+import WebhookDeliveries from './components/WebhookDeliveries';
+import ProjectDetails from './components/ProjectDetails';
+import Invitations from './components/Invitations';
+import Organisations from './components/Organisations';
+import OrganisationDetails from './components/OrganisationDetails';
 import Clear from './components/Clear';
+import Outbox from './components/Outbox';
+import Events from './components/Events';
+import Clock from './components/Clock';
+import Snapshot from './components/Snapshot';
 
 function App() {
   return (
@@ -22,8 +33,19 @@ function App() {
           <Route path="/login" element={<Login />} />
           <Route path="/account/:name" element={<Account />} />
           <Route path="/activate/:name" element={<Activate />} />
+          <Route path="/account/:name/notifications" element={<Notifications />} />
           <Route path="/account/:name/projects" element={<Projects />} />
+          <Route path="/account/:name/projects/:id" element={<ProjectDetails />} />
+          <Route path="/account/:name/invitations" element={<Invitations />} />
+          <Route path="/account/:name/orgs" element={<Organisations />} />
+          <Route path="/account/:name/orgs/:org" element={<OrganisationDetails />} />
+          <Route path="/account/:name/webhooks" element={<Webhooks />} />
+          <Route path="/account/:name/webhooks/:id" element={<WebhookDeliveries />} />
           <Route path="/admin/clear" element={<Clear />} />
+          <Route path="/admin/outbox/:name" element={<Outbox />} />
+          <Route path="/admin/events/:name" element={<Events />} />
+          <Route path="/admin/clock" element={<Clock />} />
+          <Route path="/admin/snapshot" element={<Snapshot />} />
           <Route path="/" element={<SignUp />} />
         </Routes>
       </div>
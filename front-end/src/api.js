@@ -0,0 +1,143 @@
+// readError extracts the message and machine-readable code from an API error response
+export async function readError(response) {
+  const text = await response.text();
+  try {
+    const data = JSON.parse(text);
+    return { message: data.error || text, code: data.code || '' };
+  } catch (err) {
+    return { message: text, code: '' };
+  }
+}
+
+// nextCursor returns the cursor for the next page of a list, from the link to it in the
+// response's Link header, or '' if the response holds the last page
+export function nextCursor(response) {
+  const links = response.headers.get('Link') || '';
+  const next = links.split(',').find((link) => link.includes('rel="next"'));
+  const target = next && next.match(/<([^>]*)>/);
+  if (!target) {
+    return '';
+  }
+  return new URL(target[1], window.location.origin).searchParams.get('cursor') || '';
+}
+
+// Each browser keeps its own session token for every account it signs in to
+const sessionKey = (name) => `session:${name}`;
+
+export function saveSession(name, token) {
+  localStorage.setItem(sessionKey(name), token);
+}
+
+export function clearSession(name) {
+  localStorage.removeItem(sessionKey(name));
+}
+
+// browserSessions returns the session token this browser keeps for each account
+export function browserSessions() {
+  const sessions = {};
+  Object.keys(localStorage)
+    .filter((key) => key.startsWith('session:'))
+    .forEach((key) => {
+      sessions[key.slice('session:'.length)] = localStorage.getItem(key);
+    });
+  return sessions;
+}
+
+// replaceSessions makes the browser keep exactly the given session token for each account
+export function replaceSessions(sessions) {
+  Object.keys(browserSessions()).forEach(clearSession);
+  Object.entries(sessions || {}).forEach(([name, token]) => saveSession(name, token));
+}
+
+// authHeaders returns the headers that identify this browser's session for the account
+export function authHeaders(name) {
+  const token = localStorage.getItem(sessionKey(name));
+  return token ? { Authorization: `Bearer ${token}` } : {};
+}
+
+// actingAs returns the account a page acts as. A browser may be signed in to several
+// accounts, so pages about one account can be opened as another with ?as=<name>.
+export function actingAs(searchParams, name) {
+  return searchParams.get('as') || name;
+}
+
+// Each browser remembers the last event it saw on each account's stream, so that following
+// the stream again catches up on what was missed in between
+const lastEventIdKey = (name) => `last-event-id:${name}`;
+
+// forgetLastEventIds forgets where this browser got to in every account's event stream
+export function forgetLastEventIds() {
+  Object.keys(localStorage)
+    .filter((key) => key.startsWith('last-event-id:'))
+    .forEach((key) => localStorage.removeItem(key));
+}
+
+// followEvents reads the account's event stream until it ends or signal aborts it. The
+// stream is read with fetch rather than EventSource, which cannot send the session token.
+// onReady is called once the stream has caught up, and onEvent with each event after that
+// or caught up on.
+export async function followEvents(name, { signal, onEvent, onReady, onError }) {
+  const headers = { ...authHeaders(name), Accept: 'text/event-stream' };
+  const lastEventId = localStorage.getItem(lastEventIdKey(name));
+  if (lastEventId) {
+    headers['Last-Event-ID'] = lastEventId;
+  }
+
+  try {
+    const response = await fetch(`/accounts/${encodeURIComponent(name)}/events`, { headers, signal });
+    if (!response.ok) {
+      onError(await readError(response));
+      return;
+    }
+
+    const reader = response.body.pipeThrough(new TextDecoderStream()).getReader();
+    let buffered = '';
+    for (;;) {
+      const { value, done } = await reader.read();
+      if (done) {
+        return;
+      }
+      buffered += value;
+      let end;
+      while ((end = buffered.indexOf('\n\n')) >= 0) {
+        const event = parseStreamEvent(buffered.slice(0, end));
+        buffered = buffered.slice(end + 2);
+        if (!event.name) {
+          continue;
+        }
+        localStorage.setItem(lastEventIdKey(name), event.id);
+        if (event.name === 'ready') {
+          onReady();
+        } else {
+          onEvent(JSON.parse(event.data));
+        }
+      }
+    }
+  } catch (err) {
+    if (!signal.aborted) {
+      onError({ message: `Network error: ${err.message}`, code: '' });
+    }
+  }
+}
+
+// parseStreamEvent reads the fields of one Server-Sent Event. Comments, such as
+// heartbeats, have no name.
+function parseStreamEvent(text) {
+  const event = { id: '', name: '', data: '' };
+  text.split('\n').forEach((line) => {
+    const colon = line.indexOf(':');
+    if (colon <= 0) {
+      return;
+    }
+    const field = line.slice(0, colon);
+    const value = line.slice(colon + 1).replace(/^ /, '');
+    if (field === 'id') {
+      event.id = value;
+    } else if (field === 'event') {
+      event.name = value;
+    } else if (field === 'data') {
+      event.data += value;
+    }
+  });
+  return event;
+}
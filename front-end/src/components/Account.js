@@ -1,20 +1,33 @@
 import React, { useState, useEffect } from 'react';
 import { useParams, Link } from 'react-router-dom';
+import { readError, authHeaders, clearSession } from '../api';
 
 function Account() {
   const { name } = useParams();
   const [account, setAccount] = useState(null);
+  const [authenticated, setAuthenticated] = useState(false);
+  const [message, setMessage] = useState('');
+  const [planMessage, setPlanMessage] = useState('');
+  const [confirmingClose, setConfirmingClose] = useState(false);
   const [error, setError] = useState('');
+  const [errorCode, setErrorCode] = useState('');
 
   useEffect(() => {
     const fetchAccount = async () => {
       try {
-        const response = await fetch(`/accounts/${name}`);
+        const [response, statusResponse] = await Promise.all([
+          fetch(`/accounts/${name}`),
+          fetch(`/accounts/${name}/authentication-status`, { headers: authHeaders(name) }),
+        ]);
         if (response.ok) {
           const accountData = await response.json();
+          const status = statusResponse.ok ? await statusResponse.json() : { authenticated: false };
+          setAuthenticated(status.authenticated);
           setAccount(accountData);
         } else {
-          setError(`Account not found: ${name}`);
+          const { message, code } = await readError(response);
+          setError(message);
+          setErrorCode(code);
         }
       } catch (err) {
         setError(`Network error: ${err.message}`);
@@ -26,8 +39,101 @@ function Account() {
     }
   }, [name]);
 
+  const handleSignOut = async () => {
+    setMessage('');
+    try {
+      const response = await fetch('/sessions/current/sign-out', {
+        method: 'POST',
+        headers: authHeaders(name),
+      });
+      if (response.ok) {
+        clearSession(name);
+        setAuthenticated(false);
+        setMessage(`Signed out of ${name}`);
+      } else {
+        const { message, code } = await readError(response);
+        setError(`Failed to sign out: ${message}`);
+        setErrorCode(code);
+      }
+    } catch (err) {
+      setError(`Network error: ${err.message}`);
+    }
+  };
+
+  const handleChangePlan = (plan) => async () => {
+    setPlanMessage('');
+    try {
+      const response = await fetch(`/accounts/${name}/plan`, {
+        method: 'PUT',
+        headers: {
+          'Content-Type': 'application/json',
+          ...authHeaders(name),
+        },
+        body: JSON.stringify({ plan }),
+      });
+      if (response.ok) {
+        setAccount({ ...account, plan });
+        setPlanMessage(`Moved to the ${plan} plan`);
+      } else {
+        const { message, code } = await readError(response);
+        setError(`Failed to change plan: ${message}`);
+        setErrorCode(code);
+      }
+    } catch (err) {
+      setError(`Network error: ${err.message}`);
+    }
+  };
+
+  const handleCloseAccount = async () => {
+    setMessage('');
+    try {
+      const response = await fetch(`/accounts/${name}`, {
+        method: 'DELETE',
+        headers: authHeaders(name),
+      });
+      if (response.ok) {
+        // Closing the account ends every session, this browser's included
+        clearSession(name);
+        setAuthenticated(false);
+        setConfirmingClose(false);
+        setAccount({ ...account, closedAt: new Date().toISOString() });
+        setMessage(`Closed ${name}`);
+      } else {
+        const { message, code } = await readError(response);
+        setError(`Failed to close account: ${message}`);
+        setErrorCode(code);
+      }
+    } catch (err) {
+      setError(`Network error: ${err.message}`);
+    }
+  };
+
+  const handleExport = async () => {
+    try {
+      const response = await fetch(`/accounts/${name}/export`, { headers: authHeaders(name) });
+      if (!response.ok) {
+        const { message, code } = await readError(response);
+        setError(`Failed to download data: ${message}`);
+        setErrorCode(code);
+        return;
+      }
+      // Save the archive under the name the server gives it
+      const disposition = response.headers.get('Content-Disposition') || '';
+      const filename = disposition.match(/filename="?([^";]+)"?/);
+      const link = document.createElement('a');
+      link.href = URL.createObjectURL(await response.blob());
+      link.download = filename ? filename[1] : `${name}-export.json`;
+      document.body.appendChild(link);
+      link.click();
+      link.remove();
+      URL.revokeObjectURL(link.href);
+    } catch (err) {
+      setError(`Network error: ${err.message}`);
+    }
+  };
+
   if (error) {
-    return <div className="error">{error}</div>;
+    return <div className="error" data-error-code={errorCode}>{error}</div>;
   }
 
   if (!account) {
@@ -37,30 +143,103 @@ function Account() {
   return (
     <div>
       <h2>Account: {account.name}</h2>
+      {message && <div className={`success ${account.closedAt ? 'account-closed' : 'signed-out'}`}>{message}</div>}
+      {planMessage && <div className="success plan-changed">{planMessage}</div>}
 
       <div className="account-info">
+        <p>
+          <strong>ID:</strong> <span className="account-id">{account.id}</span>
+        </p>
         <p>
           <strong>Status:</strong>{' '}
           {account.activated && <span className="status-activated">Activated</span>}
           {!account.activated && <span>Not Activated</span>}
+          {account.closedAt && (
+            <span className="status-closed" data-closed-at={account.closedAt} style={{ marginLeft: '10px' }}>
+              Closed
+            </span>
+          )}
         </p>
         <p>
           <strong>Authentication:</strong>{' '}
-          {account.authenticated && <span className="status-authenticated">Authenticated</span>}
-          {!account.authenticated && <span>Not Authenticated</span>}
+          {authenticated && <span className="status-authenticated">Authenticated</span>}
+          {!authenticated && <span>Not Authenticated</span>}
+        </p>
+        <p>
+          <strong>Plan:</strong>{' '}
+          <span className="account-plan" data-plan={account.plan}>{account.plan}</span>
+          {authenticated && account.plan !== 'pro' && (
+            <button className="change-plan" data-plan="pro" onClick={handleChangePlan('pro')} style={{ marginLeft: '10px' }}>
+              Upgrade to Pro
+            </button>
+          )}
+          {authenticated && account.plan === 'pro' && (
+            <button className="change-plan" data-plan="free" onClick={handleChangePlan('free')} style={{ marginLeft: '10px' }}>
+              Move to Free
+            </button>
+          )}
         </p>
       </div>
 
       <div>
         {!account.activated && (
-          <Link to={`/activate/${name}`}>
-            <button className="activate">Activate Account</button>
+          <Link to={`/admin/outbox/${name}`}>
+            <button>Find Activation Link</button>
           </Link>
         )}
         <Link to={`/account/${name}/projects`} style={{ marginLeft: '10px' }}>
           <button>View Projects</button>
         </Link>
+        {authenticated && (
+          <Link to={`/account/${name}/invitations`} style={{ marginLeft: '10px' }}>
+            <button>Invitations</button>
+          </Link>
+        )}
+        {authenticated && (
+          <Link to={`/account/${name}/orgs`} style={{ marginLeft: '10px' }}>
+            <button>Organisations</button>
+          </Link>
+        )}
+        {authenticated && (
+          <Link to={`/account/${name}/notifications`} style={{ marginLeft: '10px' }}>
+            <button>Watch Activity</button>
+          </Link>
+        )}
+        {authenticated && (
+          <Link to={`/account/${name}/webhooks`} style={{ marginLeft: '10px' }}>
+            <button>Webhooks</button>
+          </Link>
+        )}
+        {authenticated && (
+          <button className="sign-out" onClick={handleSignOut} style={{ marginLeft: '10px' }}>
+            Sign Out
+          </button>
+        )}
       </div>
+
+      {authenticated && (
+        <div style={{ marginTop: '20px' }}>
+          <button className="export-account" onClick={handleExport}>
+            Download My Data
+          </button>
+          {!confirmingClose && (
+            <button className="close-account" onClick={() => setConfirmingClose(true)} style={{ marginLeft: '10px' }}>
+              Close Account
+            </button>
+          )}
+          {confirmingClose && (
+            <span style={{ marginLeft: '10px' }}>
+              Closing deletes your projects and signs you out everywhere.{' '}
+              <button className="confirm-close-account" onClick={handleCloseAccount}>
+                Close for Good
+              </button>
+              <button onClick={() => setConfirmingClose(false)} style={{ marginLeft: '10px' }}>
+                Keep Account
+              </button>
+            </span>
+          )}
+        </div>
+      )}
     </div>
   );
 }
@@ -1,29 +1,41 @@
 import React, { useState } from 'react';
-import { useParams, useNavigate } from 'react-router-dom';
+import { useParams, useNavigate, useSearchParams } from 'react-router-dom';
+import { readError, saveSession } from '../api';
 
 function Activate() {
   const { name } = useParams();
+  const [searchParams] = useSearchParams();
+  const token = searchParams.get('token') || '';
   const [message, setMessage] = useState('');
   const [error, setError] = useState('');
+  const [errorCode, setErrorCode] = useState('');
   const navigate = useNavigate();
 
   const handleActivate = async () => {
     setMessage('');
     setError('');
+    setErrorCode('');
 
     try {
       const response = await fetch(`/accounts/${name}/activate`, {
         method: 'POST',
+        headers: {
+          'Content-Type': 'application/json',
+        },
+        body: JSON.stringify({ token }),
       });
 
       if (response.ok) {
+        const { token } = await response.json();
+        saveSession(name, token);
         setMessage(`Account ${name} activated successfully!`);
         setTimeout(() => {
           navigate(`/account/${name}`);
         }, 1500);
       } else {
-        const errorData = await response.text();
-        setError(`Failed to activate account: ${errorData}`);
+        const { message, code } = await readError(response);
+        setError(`Failed to activate account: ${message}`);
+        setErrorCode(code);
       }
     } catch (err) {
       setError(`Network error: ${err.message}`);
@@ -36,7 +48,7 @@ function Activate() {
       <p>Activate account for: <strong>{name}</strong></p>
 
       {message && <div className="success">{message}</div>}
-      {error && <div className="error">{error}</div>}
+      {error && <div className="error" data-error-code={errorCode}>{error}</div>}
 
       <button className="activate" onClick={handleActivate}>
         Activate Account
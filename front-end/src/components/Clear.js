@@ -1,4 +1,5 @@
 import React, { useState } from 'react';
+import { forgetLastEventIds } from '../api';
 
 function Clear() {
   const [message, setMessage] = useState('');
@@ -14,6 +15,8 @@ function Clear() {
       });
 
       if (response.ok) {
+        // Event ids from before clearing mean nothing now
+        forgetLastEventIds();
         setMessage('All data cleared successfully!');
       } else {
         setError('Failed to clear data');
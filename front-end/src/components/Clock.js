@@ -0,0 +1,60 @@
+import React, { useState } from 'react';
+import { readError } from '../api';
+
+function Clock() {
+  const [duration, setDuration] = useState('');
+  const [now, setNow] = useState('');
+  const [error, setError] = useState('');
+
+  const handleAdvance = async (e) => {
+    e.preventDefault();
+    setNow('');
+    setError('');
+
+    try {
+      const response = await fetch('/clock/advance', {
+        method: 'POST',
+        headers: {
+          'Content-Type': 'application/json',
+        },
+        body: JSON.stringify({ duration }),
+      });
+
+      if (response.ok) {
+        const data = await response.json();
+        setNow(data.now);
+      } else {
+        const { message } = await readError(response);
+        setError(`Failed to advance clock: ${message}`);
+      }
+    } catch (err) {
+      setError(`Network error: ${err.message}`);
+    }
+  };
+
+  return (
+    <div>
+      <h2>Admin: Advance Clock</h2>
+      <p>Makes time pass for the server. Only available when it runs in test mode.</p>
+
+      {now && <div className="success" data-now={now}>The time is now {new Date(now).toLocaleString()}</div>}
+      {error && <div className="error">{error}</div>}
+
+      <form onSubmit={handleAdvance}>
+        <input
+          type="text"
+          name="duration"
+          placeholder="Duration, such as 192h"
+          value={duration}
+          onChange={(e) => setDuration(e.target.value)}
+          required
+        />
+        <button type="submit" className="advance-clock">
+          Advance Clock
+        </button>
+      </form>
+    </div>
+  );
+}
+
+export default Clock;
@@ -0,0 +1,58 @@
+import React, { useState, useEffect } from 'react';
+import { useParams } from 'react-router-dom';
+import { readError } from '../api';
+
+// Events shows what the back end has published about an account, such as it being activated
+function Events() {
+  const { name } = useParams();
+  const [events, setEvents] = useState(null);
+  const [error, setError] = useState('');
+  const [errorCode, setErrorCode] = useState('');
+
+  useEffect(() => {
+    const fetchEvents = async () => {
+      try {
+        const response = await fetch(`/events/${encodeURIComponent(name)}`);
+        if (response.ok) {
+          setEvents((await response.json()) || []);
+        } else {
+          const { message, code } = await readError(response);
+          setError(`Failed to load events: ${message}`);
+          setErrorCode(code);
+        }
+      } catch (err) {
+        setError(`Network error: ${err.message}`);
+      }
+    };
+    fetchEvents();
+  }, [name]);
+
+  return (
+    <div>
+      <h2>Events for {name}</h2>
+
+      {error && <div className="error" data-error-code={errorCode}>{error}</div>}
+
+      {events && (
+        <div className="events-list">
+          {events.length === 0 ? (
+            <p>No events.</p>
+          ) : (
+            <ul>
+              {events.map((event, index) => (
+                <li key={index} className="event" data-project-id={event.projectId || ''}>
+                  <strong className="event-kind">{event.kind}</strong>{' '}
+                  <time className="event-occurred-at" dateTime={event.occurredAt}>
+                    {new Date(event.occurredAt).toLocaleString()}
+                  </time>
+                </li>
+              ))}
+            </ul>
+          )}
+        </div>
+      )}
+    </div>
+  );
+}
+
+export default Events;
@@ -0,0 +1,117 @@
+import React, { useState, useEffect, useCallback } from 'react';
+import { useParams, Link } from 'react-router-dom';
+import { readError, authHeaders } from '../api';
+
+function Invitations() {
+  const { name } = useParams();
+  const [invitations, setInvitations] = useState([]);
+  const [loaded, setLoaded] = useState(false);
+  const [message, setMessage] = useState('');
+  const [error, setError] = useState('');
+  const [errorCode, setErrorCode] = useState('');
+
+  const fetchInvitations = useCallback(async () => {
+    try {
+      const response = await fetch(`/accounts/${name}/invitations`, {
+        headers: authHeaders(name),
+      });
+      if (response.ok) {
+        setInvitations((await response.json()) || []);
+        setLoaded(true);
+      } else {
+        const { message, code } = await readError(response);
+        setError(`Failed to load invitations for ${name}: ${message}`);
+        setErrorCode(code);
+      }
+    } catch (err) {
+      setError(`Network error: ${err.message}`);
+    }
+  }, [name]);
+
+  useEffect(() => {
+    if (name) {
+      fetchInvitations();
+    }
+  }, [name, fetchInvitations]);
+
+  // handleAnswer accepts or declines the invitation, as action says
+  const handleAnswer = (invitation, action, done) => async () => {
+    setMessage('');
+    setError('');
+    setErrorCode('');
+
+    try {
+      const response = await fetch(`/accounts/${name}/invitations/${invitation.projectId}/${action}`, {
+        method: 'POST',
+        headers: authHeaders(name),
+      });
+
+      if (response.ok) {
+        setMessage(done);
+        // Refresh the invitations list
+        fetchInvitations();
+      } else {
+        const { message, code } = await readError(response);
+        setError(`Failed to ${action} invitation: ${message}`);
+        setErrorCode(code);
+      }
+    } catch (err) {
+      setError(`Network error: ${err.message}`);
+    }
+  };
+
+  return (
+    <div>
+      <h2>Invitations for {name}</h2>
+      <p>Projects that others have invited you to share. Accepted projects are listed with your own.</p>
+
+      {message && <div className="success invitation-answered">{message}</div>}
+      {error && <div className="error" data-error-code={errorCode}>{error}</div>}
+
+      {loaded && (
+        <div className="invitations-list">
+          {invitations.length === 0 ? (
+            <p>No invitations.</p>
+          ) : (
+            <ul>
+              {invitations.map((invitation) => (
+                <li
+                  key={invitation.projectId}
+                  className="invitation"
+                  data-project-id={invitation.projectId}
+                  data-role={invitation.role}
+                  data-invited-at={invitation.invitedAt}
+                >
+                  <span className="invitation-project">{invitation.projectName}</span>
+                  {' from '}
+                  <span className="invitation-owner">{invitation.owner}</span>
+                  {` as ${invitation.role}`}
+                  <button
+                    className="accept-invitation"
+                    onClick={handleAnswer(invitation, 'accept', `You now share ${invitation.projectName}.`)}
+                    style={{ marginLeft: '10px' }}
+                  >
+                    Accept
+                  </button>
+                  <button
+                    className="decline-invitation"
+                    onClick={handleAnswer(invitation, 'decline', `Declined ${invitation.projectName}.`)}
+                    style={{ marginLeft: '10px' }}
+                  >
+                    Decline
+                  </button>
+                </li>
+              ))}
+            </ul>
+          )}
+        </div>
+      )}
+
+      <Link to={`/account/${name}/projects`}>
+        <button>View Projects</button>
+      </Link>
+    </div>
+  );
+}
+
+export default Invitations;
@@ -1,30 +1,41 @@
 import React, { useState } from 'react';
 import { useNavigate } from 'react-router-dom';
+import { readError, saveSession } from '../api';
 
 function Login() {
   const [name, setName] = useState('');
+  const [password, setPassword] = useState('');
   const [message, setMessage] = useState('');
   const [error, setError] = useState('');
+  const [errorCode, setErrorCode] = useState('');
   const navigate = useNavigate();
 
   const handleSubmit = async (e) => {
     e.preventDefault();
     setMessage('');
     setError('');
+    setErrorCode('');
 
     try {
       const response = await fetch(`/accounts/${name}/authenticate`, {
         method: 'POST',
+        headers: {
+          'Content-Type': 'application/json',
+        },
+        body: JSON.stringify({ password }),
       });
 
       if (response.ok) {
+        const { token } = await response.json();
+        saveSession(name, token);
         setMessage(`Successfully authenticated ${name}!`);
         setTimeout(() => {
           navigate(`/account/${name}`);
         }, 1500);
       } else {
-        const errorData = await response.json();
-        setError(errorData.error || 'Authentication failed');
+        const { message, code } = await readError(response);
+        setError(message || 'Authentication failed');
+        setErrorCode(code);
       }
     } catch (err) {
       setError(`Network error: ${err.message}`);
@@ -35,7 +46,7 @@ function Login() {
     <div>
       <h2>Login</h2>
       {message && <div className="success">{message}</div>}
-      {error && <div className="error">{error}</div>}
+      {error && <div className="error" data-error-code={errorCode}>{error}</div>}
 
       <form onSubmit={handleSubmit} className="form">
         <input
@@ -46,6 +57,14 @@ function Login() {
           onChange={(e) => setName(e.target.value)}
           required
         />
+        <input
+          type="password"
+          name="password"
+          placeholder="Enter your password"
+          value={password}
+          onChange={(e) => setPassword(e.target.value)}
+          required
+        />
         <button type="submit">Login</button>
       </form>
     </div>
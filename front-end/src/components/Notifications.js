@@ -0,0 +1,51 @@
+import React, { useState, useEffect } from 'react';
+import { useParams } from 'react-router-dom';
+import { followEvents } from '../api';
+
+// Notifications shows what happens to an account while the page is open, for its holder.
+// Opening the page again first catches up on what happened in between.
+function Notifications() {
+  const { name } = useParams();
+  const [notifications, setNotifications] = useState([]);
+  const [watching, setWatching] = useState(false);
+  const [error, setError] = useState('');
+  const [errorCode, setErrorCode] = useState('');
+
+  useEffect(() => {
+    const controller = new AbortController();
+    followEvents(name, {
+      signal: controller.signal,
+      onEvent: (event) => setNotifications((previous) => [...previous, event]),
+      onReady: () => setWatching(true),
+      onError: ({ message, code }) => {
+        setError(`Failed to follow events: ${message}`);
+        setErrorCode(code);
+      },
+    }).then(() => setWatching(false));
+    return () => controller.abort();
+  }, [name]);
+
+  return (
+    <div>
+      <h2>Notifications for {name}</h2>
+
+      {error && <div className="error" data-error-code={errorCode}>{error}</div>}
+      {watching && <p className="watching">Watching for activity...</p>}
+
+      <div className="notifications-list">
+        <ul>
+          {notifications.map((event, index) => (
+            <li key={index} className="event" data-kind={event.kind} data-project-id={event.projectId || ''}>
+              <strong className="event-kind">{event.kind}</strong>{' '}
+              <time className="event-occurred-at" dateTime={event.occurredAt}>
+                {new Date(event.occurredAt).toLocaleString()}
+              </time>
+            </li>
+          ))}
+        </ul>
+      </div>
+    </div>
+  );
+}
+
+export default Notifications;
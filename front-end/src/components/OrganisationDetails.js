@@ -0,0 +1,205 @@
+import React, { useState, useEffect, useCallback } from 'react';
+import { useParams, Link } from 'react-router-dom';
+import { readError, authHeaders } from '../api';
+
+// OrganisationDetails shows an organisation's members and projects to one of its members,
+// with the changes they may make. The server decides what each role may do.
+function OrganisationDetails() {
+  const { name, org } = useParams();
+  const [members, setMembers] = useState([]);
+  const [projects, setProjects] = useState([]);
+  const [loaded, setLoaded] = useState(false);
+  const [member, setMember] = useState('');
+  const [memberRole, setMemberRole] = useState('member');
+  const [projectName, setProjectName] = useState('');
+  const [message, setMessage] = useState('');
+  const [deleted, setDeleted] = useState(false);
+  const [error, setError] = useState('');
+  const [errorCode, setErrorCode] = useState('');
+
+  const fetchOrganisation = useCallback(async () => {
+    try {
+      const [membersResponse, projectsResponse] = await Promise.all([
+        fetch(`/orgs/${org}/members`, { headers: authHeaders(name) }),
+        fetch(`/orgs/${org}/projects`, { headers: authHeaders(name) }),
+      ]);
+      const failed = [membersResponse, projectsResponse].find((response) => !response.ok);
+      if (failed) {
+        const { message, code } = await readError(failed);
+        setLoaded(false);
+        setError(`Failed to load organisation ${org}: ${message}`);
+        setErrorCode(code);
+        return;
+      }
+      setMembers((await membersResponse.json()) || []);
+      setProjects((await projectsResponse.json()) || []);
+      setLoaded(true);
+    } catch (err) {
+      setError(`Network error: ${err.message}`);
+    }
+  }, [name, org]);
+
+  useEffect(() => {
+    if (name && org) {
+      fetchOrganisation();
+    }
+  }, [name, org, fetchOrganisation]);
+
+  // change makes a change to the organisation, showing done once it has been made
+  const change = async (path, options, action, done) => {
+    setMessage('');
+    setError('');
+    setErrorCode('');
+
+    try {
+      const response = await fetch(`/orgs/${org}${path}`, {
+        ...options,
+        headers: {
+          'Content-Type': 'application/json',
+          ...authHeaders(name),
+        },
+      });
+
+      if (response.ok) {
+        setMessage(done);
+        return true;
+      }
+      const { message, code } = await readError(response);
+      setError(`Failed to ${action}: ${message}`);
+      setErrorCode(code);
+    } catch (err) {
+      setError(`Network error: ${err.message}`);
+    }
+    return false;
+  };
+
+  const handleSetMember = async (e) => {
+    e.preventDefault();
+    const body = JSON.stringify({ role: memberRole });
+    if (await change(`/members/${member}`, { method: 'PUT', body }, 'set member', `${member} is now ${memberRole}.`)) {
+      setMember('');
+      fetchOrganisation();
+    }
+  };
+
+  const handleRemoveMember = (account) => async () => {
+    if (await change(`/members/${account}`, { method: 'DELETE' }, 'remove member', `Removed ${account}.`)) {
+      fetchOrganisation();
+    }
+  };
+
+  const handleCreateProject = async (e) => {
+    e.preventDefault();
+    const body = JSON.stringify({ name: projectName });
+    if (await change('/projects', { method: 'POST', body }, 'create project', `Project ${projectName} created.`)) {
+      setProjectName('');
+      fetchOrganisation();
+    }
+  };
+
+  const handleDeleteProject = (project) => async () => {
+    if (await change(`/projects/${project.id}`, { method: 'DELETE' }, 'delete project', `Deleted ${project.name}.`)) {
+      fetchOrganisation();
+    }
+  };
+
+  const handleDeleteOrganisation = async () => {
+    if (await change('', { method: 'DELETE' }, 'delete organisation', `Deleted ${org}.`)) {
+      setDeleted(true);
+    }
+  };
+
+  return (
+    <div>
+      <h2>Organisation {org}</h2>
+
+      {message && <div className="success organisation-updated">{message}</div>}
+      {error && <div className="error" data-error-code={errorCode}>{error}</div>}
+
+      {loaded && !deleted && (
+        <div className="organisation-details" data-organisation={org}>
+          <h3>Members</h3>
+          <ul className="members-list">
+            {members.map((m) => (
+              <li key={m.account} className="member" data-account={m.account} data-role={m.role}>
+                <span className="member-account">{m.account}</span>
+                {` as ${m.role}`}
+                <button className="remove-member" onClick={handleRemoveMember(m.account)} style={{ marginLeft: '10px' }}>
+                  Remove
+                </button>
+              </li>
+            ))}
+          </ul>
+
+          <form onSubmit={handleSetMember}>
+            <input
+              type="text"
+              name="member"
+              placeholder="Account name"
+              value={member}
+              onChange={(e) => setMember(e.target.value)}
+              required
+            />
+            <select name="member-role" value={memberRole} onChange={(e) => setMemberRole(e.target.value)}>
+              <option value="member">Member</option>
+              <option value="admin">Admin</option>
+              <option value="owner">Owner</option>
+            </select>
+            <button type="submit" className="set-member">
+              Add or Change Member
+            </button>
+          </form>
+
+          <h3>Projects</h3>
+          <div className="org-projects-list">
+            {projects.length === 0 ? (
+              <p>No projects found.</p>
+            ) : (
+              <ul>
+                {projects.map((project) => (
+                  <li
+                    key={project.id}
+                    className="project-item"
+                    data-project-id={project.id}
+                    data-owner-id={project.ownerId}
+                    data-created-at={project.createdAt}
+                    data-state={project.state}
+                  >
+                    <span className="project-name">{project.name}</span>
+                    <button className="delete-org-project" onClick={handleDeleteProject(project)} style={{ marginLeft: '10px' }}>
+                      Delete
+                    </button>
+                  </li>
+                ))}
+              </ul>
+            )}
+          </div>
+
+          <form onSubmit={handleCreateProject}>
+            <input
+              type="text"
+              name="org-project-name"
+              placeholder="Project name"
+              value={projectName}
+              onChange={(e) => setProjectName(e.target.value)}
+              required
+            />
+            <button type="submit" className="create-org-project">
+              Create New Project
+            </button>
+          </form>
+
+          <button className="delete-organisation" onClick={handleDeleteOrganisation}>
+            Delete Organisation
+          </button>
+        </div>
+      )}
+
+      <Link to={`/account/${name}/orgs`}>
+        <button>Back to Organisations</button>
+      </Link>
+    </div>
+  );
+}
+
+export default OrganisationDetails;
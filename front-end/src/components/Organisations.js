@@ -0,0 +1,126 @@
+import React, { useState, useEffect, useCallback } from 'react';
+import { useParams, Link } from 'react-router-dom';
+import { readError, authHeaders } from '../api';
+
+function Organisations() {
+  const { name } = useParams();
+  const [memberships, setMemberships] = useState([]);
+  const [loaded, setLoaded] = useState(false);
+  const [orgName, setOrgName] = useState('');
+  const [created, setCreated] = useState(null);
+  const [error, setError] = useState('');
+  const [errorCode, setErrorCode] = useState('');
+
+  const fetchOrganisations = useCallback(async () => {
+    try {
+      const response = await fetch('/orgs', {
+        headers: authHeaders(name),
+      });
+      if (response.ok) {
+        setMemberships((await response.json()) || []);
+        setLoaded(true);
+      } else {
+        const { message, code } = await readError(response);
+        setError(`Failed to load organisations for ${name}: ${message}`);
+        setErrorCode(code);
+      }
+    } catch (err) {
+      setError(`Network error: ${err.message}`);
+    }
+  }, [name]);
+
+  useEffect(() => {
+    if (name) {
+      fetchOrganisations();
+    }
+  }, [name, fetchOrganisations]);
+
+  const handleCreateOrganisation = async (e) => {
+    e.preventDefault();
+    setCreated(null);
+    setError('');
+    setErrorCode('');
+
+    try {
+      const response = await fetch('/orgs', {
+        method: 'POST',
+        headers: {
+          'Content-Type': 'application/json',
+          ...authHeaders(name),
+        },
+        body: JSON.stringify({ name: orgName }),
+      });
+
+      if (response.ok) {
+        setCreated(await response.json());
+        setOrgName('');
+        // Refresh the organisations list
+        fetchOrganisations();
+      } else {
+        const { message, code } = await readError(response);
+        setError(`Failed to create organisation: ${message}`);
+        setErrorCode(code);
+      }
+    } catch (err) {
+      setError(`Network error: ${err.message}`);
+    }
+  };
+
+  return (
+    <div>
+      <h2>Organisations for {name}</h2>
+      <p>Organisations own projects that all of their members work on together.</p>
+
+      {created && (
+        <div className="organisation-created" data-organisation={created.name}>
+          Organisation <Link to={`/account/${name}/orgs/${created.name}`}>{created.name}</Link> created successfully!
+        </div>
+      )}
+      {error && <div className="error" data-error-code={errorCode}>{error}</div>}
+
+      <form onSubmit={handleCreateOrganisation}>
+        <input
+          type="text"
+          name="org-name"
+          placeholder="Organisation name"
+          value={orgName}
+          onChange={(e) => setOrgName(e.target.value)}
+          required
+        />
+        <button type="submit" className="create-organisation">
+          Create New Organisation
+        </button>
+      </form>
+
+      {loaded && (
+        <div className="organisations-list">
+          {memberships.length === 0 ? (
+            <p>No organisations.</p>
+          ) : (
+            <ul>
+              {memberships.map((membership) => (
+                <li
+                  key={membership.organisation}
+                  className="membership"
+                  data-organisation={membership.organisation}
+                  data-role={membership.role}
+                >
+                  <Link to={`/account/${name}/orgs/${membership.organisation}`}>
+                    <span className="membership-organisation">{membership.organisation}</span>
+                  </Link>
+                  {` as ${membership.role}`}
+                </li>
+              ))}
+            </ul>
+          )}
+        </div>
+      )}
+
+      <Link to={`/account/${name}`}>
+        <button>Back to Account</button>
+      </Link>
+    </div>
+  );
+}
+
+export default Organisations;
@@ -0,0 +1,57 @@
+import React, { useState, useEffect } from 'react';
+import { useParams, Link } from 'react-router-dom';
+import { readError } from '../api';
+
+// Outbox shows the messages the back end has sent to an account, in place of an email inbox
+function Outbox() {
+  const { name } = useParams();
+  const [messages, setMessages] = useState(null);
+  const [error, setError] = useState('');
+  const [errorCode, setErrorCode] = useState('');
+
+  useEffect(() => {
+    const fetchMessages = async () => {
+      try {
+        const response = await fetch(`/outbox/${encodeURIComponent(name)}`);
+        if (response.ok) {
+          setMessages((await response.json()) || []);
+        } else {
+          const { message, code } = await readError(response);
+          setError(`Failed to load messages: ${message}`);
+          setErrorCode(code);
+        }
+      } catch (err) {
+        setError(`Network error: ${err.message}`);
+      }
+    };
+    fetchMessages();
+  }, [name]);
+
+  return (
+    <div>
+      <h2>Messages for {name}</h2>
+
+      {error && <div className="error" data-error-code={errorCode}>{error}</div>}
+
+      {messages && (
+        <div className="messages-list">
+          {messages.length === 0 ? (
+            <p>No messages.</p>
+          ) : (
+            <ul>
+              {messages.map((message, index) => (
+                <li key={index} className="message">
+                  <strong className="message-subject">{message.subject}</strong>
+                  <p className="message-body">{message.body}</p>
+                  <Link className="message-link" to={message.link}>{message.link}</Link>
+                </li>
+              ))}
+            </ul>
+          )}
+        </div>
+      )}
+    </div>
+  );
+}
+
+export default Outbox;
@@ -0,0 +1,236 @@
+import React, { useState, useEffect } from 'react';
+import { useParams, useSearchParams, Link } from 'react-router-dom';
+import { readError, authHeaders, actingAs } from '../api';
+
+function ProjectDetails() {
+  const { name, id } = useParams();
+  const [searchParams] = useSearchParams();
+  const actor = actingAs(searchParams, name);
+  const [project, setProject] = useState(null);
+  const [newName, setNewName] = useState('');
+  const [invitee, setInvitee] = useState('');
+  const [inviteRole, setInviteRole] = useState('viewer');
+  const [message, setMessage] = useState('');
+  const [deleted, setDeleted] = useState(false);
+  const [error, setError] = useState('');
+  const [errorCode, setErrorCode] = useState('');
+
+  const showError = async (response, prefix) => {
+    const { message, code } = await readError(response);
+    setError(`${prefix}: ${message}`);
+    setErrorCode(code);
+  };
+
+  useEffect(() => {
+    const fetchProject = async () => {
+      try {
+        const response = await fetch(`/accounts/${name}/projects/${id}`, {
+          headers: authHeaders(actor),
+        });
+        if (response.ok) {
+          const projectData = await response.json();
+          setProject(projectData);
+          setNewName(projectData.name);
+        } else {
+          const { message, code } = await readError(response);
+          setError(message);
+          setErrorCode(code);
+        }
+      } catch (err) {
+        setError(`Network error: ${err.message}`);
+      }
+    };
+
+    if (name && id) {
+      fetchProject();
+    }
+  }, [name, id, actor]);
+
+  const handleRename = async (e) => {
+    e.preventDefault();
+    setMessage('');
+    setError('');
+    setErrorCode('');
+
+    try {
+      const response = await fetch(`/accounts/${name}/projects/${id}`, {
+        method: 'PATCH',
+        headers: {
+          'Content-Type': 'application/json',
+          ...authHeaders(actor),
+        },
+        body: JSON.stringify({ name: newName }),
+      });
+
+      if (response.ok) {
+        setProject(await response.json());
+        setMessage('Project renamed successfully!');
+      } else {
+        await showError(response, 'Failed to rename project');
+      }
+    } catch (err) {
+      setError(`Network error: ${err.message}`);
+    }
+  };
+
+  // handleMove archives or restores the project, as action says
+  const handleMove = (action, done) => async () => {
+    setMessage('');
+    setError('');
+    setErrorCode('');
+
+    try {
+      const response = await fetch(`/accounts/${name}/projects/${id}/${action}`, {
+        method: 'POST',
+        headers: authHeaders(actor),
+      });
+
+      if (response.ok) {
+        setProject(await response.json());
+        setMessage(done);
+      } else {
+        await showError(response, `Failed to ${action} project`);
+      }
+    } catch (err) {
+      setError(`Network error: ${err.message}`);
+    }
+  };
+
+  const handleInvite = async (e) => {
+    e.preventDefault();
+    setMessage('');
+    setError('');
+    setErrorCode('');
+
+    try {
+      const response = await fetch(`/accounts/${name}/projects/${id}/invitations`, {
+        method: 'POST',
+        headers: {
+          'Content-Type': 'application/json',
+          ...authHeaders(actor),
+        },
+        body: JSON.stringify({ account: invitee, role: inviteRole }),
+      });
+
+      if (response.ok) {
+        setMessage(`Invitation sent to ${invitee}.`);
+        setInvitee('');
+      } else {
+        await showError(response, 'Failed to send invitation');
+      }
+    } catch (err) {
+      setError(`Network error: ${err.message}`);
+    }
+  };
+
+  const handleDelete = async () => {
+    setMessage('');
+    setError('');
+    setErrorCode('');
+
+    try {
+      const response = await fetch(`/accounts/${name}/projects/${id}`, {
+        method: 'DELETE',
+        headers: authHeaders(actor),
+      });
+
+      if (response.ok) {
+        setDeleted(true);
+      } else {
+        await showError(response, 'Failed to delete project');
+      }
+    } catch (err) {
+      setError(`Network error: ${err.message}`);
+    }
+  };
+
+  if (deleted) {
+    return (
+      <div className="project-deleted">
+        Project deleted. <Link to={`/account/${name}/projects`}>Back to projects</Link>
+      </div>
+    );
+  }
+
+  if (!project) {
+    if (error) {
+      return <div className="error" data-error-code={errorCode}>{error}</div>;
+    }
+    return <div>Loading...</div>;
+  }
+
+  return (
+    <div>
+      <h2>Project for {name}</h2>
+
+      {message && <div className="project-updated">{message}</div>}
+      {error && <div className="error" data-error-code={errorCode}>{error}</div>}
+
+      <div
+        className="project-details"
+        data-project-id={project.id}
+        data-owner-id={project.ownerId}
+        data-created-at={project.createdAt}
+        data-state={project.state}
+      >
+        <p>
+          <strong>Name:</strong> <span className="project-name">{project.name}</span>
+        </p>
+        <p>
+          <strong>Created:</strong> {new Date(project.createdAt).toLocaleString()}
+        </p>
+        <p>
+          <strong>State:</strong> <span className="project-state">{project.state}</span>
+        </p>
+
+        <form onSubmit={handleRename}>
+          <input
+            type="text"
+            name="project-name"
+            value={newName}
+            onChange={(e) => setNewName(e.target.value)}
+            required
+          />
+          <button type="submit" className="rename-project">
+            Rename Project
+          </button>
+        </form>
+      </div>
+
+      <form onSubmit={handleInvite}>
+        <input
+          type="text"
+          name="invitee"
+          placeholder="Account to share with"
+          value={invitee}
+          onChange={(e) => setInvitee(e.target.value)}
+          required
+        />
+        <select name="invite-role" value={inviteRole} onChange={(e) => setInviteRole(e.target.value)}>
+          <option value="viewer">Viewer</option>
+          <option value="editor">Editor</option>
+        </select>
+        <button type="submit" className="invite-to-project">
+          Invite
+        </button>
+      </form>
+
+      <div>
+        <button className="archive-project" onClick={handleMove('archive', 'Project archived.')}>
+          Archive Project
+        </button>
+        <button className="restore-project" onClick={handleMove('restore', 'Project restored.')}>
+          Restore Project
+        </button>
+        <button className="delete-project" onClick={handleDelete}>
+          Delete Project
+        </button>
+        <Link to={`/account/${name}/projects`} style={{ marginLeft: '10px' }}>
+          <button>Back to Projects</button>
+        </Link>
+      </div>
+    </div>
+  );
+}
+
+export default ProjectDetails;
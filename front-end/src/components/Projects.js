@@ -1,51 +1,151 @@
 import React, { useState, useEffect, useCallback } from 'react';
-import { useParams } from 'react-router-dom';
+import { useParams, useSearchParams, Link } from 'react-router-dom';
+import { readError, authHeaders, actingAs, nextCursor } from '../api';
+
+// listParams are the search parameters that choose which projects to list, which are
+// passed on to the API as they are
+const listParams = ['limit', 'sort', 'namePrefix', 'createdFrom', 'createdBefore', 'includeArchived', 'cursor'];
+
+// The time inputs show local times to the minute, while the API takes UTC times
+const localTimeOf = (time) => {
+  if (!time) {
+    return '';
+  }
+  const date = new Date(time);
+  return new Date(date.getTime() - date.getTimezoneOffset() * 60000).toISOString().slice(0, 16);
+};
+const utcTimeOf = (localTime) => (localTime ? new Date(localTime).toISOString() : '');
 
 function Projects() {
   const { name } = useParams();
-  const [projects, setProjects] = useState([]);
-  const [message, setMessage] = useState('');
+  const [searchParams, setSearchParams] = useSearchParams();
+  const actor = actingAs(searchParams, name);
+  const search = actor === name ? '' : `?as=${encodeURIComponent(actor)}`;
+  // The projects, the cursor they were listed from and the one for the next page are kept
+  // together, so that the page never shows one list with another's controls
+  const [page, setPage] = useState(null);
+  const [filters, setFilters] = useState({});
+  // applied counts the times filters were applied, so that the list is loaded again even
+  // when they are unchanged
+  const [applied, setApplied] = useState(0);
+  const [projectName, setProjectName] = useState('');
+  const [created, setCreated] = useState(null);
   const [error, setError] = useState('');
+  const [errorCode, setErrorCode] = useState('');
+
+  const query = new URLSearchParams();
+  listParams.forEach((param) => {
+    if (searchParams.get(param)) {
+      query.set(param, searchParams.get(param));
+    }
+  });
+  const queryString = query.toString();
+
+  useEffect(() => {
+    const params = new URLSearchParams(queryString);
+    setFilters({
+      limit: params.get('limit') || '',
+      sort: params.get('sort') || 'created',
+      namePrefix: params.get('namePrefix') || '',
+      createdFrom: localTimeOf(params.get('createdFrom')),
+      createdBefore: localTimeOf(params.get('createdBefore')),
+      includeArchived: params.get('includeArchived') === 'true',
+    });
+  }, [queryString]);
 
   const fetchProjects = useCallback(async () => {
+    setError('');
+    setErrorCode('');
     try {
-      const response = await fetch(`/accounts/${name}/projects`);
+      const response = await fetch(`/accounts/${name}/projects?${queryString}`, {
+        headers: authHeaders(actor),
+      });
       if (response.ok) {
         const projectsData = await response.json();
-        setProjects(projectsData || []);
+        setPage({
+          cursor: new URLSearchParams(queryString).get('cursor') || '',
+          projects: projectsData || [],
+          nextCursor: nextCursor(response),
+        });
       } else {
+        const { code } = await readError(response);
+        setPage(null);
         setError(`Failed to load projects for ${name}`);
+        setErrorCode(code);
       }
     } catch (err) {
       setError(`Network error: ${err.message}`);
     }
-  }, [name]);
+  }, [name, actor, queryString]);
 
   useEffect(() => {
     if (name) {
       fetchProjects();
     }
-  }, [name, fetchProjects]);
+  }, [name, fetchProjects, applied]);
 
-  const handleCreateProject = async () => {
-    setMessage('');
+  // pageSearch returns the search string for the page of projects listed with params,
+  // keeping the account the page acts as
+  const pageSearch = (params) => {
+    const next = new URLSearchParams();
+    if (actor !== name) {
+      next.set('as', actor);
+    }
+    Object.entries(params).forEach(([param, value]) => {
+      if (value) {
+        next.set(param, value);
+      }
+    });
+    return `?${next.toString()}`;
+  };
+
+  const handleFilter = (e) => {
+    e.preventDefault();
+    // Clear the list until the projects chosen have loaded, so it is never out of date
+    setPage(null);
+    setApplied(applied + 1);
+    setSearchParams(
+      pageSearch({
+        limit: filters.limit,
+        sort: filters.sort === 'created' ? '' : filters.sort,
+        namePrefix: filters.namePrefix,
+        createdFrom: utcTimeOf(filters.createdFrom),
+        createdBefore: utcTimeOf(filters.createdBefore),
+        includeArchived: filters.includeArchived ? 'true' : '',
+      })
+    );
+  };
+
+  const setFilter = (filter) => (e) => setFilters({ ...filters, [filter]: e.target.value });
+
+  const firstPage = Object.fromEntries(query.entries());
+  delete firstPage.cursor;
+
+  const handleCreateProject = async (e) => {
+    e.preventDefault();
+    setCreated(null);
     setError('');
+    setErrorCode('');
 
     try {
       const response = await fetch(`/accounts/${name}/projects`, {
         method: 'POST',
+        headers: {
+          'Content-Type': 'application/json',
+          ...authHeaders(actor),
+        },
+        body: JSON.stringify({ name: projectName }),
       });
 
       if (response.ok) {
-        setMessage('Project created successfully!');
+        setCreated(await response.json());
+        setProjectName('');
         // Refresh the projects list
-        setTimeout(() => {
-          fetchProjects();
-          setMessage('');
-        }, 1500);
+        fetchProjects();
       } else {
-        const errorData = await response.text();
-        setError(`Failed to create project: ${errorData}`);
+        const { message, code } = await readError(response);
+        setError(`Failed to create project: ${message}`);
+        setErrorCode(code);
       }
     } catch (err) {
       setError(`Network error: ${err.message}`);
@@ -56,26 +156,120 @@ function Projects() {
     <div>
       <h2>Projects for {name}</h2>
 
-      {message && <div className="project-created">{message}</div>}
-      {error && <div className="error">{error}</div>}
-
-      <button className="create-project" onClick={handleCreateProject}>
-        Create New Project
-      </button>
-
-      <div className="projects-list">
-        {projects.length === 0 ? (
-          <p>No projects found.</p>
-        ) : (
-          <ul>
-            {projects.map((project, index) => (
-              <li key={index} className="project-item">
-                Project {index + 1}
-              </li>
-            ))}
-          </ul>
-        )}
-      </div>
+      {created && (
+        <div className="project-created" data-project-id={created.id}>
+          Project <Link to={`/account/${name}/projects/${created.id}${search}`}>{created.name}</Link> created successfully!
+        </div>
+      )}
+      {error && (
+        <div className="error" data-error-code={errorCode}>
+          {error}
+          {errorCode === 'quota_exceeded' && (
+            <Link to={`/account/${name}`} className="upgrade-plan" style={{ marginLeft: '10px' }}>
+              Upgrade your plan
+            </Link>
+          )}
+        </div>
+      )}
+
+      <form onSubmit={handleCreateProject}>
+        <input
+          type="text"
+          name="project-name"
+          placeholder="Project name"
+          value={projectName}
+          onChange={(e) => setProjectName(e.target.value)}
+          required
+        />
+        <button type="submit" className="create-project">
+          Create New Project
+        </button>
+      </form>
+
+      <form className="project-filters" onSubmit={handleFilter}>
+        <label>
+          Sort by{' '}
+          <select name="sort" value={filters.sort || 'created'} onChange={setFilter('sort')}>
+            <option value="created">Oldest first</option>
+            <option value="name">Name</option>
+          </select>
+        </label>
+        <label>
+          Name starts with{' '}
+          <input type="text" name="name-prefix" value={filters.namePrefix || ''} onChange={setFilter('namePrefix')} />
+        </label>
+        <label>
+          Created from{' '}
+          <input type="datetime-local" name="created-from" value={filters.createdFrom || ''} onChange={setFilter('createdFrom')} />
+        </label>
+        <label>
+          Created before{' '}
+          <input type="datetime-local" name="created-before" value={filters.createdBefore || ''} onChange={setFilter('createdBefore')} />
+        </label>
+        <label>
+          <input
+            type="checkbox"
+            name="include-archived"
+            checked={filters.includeArchived || false}
+            onChange={(e) => setFilters({ ...filters, includeArchived: e.target.checked })}
+          />{' '}
+          Include archived
+        </label>
+        <label>
+          Per page{' '}
+          <select name="limit" value={filters.limit || '50'} onChange={setFilter('limit')}>
+            <option value="10">10</option>
+            <option value="25">25</option>
+            <option value="50">50</option>
+            <option value="100">100</option>
+          </select>
+        </label>
+        <button type="submit" className="apply-filters">
+          Apply
+        </button>
+      </form>
+
+      {page && (
+        <div className="projects-list" data-cursor={page.cursor}>
+          {page.projects.length === 0 ? (
+            <p>No projects found.</p>
+          ) : (
+            <ul>
+              {page.projects.map((project) => (
+                <li
+                  key={project.id}
+                  className="project-item"
+                  data-project-id={project.id}
+                  data-owner-id={project.ownerId}
+                  data-created-at={project.createdAt}
+                  data-state={project.state}
+                >
+                  <Link to={`/account/${name}/projects/${project.id}${search}`}>
+                    <span className="project-name">{project.name}</span>
+                  </Link>
+                  {project.state === 'archived' && <span className="project-state"> (archived)</span>}
+                </li>
+              ))}
+            </ul>
+          )}
+          <nav className="pagination">
+            {page.cursor && (
+              <Link className="first-page" to={pageSearch(firstPage)}>
+                First page
+              </Link>
+            )}{' '}
+            {page.nextCursor && (
+              <Link
+                className="next-page"
+                data-cursor={page.nextCursor}
+                to={pageSearch({ ...firstPage, cursor: page.nextCursor })}
+              >
+                Next page
+              </Link>
+            )}
+          </nav>
+        </div>
+      )}
     </div>
   );
 }
@@ -1,16 +1,28 @@
 import React, { useState } from 'react';
 import { useNavigate } from 'react-router-dom';
+import { readError } from '../api';
 
 function SignUp() {
   const [name, setName] = useState('');
+  const [password, setPassword] = useState('');
+  const [webhookUrl, setWebhookUrl] = useState('');
+  const [webhookSecret, setWebhookSecret] = useState('');
   const [message, setMessage] = useState('');
   const [error, setError] = useState('');
+  const [errorCode, setErrorCode] = useState('');
   const navigate = useNavigate();
 
   const handleSubmit = async (e) => {
     e.preventDefault();
     setMessage('');
     setError('');
+    setErrorCode('');
+
+    // A webhook is optional, but one registered now is told when the account is activated
+    const account = { name, password };
+    if (webhookUrl) {
+      account.webhook = { url: webhookUrl, secret: webhookSecret };
+    }
 
     try {
       const response = await fetch('/accounts', {
@@ -18,7 +30,7 @@ function SignUp() {
         headers: {
           'Content-Type': 'application/json',
         },
-        body: JSON.stringify({ name }),
+        body: JSON.stringify(account),
       });
 
       if (response.ok) {
@@ -27,8 +39,9 @@ function SignUp() {
           navigate(`/account/${name}`);
         }, 1500);
       } else {
-        const errorData = await response.text();
-        setError(`Failed to create account: ${errorData}`);
+        const { message, code } = await readError(response);
+        setError(`Failed to create account: ${message}`);
+        setErrorCode(code);
       }
     } catch (err) {
       setError(`Network error: ${err.message}`);
@@ -39,7 +52,7 @@ function SignUp() {
     <div>
       <h2>Sign Up</h2>
       {message && <div className="success message">{message}</div>}
-      {error && <div className="error">{error}</div>}
+      {error && <div className="error" data-error-code={errorCode}>{error}</div>}
 
       <form onSubmit={handleSubmit} className="form">
         <input
@@ -50,6 +63,29 @@ function SignUp() {
           onChange={(e) => setName(e.target.value)}
           required
         />
+        <input
+          type="password"
+          name="password"
+          placeholder="Choose a password"
+          value={password}
+          onChange={(e) => setPassword(e.target.value)}
+          required
+        />
+        <input
+          type="url"
+          name="webhook-url"
+          placeholder="Webhook URL (optional)"
+          value={webhookUrl}
+          onChange={(e) => setWebhookUrl(e.target.value)}
+        />
+        <input
+          type="password"
+          name="webhook-secret"
+          placeholder="Webhook secret"
+          value={webhookSecret}
+          onChange={(e) => setWebhookSecret(e.target.value)}
+          required={webhookUrl !== ''}
+        />
         <button type="submit">Create Account</button>
       </form>
     </div>
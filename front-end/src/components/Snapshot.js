@@ -0,0 +1,93 @@
+import React, { useState } from 'react';
+import { browserSessions, forgetLastEventIds, readError, replaceSessions } from '../api';
+
+// Snapshot takes a snapshot of all data, along with this browser's sessions, and restores
+// one, so that tests can build what they start from once and put it back before each test
+function Snapshot() {
+  const [snapshot, setSnapshot] = useState('');
+  const [message, setMessage] = useState('');
+  const [error, setError] = useState('');
+
+  const handleTake = async () => {
+    setMessage('');
+    setError('');
+
+    try {
+      const response = await fetch('/admin/snapshot');
+      if (response.ok) {
+        const data = await response.json();
+        setSnapshot(JSON.stringify({ ...data, sessions: browserSessions() }));
+        setMessage('Snapshot taken');
+      } else {
+        const { message } = await readError(response);
+        setError(`Failed to take snapshot: ${message}`);
+      }
+    } catch (err) {
+      setError(`Network error: ${err.message}`);
+    }
+  };
+
+  const handleRestore = async () => {
+    setMessage('');
+    setError('');
+
+    let data;
+    try {
+      data = JSON.parse(snapshot);
+    } catch (err) {
+      setError(`Snapshot is not valid JSON: ${err.message}`);
+      return;
+    }
+
+    try {
+      const response = await fetch('/admin/snapshot', {
+        method: 'PUT',
+        headers: {
+          'Content-Type': 'application/json',
+        },
+        body: JSON.stringify({ takenAt: data.takenAt, state: data.state }),
+      });
+
+      if (response.ok) {
+        replaceSessions(data.sessions);
+        // Restoring clears the events, so event ids from before mean nothing now
+        forgetLastEventIds();
+        setMessage('Snapshot restored');
+      } else {
+        const { message } = await readError(response);
+        setError(`Failed to restore snapshot: ${message}`);
+      }
+    } catch (err) {
+      setError(`Network error: ${err.message}`);
+    }
+  };
+
+  return (
+    <div>
+      <h2>Admin: Snapshot</h2>
+      <p>Takes a snapshot of all data and this browser's sessions, or restores one. Only available when the server runs in test mode.</p>
+
+      {message && <div className="success">{message}</div>}
+      {error && <div className="error">{error}</div>}
+
+      <textarea
+        name="snapshot"
+        rows={10}
+        cols={80}
+        placeholder="Snapshot to restore"
+        value={snapshot}
+        onChange={(e) => setSnapshot(e.target.value)}
+      />
+      <div>
+        <button type="button" className="take-snapshot" onClick={handleTake}>
+          Take Snapshot
+        </button>
+        <button type="button" className="restore-snapshot" onClick={handleRestore}>
+          Restore Snapshot
+        </button>
+      </div>
+    </div>
+  );
+}
+
+export default Snapshot;
@@ -0,0 +1,84 @@
+import React, { useState, useEffect } from 'react';
+import { useParams, Link } from 'react-router-dom';
+import { readError, authHeaders } from '../api';
+
+function WebhookDeliveries() {
+  const { name, id } = useParams();
+  const [webhook, setWebhook] = useState(null);
+  const [deliveries, setDeliveries] = useState([]);
+  const [error, setError] = useState('');
+  const [errorCode, setErrorCode] = useState('');
+
+  useEffect(() => {
+    const fetchDeliveries = async () => {
+      try {
+        const [webhookResponse, deliveriesResponse] = await Promise.all([
+          fetch(`/accounts/${name}/webhooks/${id}`, { headers: authHeaders(name) }),
+          fetch(`/accounts/${name}/webhooks/${id}/deliveries`, { headers: authHeaders(name) }),
+        ]);
+        const failed = [webhookResponse, deliveriesResponse].find((response) => !response.ok);
+        if (failed) {
+          const { message, code } = await readError(failed);
+          setError(message);
+          setErrorCode(code);
+          return;
+        }
+        setDeliveries((await deliveriesResponse.json()) || []);
+        setWebhook(await webhookResponse.json());
+      } catch (err) {
+        setError(`Network error: ${err.message}`);
+      }
+    };
+
+    if (name && id) {
+      fetchDeliveries();
+    }
+  }, [name, id]);
+
+  if (error) {
+    return <div className="error" data-error-code={errorCode}>{error}</div>;
+  }
+
+  if (!webhook) {
+    return <div>Loading...</div>;
+  }
+
+  return (
+    <div>
+      <h2>Deliveries to {webhook.url}</h2>
+      <Link to={`/account/${name}/webhooks`}>Back to webhooks</Link>
+
+      <div className="deliveries-list">
+        {deliveries.length === 0 ? (
+          <p>Nothing has been delivered yet.</p>
+        ) : (
+          <ul>
+            {deliveries.map((delivery) => (
+              <li
+                key={delivery.id}
+                className="delivery"
+                data-delivery-id={delivery.id}
+                data-kind={delivery.event.kind}
+                data-status={delivery.status}
+                data-attempts={delivery.attempts}
+                data-response-status={delivery.responseStatus || ''}
+              >
+                <span className="delivery-kind">{delivery.event.kind}</span>{' '}
+                <span className="delivery-status">{delivery.status}</span> after {delivery.attempts} attempt(s)
+                {delivery.responseStatus && <span> (responded {delivery.responseStatus})</span>}
+                {delivery.error && <div className="delivery-error">{delivery.error}</div>}
+                {delivery.nextAttemptAt && (
+                  <div>
+                    Next attempt at <time dateTime={delivery.nextAttemptAt}>{delivery.nextAttemptAt}</time>
+                  </div>
+                )}
+              </li>
+            ))}
+          </ul>
+        )}
+      </div>
+    </div>
+  );
+}
+
+export default WebhookDeliveries;
@@ -0,0 +1,129 @@
+import React, { useState, useEffect, useCallback } from 'react';
+import { useParams, Link } from 'react-router-dom';
+import { readError, authHeaders } from '../api';
+
+function Webhooks() {
+  const { name } = useParams();
+  const [webhooks, setWebhooks] = useState([]);
+  const [loaded, setLoaded] = useState(false);
+  const [url, setUrl] = useState('');
+  const [secret, setSecret] = useState('');
+  const [registered, setRegistered] = useState(null);
+  const [error, setError] = useState('');
+  const [errorCode, setErrorCode] = useState('');
+
+  const fetchWebhooks = useCallback(async () => {
+    try {
+      const response = await fetch(`/accounts/${name}/webhooks`, {
+        headers: authHeaders(name),
+      });
+      if (response.ok) {
+        setWebhooks((await response.json()) || []);
+        setLoaded(true);
+      } else {
+        const { message, code } = await readError(response);
+        setError(`Failed to load webhooks for ${name}: ${message}`);
+        setErrorCode(code);
+      }
+    } catch (err) {
+      setError(`Network error: ${err.message}`);
+    }
+  }, [name]);
+
+  useEffect(() => {
+    if (name) {
+      fetchWebhooks();
+    }
+  }, [name, fetchWebhooks]);
+
+  const handleRegister = async (e) => {
+    e.preventDefault();
+    setRegistered(null);
+    setError('');
+    setErrorCode('');
+
+    try {
+      const response = await fetch(`/accounts/${name}/webhooks`, {
+        method: 'POST',
+        headers: {
+          'Content-Type': 'application/json',
+          ...authHeaders(name),
+        },
+        body: JSON.stringify({ url, secret }),
+      });
+
+      if (response.ok) {
+        setRegistered(await response.json());
+        setUrl('');
+        setSecret('');
+        // Refresh the webhooks list
+        fetchWebhooks();
+      } else {
+        const { message, code } = await readError(response);
+        setError(`Failed to register webhook: ${message}`);
+        setErrorCode(code);
+      }
+    } catch (err) {
+      setError(`Network error: ${err.message}`);
+    }
+  };
+
+  return (
+    <div>
+      <h2>Webhooks for {name}</h2>
+      <p>
+        Projects created and the account being activated are posted to each webhook, signed with
+        its secret in the X-Webhook-Signature header.
+      </p>
+
+      {registered && (
+        <div className="success webhook-registered" data-webhook-id={registered.id}>
+          Webhook <Link to={`/account/${name}/webhooks/${registered.id}`}>{registered.url}</Link> registered successfully!
+        </div>
+      )}
+      {error && <div className="error" data-error-code={errorCode}>{error}</div>}
+
+      <form onSubmit={handleRegister}>
+        <input
+          type="url"
+          name="webhook-url"
+          placeholder="Webhook URL"
+          value={url}
+          onChange={(e) => setUrl(e.target.value)}
+          required
+        />
+        <input
+          type="password"
+          name="webhook-secret"
+          placeholder="Secret to sign payloads with"
+          value={secret}
+          onChange={(e) => setSecret(e.target.value)}
+          required
+        />
+        <button type="submit" className="register-webhook">
+          Register Webhook
+        </button>
+      </form>
+
+      {loaded && (
+        <div className="webhooks-list">
+          {webhooks.length === 0 ? (
+            <p>No webhooks registered.</p>
+          ) : (
+            <ul>
+              {webhooks.map((webhook) => (
+                <li key={webhook.id} className="webhook" data-webhook-id={webhook.id} data-created-at={webhook.createdAt}>
+                  <Link to={`/account/${name}/webhooks/${webhook.id}`}>
+                    <span className="webhook-url">{webhook.url}</span>
+                  </Link>
+                </li>
+              ))}
+            </ul>
+          )}
+        </div>
+      )}
+    </div>
+  );
+}
+
+export default Webhooks;